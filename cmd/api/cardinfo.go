@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	gcstorage "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/gstorage"
@@ -13,6 +14,12 @@ func cardrange(app *application) error {
 
 	app.cardinfo = cardinfo.NewCollection(app.conf.BlockedBins)
 
+	testRanges, err := testCardRanges(app)
+	if err != nil {
+		return err
+	}
+	app.cardNumberGuard = cardinfo.NewCardNumberGuard(app.conf.AllowProductionCardNumbers, testRanges, app.logger)
+
 	storageClient, err := newStorageClient(app)
 	if err != nil {
 		message := fmt.Sprintf("storage failed to setup: %v", err)
@@ -31,7 +38,7 @@ func cardrange(app *application) error {
 		"mastercard": app.conf.MasterCard.BinrangeFiletype,
 		"visa":       app.conf.Visa.BinrangeFiletype,
 	}
-	serv := cardinfo.NewService(app.cardinfo, bucket, app.logger, binRangeFiles, app.conf.Visa.AddTestPans)
+	serv := cardinfo.NewService(app.cardinfo, bucket, app.logger, binRangeFiles, app.conf.Visa.AddTestPans, app.conf.BinrangeMaxShrinkPercentage)
 
 	if !app.conf.Development.MockCardInfo {
 		go func() {
@@ -57,3 +64,30 @@ func cardrange(app *application) error {
 
 	return serv.LoadBinRanges(app.ctx)
 }
+
+// testCardRanges loads the scheme test ranges from the configured file, or uses the default ranges
+// when no file is configured
+func testCardRanges(app *application) (*cardinfo.TestRanges, error) {
+	testRanges := cardinfo.NewTestRanges()
+
+	if app.conf.TestCardRangesFile == "" {
+		testRanges.Set(cardinfo.DefaultTestRanges())
+		return testRanges, nil
+	}
+
+	f, err := os.Open(app.conf.TestCardRangesFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open test card ranges: %w", err)
+	}
+	defer f.Close()
+
+	ranges, err := cardinfo.ParseTestRanges(f)
+	if err != nil {
+		return nil, err
+	}
+
+	app.logger.Info(app.ctx, fmt.Sprintf("Found %d test card ranges", len(ranges)))
+	testRanges.Set(ranges)
+
+	return testRanges, nil
+}
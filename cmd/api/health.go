@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/probes"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+// healthChecks registers the readiness checks of the dependencies the application has set up. The
+// checks of the schemes are only registered for the schemes with a connection pool, a scheme that is
+// down leaves the instance ready for the other schemes. The certificate check is only registered for the
+// pools with certificate files, a pool that verifies the scheme with the system roots has nothing to
+// expire. The STAN and echo checks are registered by
+// SchemeMapper.
+func (app application) healthChecks(checks probes.Registry) {
+	for scheme, pool := range app.connectionPools() {
+		checks.RegisterScheme(scheme, scheme+"_pool", probes.MinCount("connected connections", pool.Connected, app.conf.Health.MinConnections))
+		if pool.HasCertificates() {
+			checks.RegisterScheme(scheme, scheme+"_certificates", probes.MinValidity("certificates", pool.CertificateExpiry, app.conf.Health.MinCertificateValidity))
+		}
+	}
+
+	if app.spannerClient != nil {
+		client := app.spannerClient
+		checks.Register("spanner", func(ctx context.Context) error {
+			return spanner.Ping(ctx, client)
+		})
+	}
+
+	if app.cardinfo != nil && !app.conf.Development.MockCardInfo {
+		for scheme := range app.connectionPools() {
+			scheme := scheme
+			checks.RegisterScheme(scheme, scheme+"_bins", func(ctx context.Context) error {
+				return app.binsLoaded(scheme)
+			})
+		}
+	}
+}
+
+// echoChecks makes the instance unready when a scheme that echoes periodically stopped answering
+func (app application) echoChecks(checks probes.Registry) {
+	if app.conf.Health.MaxEchoAge == 0 {
+		return
+	}
+
+	if app.mcConnectionPool != nil && app.conf.MasterCard.EchoInterval != 0 {
+		checks.RegisterScheme(mastercard, mastercard+"_echo", probes.MaxAge("answered echo", app.mip.LastEcho, app.conf.Health.MaxEchoAge))
+	}
+	if app.visaConnectionPool != nil && app.conf.Visa.ConnectionPool.TickDelay != 0 {
+		checks.RegisterScheme(visa, visa+"_echo", probes.MaxAge("answered echo", app.eas.LastEcho, app.conf.Health.MaxEchoAge))
+	}
+}
+
+func (app application) binsLoaded(scheme string) error {
+	for _, status := range app.cardinfo.LoadStatuses() {
+		if status.Scheme == scheme && status.Ranges > 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no %s BIN ranges loaded", scheme)
+}
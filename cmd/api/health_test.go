@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/probes"
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+// registeredChecks records the names of the checks that are registered
+type registeredChecks []string
+
+func (r *registeredChecks) Register(name string, check probes.Check) {
+	*r = append(*r, name)
+}
+
+func (r *registeredChecks) RegisterScheme(scheme, name string, check probes.Check) {
+	*r = append(*r, name)
+}
+
+func TestHealthChecks_certificates(t *testing.T) {
+	tests := []struct {
+		name             string
+		tls              connection.TLSConfiguration
+		wantCertificates bool
+	}{
+		{name: "system_roots", tls: connection.TLSConfiguration{Enabled: true}},
+		{name: "ca_file", tls: connection.TLSConfiguration{Enabled: true, CAFile: "visa-ca.pem"}, wantCertificates: true},
+		{name: "tls_disabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := application{
+				visaConnectionPool: connection.NewPool(connection.PoolConfiguration{Name: visa, TLS: tt.tls}, nil, logging.Logger{}, 0),
+			}
+
+			var checks registeredChecks
+			a.healthChecks(&checks)
+
+			registered := false
+			for _, name := range checks {
+				registered = registered || name == visa+"_certificates"
+			}
+			if registered != tt.wantCertificates {
+				t.Errorf("healthChecks() registered %v, want the certificate check %t", checks, tt.wantCertificates)
+			}
+		})
+	}
+}
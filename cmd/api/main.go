@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"sync"
 	"sync/atomic"
@@ -13,6 +14,8 @@ import (
 	gspanner "cloud.google.com/go/spanner"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/app"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/hsm"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	mastercardScheme "gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/mastercard"
 	visaScheme "gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/visa"
 	"gitlab.cmpayments.local/creditcard/platform"
@@ -54,6 +57,18 @@ func main() {
 	logger.Info(ctx, "starting authorization/"+version+" "+*configFile)
 	wg := &sync.WaitGroup{}
 
+	shutdownTracing, err := tracing.Setup(ctx, conf.Tracing, loggerName, version)
+	if err != nil {
+		logger.Emergency(logging.ContextWithError(ctx, err), "cannot set up tracing")
+		cancel()
+		return
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error(logging.ContextWithError(ctx, err), "cannot flush traces")
+		}
+	}()
+
 	app := application{
 		ctx:      ctx,
 		conf:     conf,
@@ -76,6 +91,31 @@ func main() {
 		defer app.visaConnectionPool.Stop()
 	}
 
+	// The software security module is for local development, online PINs fail without a PIN translator
+	if conf.Hsm.Enabled {
+		if conf.AllowProductionCardNumbers {
+			logger.Emergency(ctx, "the software security module cannot be enabled while production card numbers are allowed")
+			cancel()
+			return
+		}
+
+		mcPinTranslator, err := hsm.NewSoftware(conf.Hsm, conf.MasterCard.PinZone)
+		if err != nil {
+			logger.Emergency(logging.ContextWithError(ctx, err), "cannot set up the Mastercard PIN translation")
+			cancel()
+			return
+		}
+		app.mcPinTranslator = mcPinTranslator
+
+		visaPinTranslator, err := hsm.NewSoftware(conf.Hsm, conf.Visa.PinZone)
+		if err != nil {
+			logger.Emergency(logging.ContextWithError(ctx, err), "cannot set up the Visa PIN translation")
+			cancel()
+			return
+		}
+		app.visaPinTranslator = visaPinTranslator
+	}
+
 	app.isReady.Store(false)
 
 	// The application contains multiple services
@@ -115,9 +155,13 @@ type application struct {
 	mcConnectionPool   *connection.Pool
 	visaConnectionPool *connection.Pool
 	isReady            *atomic.Value
+	server             *http.Server
 	mip                *mastercardScheme.Mip
 	eas                *visaScheme.Eas
+	mcPinTranslator    mastercardScheme.PinTranslator
+	visaPinTranslator  visaScheme.PinTranslator
 	cardinfo           *cardinfo.Collection
+	cardNumberGuard    *cardinfo.CardNumberGuard
 }
 
 type service func(*application) error
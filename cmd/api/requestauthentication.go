@@ -7,6 +7,7 @@ import (
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/cmplatform"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/mock"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/web"
 )
 
@@ -17,7 +18,7 @@ func (app *application) RequestAuthenticator() web.RequestAuthenticator {
 	if app.conf.Development.MockCmPlatform {
 		pc = mock.PlatformClient{}
 	} else {
-		pc = cmplatform.NewIdentityClient(app.conf.CmPlatform.BaseDomain, http.Client{})
+		pc = cmplatform.NewIdentityClient(app.conf.CmPlatform.BaseDomain, http.Client{Transport: tracing.Transport(nil)})
 	}
 
 	if app.conf.Development.MockPermissionStore {
@@ -4,10 +4,22 @@ import (
 	"github.com/julienschmidt/httprouter"
 	authorizationApp "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app"
 	authorizationPorts "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/ports"
+	binPorts "gitlab.cmpayments.local/creditcard/authorization/internal/bin/ports"
 	captureApp "gitlab.cmpayments.local/creditcard/authorization/internal/capture/app"
 	capturePorts "gitlab.cmpayments.local/creditcard/authorization/internal/capture/ports"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/config/fetcher"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/echo/ports"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/probes"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
+	journalApp "gitlab.cmpayments.local/creditcard/authorization/internal/journal/app"
+	journalPorts "gitlab.cmpayments.local/creditcard/authorization/internal/journal/ports"
+	merchantApp "gitlab.cmpayments.local/creditcard/authorization/internal/merchant/app"
+	merchantPorts "gitlab.cmpayments.local/creditcard/authorization/internal/merchant/ports"
+	payoutApp "gitlab.cmpayments.local/creditcard/authorization/internal/payout/app"
+	payoutPorts "gitlab.cmpayments.local/creditcard/authorization/internal/payout/ports"
+	policyApp "gitlab.cmpayments.local/creditcard/authorization/internal/policy/app"
+	policyPorts "gitlab.cmpayments.local/creditcard/authorization/internal/policy/ports"
+	poolPorts "gitlab.cmpayments.local/creditcard/authorization/internal/pool/ports"
 	refundApp "gitlab.cmpayments.local/creditcard/authorization/internal/refund/app"
 	refundPorts "gitlab.cmpayments.local/creditcard/authorization/internal/refund/ports"
 	reversalApp "gitlab.cmpayments.local/creditcard/authorization/internal/reversal/app"
@@ -22,7 +34,8 @@ import (
 func (app *application) routes() (http.Handler, error) {
 	router := httprouter.New()
 
-	p := probes.NewProbesController(app.logger)
+	p := probes.NewProbesController(app.logger, app.conf.Health.CheckTimeout)
+	app.healthChecks(p)
 
 	timeTrack := timing.NewTracker(
 		timing.Slice{Interval: time.Second * 5, Duration: time.Minute},
@@ -37,6 +50,7 @@ func (app *application) routes() (http.Handler, error) {
 		//timing.Slice{Interval: time.Second, Duration: time.Second * 60},
 	)
 	timeTrack.Process(app.ctx)
+	app.poolGauges(timeTrack)
 
 	webReqAuthz := app.RequestAuthenticator()
 	webNonce := app.WebNonce()
@@ -45,10 +59,15 @@ func (app *application) routes() (http.Handler, error) {
 	captureRepo := app.CaptureStore()
 	reversalRepo := app.ReversalStore()
 	refundRepo := app.RefundStore()
+	payoutRepo := app.PayoutStore()
+	merchantRepo := app.MerchantStore()
+	policyRepo := app.PolicyStore()
+	journalRepo := app.JournalStore()
 
 	mastercardss := app.SequenceStore("visa_stan")
 	visass := app.SequenceStore("mastercard_stan")
-	schemeMapper := app.SchemeMapper(visass, mastercardss)
+	journalService := journalApp.NewJournalService(app.logger, journalRepo)
+	schemeMapper := app.SchemeMapper(timeTrack, p, journalService, visass, mastercardss)
 	tokenization, err := app.TokenizationService()
 	if err != nil {
 		return nil, err
@@ -60,11 +79,15 @@ func (app *application) routes() (http.Handler, error) {
 	}
 
 	reversalService := reversalApp.NewReversalService(app.logger, authRepo, captureRepo, reversalRepo, tokenization, schemeMapper)
-	authorizationService := authorizationApp.NewAuthorizationService(app.logger, authRepo, tokenization, reversalService, schemeMapper)
+	policyService := policyApp.NewPolicyService(app.logger, policyRepo)
+	authorizationService := authorizationApp.NewAuthorizationService(app.logger, authRepo, tokenization, reversalService, policyService, schemeMapper)
 	captureService := captureApp.NewCaptureService(authRepo, refundRepo, captureRepo, publisher, app.conf.GCP.PubSub.AuthorizationCapturedTopicID, app.conf.GCP.PubSub.RefundCapturedTopicID)
-	refundService := refundApp.NewRefundService(app.logger, refundRepo, tokenization, schemeMapper)
+	refundService := refundApp.NewRefundService(app.logger, refundRepo, tokenization, policyService, schemeMapper)
+	payoutService := payoutApp.NewPayoutService(app.logger, payoutRepo, tokenization, policyService, schemeMapper)
+	merchantService := merchantApp.NewMerchantService(app.logger, merchantRepo)
+	configFetcher := fetcher.NewConfigFetcher(app.MerchantSnapshotter(merchantRepo))
 
-	authorizationHandler := authorizationPorts.NewAuthorizationHandler(app.conf.AllowProductionCardNumbers, app.cardinfo, app.logger, authorizationService)
+	authorizationHandler := authorizationPorts.NewAuthorizationHandler(app.cardNumberGuard, app.cardinfo, app.logger, authorizationService, configFetcher, app.conf.Hsm.Enabled)
 
 	captureHandler := capturePorts.NewHttp(
 		captureService,
@@ -72,31 +95,44 @@ func (app *application) routes() (http.Handler, error) {
 	)
 
 	refundHandler := refundPorts.NewRefundHandler(
-		app.conf.AllowProductionCardNumbers,
+		app.cardNumberGuard,
 		app.logger,
 		refundService,
 		app.cardinfo,
 	)
 
+	payoutHandler := payoutPorts.NewPayoutHandler(
+		app.cardNumberGuard,
+		app.logger,
+		payoutService,
+		app.cardinfo,
+	)
+
 	reversalHandler := reversalPorts.NewReversalHandler(
-		app.conf.AllowProductionCardNumbers,
 		app.cardinfo,
 		app.logger,
 		reversalService,
 	)
 
+	merchantHandler := merchantPorts.NewMerchantHandler(app.logger, merchantService)
+	policyHandler := policyPorts.NewPolicyHandler(app.logger, policyService)
+	binHandler := binPorts.NewBinHandler(app.logger, app.cardinfo)
+	poolHandler := poolPorts.NewPoolHandler(app.logger, app.connectionPools())
+	journalHandler := journalPorts.NewJournalHandler(app.logger, journalService)
+
 	echoHandler := ports.NewEchoHandler(app.logger, schemeMapper)
 
 	fs := http.FileServer(http.Dir("./docs"))
 
 	// Endpoints
 	router.HandlerFunc(http.MethodGet, "/docs/*everything", http.StripPrefix("/docs", fs).ServeHTTP)
-	router.HandlerFunc(http.MethodGet, "/v1/probe/liveness", p.Ready(app.isReady))
-	router.HandlerFunc(http.MethodGet, "/v1/probe/readiness", p.Health())
+	router.HandlerFunc(http.MethodGet, "/v1/probe/liveness", p.Health())
+	router.HandlerFunc(http.MethodGet, "/v1/probe/readiness", p.Ready(app.isReady))
 	router.HandlerFunc(http.MethodGet, "/v1/echo/mastercard", echoHandler.SendEchoFn("mastercard"))
 	router.HandlerFunc(http.MethodGet, "/v1/echo/visa", echoHandler.SendEchoFn("visa"))
 
 	router.HandlerFunc(http.MethodGet, `/v1/metrics`, timing.MetricsHandler(timeTrack))
+	router.HandlerFunc(http.MethodGet, `/metrics`, timing.PrometheusHandler(timeTrack))
 
 	router.HandlerFunc(http.MethodPost, "/v1/authorizations",
 		timeTrack.Http("create_authorization",
@@ -140,8 +176,86 @@ func (app *application) routes() (http.Handler, error) {
 		timeTrack.Http("get_refunds",
 			webReqAuthz.WithPermission("get_refunds", refundHandler.GetRefunds)))
 
+	router.HandlerFunc(http.MethodPost, "/v1/payouts",
+		timeTrack.Http("create_payout",
+			webReqAuthz.WithPermission("create_payout",
+				webNonce.WithNonceCheck(payoutHandler.CreatePayout))))
+
+	router.HandlerFunc(http.MethodPost, "/v1/merchants",
+		timeTrack.Http("create_merchant",
+			webReqAuthz.WithPermission("manage_merchants", merchantHandler.CreateMerchant)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/merchants",
+		timeTrack.Http("get_merchants",
+			webReqAuthz.WithPermission("get_merchants", merchantHandler.GetMerchants)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/merchants/:merchantID",
+		timeTrack.Http("get_merchant",
+			webReqAuthz.WithPermission("get_merchants", merchantHandler.GetMerchant)))
+
+	router.HandlerFunc(http.MethodPut, "/v1/merchants/:merchantID",
+		timeTrack.Http("update_merchant",
+			webReqAuthz.WithPermission("manage_merchants", merchantHandler.UpdateMerchant)))
+
+	router.HandlerFunc(http.MethodDelete, "/v1/merchants/:merchantID",
+		timeTrack.Http("delete_merchant",
+			webReqAuthz.WithPermission("manage_merchants", merchantHandler.DeleteMerchant)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/merchant-defaults",
+		timeTrack.Http("get_merchant_defaults",
+			webReqAuthz.WithPermission("get_merchants", merchantHandler.GetMerchantDefaults)))
+
+	router.HandlerFunc(http.MethodPut, "/v1/merchant-defaults",
+		timeTrack.Http("update_merchant_defaults",
+			webReqAuthz.WithPermission("manage_merchants", merchantHandler.UpdateMerchantDefaults)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/admin/psps/:pspID/policy",
+		timeTrack.Http("get_policy",
+			webReqAuthz.WithPermission("get_policies", policyHandler.GetPolicy)))
+
+	router.HandlerFunc(http.MethodPut, "/v1/admin/psps/:pspID/policy",
+		timeTrack.Http("update_policy",
+			webReqAuthz.WithPermission("manage_policies", policyHandler.UpdatePolicy)))
+
+	router.HandlerFunc(http.MethodDelete, "/v1/admin/psps/:pspID/policy",
+		timeTrack.Http("delete_policy",
+			webReqAuthz.WithPermission("manage_policies", policyHandler.DeletePolicy)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/bins/:bin",
+		timeTrack.Http("get_bin",
+			webReqAuthz.WithPermission("get_bins", binHandler.GetBin)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/admin/bins/status",
+		timeTrack.Http("get_bin_status",
+			webReqAuthz.WithPermission("get_bin_status", binHandler.GetLoadStatus)))
+
+	router.HandlerFunc(http.MethodPost, "/v1/admin/bins/:scheme/rollback",
+		timeTrack.Http("rollback_bin_table",
+			webReqAuthz.WithPermission("manage_bin_tables", binHandler.RollbackTable)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/admin/connections",
+		timeTrack.Http("get_connections",
+			webReqAuthz.WithPermission("get_connections", poolHandler.GetConnections)))
+
+	router.HandlerFunc(http.MethodPost, "/v1/admin/connections/:scheme/reconnect/:connectionID",
+		timeTrack.Http("reconnect_connection",
+			webReqAuthz.WithPermission("manage_connections", poolHandler.Reconnect)))
+
+	router.HandlerFunc(http.MethodPost, "/v1/admin/connections/:scheme/drain",
+		timeTrack.Http("drain_pool",
+			webReqAuthz.WithPermission("manage_connections", poolHandler.Drain)))
+
+	router.HandlerFunc(http.MethodPost, "/v1/admin/connections/:scheme/resume",
+		timeTrack.Http("resume_pool",
+			webReqAuthz.WithPermission("manage_connections", poolHandler.Resume)))
+
+	router.HandlerFunc(http.MethodGet, "/v1/admin/transactions/:transactionID/messages",
+		timeTrack.Http("get_message_journal",
+			webReqAuthz.WithPermission("get_message_journal", journalHandler.GetMessages)))
+
 	// Wrap the router with all the middlewares
-	return logging.NewTraceIDMiddlewareFunc()(
-		httplog.NewHandler(app.logger,
-			logging.EnableCORS(router, app.conf.Cors.AllowedOrigins))), nil
+	return tracing.Handler(
+		logging.NewTraceIDMiddlewareFunc()(
+			httplog.NewHandler(app.logger,
+				logging.EnableCORS(router, app.conf.Cors.AllowedOrigins)))), nil
 }
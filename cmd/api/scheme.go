@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+
 	internalApp "gitlab.cmpayments.local/creditcard/authorization/internal/app"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/probes"
+	journalApp "gitlab.cmpayments.local/creditcard/authorization/internal/journal/app"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	_ "gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/mastercard"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 	timingwrappers "gitlab.cmpayments.local/creditcard/authorization/internal/timing/wrappers"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/sequences"
 )
@@ -13,12 +20,72 @@ const (
 	visa       = "visa"
 )
 
-func (app application) SchemeMapper(visaSequenceStore sequences.Store, mastercardSequenceStore sequences.Store) *authorization.Mapper {
-	app.mip = internalApp.Mip(app.ctx, app.logger, app.mcConnectionPool, app.mip, mastercardSequenceStore, app.shutdown)
-	app.eas = internalApp.Eas(app.ctx, app.logger, app.visaConnectionPool, app.eas, visaSequenceStore, app.conf.Visa.SourceStationID, app.conf.Visa.ConnectionPool.TickDelay, app.shutdown)
+func (app application) SchemeMapper(tracker *timing.Tracker, checks probes.Registry, messageJournal journalApp.JournalService, visaSequenceStore sequences.Store, mastercardSequenceStore sequences.Store) *authorization.Mapper {
+	app.mip = internalApp.Mip(app.ctx, app.logger, app.mcConnectionPool, app.mip, mastercardSequenceStore, messageJournal, app.mcPinTranslator, app.conf.MasterCard.EchoInterval, tracker, checks, app.shutdown)
+	app.eas = internalApp.Eas(app.ctx, app.logger, app.visaConnectionPool, app.eas, visaSequenceStore, messageJournal, app.visaPinTranslator, app.conf.Visa.SourceStationID, app.conf.Visa.ConnectionPool.TickDelay, tracker, checks, app.shutdown)
+	app.echoChecks(checks)
+	breakers := map[string]*authorization.Breaker{
+		mastercard: authorization.NewBreaker(mastercard, timingwrappers.SchemeConnection{Scheme: mastercard, Connection: app.mip}, app.conf.MasterCard.CircuitBreaker, app.logger),
+		visa:       authorization.NewBreaker(visa, timingwrappers.SchemeConnection{Scheme: visa, Connection: app.eas}, app.conf.Visa.CircuitBreaker, app.logger),
+	}
+	app.breakerMonitoring(tracker, checks, breakers)
+
 	sc := authorization.SchemeConnections{
-		mastercard: timingwrappers.SchemeConnection{Scheme: mastercard, Connection: app.mip},
-		visa:       timingwrappers.SchemeConnection{Scheme: visa, Connection: app.eas},
+		mastercard: breakers[mastercard],
+		visa:       breakers[visa],
 	}
 	return authorization.NewMapper(sc, app.logger)
 }
+
+// breakerMonitoring exposes the state of the circuit breakers, 0 is closed, 1 half-open and 2 open. A
+// breaker that is not closed degrades the readiness of the instance but doesn't take it down, the
+// breaker itself fails the requests of its scheme fast.
+func (app application) breakerMonitoring(tracker *timing.Tracker, checks probes.Registry, breakers map[string]*authorization.Breaker) {
+	for scheme, breaker := range breakers {
+		breaker := breaker
+		tracker.Gauge("authorization_circuit_breaker_state", "State of the scheme circuit breakers, 0 closed, 1 half-open and 2 open.",
+			map[string]string{"scheme": scheme},
+			func() float64 { return float64(breaker.State()) })
+
+		checks.RegisterScheme(scheme, scheme+"_circuit_breaker", breakerCheck(breaker))
+	}
+}
+
+// breakerCheck reports a breaker that is not closed as degraded
+func breakerCheck(breaker *authorization.Breaker) probes.Check {
+	return probes.Degraded(func(ctx context.Context) error {
+		if state := breaker.State(); state != authorization.BreakerClosed {
+			return fmt.Errorf("circuit breaker %s", state)
+		}
+		return nil
+	})
+}
+
+// connectionPools returns the scheme connection pools that are configured
+func (app application) connectionPools() map[string]*connection.Pool {
+	pools := map[string]*connection.Pool{}
+	if app.mcConnectionPool != nil {
+		pools[mastercard] = app.mcConnectionPool
+	}
+	if app.visaConnectionPool != nil {
+		pools[visa] = app.visaConnectionPool
+	}
+
+	return pools
+}
+
+// poolGauges exposes the configured and connected connections of the scheme connection pools
+func (app application) poolGauges(tracker *timing.Tracker) {
+	for scheme, pool := range app.connectionPools() {
+		pool := pool
+		tracker.Gauge("authorization_pool_connections", "Connections of the scheme connection pools.",
+			map[string]string{"pool": scheme, "state": "configured"},
+			func() float64 { return float64(pool.Size()) })
+		tracker.Gauge("authorization_pool_connections", "Connections of the scheme connection pools.",
+			map[string]string{"pool": scheme, "state": "connected"},
+			func() float64 { return float64(pool.Connected()) })
+		tracker.Gauge("authorization_pool_waiting", "Requests waiting for a free connection of the scheme connection pools.",
+			map[string]string{"pool": scheme},
+			func() float64 { return float64(pool.Waiting()) })
+	}
+}
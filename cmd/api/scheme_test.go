@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/probes"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+// unreachableScheme fails every request like a scheme that cannot be reached
+type unreachableScheme struct{}
+
+func (unreachableScheme) Authorize(ctx context.Context, a *entity.Authorization) error {
+	return connection.ErrNotConnected
+}
+
+func (unreachableScheme) Reverse(ctx context.Context, r *entity.Reversal) error {
+	return connection.ErrNotConnected
+}
+
+func (unreachableScheme) Refund(ctx context.Context, r *entity.Refund) error {
+	return connection.ErrNotConnected
+}
+
+func (unreachableScheme) Payout(ctx context.Context, p *entity.Payout) error {
+	return connection.ErrNotConnected
+}
+
+func (unreachableScheme) Echo(ctx context.Context) error {
+	return connection.ErrNotConnected
+}
+
+func TestBreakerCheck(t *testing.T) {
+	breaker := authorization.NewBreaker(mastercard, unreachableScheme{}, authorization.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Minute}, logging.Logger{})
+	_ = breaker.Authorize(context.Background(), &entity.Authorization{})
+	if breaker.State() != authorization.BreakerOpen {
+		t.Fatalf("State() = %s, want %s", breaker.State(), authorization.BreakerOpen)
+	}
+
+	checks := probes.NewProbesController(logging.Logger{}, time.Second)
+	checks.RegisterScheme(mastercard, mastercard+"_circuit_breaker", breakerCheck(breaker))
+
+	isReady := &atomic.Value{}
+	isReady.Store(true)
+
+	w := httptest.NewRecorder()
+	checks.Ready(isReady)(w, httptest.NewRequest(http.MethodGet, "/v1/probe/readiness", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Ready() code = %d, want %d", w.Code, http.StatusOK)
+	}
+	var got struct {
+		Status string `json:"status"`
+		Checks []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Ready() cannot parse response: %v", err)
+	}
+	if got.Status != "degraded" {
+		t.Errorf("Ready() status = %s, want degraded", got.Status)
+	}
+	if len(got.Checks) != 1 || got.Checks[0].Status != "degraded" || got.Checks[0].Error != "circuit breaker open" {
+		t.Errorf("Ready() checks = %+v, want the circuit breaker degraded", got.Checks)
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"net/http"
+
 	timingwrappers "gitlab.cmpayments.local/creditcard/authorization/internal/timing/wrappers"
 
 	cardinfoclient "gitlab.cmpayments.local/creditcard/card-info-api/pkg/cardinfoapi"
@@ -12,6 +14,7 @@ import (
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/cardInfo"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/mock"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tokenization"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
 )
 
@@ -20,6 +23,10 @@ func (app *application) TokenizationService() (app.Tokenizer, error) {
 		return mock.Tokenization{}, nil
 	}
 
+	if err := propagateTraceContext(app.conf.Tokenization.BaseURL, app.conf.Detokenization.BaseURL); err != nil {
+		return nil, fmt.Errorf("failed to propagate the trace context to tokenization: %w", err)
+	}
+
 	tokenizationClient, err := platformclient.New(app.conf.Tokenization.BaseURL, app.conf.JWT, app.logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tokenization client: %w", err)
@@ -44,6 +51,10 @@ func (app *application) CardInfoService() (processing.CardInfoService, error) {
 		return mock.CardInfoApi{}, nil
 	}
 
+	if err := propagateTraceContext(app.conf.CardInfoApi.BaseURL); err != nil {
+		return nil, fmt.Errorf("failed to propagate the trace context to card info: %w", err)
+	}
+
 	// uses fake jwt token. The cardinfoapi does not use a token.
 	pc, err := platformclient.New(app.conf.CardInfoApi.BaseURL, app.conf.JWT, app.logger)
 	if err != nil {
@@ -54,3 +65,16 @@ func (app *application) CardInfoService() (processing.CardInfoService, error) {
 		cardinfoclient.NewClient(pc),
 	), nil
 }
+
+// propagateTraceContext propagates the trace context on the requests to baseURLs. The platform clients
+// cannot be given a transport and send their requests over the default transport, so only the requests
+// to their services are traced on it.
+func propagateTraceContext(baseURLs ...string) error {
+	transport, err := tracing.HostTransport(http.DefaultTransport, baseURLs...)
+	if err != nil {
+		return err
+	}
+	http.DefaultTransport = transport
+
+	return nil
+}
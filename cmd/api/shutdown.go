@@ -1,9 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/libraries-go/logging"
 )
 
 func shutdown(app *application) error {
@@ -17,6 +23,7 @@ func shutdown(app *application) error {
 		select {
 		case <-c:
 			app.logger.Info(app.ctx, "shutdown: got signal")
+			app.drain()
 			app.shutdown()
 		case <-app.ctx.Done():
 			app.logger.Info(app.ctx, "shutdown: context canceled, all done")
@@ -27,3 +34,49 @@ func shutdown(app *application) error {
 
 	return nil
 }
+
+// drain stops taking requests before the application context is canceled. The requests that were sent
+// to the schemes get until the drain timeout for their response, the authorizations that are unanswered
+// then are reversed before the connections close.
+func (app *application) drain() {
+	app.isReady.Store(false)
+
+	pools := app.connectionPools()
+	for _, pool := range pools {
+		pool.Drain()
+	}
+
+	// The request contexts derive from the application context, so the handlers and the reversals they
+	// send keep running until the webserver is shut down
+	ctx, cancel := context.WithTimeout(context.Background(), app.conf.Shutdown.DrainTimeout+app.conf.Shutdown.ReversalTimeout)
+	defer cancel()
+
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		if app.server == nil {
+			return
+		}
+		if err := app.server.Shutdown(ctx); err != nil {
+			app.logger.Error(logging.ContextWithError(app.ctx, err), "shutdown: requests still running after the reversal timeout")
+		}
+	}()
+
+	deadline, cancelDeadline := context.WithTimeout(ctx, app.conf.Shutdown.DrainTimeout)
+	defer cancelDeadline()
+
+	wg := sync.WaitGroup{}
+	for scheme, pool := range pools {
+		wg.Add(1)
+		go func(scheme string, pool *connection.Pool) {
+			defer wg.Done()
+			if abandoned := pool.WaitIdle(deadline); abandoned > 0 {
+				app.logger.Warning(app.ctx, fmt.Sprintf("shutdown: %d %s requests unanswered at the drain timeout, reversing", abandoned, scheme))
+			}
+		}(scheme, pool)
+	}
+	wg.Wait()
+
+	<-served
+	app.logger.Info(app.ctx, "shutdown: drained")
+}
@@ -8,6 +8,10 @@ import (
 	authorizationAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/adapters"
 	captureAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/capture/adapters"
 	captureService "gitlab.cmpayments.local/creditcard/authorization/internal/capture/app"
+	journalAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/journal/adapters"
+	merchantAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/merchant/adapters"
+	payoutAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/payout/adapters"
+	policyAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/policy/adapters"
 	refundAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/refund/adapters"
 	reversalAdapter "gitlab.cmpayments.local/creditcard/authorization/internal/reversal/adapters"
 
@@ -15,8 +19,18 @@ import (
 	authApp "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app"
 	authMock "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app/mock"
 	captureMock "gitlab.cmpayments.local/creditcard/authorization/internal/capture/app/mock"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/config"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/mock"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+	journalApp "gitlab.cmpayments.local/creditcard/authorization/internal/journal/app"
+	journalMock "gitlab.cmpayments.local/creditcard/authorization/internal/journal/app/mock"
+	merchantApp "gitlab.cmpayments.local/creditcard/authorization/internal/merchant/app"
+	merchantMock "gitlab.cmpayments.local/creditcard/authorization/internal/merchant/app/mock"
+	payoutApp "gitlab.cmpayments.local/creditcard/authorization/internal/payout/app"
+	payoutMock "gitlab.cmpayments.local/creditcard/authorization/internal/payout/app/mock"
+	policyApp "gitlab.cmpayments.local/creditcard/authorization/internal/policy/app"
+	policyMock "gitlab.cmpayments.local/creditcard/authorization/internal/policy/app/mock"
 	refundApp "gitlab.cmpayments.local/creditcard/authorization/internal/refund/app"
 	refundMock "gitlab.cmpayments.local/creditcard/authorization/internal/refund/app/mock"
 	reversal "gitlab.cmpayments.local/creditcard/authorization/internal/reversal/app"
@@ -100,6 +114,18 @@ func (app *application) RefundStore() refundApp.Repository {
 	return timedRepo
 }
 
+func (app *application) PayoutStore() payoutApp.Repository {
+	if app.conf.Development.MockData {
+		return payoutMock.PayoutRepo{}
+	}
+	repo := payoutAdapter.NewPayoutRepository(app.spannerClient,
+		app.conf.GCP.Spanner.ReadTimeout, app.conf.GCP.Spanner.WriteTimeout)
+
+	timedRepo := timingwrappers.PayoutRepository{Base: repo}
+
+	return timedRepo
+}
+
 func (app *application) CaptureStore() captureService.CaptureRepository {
 	if app.conf.Development.MockData {
 		return captureMock.CaptureRepo{}
@@ -125,6 +151,62 @@ func (app *application) ReversalStore() reversal.ReversalRepository {
 	return timedRepo
 }
 
+func (app *application) MerchantStore() merchantApp.Repository {
+	if app.conf.Development.MockData {
+		return merchantMock.MerchantRepo{}
+	}
+
+	repo := merchantAdapter.NewMerchantRepository(app.spannerClient,
+		app.conf.GCP.Spanner.ReadTimeout, app.conf.GCP.Spanner.WriteTimeout)
+
+	timedRepo := timingwrappers.MerchantRepository{Base: repo}
+
+	return timedRepo
+}
+
+// MerchantSnapshotter resolves the effective merchant configuration, with the merchant defaults
+// from the configuration file as the least specific level
+func (app *application) MerchantSnapshotter(store merchantApp.Repository) config.Snapshotter {
+	d := app.conf.MerchantDefaults
+
+	return config.NewSnapshotter(store, entity.CardAcceptor{
+		CategoryCode: d.CategoryCode,
+		ID:           d.ID,
+		Name:         d.Name,
+		Address: entity.CardAcceptorAddress{
+			PostalCode:  d.PostalCode,
+			City:        d.City,
+			CountryCode: d.Country,
+		},
+	})
+}
+
+func (app *application) PolicyStore() policyApp.Repository {
+	if app.conf.Development.MockData {
+		return policyMock.PolicyRepo{}
+	}
+
+	repo := policyAdapter.NewPolicyRepository(app.spannerClient,
+		app.conf.GCP.Spanner.ReadTimeout, app.conf.GCP.Spanner.WriteTimeout)
+
+	timedRepo := timingwrappers.PolicyRepository{Base: repo}
+
+	return timedRepo
+}
+
+func (app *application) JournalStore() journalApp.Repository {
+	if app.conf.Development.MockData {
+		return journalMock.JournalRepo{}
+	}
+
+	repo := journalAdapter.NewJournalRepository(app.spannerClient,
+		app.conf.GCP.Spanner.ReadTimeout, app.conf.GCP.Spanner.WriteTimeout)
+
+	timedRepo := timingwrappers.JournalRepository{Base: repo}
+
+	return timedRepo
+}
+
 func (app *application) PaymentServiceProviderStore() web.PspStore {
 	if app.conf.Development.MockData {
 		return mock.NewMockPaymentServiceProvider()
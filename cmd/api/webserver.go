@@ -16,7 +16,7 @@ func webserver(app *application) error {
 		return err
 	}
 
-	server := http.Server{
+	server := &http.Server{
 		Addr:    app.conf.Listen,
 		Handler: routes,
 		BaseContext: func(listener net.Listener) context.Context {
@@ -24,6 +24,8 @@ func webserver(app *application) error {
 		},
 	}
 
+	app.server = server
+
 	app.wg.Add(1)
 	go func() {
 		app.logger.Info(ctx, "webserver starting on "+app.conf.Listen)
@@ -31,11 +33,12 @@ func webserver(app *application) error {
 		if err != nil && err != http.ErrServerClosed {
 			ctx = logging.ContextWithError(ctx, err)
 			app.logger.Error(ctx, "webserver crashed")
+			app.shutdown()
 		} else {
+			// Closed by the shutdown, which waits for the running requests before it cancels the context
 			app.logger.Info(ctx, "webserver stopped")
 		}
 		app.wg.Done()
-		app.shutdown()
 	}()
 
 	go func() {
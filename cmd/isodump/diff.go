@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/mastercard"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/visa"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+// printDiff encodes the decoded message again with our encoder and writes per element where it differs
+// from the packet. The Visa header is built from the source station ID of the message and is left out.
+func printDiff(w io.Writer, ref *packet) {
+	if ref.decodeErr != nil {
+		fmt.Fprintf(w, "diff: the packet did not decode, there is nothing to encode again\n\n")
+		return
+	}
+
+	var (
+		raw []byte
+		err error
+	)
+	switch ref.framing {
+	case mipFraming:
+		raw, err = mastercard.NewRequest(&ref.mip).Packet()
+	case easFraming:
+		raw, err = visa.NewRequest(&ref.eas).Packet()
+	}
+	if err != nil {
+		fmt.Fprintf(w, "diff: our encoder fails on the message: %s\n\n", err)
+		return
+	}
+
+	ours, err := decodePacket(raw, ref.framing)
+	if err != nil {
+		fmt.Fprintf(w, "diff: %s\n\n", err)
+		return
+	}
+	if ours.decodeErr != nil {
+		fmt.Fprintf(w, "diff: the message we encoded does not decode: %s\n", ours.decodeErr)
+	}
+
+	_, values, masked := ref.fields()
+	indexes := elementIndexes(values.Type())
+
+	refElements, ourElements := byNumber(ref.offsets), byNumber(ours.offsets)
+	identical := len(ref.raw)-ref.offset == len(ours.raw)-ours.offset
+
+	fmt.Fprintf(w, "diff: reference message %d bytes, encoded again %d bytes\n", len(ref.raw)-ref.offset, len(ours.raw)-ours.offset)
+	for _, number := range numbers(refElements, ourElements) {
+		r, inRef := refElements[number]
+		o, inOurs := ourElements[number]
+		label := elementLabel(number, indexes, values.Type())
+
+		switch {
+		case !inOurs:
+			identical = false
+			fmt.Fprintf(w, "  -   %-50s only in the reference, %d bytes at %d\n", label, r.Length, ref.offset+r.Offset)
+		case !inRef:
+			identical = false
+			fmt.Fprintf(w, "  +   %-50s only encoded by us, %d bytes at %d\n", label, o.Length, ours.offset+o.Offset)
+		case bytes.Equal(ref.element(r), ours.element(o)):
+			fmt.Fprintf(w, "  =   %s\n", label)
+		default:
+			identical = false
+			refBytes, ourBytes := ref.element(r), ours.element(o)
+			fmt.Fprintf(w, "  !=  %-50s differs from byte %d: reference %d bytes at %d, ours %d bytes at %d\n",
+				label, firstDifference(refBytes, ourBytes), r.Length, ref.offset+r.Offset, o.Length, ours.offset+o.Offset)
+			if !sensitive(number, indexes, values, masked) {
+				fmt.Fprintf(w, "        reference %s\n", hex.EncodeToString(refBytes))
+				fmt.Fprintf(w, "        ours      %s\n", hex.EncodeToString(ourBytes))
+			}
+		}
+	}
+
+	if identical {
+		fmt.Fprintln(w, "  the message encoded again is identical to the reference")
+	}
+	fmt.Fprintln(w)
+}
+
+func byNumber(offsets []iso8583.ElementOffset) map[int]iso8583.ElementOffset {
+	elements := make(map[int]iso8583.ElementOffset, len(offsets))
+	for _, o := range offsets {
+		elements[o.Number] = o
+	}
+
+	return elements
+}
+
+// numbers returns the element numbers of both messages in order
+func numbers(a, b map[int]iso8583.ElementOffset) []int {
+	seen := make(map[int]bool, len(a)+len(b))
+	var sorted []int
+	for _, elements := range []map[int]iso8583.ElementOffset{a, b} {
+		for number := range elements {
+			if !seen[number] {
+				seen[number] = true
+				sorted = append(sorted, number)
+			}
+		}
+	}
+	sort.Ints(sorted)
+
+	return sorted
+}
+
+func firstDifference(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	if len(a) < len(b) {
+		return len(a)
+	}
+	return len(b)
+}
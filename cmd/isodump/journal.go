@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+// journal is the response of GET /v1/admin/transactions/:transactionID/messages
+type journal struct {
+	TransactionID   string `json:"transactionId"`
+	TransactionType string `json:"transactionType"`
+	Scheme          string `json:"scheme"`
+	Messages        []struct {
+		Direction string          `json:"direction"`
+		Mti       string          `json:"mti"`
+		Stan      string          `json:"stan"`
+		Elements  json.RawMessage `json:"elements"`
+		CreatedAt string          `json:"createdAt"`
+	} `json:"messages"`
+}
+
+// printJournal writes the fields of the journaled messages of a transaction. The journal holds the
+// decoded elements without the raw packets, so there are no offsets to show and nothing to encode again.
+func printJournal(w io.Writer, r io.Reader) error {
+	var j journal
+	if err := json.NewDecoder(r).Decode(&j); err != nil {
+		return fmt.Errorf("read journal: %w", err)
+	}
+
+	fmt.Fprintf(w, "%s %s %s, %d messages\n\n", j.Scheme, j.TransactionType, j.TransactionID, len(j.Messages))
+
+	for i, m := range j.Messages {
+		fmt.Fprintf(w, "message %d: %s %s, STAN %s at %s\n", i+1, m.Direction, m.Mti, m.Stan, m.CreatedAt)
+
+		var failed struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(m.Elements, &failed); err == nil && failed.Error != "" {
+			fmt.Fprintf(w, "  not journaled: %s\n\n", failed.Error)
+			continue
+		}
+
+		var fields interface{}
+		switch j.Scheme {
+		case entity.Mastercard:
+			fields = &cis.DataElements{}
+		case entity.Visa:
+			fields = &base1.Fields{}
+		default:
+			return fmt.Errorf("unknown scheme %q", j.Scheme)
+		}
+
+		if err := json.Unmarshal(m.Elements, fields); err != nil {
+			return fmt.Errorf("message %d: %w", i+1, err)
+		}
+
+		fmt.Fprintln(w, "fields:")
+		printFields(w, "  ", reflect.ValueOf(fields).Elem())
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Isodump decodes Mastercard MIP and Visa EAS packets and prints their elements with their offsets and
+// the decoded fields, so a message a scheme rejects with a format error does not have to be decoded by
+// hand. The cardholder data is masked like in the message journal.
+//
+// Packets are read as hex, a packet per line, or as binary packets one after the other:
+//
+//	isodump -in packets.hex
+//	isodump -binary -in capture.bin -diff
+//	isodump -journal messages.json
+//	isodump -spec mastercard > spec.yaml
+//
+// With -diff every packet is encoded again with our encoder and the elements that differ are shown, to
+// compare our messages with a reference message. With -spec the layout of the elements of a scheme is written
+// as a spec that iso8583.LoadSpec reads.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+type config struct {
+	in          string
+	binary      bool
+	framing     string
+	diff        bool
+	journalFile string
+	spec        string
+}
+
+var cfg config
+
+func main() {
+	flag.StringVar(&cfg.in, "in", "-", "file with the packets, - reads stdin")
+	flag.BoolVar(&cfg.binary, "binary", false, "read binary packets one after the other, as on the connection, instead of a hex packet per line")
+	flag.StringVar(&cfg.framing, "framing", "", "framing of the packets, mip or eas, instead of detecting it per packet")
+	flag.BoolVar(&cfg.diff, "diff", false, "encode every packet again and show the elements where our encoder differs")
+	flag.StringVar(&cfg.journalFile, "journal", "", "file with the response of GET /v1/admin/transactions/:transactionID/messages, - reads stdin")
+	flag.StringVar(&cfg.spec, "spec", "", "write the element layout of a scheme, mastercard or visa, as a YAML spec")
+
+	flag.Parse()
+
+	if err := run(os.Stdout); err != nil {
+		log.Printf("isodump: %s", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer) error {
+	if cfg.spec != "" {
+		return printSpec(w, cfg.spec)
+	}
+
+	if cfg.journalFile != "" {
+		r, err := open(cfg.journalFile)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		return printJournal(w, r)
+	}
+
+	forced := framing(cfg.framing)
+	if forced != "" && forced != mipFraming && forced != easFraming {
+		return fmt.Errorf("unknown framing %q, want mip or eas", cfg.framing)
+	}
+
+	r, err := open(cfg.in)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var packets [][]byte
+	if cfg.binary {
+		packets, err = readBinaryPackets(r, forced)
+	} else {
+		packets, err = readHexPackets(r)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, raw := range packets {
+		if !cfg.binary {
+			raw = withLengthPrefix(raw)
+		}
+
+		p, err := decodePacket(raw, forced)
+		if err != nil {
+			fmt.Fprintf(w, "packet %d: %d bytes, %s\n\n", i+1, len(raw), err)
+			continue
+		}
+
+		printPacket(w, i+1, p)
+		if cfg.diff {
+			printDiff(w, p)
+		}
+	}
+
+	return nil
+}
+
+func open(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(name)
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/mastercard"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/visa"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+type framing string
+
+const (
+	// mipFraming is a 2 byte length followed by a CIS message, EBCDIC encoded (ASCII is also accepted)
+	mipFraming framing = "mip"
+	// easFraming is a 4 byte VMLH and the Visa header followed by a BASE I message
+	easFraming framing = "eas"
+)
+
+const (
+	mipLengthSize    = 2
+	easVmlhSize      = 4
+	easHeaderLength  = 22
+	easRejectHeader  = 26
+	easHeaderVersion = 1
+	asciiZero        = byte(0x30)
+	ebcdicZero       = byte(0xf0)
+)
+
+var (
+	ErrUnknownFraming = errors.New("packet is neither MIP nor EAS framed")
+	ErrNoMessage      = errors.New("packet ends before the message")
+)
+
+// packet is a decoded packet with the position of its elements in the packet
+type packet struct {
+	raw     []byte
+	framing framing
+	// offset is where the ISO 8583 message starts in raw, the element offsets are relative to it
+	offset    int
+	offsets   []iso8583.ElementOffset
+	mip       mastercard.Message
+	eas       visa.Message
+	decodeErr error
+}
+
+// readHexPackets reads a packet per line, written in hex. Whitespace, colons and a 0x prefix are
+// ignored, as are empty lines and lines starting with #.
+func readHexPackets(r io.Reader) ([][]byte, error) {
+	var packets [][]byte
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		text = strings.TrimPrefix(strings.TrimPrefix(text, "0x"), "0X")
+		text = strings.NewReplacer(" ", "", "\t", "", ":", "").Replace(text)
+
+		p, err := hex.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		packets = append(packets, p)
+	}
+
+	return packets, scanner.Err()
+}
+
+// readBinaryPackets splits a stream of framed packets, like a capture of a scheme connection
+func readBinaryPackets(r io.Reader, forced framing) ([][]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var packets [][]byte
+	for len(data) > 0 {
+		f := forced
+		if f == "" {
+			if f, err = detectFraming(data); err != nil {
+				return nil, fmt.Errorf("packet %d: %w", len(packets)+1, err)
+			}
+		}
+
+		size := framedSize(data, f)
+		if size > len(data) {
+			return nil, fmt.Errorf("packet %d: %d bytes framed, %d left", len(packets)+1, size, len(data))
+		}
+
+		packets = append(packets, data[:size])
+		data = data[size:]
+	}
+
+	return packets, nil
+}
+
+// framedSize returns the size of the packet at the start of data, including its length prefix
+func framedSize(data []byte, f framing) int {
+	if len(data) < mipLengthSize {
+		return len(data) + 1
+	}
+
+	length := int(binary.BigEndian.Uint16(data))
+	if f == easFraming {
+		return easVmlhSize + length
+	}
+
+	return mipLengthSize + length
+}
+
+// detectFraming tells MIP and EAS packets apart: after its length a MIP packet starts with the MTI, in
+// EBCDIC or ASCII digits, and an EAS packet with 2 reserved zero bytes and the length of the Visa header
+func detectFraming(p []byte) (framing, error) {
+	if len(p) > mipLengthSize && (p[mipLengthSize] == ebcdicZero || p[mipLengthSize] == asciiZero) {
+		return mipFraming, nil
+	}
+
+	if len(p) > easVmlhSize && p[2] == 0 && p[3] == 0 &&
+		(p[easVmlhSize] == easHeaderLength || p[easVmlhSize] >= easRejectHeader) {
+		return easFraming, nil
+	}
+
+	return "", ErrUnknownFraming
+}
+
+// withLengthPrefix adds the length prefix to a message that was copied without it, so packets from a
+// trace and from the connection logs can be used alike
+func withLengthPrefix(p []byte) []byte {
+	switch {
+	case len(p) > 0 && (p[0] == ebcdicZero || p[0] == asciiZero):
+		prefix := make([]byte, mipLengthSize)
+		binary.BigEndian.PutUint16(prefix, uint16(len(p)))
+		return append(prefix, p...)
+	case len(p) > 1 && p[0] == easHeaderLength && p[1] == easHeaderVersion:
+		prefix := make([]byte, easVmlhSize)
+		binary.BigEndian.PutUint16(prefix, uint16(len(p)))
+		return append(prefix, p...)
+	default:
+		return p
+	}
+}
+
+// decodePacket decodes a framed packet, a packet that fails to decode keeps the elements that were
+// decoded up to the failing one
+func decodePacket(raw []byte, forced framing) (*packet, error) {
+	f := forced
+	if f == "" {
+		var err error
+		if f, err = detectFraming(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &packet{raw: raw, framing: f}
+
+	switch f {
+	case mipFraming:
+		p.offset = mipLengthSize
+	case easFraming:
+		p.offset = easMessageOffset(raw)
+	default:
+		return nil, fmt.Errorf("unknown framing %q", f)
+	}
+
+	if p.offset >= len(raw) {
+		return nil, ErrNoMessage
+	}
+
+	if f == mipFraming {
+		p.mip, p.decodeErr = mastercard.Decode(raw[mipLengthSize:], iso8583.Offsets(&p.offsets))
+	} else {
+		p.eas, p.decodeErr = visa.Decode(raw[mipLengthSize:], iso8583.Offsets(&p.offsets))
+	}
+
+	return p, nil
+}
+
+// easMessageOffset returns where the BASE I message starts, after the VMLH, the Visa header and, for a
+// rejected message, the header of the original message
+func easMessageOffset(raw []byte) int {
+	if len(raw) <= easVmlhSize {
+		return len(raw)
+	}
+
+	offset := easVmlhSize + int(raw[easVmlhSize])
+	if raw[easVmlhSize] >= easRejectHeader && offset < len(raw) {
+		offset += int(raw[offset])
+	}
+
+	return offset
+}
+
+// element returns the bytes of the element as they are in the packet
+func (p *packet) element(o iso8583.ElementOffset) []byte {
+	start := p.offset + o.Offset
+	end := start + o.Length
+	if end > len(p.raw) {
+		end = len(p.raw)
+	}
+
+	return p.raw[start:end]
+}
+
+// ebcdic tells whether the text of the message is EBCDIC encoded
+func (p *packet) ebcdic() bool {
+	return p.framing == mipFraming && p.offset < len(p.raw) && p.raw[p.offset] == ebcdicZero
+}
+
+func (p *packet) describe() string {
+	switch {
+	case p.framing == easFraming:
+		return "EAS (Visa header, BASE I)"
+	case p.ebcdic():
+		return "MIP (CIS, EBCDIC)"
+	default:
+		return "MIP (CIS, ASCII)"
+	}
+}
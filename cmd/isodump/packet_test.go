@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDetectFraming(t *testing.T) {
+	tests := []struct {
+		name     string
+		packet   []byte
+		expected framing
+		err      error
+	}{
+		{
+			name:     "mip ebcdic",
+			packet:   []byte{0x00, 0x04, 0xf0, 0xf8, 0xf0, 0xf0},
+			expected: mipFraming,
+		},
+		{
+			name:     "mip ascii",
+			packet:   []byte{0x00, 0x04, '0', '8', '0', '0'},
+			expected: mipFraming,
+		},
+		{
+			name:     "eas",
+			packet:   []byte{0x00, 0x18, 0x00, 0x00, 0x16, 0x01, 0x02},
+			expected: easFraming,
+		},
+		{
+			name:     "eas rejected",
+			packet:   []byte{0x00, 0x18, 0x00, 0x00, 0x1a, 0x01, 0x02},
+			expected: easFraming,
+		},
+		{
+			name:   "unknown",
+			packet: []byte{0x00, 0x04, 0x01, 0x02, 0x03, 0x04},
+			err:    ErrUnknownFraming,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectFraming(tt.packet)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("detectFraming() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.expected {
+				t.Errorf("detectFraming() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubelements(t *testing.T) {
+	tests := []struct {
+		name     string
+		split    func([]byte) ([]span, error)
+		element  []byte
+		expected []span
+		wantErr  bool
+	}{
+		{
+			name:    "de48",
+			split:   de48Subelements,
+			element: []byte("014T4203210920299"),
+			expected: []span{
+				{label: "TCC", offset: 3, length: 1, depth: 1},
+				{label: "SE42", offset: 4, length: 7, depth: 1},
+				{label: "SE92", offset: 11, length: 6, depth: 1},
+			},
+		},
+		{
+			name:    "de48 truncated subelement",
+			split:   de48Subelements,
+			element: []byte("010T42053210"),
+			expected: []span{
+				{label: "TCC", offset: 3, length: 1, depth: 1},
+				{label: "SE42", offset: 4, length: 9, depth: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "f034",
+			split:   f034Datasets,
+			element: []byte{0x00, 0x0a, 0x01, 0x00, 0x07, 0x86, 0x05, 0xf2, 0x4b, 0xf2, 0x4b, 0xf0},
+			expected: []span{
+				{label: "dataset 01", offset: 2, length: 10, depth: 1},
+				{label: "tag 86", offset: 5, length: 7, depth: 2},
+			},
+		},
+		{
+			name:    "f034 tag longer than its dataset",
+			split:   f034Datasets,
+			element: []byte{0x00, 0x06, 0x02, 0x00, 0x03, 0x80, 0x05, 0xf1},
+			expected: []span{
+				{label: "dataset 02", offset: 2, length: 6, depth: 1},
+				{label: "tag 80", offset: 5, length: 7, depth: 2},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.split(tt.element)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("split error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("split = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+// printSpec writes the layout of the elements of a scheme as a YAML spec, as it is defined by the iso8583-tags
+// of its struct. It is how pkg/mastercard/cis/spec.yaml and pkg/visa/base1/spec.yaml are made.
+func printSpec(w io.Writer, scheme string) error {
+	var (
+		spec *iso8583.Spec
+		err  error
+	)
+
+	switch scheme {
+	case "mastercard":
+		spec, err = iso8583.StructSpec(scheme, cis.DataElements{})
+	case "visa":
+		spec, err = iso8583.StructSpec(scheme, base1.Fields{})
+	default:
+		return fmt.Errorf("unknown scheme %q, want mastercard or visa", scheme)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "# The %s elements as defined by the iso8583-tags, written by isodump -spec %s\n", scheme, scheme); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	return encoder.Encode(spec)
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/mastercard"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/visa"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+const (
+	mcAdditionalData         = 48
+	visaElectronicCommerce   = 34
+	de48LengthIndicator      = 3
+	f034LengthIndicator      = 2
+	de48SubelementHeaderSize = 4
+	f034DatasetHeaderSize    = 3
+	f034TagHeaderSize        = 2
+)
+
+// span is a part of an element, relative to the start of the element
+type span struct {
+	label  string
+	offset int
+	length int
+	depth  int
+}
+
+// fields returns the MTI and the decoded elements of the packet, as they were decoded and with the
+// cardholder data masked like in the message journal
+func (p *packet) fields() (string, reflect.Value, reflect.Value) {
+	if p.framing == easFraming {
+		return p.eas.Mti.String(), reflect.ValueOf(p.eas.Fields), reflect.ValueOf(visa.Masked(p.eas).Fields)
+	}
+
+	return p.mip.Mti.String(), reflect.ValueOf(p.mip.DataElements), reflect.ValueOf(mastercard.Masked(p.mip).DataElements)
+}
+
+// sensitive tells whether masking changed the element, its raw bytes must not be shown then
+func sensitive(number int, indexes map[int]int, values, masked reflect.Value) bool {
+	i, ok := indexes[number]
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(values.Field(i).Interface(), masked.Field(i).Interface())
+}
+
+// printPacket writes the elements of the packet with their offsets in the packet and the decoded fields
+func printPacket(w io.Writer, n int, p *packet) {
+	mti, values, masked := p.fields()
+	indexes := elementIndexes(values.Type())
+
+	fmt.Fprintf(w, "packet %d: %d bytes, %s\n", n, len(p.raw), p.describe())
+	fmt.Fprintf(w, "MTI %s, message at offset %d\n", mti, p.offset)
+	fmt.Fprintf(w, "  %6s  %6s  %-50s %s\n", "offset", "length", "element", "raw")
+
+	for _, o := range p.offsets {
+		raw := hex.EncodeToString(p.element(o))
+		if sensitive(o.Number, indexes, values, masked) {
+			raw = "(masked)"
+		}
+		fmt.Fprintf(w, "  %6d  %6d  %-50s %s\n", p.offset+o.Offset, o.Length, elementLabel(o.Number, indexes, values.Type()), raw)
+		p.printSubelements(w, o)
+	}
+
+	if p.decodeErr != nil {
+		stop := decodedLength(p.offsets)
+		fmt.Fprintf(w, "  decoding stopped at offset %d: %s\n", p.offset+stop, p.decodeErr)
+
+		// The layout of the element that failed can still show where it went wrong
+		var elementErr iso8583.ElementError
+		if errors.As(p.decodeErr, &elementErr) {
+			p.printSubelements(w, iso8583.ElementOffset{Number: elementErr.Number, Offset: stop, Length: len(p.raw) - p.offset - stop})
+		}
+	}
+
+	fmt.Fprintln(w, "fields:")
+	printFields(w, "  ", masked)
+	fmt.Fprintln(w)
+}
+
+func (p *packet) printSubelements(w io.Writer, o iso8583.ElementOffset) {
+	spans, err := p.subelements(o)
+	for _, s := range spans {
+		fmt.Fprintf(w, "  %6d  %6d  %s%s\n", p.offset+o.Offset+s.offset, s.length, strings.Repeat("  ", s.depth), s.label)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "  %6s  %6s    %s\n", "", "", err)
+	}
+}
+
+// subelements splits the elements that hold subelements in the TLV like layouts the decoded fields do
+// not show: the DE48 subelements of Mastercard and the F034 datasets of Visa
+func (p *packet) subelements(o iso8583.ElementOffset) ([]span, error) {
+	switch {
+	case p.framing == mipFraming && o.Number == mcAdditionalData:
+		text := p.element(o)
+		if p.ebcdic() {
+			var err error
+			if text, err = charmap.CodePage1047.NewDecoder().Bytes(text); err != nil {
+				return nil, err
+			}
+		}
+		return de48Subelements(text)
+	case p.framing == easFraming && o.Number == visaElectronicCommerce:
+		return f034Datasets(p.element(o))
+	default:
+		return nil, nil
+	}
+}
+
+// de48Subelements splits DE48 after its length indicator into the transaction category code and the
+// subelements, each a 2 digit tag, a 2 digit length and the data
+func de48Subelements(text []byte) ([]span, error) {
+	if len(text) <= de48LengthIndicator {
+		return nil, nil
+	}
+
+	length, err := strconv.Atoi(string(text[:de48LengthIndicator]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid length indicator %q", text[:de48LengthIndicator])
+	}
+	if end := de48LengthIndicator + length; end < len(text) {
+		text = text[:end]
+	}
+
+	spans := []span{{label: "TCC", offset: de48LengthIndicator, length: 1, depth: 1}}
+	for i := de48LengthIndicator + 1; i < len(text); {
+		if i+de48SubelementHeaderSize > len(text) {
+			return spans, fmt.Errorf("subelement at %d is truncated", i)
+		}
+
+		seLength, err := strconv.Atoi(string(text[i+2 : i+de48SubelementHeaderSize]))
+		if err != nil {
+			return spans, fmt.Errorf("subelement at %d has an invalid length %q", i, text[i+2:i+de48SubelementHeaderSize])
+		}
+
+		size := de48SubelementHeaderSize + seLength
+		spans = append(spans, span{label: "SE" + string(text[i:i+2]), offset: i, length: size, depth: 1})
+		if i+size > len(text) {
+			return spans, fmt.Errorf("SE%s is %d bytes, %d left", text[i:i+2], size, len(text)-i)
+		}
+		i += size
+	}
+
+	return spans, nil
+}
+
+// f034Datasets splits F034 after its length indicator into datasets, each an ID, a 2 byte length and
+// TLV encoded tags with a 1 byte tag and length
+func f034Datasets(raw []byte) ([]span, error) {
+	if len(raw) <= f034LengthIndicator {
+		return nil, nil
+	}
+	if end := f034LengthIndicator + int(binary.BigEndian.Uint16(raw)); end < len(raw) {
+		raw = raw[:end]
+	}
+
+	var spans []span
+	for i := f034LengthIndicator; i < len(raw); {
+		if i+f034DatasetHeaderSize > len(raw) {
+			return spans, fmt.Errorf("dataset at %d is truncated", i)
+		}
+
+		end := i + f034DatasetHeaderSize + int(binary.BigEndian.Uint16(raw[i+1:i+f034DatasetHeaderSize]))
+		spans = append(spans, span{label: fmt.Sprintf("dataset %02X", raw[i]), offset: i, length: end - i, depth: 1})
+		if end > len(raw) {
+			return spans, fmt.Errorf("dataset %02X is %d bytes, %d left", raw[i], end-i, len(raw)-i)
+		}
+
+		for j := i + f034DatasetHeaderSize; j < end; {
+			if j+f034TagHeaderSize > end {
+				return spans, fmt.Errorf("tag at %d is truncated", j)
+			}
+
+			size := f034TagHeaderSize + int(raw[j+1])
+			spans = append(spans, span{label: fmt.Sprintf("tag %02X", raw[j]), offset: j, length: size, depth: 2})
+			if j+size > end {
+				return spans, fmt.Errorf("tag %02X is %d bytes, %d left in dataset %02X", raw[j], size, end-j, raw[i])
+			}
+			j += size
+		}
+
+		i = end
+	}
+
+	return spans, nil
+}
+
+// decodedLength returns where the last decoded element ends
+func decodedLength(offsets []iso8583.ElementOffset) int {
+	if len(offsets) == 0 {
+		return 0
+	}
+
+	last := offsets[len(offsets)-1]
+	return last.Offset + last.Length
+}
+
+// elementIndexes maps the element numbers of the iso8583 tags of a struct to the index of their field
+func elementIndexes(t reflect.Type) map[int]int {
+	indexes := make(map[int]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("iso8583")
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimSpace(strings.SplitN(tag, "=", 2)[0]))
+		if err == nil {
+			indexes[number] = i
+		}
+	}
+
+	return indexes
+}
+
+func elementLabel(number int, indexes map[int]int, t reflect.Type) string {
+	switch {
+	case number == 0:
+		return "MTI and primary bit map"
+	case number%64 == 1:
+		return fmt.Sprintf("bit map (%d)", number)
+	}
+
+	if i, ok := indexes[number]; ok {
+		return t.Field(i).Name
+	}
+
+	return fmt.Sprintf("element %d", number)
+}
+
+// printFields writes the exported fields of a struct that have a value, a field per line and the
+// subfields of a field indented below it
+func printFields(w io.Writer, indent string, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() || v.Field(i).IsZero() {
+			continue
+		}
+
+		printValue(w, indent, t.Field(i).Name, v.Field(i))
+	}
+}
+
+func printValue(w io.Writer, indent, name string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.IsZero() {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if hasExportedFields(v.Type()) {
+			fmt.Fprintf(w, "%s%s:\n", indent, name)
+			printFields(w, indent+"  ", v)
+			return
+		}
+	case reflect.Slice:
+		fmt.Fprintf(w, "%s%s:\n", indent, name)
+		for i := 0; i < v.Len(); i++ {
+			printValue(w, indent+"  ", fmt.Sprintf("[%d]", i), v.Index(i))
+		}
+		return
+	case reflect.String:
+		fmt.Fprintf(w, "%s%s: %q\n", indent, name, v.String())
+		return
+	}
+
+	fmt.Fprintf(w, "%s%s: %v\n", indent, name, v.Interface())
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
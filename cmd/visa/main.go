@@ -221,7 +221,7 @@ func main() {
 
 		visa.AuthorizationSchemeData(a)
 
-		authMsg, err := visa.MessageFromAuthorization(*a)
+		authMsg, err := visa.MessageFromAuthorization(*a, entity.ZonePinBlock{})
 		if err != nil {
 			logError("error defining visa message: %w", err.Error())
 			os.Exit(1)
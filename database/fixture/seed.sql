@@ -12,6 +12,28 @@ INSERT INTO permissions (permission_id, code, label)
 VALUES ("44df31eb-3384-4f5a-9335-8207cbe9d45d", "get_refunds", "Get refunds");
 INSERT INTO permissions (permission_id, code, label)
 VALUES ("37a71765-99a0-44e0-b536-58cf0f973eec", "create_capture", "Create new capture");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("c2f0a3d1-5b7e-4c29-9e61-7a8d4b3f2e10", "get_merchants", "Get merchants");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("9e4b7c62-1d3a-4f85-b0c7-2a6e5d8f1b34", "manage_merchants", "Manage merchants");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("5a1d8e3f-7c24-4b96-a0e2-9f3b6c1d4e57", "get_policies", "Get PSP policies");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("e7c3b9a2-4f61-4d08-8b5e-1c2a7d9f3e64", "manage_policies", "Manage PSP policies");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("3b8f2d6a-9c14-4e7b-a5d3-6f0e1c9b2a87", "get_bins", "Get BIN information");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("a4d6e1b9-2f83-4c5a-9e07-8b3c5d2f1a96", "get_bin_status", "Get BIN table load status");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("d81c5f3a-6e27-4b90-9a4d-3f7e2b1c8d05", "manage_bin_tables", "Roll back BIN tables");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("6f2e9a4c-1b83-4d57-8c0a-e5d3b7f91a26", "get_connections", "Get scheme connections");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("b9c47d1e-3a62-4f08-9e5b-72d1a8c4f3e9", "manage_connections", "Reconnect and drain scheme connections");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("2e8b4f6a-9c17-4d3e-b5a0-6f1c8d2e7b93", "get_message_journal", "Get the scheme messages of a transaction");
+INSERT INTO permissions (permission_id, code, label)
+VALUES ("8d3a6c1f-5e92-4b07-a4d8-1c7f9e2b6a53", "create_payout", "Create new payout");
 
 INSERT psp (psp_id, name, prefix)
 VALUES ("1779edcd-4f14-4c97-a61e-29a827e7ed89", "mycompany.com PS", "002");
@@ -36,6 +58,12 @@ VALUES ("b93ac092-b8fb-43cb-9a2d-fd099c8ddc05", "1779edcd-4f14-4c97-a61e-29a827e
 INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
 VALUES ("75dfed92-1006-4672-abe0-67deba0a0b55", "1779edcd-4f14-4c97-a61e-29a827e7ed89", "7ed6c825-aef2-4a65-8b01-be25e778f48c");
 INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
+VALUES ("c2f0a3d1-5b7e-4c29-9e61-7a8d4b3f2e10", "1779edcd-4f14-4c97-a61e-29a827e7ed89", "7ed6c825-aef2-4a65-8b01-be25e778f48c");
+INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
+VALUES ("9e4b7c62-1d3a-4f85-b0c7-2a6e5d8f1b34", "1779edcd-4f14-4c97-a61e-29a827e7ed89", "7ed6c825-aef2-4a65-8b01-be25e778f48c");
+INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
+VALUES ("3b8f2d6a-9c14-4e7b-a5d3-6f0e1c9b2a87", "1779edcd-4f14-4c97-a61e-29a827e7ed89", "7ed6c825-aef2-4a65-8b01-be25e778f48c");
+INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
 VALUES ("44df31eb-3384-4f5a-9335-8207cbe9d45d", "1779edcd-4f14-4c97-a61e-29a827e7ed89", "7ed6c825-aef2-4a65-8b01-be25e778f48c");
 
 INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
@@ -52,6 +80,8 @@ INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
 VALUES ("44df31eb-3384-4f5a-9335-8207cbe9d45d", "0cd8d732-66c2-4dae-bb99-16494dea7796", "26c6c05d-b9f0-4e96-8265-e18df255197d");
 INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
 VALUES ("75dfed92-1006-4672-abe0-67deba0a0b55", "0cd8d732-66c2-4dae-bb99-16494dea7796", "26c6c05d-b9f0-4e96-8265-e18df255197d");
+INSERT INTO api_consumers_permissions (permission_id, psp_id, api_consumer_id)
+VALUES ("3b8f2d6a-9c14-4e7b-a5d3-6f0e1c9b2a87", "0cd8d732-66c2-4dae-bb99-16494dea7796", "26c6c05d-b9f0-4e96-8265-e18df255197d");
 
 INSERT INTO sequences (name, next_value, rollover_value)
 VALUES ('mastercard_stan', 1000000, '20220627');
@@ -0,0 +1,5 @@
+ALTER TABLE authorizations DROP COLUMN merchant_snapshot;
+ALTER TABLE authorizations DROP COLUMN merchant_id;
+
+DROP TABLE merchant_defaults;
+DROP TABLE merchants;
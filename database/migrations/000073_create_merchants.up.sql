@@ -0,0 +1,31 @@
+CREATE TABLE merchants
+(
+    psp_id           STRING(36) NOT NULL,
+    merchant_id      STRING(36) NOT NULL,
+    card_acceptor_id STRING(15),
+    name             STRING(22),
+    city             STRING(13),
+    postal_code      STRING(10),
+    country          STRING(3),
+    category_code    STRING(4),
+    created_at       TIMESTAMP NOT NULL,
+    updated_at       TIMESTAMP,
+    CONSTRAINT FK_merchants_psp FOREIGN KEY (psp_id) REFERENCES psp (psp_id),
+) PRIMARY KEY(psp_id, merchant_id);
+
+CREATE TABLE merchant_defaults
+(
+    psp_id           STRING(36) NOT NULL,
+    card_acceptor_id STRING(15),
+    name             STRING(22),
+    city             STRING(13),
+    postal_code      STRING(10),
+    country          STRING(3),
+    category_code    STRING(4),
+    created_at       TIMESTAMP NOT NULL,
+    updated_at       TIMESTAMP,
+    CONSTRAINT FK_merchant_defaults_psp FOREIGN KEY (psp_id) REFERENCES psp (psp_id),
+) PRIMARY KEY(psp_id);
+
+ALTER TABLE authorizations ADD COLUMN merchant_id STRING(36);
+ALTER TABLE authorizations ADD COLUMN merchant_snapshot JSON;
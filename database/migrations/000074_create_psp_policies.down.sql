@@ -0,0 +1,2 @@
+DROP INDEX authorizations_psp_id_created_at;
+DROP TABLE psp_policies;
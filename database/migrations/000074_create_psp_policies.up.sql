@@ -0,0 +1,16 @@
+CREATE TABLE psp_policies
+(
+    psp_id                 STRING(36) NOT NULL,
+    max_amount             INT64,
+    daily_volume_cap       INT64,
+    allowed_currencies     ARRAY<STRING(3)>,
+    allowed_countries      ARRAY<STRING(3)>,
+    allowed_sources        ARRAY<STRING(20)>,
+    allowed_category_codes ARRAY<STRING(4)>,
+    refunds_allowed        BOOL NOT NULL,
+    created_at             TIMESTAMP NOT NULL,
+    updated_at             TIMESTAMP,
+    CONSTRAINT FK_psp_policies_psp FOREIGN KEY (psp_id) REFERENCES psp (psp_id),
+) PRIMARY KEY(psp_id);
+
+CREATE INDEX authorizations_psp_id_created_at ON authorizations (psp_id, created_at);
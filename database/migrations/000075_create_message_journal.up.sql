@@ -0,0 +1,12 @@
+CREATE TABLE message_journal
+(
+    transaction_id   STRING(36) NOT NULL,
+    message_id       STRING(36) NOT NULL,
+    transaction_type STRING(20) NOT NULL,
+    scheme           STRING(20) NOT NULL,
+    direction        STRING(8)  NOT NULL,
+    mti              STRING(4)  NOT NULL,
+    stan             STRING(6),
+    elements         JSON       NOT NULL,
+    created_at       TIMESTAMP  NOT NULL,
+) PRIMARY KEY(transaction_id, created_at, message_id);
@@ -0,0 +1,2 @@
+ALTER TABLE authorizations DROP COLUMN approved_amount;
+ALTER TABLE authorizations DROP COLUMN partial_approval_supported;
@@ -0,0 +1,2 @@
+ALTER TABLE authorizations ADD COLUMN partial_approval_supported BOOL;
+ALTER TABLE authorizations ADD COLUMN approved_amount INT64;
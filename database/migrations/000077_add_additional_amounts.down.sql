@@ -0,0 +1 @@
+ALTER TABLE authorizations DROP COLUMN additional_amounts;
@@ -0,0 +1 @@
+ALTER TABLE authorizations ADD COLUMN additional_amounts JSON;
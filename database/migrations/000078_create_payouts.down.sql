@@ -0,0 +1,8 @@
+ALTER TABLE psp_policies DROP COLUMN daily_payout_cap;
+ALTER TABLE psp_policies DROP COLUMN max_payout_amount;
+ALTER TABLE psp_policies DROP COLUMN payouts_allowed;
+
+DROP TABLE visa_payouts;
+DROP TABLE mastercard_payouts;
+DROP INDEX payouts_psp_id_created_at;
+DROP TABLE payouts;
@@ -0,0 +1,60 @@
+CREATE TABLE payouts
+(
+    payout_id                        STRING(36) NOT NULL,
+    status                           STRING(50) NOT NULL,
+    masked_pan                       STRING(40) NOT NULL,
+    pan_token_id                     STRING(40) NOT NULL,
+    card_scheme                      STRING(30) NOT NULL,
+    amount                           INT64 NOT NULL,
+    currency                         STRING(3) NOT NULL,
+    localdatetime                    TIMESTAMP NOT NULL,
+    system_trace_audit_number        INT64,
+    created_at                       TIMESTAMP NOT NULL,
+    updated_at                       TIMESTAMP,
+    response_code                    STRING(20),
+    authorization_id_response        STRING(6),
+    retrieval_reference_number       STRING(12),
+    psp_id                           STRING(36) NOT NULL,
+    transmitted_at                   TIMESTAMP,
+    customer_reference               STRING(100),
+    card_acceptor_name               STRING(22) NOT NULL,
+    card_acceptor_city               STRING(13) NOT NULL,
+    card_acceptor_country            STRING(3) NOT NULL,
+    card_acceptor_id                 STRING(12) NOT NULL,
+    card_acceptor_postal_code        STRING(10),
+    card_acceptor_category_code      STRING(4) NOT NULL,
+    card_issuer_id                   STRING(11),
+    card_issuer_name                 STRING(70),
+    card_issuer_countrycode          STRING(3),
+    cardholder_transaction_type_code STRING(2),
+    point_of_service_pan_entry_mode  STRING(2),
+    sender_name                      STRING(30) NOT NULL,
+    sender_country                   STRING(3) NOT NULL,
+    recipient_name                   STRING(30) NOT NULL,
+    CONSTRAINT FK_psp_payouts FOREIGN KEY (psp_id) REFERENCES psp (psp_id),
+) PRIMARY KEY(payout_id);
+
+CREATE INDEX payouts_psp_id_created_at ON payouts (psp_id, created_at);
+
+CREATE TABLE mastercard_payouts
+(
+    payout_id                 STRING(36) NOT NULL,
+    created_at                TIMESTAMP NOT NULL,
+    network_reporting_date    STRING(100),
+    financial_network_code    STRING(100),
+    banknet_reference_number  STRING(100),
+    authorization_id_response STRING(6)
+) PRIMARY KEY(payout_id),
+INTERLEAVE IN PARENT payouts ON DELETE CASCADE;
+
+CREATE TABLE visa_payouts
+(
+    payout_id              STRING(36) NOT NULL,
+    created_at             TIMESTAMP NOT NULL,
+    transaction_identifier INT64
+) PRIMARY KEY(payout_id),
+INTERLEAVE IN PARENT payouts ON DELETE CASCADE;
+
+ALTER TABLE psp_policies ADD COLUMN payouts_allowed BOOL NOT NULL DEFAULT (false);
+ALTER TABLE psp_policies ADD COLUMN max_payout_amount INT64;
+ALTER TABLE psp_policies ADD COLUMN daily_payout_cap INT64;
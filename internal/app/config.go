@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/hsm"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gopkg.in/yaml.v3"
 )
 
@@ -55,12 +58,17 @@ type Config struct {
 	MasterCard  struct {
 		ConnectionPool   connection.PoolConfiguration `yaml:"connection_pool"`
 		BinrangeFiletype string                       `yaml:"binrange_filetype"`
+		EchoInterval     time.Duration                `yaml:"echo_interval"`
+		CircuitBreaker   authorization.BreakerConfig  `yaml:"circuit_breaker"`
+		PinZone          hsm.ZoneConfig               `yaml:"pin_zone"`
 	} `yaml:"mastercard"`
 	Visa struct {
 		ConnectionPool   connection.PoolConfiguration `yaml:"connection_pool"`
 		SourceStationID  string                       `yaml:"source_station_id"`
 		BinrangeFiletype string                       `yaml:"binrange_filetype"`
 		AddTestPans      bool                         `yaml:"add_test_pans"`
+		CircuitBreaker   authorization.BreakerConfig  `yaml:"circuit_breaker"`
+		PinZone          hsm.ZoneConfig               `yaml:"pin_zone"`
 	} `yaml:"visa"`
 	Cors struct {
 		AllowedOrigins string `yaml:"allowed_origins"`
@@ -71,12 +79,34 @@ type Config struct {
 	Detokenization struct {
 		BaseURL string `yaml:"base_url"`
 	} `yaml:"detokenization"`
+	Shutdown struct {
+		DrainTimeout    time.Duration `yaml:"drain_timeout"`
+		ReversalTimeout time.Duration `yaml:"reversal_timeout"`
+	} `yaml:"shutdown"`
+	Health struct {
+		CheckTimeout           time.Duration `yaml:"check_timeout"`
+		MinConnections         int           `yaml:"min_connections"`
+		MaxEchoAge             time.Duration `yaml:"max_echo_age"`
+		MinCertificateValidity time.Duration `yaml:"min_certificate_validity"`
+	} `yaml:"health"`
 	CardInfoApi struct {
 		BaseURL string `yaml:"base_url"`
 	} `yaml:"card_info_api"`
-	JWT                        string `yaml:"jwt"`
-	MinLogLevel                string `yaml:"min_log_level"`
-	AllowProductionCardNumbers bool   `yaml:"allow_production_card_numbers"`
+	MerchantDefaults struct {
+		ID           string `yaml:"id"`
+		CategoryCode string `yaml:"category_code"`
+		Name         string `yaml:"name"`
+		City         string `yaml:"city"`
+		Country      string `yaml:"country"`
+		PostalCode   string `yaml:"postal_code"`
+	} `yaml:"merchant_defaults"`
+	JWT                         string         `yaml:"jwt"`
+	MinLogLevel                 string         `yaml:"min_log_level"`
+	AllowProductionCardNumbers  bool           `yaml:"allow_production_card_numbers"`
+	TestCardRangesFile          string         `yaml:"test_card_ranges_file"`
+	BinrangeMaxShrinkPercentage int            `yaml:"binrange_max_shrink_percentage"`
+	Tracing                     tracing.Config `yaml:"tracing"`
+	Hsm                         hsm.Config     `yaml:"hsm"`
 }
 
 func LoadConfig(path string, conf interface{}) error {
@@ -6,15 +6,21 @@ import (
 	"time"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/probes"
 	mastercardScheme "gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/mastercard"
 	visaScheme "gitlab.cmpayments.local/creditcard/authorization/internal/processing/scheme/visa"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/sequences"
 	"gitlab.cmpayments.local/creditcard/platform"
 )
 
-func Mip(ctx context.Context, logger platform.Logger, pool *connection.Pool, mip *mastercardScheme.Mip, ss sequences.Store, shutdown func()) *mastercardScheme.Mip {
+func Mip(ctx context.Context, logger platform.Logger, pool *connection.Pool, mip *mastercardScheme.Mip, ss sequences.Store, messageJournal mastercardScheme.Journal, pinTranslator mastercardScheme.PinTranslator, echoInterval time.Duration, tracker *timing.Tracker, checks probes.Registry, shutdown func()) *mastercardScheme.Mip {
 	if mip == nil {
 		stanGen := sequences.NewDaily(100, 100000, 999999, ss)
+		stanGauge(tracker, "mastercard_stan", stanGen.Buffered)
+		if pool != nil {
+			stanCheck(checks, "mastercard", "mastercard_stan", stanGen.Buffered)
+		}
 
 		go func() {
 			err := stanGen.Fill(ctx)
@@ -24,16 +30,23 @@ func Mip(ctx context.Context, logger platform.Logger, pool *connection.Pool, mip
 			}
 		}()
 
-		mip := mastercardScheme.NewMip(pool, &stanGen)
+		mip := mastercardScheme.NewMip(pool, &stanGen, messageJournal, pinTranslator)
+		if echoInterval != 0 {
+			go echoes(ctx, logger, echoInterval, mip.Echo)
+		}
 		return &mip
 	}
 
 	return mip
 }
 
-func Eas(ctx context.Context, logger platform.Logger, pool *connection.Pool, eas *visaScheme.Eas, ss sequences.Store, sourceID string, tickDelay time.Duration, shutdown func()) *visaScheme.Eas {
+func Eas(ctx context.Context, logger platform.Logger, pool *connection.Pool, eas *visaScheme.Eas, ss sequences.Store, messageJournal visaScheme.Journal, pinTranslator visaScheme.PinTranslator, sourceID string, tickDelay time.Duration, tracker *timing.Tracker, checks probes.Registry, shutdown func()) *visaScheme.Eas {
 	if eas == nil {
 		stanGen := sequences.NewDaily(100, 100000, 999999, ss)
+		stanGauge(tracker, "visa_stan", stanGen.Buffered)
+		if pool != nil {
+			stanCheck(checks, "visa", "visa_stan", stanGen.Buffered)
+		}
 
 		go func() {
 			err := stanGen.Fill(ctx)
@@ -43,28 +56,42 @@ func Eas(ctx context.Context, logger platform.Logger, pool *connection.Pool, eas
 			}
 		}()
 
-		eas := visaScheme.NewEas(pool, &stanGen, sourceID)
+		eas := visaScheme.NewEas(pool, &stanGen, sourceID, messageJournal, pinTranslator)
 		if tickDelay != 0 {
-			go func() {
-				// receive time from config.
-				ticker := time.NewTicker(tickDelay)
-				defer ticker.Stop()
-				for {
-					select {
-					case <-ticker.C:
-						err := eas.Echo(ctx)
-						if err != nil {
-							logger.Error(ctx, err.Error())
-						}
-					case <-ctx.Done():
-						return
-					}
-				}
-			}()
-
+			go echoes(ctx, logger, tickDelay, eas.Echo)
 		}
 		return &eas
 	}
 
 	return eas
 }
+
+// echoes sends an echo every interval until the context is done
+func echoes(ctx context.Context, logger platform.Logger, interval time.Duration, echo func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := echo(ctx)
+			if err != nil {
+				logger.Error(ctx, err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stanGauge exposes the number of STANs the generator has reserved, so running out can be alerted on
+func stanGauge(tracker *timing.Tracker, sequence string, buffered func() int) {
+	tracker.Gauge("authorization_sequence_buffered", "Sequence values reserved and not yet handed out.",
+		map[string]string{"sequence": sequence},
+		func() float64 { return float64(buffered()) })
+}
+
+// stanCheck makes the instance unready when the generator has no STANs left to hand out, requests
+// would wait for the sequence store
+func stanCheck(checks probes.Registry, scheme, sequence string, buffered func() int) {
+	checks.RegisterScheme(scheme, sequence, probes.MinCount("buffered "+sequence+" values", buffered, 1))
+}
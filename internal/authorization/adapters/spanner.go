@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -53,7 +54,8 @@ func (ar AuthorizationRepository) CreateAuthorization(ctx context.Context, a ent
                     card_issuer_id, card_issuer_name, card_issuer_countrycode,
                     accountholder_authentication_value, transaction_initiated_by, transaction_subcategory,
                     terminal_id, card_holder_activated_terminal_level, terminal_capability, card_sequence,
-                    card_holder_verification_method
+                    card_holder_verification_method, merchant_id, merchant_snapshot,
+                    partial_approval_supported, additional_amounts
                 ) VALUES (
                     @authorization_id, @masked_pan, @pan_token_id,
                     @amount, @currency, @localdatetime,
@@ -66,7 +68,8 @@ func (ar AuthorizationRepository) CreateAuthorization(ctx context.Context, a ent
                     @card_issuer_id, @card_issuer_name, @card_issuer_countrycode,
                     @accountholder_authentication_value, @transaction_initiated_by, @transaction_subcategory,
                     @terminal_id, @card_holder_activated_terminal_level, @terminal_capability, @card_sequence,
-                    @card_holder_verification_method
+                    @card_holder_verification_method, @merchant_id, @merchant_snapshot,
+                    @partial_approval_supported, @additional_amounts
                 )`,
 		Params: mapCreateAuthParams(a),
 	}
@@ -119,9 +122,67 @@ func mapCreateAuthParams(a entity.Authorization) map[string]interface{} {
 		"terminal_capability":                  a.Terminal.TerminalCapability,
 		"card_sequence":                        a.Card.SequenceNumber,
 		"card_holder_verification_method":      a.CardSchemeData.Request.CardHolderVerificationMethod,
+		"merchant_id":                          sql.NewNullString(merchantID(a.Merchant)),
+		"merchant_snapshot":                    spanner.NullJSON{Value: a.Merchant, Valid: a.Merchant.IsSet()},
+		"partial_approval_supported":           a.PartialApprovalSupported,
+		"additional_amounts":                   spanner.NullJSON{Value: mapAdditionalAmountRecords(a.AdditionalAmounts), Valid: len(a.AdditionalAmounts) > 0},
 	}
 }
 
+// additionalAmountRecord is the JSON of an additional amount in the additional_amounts column
+type additionalAmountRecord struct {
+	Type     string `json:"type"`
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func mapAdditionalAmountRecords(amounts []entity.AdditionalAmount) []additionalAmountRecord {
+	records := make([]additionalAmountRecord, 0, len(amounts))
+	for _, aa := range amounts {
+		records = append(records, additionalAmountRecord{
+			Type:     string(aa.Type),
+			Amount:   aa.Amount,
+			Currency: aa.Currency.Alpha3(),
+		})
+	}
+
+	return records
+}
+
+// mapAdditionalAmounts reads the additional_amounts column, which spanner decodes into generic JSON values
+func mapAdditionalAmounts(j spanner.NullJSON) []entity.AdditionalAmount {
+	if !j.Valid {
+		return nil
+	}
+
+	var records []additionalAmountRecord
+	b, err := json.Marshal(j.Value)
+	if err != nil {
+		return nil
+	}
+	if err = json.Unmarshal(b, &records); err != nil {
+		return nil
+	}
+
+	amounts := make([]entity.AdditionalAmount, 0, len(records))
+	for _, r := range records {
+		amounts = append(amounts, entity.AdditionalAmount{
+			Type:     entity.AdditionalAmountType(r.Type),
+			Amount:   r.Amount,
+			Currency: currencycode.Must(r.Currency),
+		})
+	}
+
+	return amounts
+}
+
+func merchantID(m entity.MerchantSnapshot) string {
+	if !m.IsSet() {
+		return ""
+	}
+	return m.MerchantID.String()
+}
+
 func (ar AuthorizationRepository) CreateMastercardAuthorization(ctx context.Context, a entity.Authorization) error {
 	_, err := ar.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
 		statement := spanner.Statement{
@@ -177,7 +238,8 @@ func (ar AuthorizationRepository) UpdateAuthorizationResponse(ctx context.Contex
 					cardholder_from_account_type_code = @cardholder_from_account_type_code,
 					cardholder_to_account_type_code = @cardholder_to_account_type_code,
 					point_of_service_pan_entry_mode = @point_of_service_pan_entry_mode,
-					point_of_service_pin_entry_mode = @point_of_service_pin_entry_mode
+					point_of_service_pin_entry_mode = @point_of_service_pin_entry_mode,
+					approved_amount = @approved_amount
 				WHERE authorization_id = @authorization_id`,
 		Params: mapUpdateAuthorizationResponseParams(a),
 	}
@@ -215,6 +277,7 @@ func mapUpdateAuthorizationResponseParams(a entity.Authorization) map[string]int
 		"cardholder_to_account_type_code":   a.CardSchemeData.Request.ProcessingCode.ToAccountTypeCode,
 		"point_of_service_pan_entry_mode":   pos.PanEntryCode(a.CardSchemeData.Request.POSEntryMode.PanEntryMode),
 		"point_of_service_pin_entry_mode":   pos.PinEntryCode(a.CardSchemeData.Request.POSEntryMode.PinEntryMode),
+		"approved_amount":                   spanner.NullInt64{Int64: int64(a.ApprovedAmount()), Valid: a.CardSchemeData.Response.Status == entity.AuthorizeApproved},
 	}
 }
 
@@ -252,7 +315,8 @@ func (ar AuthorizationRepository) GetAllAuthorizations(ctx context.Context, pspI
 				a.response_code, a.created_at,
 				a.updated_at, a.initial_trace_id, a.transaction_initiated_by, a.transaction_subcategory,
 				ma.authorization_type, ma.financial_network_code, ma.banknet_reference_number, ma.network_reporting_date,
-				ma.reason_ucaf_downgrade, ma.card_program_id, ma.card_product_id
+				ma.reason_ucaf_downgrade, ma.card_program_id, ma.card_product_id,
+				a.partial_approval_supported, a.approved_amount, a.additional_amounts
 			FROM authorizations a
 			JOIN mastercard_authorizations ma on a.authorization_id = ma.authorization_id
 			WHERE a.psp_id = @psp_id
@@ -359,7 +423,8 @@ func (ar AuthorizationRepository) GetAuthorization(ctx context.Context, pspID, a
 		       cardholder_to_account_type_code, card_acceptor_name, card_acceptor_city, 
 		       card_acceptor_country, card_acceptor_id, card_acceptor_postal_code, 
 		       card_acceptor_category_code, exemption, accountholder_authentication_value,
-		       transaction_initiated_by, transaction_subcategory
+		       transaction_initiated_by, transaction_subcategory, merchant_id,
+		       partial_approval_supported, approved_amount, additional_amounts
 		FROM authorizations AS a
 		WHERE a.authorization_id = @authorizationID
     `)
@@ -422,6 +487,7 @@ func mapAuthorizationEntity(a AuthorizationRecord) entity.Authorization {
 				CountryCode: a.AcceptorCountry,
 			},
 		},
+		Merchant: mapMerchantSnapshot(a.MerchantID),
 		Psp: entity.PSP{
 			ID:     uuid.MustParse(a.PspID),
 			Name:   a.PspName.StringVal,
@@ -453,13 +519,35 @@ func mapAuthorizationEntity(a AuthorizationRecord) entity.Authorization {
 					Description: entity.ResponseCodeFromString(a.ResponseCode.StringVal).Description,
 				},
 				AuthorizationIDResponse: a.AuthorizationIDResponse.StringVal,
+				ApprovedAmount:          approvedAmount(a),
 			},
 		},
 		CitMitIndicator: entity.CitMitIndicator{
 			InitiatedBy: entity.MapInitiatedByFromStr(a.TransactionInitiatedBy.StringVal),
 			SubCategory: entity.MapSubCategoryFromStr(a.TransactionSubCategory.StringVal),
 		},
+		PartialApprovalSupported: a.PartialApprovalSupported.Bool,
+		AdditionalAmounts:        mapAdditionalAmounts(a.AdditionalAmounts),
+	}
+}
+
+// approvedAmount is the approved amount of a partial approval, the entity has none for a full approval. A partial
+// approval of the whole amount keeps it, as the entity approved nothing for a partial approval without one.
+func approvedAmount(a AuthorizationRecord) int {
+	partial := a.ResponseCode.StringVal == entity.PartialApprovalResponseCode
+	if !a.ApprovedAmount.Valid || (a.ApprovedAmount.Int64 == a.Amount && !partial) {
+		return 0
+	}
+
+	return int(a.ApprovedAmount.Int64)
+}
+
+func mapMerchantSnapshot(merchantID spanner.NullString) entity.MerchantSnapshot {
+	id, err := uuid.Parse(merchantID.StringVal)
+	if err != nil {
+		return entity.MerchantSnapshot{}
 	}
+	return entity.MerchantSnapshot{MerchantID: id}
 }
 
 func mapVisaAuthorizationEntity(v VisaAuthorizationRecord) entity.Authorization {
@@ -521,7 +609,8 @@ func (ar AuthorizationRepository) GetAuthorizationWithSchemeData(ctx context.Con
 				a.retrieval_reference_number, v.additional_pos_info_terminal_type, v.transaction_identifier,
 				v.chip_condition_code, v.special_condition_indicator, v.chip_transaction_indicator,
 				v.chip_card_authentication_reliability_indicator, v.cardholder_id_method_indicator,
-				v.additional_authorization_indicators
+				v.additional_authorization_indicators,
+				a.partial_approval_supported, a.approved_amount, a.additional_amounts
 			FROM authorizations AS a
 				 LEFT JOIN mastercard_authorizations AS m ON a.authorization_id = m.authorization_id
 			     LEFT JOIN visa_authorizations AS v ON a.authorization_id = v.authorization_id 
@@ -654,6 +743,10 @@ type AuthorizationRecord struct {
 	PinEntryMode                      string             `spanner:"point_of_service_pin_entry_mode"`
 	AuthorizationIDResponse           spanner.NullString `spanner:"authorization_id_response"`
 	RetrievalReferenceNumber          spanner.NullString `spanner:"retrieval_reference_number"`
+	MerchantID                        spanner.NullString `spanner:"merchant_id"`
+	PartialApprovalSupported          spanner.NullBool   `spanner:"partial_approval_supported"`
+	ApprovedAmount                    spanner.NullInt64  `spanner:"approved_amount"`
+	AdditionalAmounts                 spanner.NullJSON   `spanner:"additional_amounts"`
 }
 
 // MastercardAuthorizationRecord is exported to allow spanner.ToStructLenient() to fill it - do not use outside this file.
@@ -765,11 +858,14 @@ func mapRowToAuthorizationEntity(mar MastercardAuthorizationRecord) entity.Autho
 				Description: schemeResponseMessage,
 			},
 			AuthorizationIDResponse: mar.Reference.StringVal,
+			ApprovedAmount:          approvedAmount(mar.AuthorizationRecord),
 		}},
 		CitMitIndicator: entity.CitMitIndicator{
 			InitiatedBy: entity.MapInitiatedByFromStr(mar.TransactionInitiatedBy.StringVal),
 			SubCategory: entity.MapSubCategoryFromStr(mar.TransactionSubCategory.StringVal),
 		},
+		PartialApprovalSupported: mar.PartialApprovalSupported.Bool,
+		AdditionalAmounts:        mapAdditionalAmounts(mar.AdditionalAmounts),
 		MastercardSchemeData: entity.MastercardSchemeData{
 			Request: entity.MastercardSchemeRequest{
 				AuthorizationType: entity.AuthorizationType(mar.AuthorizationType),
@@ -868,11 +964,14 @@ func mapMastercardAuthorizationEntity(m MastercardAuthorizationRecord) entity.Au
 					Description: entity.ResponseCodeFromString(m.ResponseCode.StringVal).Description,
 				},
 				AuthorizationIDResponse: m.AuthorizationIDResponse.StringVal,
+				ApprovedAmount:          approvedAmount(m.AuthorizationRecord),
 			}},
 		CitMitIndicator: entity.CitMitIndicator{
 			InitiatedBy: entity.MapInitiatedByFromStr(m.TransactionInitiatedBy.StringVal),
 			SubCategory: entity.MapSubCategoryFromStr(m.TransactionSubCategory.StringVal),
 		},
+		PartialApprovalSupported: m.PartialApprovalSupported.Bool,
+		AdditionalAmounts:        mapAdditionalAmounts(m.AdditionalAmounts),
 		MastercardSchemeData: entity.MastercardSchemeData{
 			Request: entity.MastercardSchemeRequest{
 				AuthorizationType: entity.AuthorizationType(m.AuthorizationType),
@@ -219,3 +219,40 @@ func (mr *MockRepositoryMockRecorder) UpdateAuthorizationStatus(ctx, authorizati
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthorizationStatus", reflect.TypeOf((*MockRepository)(nil).UpdateAuthorizationStatus), ctx, authorizationID, status)
 }
+
+// MockPolicyEvaluator is a mock of PolicyEvaluator interface.
+type MockPolicyEvaluator struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyEvaluatorMockRecorder
+}
+
+// MockPolicyEvaluatorMockRecorder is the mock recorder for MockPolicyEvaluator.
+type MockPolicyEvaluatorMockRecorder struct {
+	mock *MockPolicyEvaluator
+}
+
+// NewMockPolicyEvaluator creates a new mock instance.
+func NewMockPolicyEvaluator(ctrl *gomock.Controller) *MockPolicyEvaluator {
+	mock := &MockPolicyEvaluator{ctrl: ctrl}
+	mock.recorder = &MockPolicyEvaluatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyEvaluator) EXPECT() *MockPolicyEvaluatorMockRecorder {
+	return m.recorder
+}
+
+// EvaluateAuthorization mocks base method.
+func (m *MockPolicyEvaluator) EvaluateAuthorization(ctx context.Context, a entity.Authorization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateAuthorization", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EvaluateAuthorization indicates an expected call of EvaluateAuthorization.
+func (mr *MockPolicyEvaluatorMockRecorder) EvaluateAuthorization(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateAuthorization", reflect.TypeOf((*MockPolicyEvaluator)(nil).EvaluateAuthorization), ctx, a)
+}
@@ -2,10 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"gitlab.cmpayments.local/creditcard/platform"
+	"gitlab.cmpayments.local/libraries-go/logging"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
@@ -31,11 +33,16 @@ type Repository interface {
 	UpdateAuthorizationStatus(ctx context.Context, authorizationID uuid.UUID, status entity.Status) error
 }
 
+type PolicyEvaluator interface {
+	EvaluateAuthorization(ctx context.Context, a entity.Authorization) error
+}
+
 type AuthorizationService struct {
 	log          platform.Logger
 	repo         Repository
 	tokenizer    Tokenizer
 	revSer       app.ReversalService
+	policy       PolicyEvaluator
 	riskAssessor *risk.Assessor
 	mapper       *authorization.Mapper
 }
@@ -45,6 +52,7 @@ func NewAuthorizationService(
 	repo Repository,
 	tokenizer Tokenizer,
 	revSer app.ReversalService,
+	policy PolicyEvaluator,
 	mapper *authorization.Mapper,
 ) AuthorizationService {
 	return AuthorizationService{
@@ -52,13 +60,17 @@ func NewAuthorizationService(
 		repo:         repo,
 		tokenizer:    tokenizer,
 		revSer:       revSer,
+		policy:       policy,
 		riskAssessor: risk.NewAssessor(risk.Rules()),
 		mapper:       mapper,
 	}
 }
 
 func (as AuthorizationService) Authorize(ctx context.Context, a *entity.Authorization) error {
-	var err error
+	err := as.policy.EvaluateAuthorization(ctx, *a)
+	if err != nil {
+		return fmt.Errorf("authorization not allowed by policy: %w", err)
+	}
 
 	a.Card.PanTokenID, err = as.tokenizer.Tokenize(ctx, a.Psp.ID.String(), a.Card)
 	if err != nil {
@@ -81,6 +93,9 @@ func (as AuthorizationService) Authorize(ctx context.Context, a *entity.Authoriz
 	as.log.Info(ctx, "risk assessed")
 
 	err = as.mapper.SendAuthorization(ctx, a)
+	if errors.Is(err, authorization.ErrUnanswered) {
+		as.reverse(ctx, a, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to send authorization to card scheme: %w", err)
 	}
@@ -105,30 +120,48 @@ func (as AuthorizationService) Authorize(ctx context.Context, a *entity.Authoriz
 		return fmt.Errorf("failed to update authorization with response: %w", err)
 	}
 
+	if a.InvalidPartialApproval() {
+		as.reverse(ctx, a, entity.ErrInvalidPartialApproval)
+	}
+
 	return nil
 }
 
-//func (as AuthorizationService) updateAuthorizationAndReverse(ctx context.Context, a *entity.Authorization, err error) {
-//	a.Status = entity.Failed
-//	updateErr := as.repo.UpdateAuthorizationStatus(ctx, a.ID, a.Status)
-//	if updateErr != nil {
-//		as.log.Critical(ctx, fmt.Sprintf("failed updating authorization status to: %s, %s", a.Status, updateErr.Error()))
-//	}
-//
-//	rev := entity.Reversal{
-//		ID:              uuid.New(),
-//		LogID:           uuid.MustParse(ctx.Value(logging.LogIDKey).(string)),
-//		AuthorizationID: a.ID,
-//		Authorization:   *a,
-//		Status:          entity.ReversalNew,
-//		Reason:          err,
-//	}
-//
-//	revErr := as.revSer.Reverse(ctx, a.Psp.ID, &rev)
-//	if revErr != nil {
-//		as.log.Critical(ctx, fmt.Sprintf("failed reversing authorization: %s", revErr.Error()))
-//	}
-//}
+// reverse marks the authorization failed and reverses it, the issuer may have approved an
+// authorization whose response never arrived or whose partial approval has no valid approved amount
+func (as AuthorizationService) reverse(ctx context.Context, a *entity.Authorization, reason error) {
+	a.Status = entity.Failed
+	updateErr := as.repo.UpdateAuthorizationStatus(ctx, a.ID, a.Status)
+	if updateErr != nil {
+		as.log.Error(ctx, fmt.Sprintf("failed updating authorization status to: %s, %s", a.Status, updateErr.Error()))
+	}
+
+	rev := entity.Reversal{
+		ID:              uuid.New(),
+		LogID:           logID(ctx),
+		AuthorizationID: a.ID,
+		Authorization:   *a,
+		Status:          entity.ReversalNew,
+		Reason:          reason,
+	}
+
+	revErr := as.revSer.Reverse(ctx, a.Psp.ID, &rev)
+	if revErr != nil {
+		as.log.Error(ctx, fmt.Sprintf("failed reversing authorization: %s", revErr.Error()))
+		return
+	}
+
+	as.log.Info(ctx, fmt.Sprintf("reversed failed authorization %s: %s", a.ID, reason))
+}
+
+func logID(ctx context.Context) uuid.UUID {
+	if id, ok := ctx.Value(logging.LogIDKey).(string); ok {
+		if logID, err := uuid.Parse(id); err == nil {
+			return logID
+		}
+	}
+	return uuid.New()
+}
 
 func (as AuthorizationService) GetAuthorizations(ctx context.Context, pspID uuid.UUID, f entity.Filters, params map[string]interface{}) (entity.Metadata, []entity.Authorization, error) {
 	return as.repo.GetAllAuthorizations(ctx, pspID, f, params)
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,6 +11,7 @@ import (
 	authMock "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app/mock"
 	captureMock "gitlab.cmpayments.local/creditcard/authorization/internal/capture/app/mock"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization/mocks"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/reversal/app"
@@ -81,6 +83,7 @@ func TestAuthorizationService_Authorize(t *testing.T) {
 		scheme         string
 		expectedError  string
 		expectedStatus entity.Status
+		policyErr      error
 		mockedAuth     func() entity.Authorization
 		mocks          func(ctx context.Context, scheme *mocks.MockSchemeConnection, mockAuthRepository *authMock.MockRepository,
 			reversal *reversalMock.MockReversalRepository, tokenizer *authMock.MockTokenizer, captureMock *captureMock.MockCaptureRepository,
@@ -120,6 +123,22 @@ func TestAuthorizationService_Authorize(t *testing.T) {
 				tokenizer.EXPECT().Tokenize(ctx, uuid.Nil.String(), auth.Card).Return("", errors.New("merchantID cannot be empty"))
 			},
 		},
+		{
+			name:   "authorization_not_allowed_by_policy",
+			scheme: visa,
+			mockedAuth: func() entity.Authorization {
+				auth := entity.Authorization{}
+				auth.Card.Number = "1230981230981234"
+				auth.Card.Info.Scheme = "visa"
+				auth.Psp.ID = uuid.New()
+				auth.Amount = 20000
+				return auth
+			},
+			policyErr:     entity.PolicyViolation{Field: "amount", Reason: "amount must be less than or equal to 10000"},
+			expectedError: "authorization not allowed by policy: policy violation on amount: amount must be less than or equal to 10000",
+			mocks: func(ctx context.Context, scheme *mocks.MockSchemeConnection, mockAuthRepository *authMock.MockRepository, reversal *reversalMock.MockReversalRepository, tokenizer *authMock.MockTokenizer, captureMock *captureMock.MockCaptureRepository, auth entity.Authorization) {
+			},
+		},
 		{
 			name:   "authorization_amount_too_long",
 			scheme: visa,
@@ -154,6 +173,86 @@ func TestAuthorizationService_Authorize(t *testing.T) {
 				mockAuthRepository.EXPECT().CreateAuthorization(ctx, auth).Return(errors.New("Authorization amount is too short"))
 			},
 		},
+		{
+			name:   "authorization_unanswered_reversed",
+			scheme: mastercard,
+			mockedAuth: func() entity.Authorization {
+				auth := entity.Authorization{}
+				auth.ID = uuid.New()
+				auth.Card.Number = "5204740000001002"
+				auth.Card.PanTokenID = "authorization_unanswered_reversed_test"
+				auth.Card.Info.Scheme = "mastercard"
+				auth.Psp.ID = uuid.New()
+				auth.Amount = 5000
+				return auth
+			},
+			expectedError:  "failed to send authorization to card scheme: failed to authorize with the cardscheme: authorization unanswered: request unanswered at the shutdown deadline",
+			expectedStatus: entity.Failed,
+			mocks: func(ctx context.Context, scheme *mocks.MockSchemeConnection, mockAuthRepository *authMock.MockRepository, reversal *reversalMock.MockReversalRepository, tokenizer *authMock.MockTokenizer, captureMock *captureMock.MockCaptureRepository, auth entity.Authorization) {
+				tokenizer.EXPECT().Tokenize(ctx, auth.Psp.ID.String(), auth.Card).Return(auth.Card.PanTokenID, nil)
+				mockAuthRepository.EXPECT().CreateAuthorization(ctx, auth).Return(nil)
+				scheme.EXPECT().Authorize(ctx, gomock.Any()).Return(connection.ErrShutdownDeadline)
+				mockAuthRepository.EXPECT().UpdateAuthorizationStatus(ctx, auth.ID, entity.Failed).Return(nil)
+				tokenizer.EXPECT().Detokenize(ctx, auth.Psp.ID.String(), gomock.Any()).Return(auth.Card, nil)
+				mockAuthRepository.EXPECT().AuthorizationAlreadyReversed(ctx, auth.ID).Return(false, nil)
+				captureMock.EXPECT().FinalCaptureExists(ctx, auth.ID).Return(false, nil)
+				captureMock.EXPECT().GetCaptureSummary(ctx, gomock.Any()).Return(entity.CaptureSummary{}, nil)
+				reversal.EXPECT().CreateReversal(ctx, gomock.Any()).Return(nil)
+				scheme.EXPECT().Reverse(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.Reversal) error {
+					if !errors.Is(r.Reason, authorization.ErrUnanswered) {
+						return fmt.Errorf("reversal reason %v, want %v", r.Reason, authorization.ErrUnanswered)
+					}
+					if r.Amount != auth.Amount {
+						return fmt.Errorf("reversal amount %d, want %d", r.Amount, auth.Amount)
+					}
+					return nil
+				})
+				reversal.EXPECT().UpdateReversalResponse(ctx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "partial_approval_without_amount_reversed",
+			scheme: visa,
+			mockedAuth: func() entity.Authorization {
+				auth := entity.Authorization{}
+				auth.ID = uuid.New()
+				auth.Card.Number = "4761739001010010"
+				auth.Card.PanTokenID = "partial_approval_without_amount_reversed_test"
+				auth.Card.Info.Scheme = "visa"
+				auth.Psp.ID = uuid.New()
+				auth.Amount = 5000
+				auth.PartialApprovalSupported = true
+				return auth
+			},
+			expectedStatus: entity.Failed,
+			mocks: func(ctx context.Context, scheme *mocks.MockSchemeConnection, mockAuthRepository *authMock.MockRepository, reversal *reversalMock.MockReversalRepository, tokenizer *authMock.MockTokenizer, captureMock *captureMock.MockCaptureRepository, auth entity.Authorization) {
+				tokenizer.EXPECT().Tokenize(ctx, auth.Psp.ID.String(), auth.Card).Return(auth.Card.PanTokenID, nil)
+				mockAuthRepository.EXPECT().CreateAuthorization(ctx, auth).Return(nil)
+				scheme.EXPECT().Authorize(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, a *entity.Authorization) error {
+					a.CardSchemeData.Response.ResponseCode.Value = entity.PartialApprovalResponseCode
+					a.CardSchemeData.Response.Status = entity.AuthorizeFailed
+					return nil
+				})
+				mockAuthRepository.EXPECT().CreateVisaAuthorization(ctx, gomock.Any()).Return(nil)
+				mockAuthRepository.EXPECT().UpdateAuthorizationResponse(ctx, gomock.Any()).Return(nil)
+				mockAuthRepository.EXPECT().UpdateAuthorizationStatus(ctx, auth.ID, entity.Failed).Return(nil)
+				tokenizer.EXPECT().Detokenize(ctx, auth.Psp.ID.String(), gomock.Any()).Return(auth.Card, nil)
+				mockAuthRepository.EXPECT().AuthorizationAlreadyReversed(ctx, auth.ID).Return(false, nil)
+				captureMock.EXPECT().FinalCaptureExists(ctx, auth.ID).Return(false, nil)
+				captureMock.EXPECT().GetCaptureSummary(ctx, gomock.Any()).Return(entity.CaptureSummary{}, nil)
+				reversal.EXPECT().CreateReversal(ctx, gomock.Any()).Return(nil)
+				scheme.EXPECT().Reverse(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.Reversal) error {
+					if !errors.Is(r.Reason, entity.ErrInvalidPartialApproval) {
+						return fmt.Errorf("reversal reason %v, want %v", r.Reason, entity.ErrInvalidPartialApproval)
+					}
+					if r.Amount != auth.Amount {
+						return fmt.Errorf("reversal amount %d, want %d", r.Amount, auth.Amount)
+					}
+					return nil
+				})
+				reversal.EXPECT().UpdateReversalResponse(ctx, gomock.Any()).Return(nil)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,11 +263,13 @@ func TestAuthorizationService_Authorize(t *testing.T) {
 			reversalRepo := reversalMock.NewMockReversalRepository(ctrl)
 			captureRepo := captureMock.NewMockCaptureRepository(ctrl)
 			tokenizer := authMock.NewMockTokenizer(ctrl)
+			policy := authMock.NewMockPolicyEvaluator(ctrl)
+			policy.EXPECT().EvaluateAuthorization(gomock.Any(), gomock.Any()).Return(tt.policyErr)
 			mapper := authorization.NewMapper(authorization.SchemeConnections{mastercard: schemeMock, visa: schemeMock}, logging.Logger{})
 
 			reversalService := app.NewReversalService(logging.Logger{}, authRepo, captureRepo, reversalRepo, tokenizer, mapper)
 
-			service := NewAuthorizationService(logging.Logger{}, authRepo, tokenizer, reversalService, mapper)
+			service := NewAuthorizationService(logging.Logger{}, authRepo, tokenizer, reversalService, policy, mapper)
 
 			auth := tt.mockedAuth()
 			ctx := context.Background()
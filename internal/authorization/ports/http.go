@@ -2,9 +2,11 @@ package ports
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,11 +20,14 @@ import (
 	liblogging "gitlab.cmpayments.local/libraries-go/logging"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/config"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tokenization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
+	schemeAuthorization "gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/cardinfo"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/web"
+	httpErrors "gitlab.cmpayments.local/creditcard/authorization/pkg/web/errors"
 	"gitlab.cmpayments.local/creditcard/platform/currencycode"
 )
 
@@ -31,18 +36,24 @@ var (
 )
 
 type authorizationHandler struct {
-	logger                     platform.Logger
-	allowProductionCardNumbers bool
-	cardRanges                 *cardinfo.Collection
-	authorizationService       app.AuthorizationService
+	logger               platform.Logger
+	cardNumberGuard      *cardinfo.CardNumberGuard
+	cardRanges           *cardinfo.Collection
+	authorizationService app.AuthorizationService
+	configService        config.ConfigService
+	pinTranslation       bool
 }
 
-func NewAuthorizationHandler(allowProductionCardNumbers bool, cardRanges *cardinfo.Collection, logger platform.Logger, authService app.AuthorizationService) *authorizationHandler {
+// NewAuthorizationHandler creates the authorization handler, online PINs are refused unless pinTranslation tells a
+// security module translates them
+func NewAuthorizationHandler(cardNumberGuard *cardinfo.CardNumberGuard, cardRanges *cardinfo.Collection, logger platform.Logger, authService app.AuthorizationService, configService config.ConfigService, pinTranslation bool) *authorizationHandler {
 	return &authorizationHandler{
-		allowProductionCardNumbers: allowProductionCardNumbers,
-		cardRanges:                 cardRanges,
-		logger:                     logger,
-		authorizationService:       authService,
+		cardNumberGuard:      cardNumberGuard,
+		cardRanges:           cardRanges,
+		logger:               logger,
+		authorizationService: authService,
+		configService:        configService,
+		pinTranslation:       pinTranslation,
 	}
 }
 
@@ -57,6 +68,11 @@ func (h *authorizationHandler) CreateAuthorization(w http.ResponseWriter, r *htt
 		return
 	}
 
+	if err := h.cardNumberGuard.Check(ctx, input.Card.Number); err != nil {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"card.number": {err.Error()}})
+		return
+	}
+
 	cardInfo, ok := h.cardRanges.Find(input.Card.Number)
 	if !ok {
 		platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"card.number": {"unknown card range"}})
@@ -69,6 +85,9 @@ func (h *authorizationHandler) CreateAuthorization(w http.ResponseWriter, r *htt
 	}
 
 	input.Card.scheme = cardInfo.Scheme
+	if input.OnlinePin != nil {
+		input.OnlinePin.translated = h.pinTranslation
+	}
 
 	recurring, err := mapRecurring(input.InitialTraceID, input.InitialRecurring)
 	if err != nil {
@@ -86,6 +105,11 @@ func (h *authorizationHandler) CreateAuthorization(w http.ResponseWriter, r *htt
 
 	v := validator.New()
 
+	merchant, ok := h.merchantSnapshot(ctx, w, psp, &input, v)
+	if !ok {
+		return
+	}
+
 	input.validate(v)
 	if !v.Valid() {
 		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
@@ -93,10 +117,20 @@ func (h *authorizationHandler) CreateAuthorization(w http.ResponseWriter, r *htt
 	}
 
 	authorization := mapAuthorizationRequest(ctx, cardInfo, psp, input, recurring)
+	authorization.Merchant = merchant
 
 	err = h.authorizationService.Authorize(ctx, &authorization)
 	if err != nil {
+		var violation entity.PolicyViolation
+		var unavailable *schemeAuthorization.UnavailableError
 		switch {
+		case errors.As(err, &violation):
+			h.logger.Info(ctx, violation.Error())
+			platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{violation.Field: {violation.Reason}})
+			return
+		case errors.As(err, &unavailable):
+			httpErrors.ServiceUnavailableResponse(ctx, w, h.logger, err, unavailable.RetryAfter)
+			return
 		case errors.Is(err, visa.CavvErrorNumeric):
 			h.logger.Error(liblogging.ContextWithError(ctx, err), "unprocessable content")
 			platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"authenticationVerificationValue": {"failed to encode CAVV"}})
@@ -116,6 +150,44 @@ func (h *authorizationHandler) CreateAuthorization(w http.ResponseWriter, r *htt
 	}
 }
 
+// merchantSnapshot resolves the effective configuration of the merchant referenced in the request and uses
+// it as the card acceptor. A request either references a merchant or passes the full card acceptor.
+func (h *authorizationHandler) merchantSnapshot(ctx context.Context, w http.ResponseWriter, psp entity.PSP, input *authorizationRequest, v *validator.Validator) (entity.MerchantSnapshot, bool) {
+	if input.MerchantID == "" {
+		return entity.MerchantSnapshot{}, true
+	}
+
+	merchantID, err := uuid.Parse(input.MerchantID)
+	v.Check(err == nil, "merchantId", []string{"merchant id must be a valid uuid"})
+	v.Check(input.CardAcceptor == (CardAcceptor{}), "cardAcceptor", []string{"cardAcceptor cannot be combined with merchantId"})
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return entity.MerchantSnapshot{}, false
+	}
+
+	merchant, err := h.configService.FetchConfig(ctx, psp.ID, merchantID)
+	if err != nil {
+		switch {
+		case errors.Is(err, config.ErrMerchantNotFound):
+			platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"merchantId": {"unknown merchant"}})
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+		}
+		return entity.MerchantSnapshot{}, false
+	}
+
+	input.CardAcceptor = CardAcceptor{
+		ID:           merchant.CardAcceptor.ID,
+		CategoryCode: merchant.CardAcceptor.CategoryCode,
+		Name:         merchant.CardAcceptor.Name,
+		City:         merchant.CardAcceptor.Address.City,
+		Country:      merchant.CardAcceptor.Address.CountryCode,
+		PostalCode:   merchant.CardAcceptor.Address.PostalCode,
+	}
+
+	return merchant, true
+}
+
 func (h *authorizationHandler) GetAuthorization(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	params := httprouter.ParamsFromContext(ctx)
@@ -170,13 +242,16 @@ func mapAuthorizationRequest(ctx context.Context, cardInfo cardinfo.Range, psp e
 		Source:                   entity.Source(input.Source),
 		LocalTransactionDateTime: input.LocalTransactionDateTime,
 		Recurring:                recurring,
+		PartialApprovalSupported: input.PartialApprovalSupported,
+		AdditionalAmounts:        mapAdditionalAmounts(input.AdditionalAmounts),
 		Card: entity.Card{
-			Number:    input.Card.Number,
-			MaskedPan: entity.MaskPan(input.Card.Number),
-			Cvv:       input.Card.Cvv,
-			Holder:    input.Card.Holder,
-			Expiry:    entity.Expiry{Year: input.Card.Expiry.Year, Month: input.Card.Expiry.Month},
-			Info:      cardInfo,
+			Number:         input.Card.Number,
+			MaskedPan:      entity.MaskPan(input.Card.Number),
+			Cvv:            input.Card.Cvv,
+			Holder:         input.Card.Holder,
+			Expiry:         entity.Expiry{Year: input.Card.Expiry.Year, Month: input.Card.Expiry.Month},
+			Info:           cardInfo,
+			SequenceNumber: input.Card.SequenceNumber,
 		},
 		CardAcceptor: entity.CardAcceptor{
 			CategoryCode: input.CardAcceptor.CategoryCode,
@@ -209,9 +284,66 @@ func mapAuthorizationRequest(ctx context.Context, cardInfo cardinfo.Range, psp e
 		},
 	}
 
+	if authorization.Source == entity.CardPresent {
+		// The ICC data was validated as hex
+		authorization.Card.IccData, _ = hex.DecodeString(input.IccData)
+		authorization.Card.Track2Data = input.Card.Track2Data
+		authorization.Terminal = entity.Terminal{
+			TerminalId:         input.Terminal.ID,
+			TerminalCapability: entity.TerminalCapability(input.Terminal.Capability),
+			TerminalLevel:      entity.TerminalLevel(input.Terminal.Level),
+		}
+		authorization.CardSchemeData.Request.POSEntryMode = entity.POSEntryMode{
+			PanEntryMode: entity.PANEntry(input.PanEntryMode),
+			PinEntryMode: entity.PINEntryUnspecified,
+		}
+
+		if input.OnlinePin != nil {
+			// The online PIN was validated as hex, it is translated to the zone PIN key of the scheme when it is sent
+			authorization.Card.OnlinePin.PinBlock, _ = hex.DecodeString(input.OnlinePin.PinBlock)
+			authorization.Card.OnlinePin.KeySerialNumber, _ = hex.DecodeString(input.OnlinePin.KeySerialNumber)
+			authorization.CardSchemeData.Request.POSEntryMode.PinEntryMode = entity.PINEntryTerminalCanAcceptOnlinePin
+			authorization.CardSchemeData.Request.CardHolderVerificationMethod = entity.CardHolderVerificationMethodOnlinePin
+		}
+	}
+
 	return authorization
 }
 
+func mapAdditionalAmounts(amounts []AdditionalAmount) []entity.AdditionalAmount {
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	additionalAmounts := make([]entity.AdditionalAmount, 0, len(amounts))
+	for _, aa := range amounts {
+		additionalAmounts = append(additionalAmounts, entity.AdditionalAmount{
+			Type:     entity.AdditionalAmountType(aa.Type),
+			Amount:   aa.Amount,
+			Currency: currencycode.Must(aa.Currency),
+		})
+	}
+
+	return additionalAmounts
+}
+
+func mapAdditionalAmountsResponse(amounts []entity.AdditionalAmount) []AdditionalAmount {
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	additionalAmounts := make([]AdditionalAmount, 0, len(amounts))
+	for _, aa := range amounts {
+		additionalAmounts = append(additionalAmounts, AdditionalAmount{
+			Type:     string(aa.Type),
+			Amount:   aa.Amount,
+			Currency: aa.Currency.Alpha3(),
+		})
+	}
+
+	return additionalAmounts
+}
+
 func mapAuthorizationResponse(a entity.Authorization) authorizationResponse {
 	var citMitIndicator *CitMitIndicator
 	if a.CitMitIndicator != (entity.CitMitIndicator{}) {
@@ -225,6 +357,8 @@ func mapAuthorizationResponse(a entity.Authorization) authorizationResponse {
 		ID:                       a.ID.String(),
 		LogID:                    a.LogID.String(),
 		Amount:                   a.Amount,
+		ApprovedAmount:           approvedAmount(a),
+		AdditionalAmounts:        mapAdditionalAmountsResponse(a.AdditionalAmounts),
 		Currency:                 a.Currency.Alpha3(),
 		Reference:                a.CustomerReference,
 		Source:                   string(a.Source),
@@ -234,6 +368,7 @@ func mapAuthorizationResponse(a entity.Authorization) authorizationResponse {
 		TraceID:                  a.Recurring.TraceID,
 		ProcessingDate:           a.ProcessingDate.Format(time.RFC3339),
 		Card:                     CardResponse{Number: a.Card.MaskedPan, Scheme: a.Card.Info.Scheme},
+		MerchantID:               merchantID(a.Merchant),
 		CardAcceptor: CardAcceptor{
 			ID:           a.CardAcceptor.ID,
 			CategoryCode: a.CardAcceptor.CategoryCode,
@@ -254,12 +389,29 @@ func mapAuthorizationResponse(a entity.Authorization) authorizationResponse {
 			Code:    a.CardSchemeData.Response.ResponseCode.Value,
 			Message: a.CardSchemeData.Response.ResponseCode.Description,
 			TraceID: a.CardSchemeData.Response.TraceId,
+			IccData: strings.ToUpper(hex.EncodeToString(a.CardSchemeData.Response.IccData)),
 		},
 	}
 
 	return authorization
 }
 
+// approvedAmount is the amount the PSP can capture, which is left out of the response when the issuer did not approve
+func approvedAmount(a entity.Authorization) int {
+	code := a.CardSchemeData.Response.ResponseCode.Value
+	if code == "" || entity.AuthorizationStatusFromCardSchemeResponseCode(code) != entity.AuthorizeApproved {
+		return 0
+	}
+	return a.ApprovedAmount()
+}
+
+func merchantID(m entity.MerchantSnapshot) string {
+	if !m.IsSet() {
+		return ""
+	}
+	return m.MerchantID.String()
+}
+
 func (h *authorizationHandler) GetAuthorizations(w http.ResponseWriter, r *http.Request) {
 	var input struct {
 		params map[string]interface{}
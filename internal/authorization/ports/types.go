@@ -1,6 +1,7 @@
 package ports
 
 import (
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"strings"
@@ -12,24 +13,56 @@ import (
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 	"gitlab.cmpayments.local/creditcard/platform/currencycode"
 )
 
+// The most ICC data the schemes have room for: Mastercard DE55 and Visa F055 after its dataset ID and length
+const (
+	maxMastercardIccDataLength = 255
+	maxVisaIccDataLength       = 252
+)
+
+// The key serial numbers of TDES and AES DUKPT and the size of their PIN blocks, ISO format 0 and 4
+const (
+	tdesKeySerialNumberLength = 10
+	aesKeySerialNumberLength  = 12
+	iso0PinBlockLength        = 8
+	iso4PinBlockLength        = 16
+)
+
 type Card struct {
-	Holder string `json:"holder"`
-	Number string `json:"number"`
-	Cvv    string `json:"cvv"`
-	Expiry Expiry `json:"expiry"`
-	scheme string
+	Holder         string `json:"holder"`
+	Number         string `json:"number"`
+	Cvv            string `json:"cvv"`
+	Expiry         Expiry `json:"expiry"`
+	SequenceNumber string `json:"sequenceNumber,omitempty"`
+	Track2Data     string `json:"track2Data,omitempty"`
+	scheme         string
 }
 
 func (c Card) validate(v *validator.Validator) {
 	v.Check(len(c.Holder) >= 2 && len(c.Holder) <= 26, "card.holder", []string{"card holder must be min 2 and max 26 characters long"})
 	v.Check(len(c.Number) >= 12 && len(c.Number) <= 19, "card.number", []string{"card number must be min 12 and max 19 characters long"})
 	v.Check(c.Cvv == "" || len(c.Cvv) == 3, "card.cvv", []string{"length cvv must be 3 digits"})
+	v.Check(c.SequenceNumber == "" || regexp.MustCompile(`^[0-9]{1,3}$`).MatchString(c.SequenceNumber), "card.sequenceNumber", []string{"card sequence number must be max 3 digits"})
 	c.Expiry.validate(v)
 }
 
+// validateTrack2Data checks the track 2 data is of the card and has a valid service code. The messages never hold
+// the data itself.
+func (c Card) validateTrack2Data(v *validator.Validator) {
+	track, err := entity.ParseTrack2(c.Track2Data)
+	if err != nil {
+		v.AddError("card.track2Data", []string{"track 2 data must be the card number, =, expiry, service code and discretionary data, max 37 digits"})
+		return
+	}
+
+	v.Check(track.PAN == c.Number, "card.track2Data", []string{"track 2 data must be of the card number"})
+	v.Check(track.Expiry == entity.Expiry{Year: c.Expiry.Year, Month: c.Expiry.Month}, "card.track2Data", []string{"track 2 data must have the card expiry"})
+	v.Check(entity.IsValidServiceCode(track.ServiceCode), "card.track2Data", []string{"invalid service code"})
+}
+
 type CardResponse struct {
 	Number string `json:"number"`
 	Scheme string `json:"scheme"`
@@ -91,6 +124,110 @@ func (cof CitMitIndicator) validate(scheme string, v *validator.Validator) {
 	}
 }
 
+type Terminal struct {
+	ID         string `json:"id"`
+	Capability string `json:"capability"`
+	Level      string `json:"level,omitempty"`
+}
+
+func (t Terminal) validate(v *validator.Validator) {
+	v.Check(t.ID != "" && len(t.ID) <= 8, "terminal.id", []string{"terminal id must be min 1 and max 8 characters long"})
+	v.Check(entity.IsValidTerminalCapability(t.Capability), "terminal.capability", []string{"invalid terminal capability"})
+	v.Check(t.Level == "" || entity.IsValidTerminalLevel(t.Level), "terminal.level", []string{"invalid terminal level"})
+}
+
+// validateIccData checks the hex encoded EMV data objects of a chip or contactless card hold the mandatory tags
+// and fit in the message of the scheme
+func validateIccData(iccData, scheme string, v *validator.Validator) {
+	data, err := hex.DecodeString(iccData)
+	if err != nil || len(data) == 0 {
+		v.AddError("iccData", []string{"icc data must be hex encoded"})
+		return
+	}
+
+	maxLength := maxVisaIccDataLength
+	if scheme == entity.Mastercard {
+		maxLength = maxMastercardIccDataLength
+	}
+	if len(data) > maxLength {
+		v.AddError("iccData", []string{fmt.Sprintf("icc data must be max %d bytes long", maxLength)})
+		return
+	}
+
+	tlvs, err := iso8583.ParseTLVs(data, iso8583.BerTLV)
+	if err != nil {
+		v.AddError("iccData", []string{fmt.Sprintf("invalid icc data: %s", err)})
+		return
+	}
+
+	var missing []string
+	for _, tag := range entity.MandatoryIccTags {
+		if _, ok := tlvs.Find(tag.Tag); !ok {
+			missing = append(missing, fmt.Sprintf("icc data must have tag %s (%s)", tag.Tag, tag.Name))
+		}
+	}
+
+	if len(missing) > 0 {
+		v.AddError("iccData", missing)
+	}
+}
+
+type OnlinePin struct {
+	PinBlock        string `json:"pinBlock"`
+	KeySerialNumber string `json:"keySerialNumber"`
+
+	// translated tells whether a security module translates the PIN for the scheme
+	translated bool
+}
+
+// validate checks the hex encoded PIN block is of the DUKPT of the key serial number, an ISO format 0 PIN block for
+// TDES and an ISO format 4 PIN block for AES. The PIN itself is only known to the security module.
+func (p OnlinePin) validate(v *validator.Validator) {
+	if !p.translated {
+		v.AddError("onlinePin", []string{"online pin is not supported"})
+		return
+	}
+
+	ksn, err := hex.DecodeString(p.KeySerialNumber)
+	if err != nil || (len(ksn) != tdesKeySerialNumberLength && len(ksn) != aesKeySerialNumberLength) {
+		v.AddError("onlinePin.keySerialNumber", []string{"key serial number must be 20 hex digits for TDES or 24 hex digits for AES DUKPT"})
+		return
+	}
+
+	pinBlockLength := iso0PinBlockLength
+	if len(ksn) == aesKeySerialNumberLength {
+		pinBlockLength = iso4PinBlockLength
+	}
+
+	pinBlock, err := hex.DecodeString(p.PinBlock)
+	v.Check(err == nil && len(pinBlock) == pinBlockLength, "onlinePin.pinBlock", []string{fmt.Sprintf("pin block must be %d hex digits for the key serial number", 2*pinBlockLength)})
+}
+
+type AdditionalAmount struct {
+	Type     string `json:"type"`
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// validateAdditionalAmounts checks the additional amounts are part of the amount, in its currency, and leave a
+// purchase amount. A cardholder only gets cash back at a terminal.
+func (a authorizationRequest) validateAdditionalAmounts(v *validator.Validator) {
+	var total int
+	types := make(map[string]bool, len(a.AdditionalAmounts))
+	for i, aa := range a.AdditionalAmounts {
+		key := fmt.Sprintf("additionalAmounts[%d]", i)
+		v.Check(entity.IsValidAdditionalAmountType(aa.Type), key+".type", []string{"type must be cashback, surcharge or gratuity"})
+		v.Check(!types[aa.Type], key+".type", []string{"only one additional amount per type is allowed"})
+		v.Check(aa.Type != string(entity.CashbackAmount) || a.Source == string(entity.CardPresent), key+".type", []string{"cashback is only allowed for card present authorizations"})
+		v.Check(aa.Amount > 0, key+".amount", []string{"amount must be greater than 0"})
+		v.Check(aa.Currency == a.Currency, key+".currency", []string{"currency must be the currency of the authorization"})
+		types[aa.Type] = true
+		total += aa.Amount
+	}
+
+	v.Check(total < a.Amount, "additionalAmounts", []string{"additional amounts must be less than the amount"})
+}
+
 type ThreeDSecure struct {
 	AuthenticationVerificationValue string                  `json:"authenticationVerificationValue"`
 	Version                         string                  `json:"version"`
@@ -122,10 +259,17 @@ type authorizationRequest struct {
 	InitialRecurring         bool                          `json:"initialRecurring"`
 	InitialTraceID           string                        `json:"initialTraceId"`
 	Card                     Card                          `json:"card"`
+	MerchantID               string                        `json:"merchantId,omitempty"`
 	CardAcceptor             CardAcceptor                  `json:"cardAcceptor"`
 	CitMitIndicator          CitMitIndicator               `json:"citMitIndicator"`
 	Exemption                string                        `json:"exemption"`
 	ThreeDSecure             ThreeDSecure                  `json:"threeDSecure"`
+	PanEntryMode             string                        `json:"panEntryMode,omitempty"`
+	Terminal                 Terminal                      `json:"terminal"`
+	IccData                  string                        `json:"iccData,omitempty"`
+	OnlinePin                *OnlinePin                    `json:"onlinePin,omitempty"`
+	PartialApprovalSupported bool                          `json:"partialApprovalSupported"`
+	AdditionalAmounts        []AdditionalAmount            `json:"additionalAmounts,omitempty"`
 }
 
 func (a authorizationRequest) validate(v *validator.Validator) {
@@ -149,6 +293,36 @@ func (a authorizationRequest) validate(v *validator.Validator) {
 	a.CitMitIndicator.validate(a.Card.scheme, v)
 	v.Check(a.Exemption == "" || entity.IsValidExemption(a.Exemption), "exemption", []string{"invalid exemption"})
 	a.ThreeDSecure.validate(v)
+	a.validateCardPresent(v)
+	a.validateAdditionalAmounts(v)
+}
+
+// validateCardPresent checks a card present authorization has the terminal and the chip data or track 2 data the
+// card was read with, and a valid online PIN when the cardholder entered one. Other authorizations must not have
+// chip or track data or an online PIN.
+func (a authorizationRequest) validateCardPresent(v *validator.Validator) {
+	if a.Source != string(entity.CardPresent) {
+		v.Check(a.IccData == "", "iccData", []string{"icc data is only allowed for card present authorizations"})
+		v.Check(a.Card.Track2Data == "", "card.track2Data", []string{"track 2 data is only allowed for card present authorizations"})
+		v.Check(a.OnlinePin == nil, "onlinePin", []string{"online pin is only allowed for card present authorizations"})
+		return
+	}
+
+	a.Terminal.validate(v)
+	if a.OnlinePin != nil {
+		a.OnlinePin.validate(v)
+	}
+
+	switch entity.PANEntry(a.PanEntryMode) {
+	case entity.PANEntryChip, entity.PANEntryContactless:
+		v.Check(a.Card.Track2Data == "", "card.track2Data", []string{"track 2 data is only allowed for magnetic stripe authorizations"})
+		validateIccData(a.IccData, a.Card.scheme, v)
+	case entity.PANEntryMagneticStrip, entity.PANEntryContactlessMagneticStrip:
+		v.Check(a.IccData == "", "iccData", []string{"icc data is only allowed for chip or contactless authorizations"})
+		a.Card.validateTrack2Data(v)
+	default:
+		v.AddError("panEntryMode", []string{"pan entry mode must be chip, contactless, magneticStrip or contactlessMagneticStrip"})
+	}
 }
 
 type CardSchemeResponse struct {
@@ -156,12 +330,15 @@ type CardSchemeResponse struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	TraceID string `json:"traceId,omitempty"`
+	IccData string `json:"iccData,omitempty"`
 }
 
 type authorizationResponse struct {
 	ID                       string                         `json:"id"`
 	LogID                    string                         `json:"logID,omitempty"`
 	Amount                   int                            `json:"amount"`
+	ApprovedAmount           int                            `json:"approvedAmount,omitempty"`
+	AdditionalAmounts        []AdditionalAmount             `json:"additionalAmounts,omitempty"`
 	Currency                 string                         `json:"currency"`
 	Reference                string                         `json:"reference"`
 	Source                   string                         `json:"source"`
@@ -171,6 +348,7 @@ type authorizationResponse struct {
 	TraceID                  string                         `json:"traceId,omitempty"`
 	ProcessingDate           string                         `json:"processingDate"`
 	Card                     CardResponse                   `json:"card"`
+	MerchantID               string                         `json:"merchantId,omitempty"`
 	CardAcceptor             CardAcceptor                   `json:"cardAcceptor"`
 	Exemption                string                         `json:"exemption,omitempty"`
 	ThreeDSecure             ThreeDSecureResponse           `json:"threeDSecure"`
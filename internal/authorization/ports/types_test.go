@@ -1,12 +1,15 @@
 package ports
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/platform/http/validator"
 )
 
@@ -221,3 +224,295 @@ func TestValidateCardAcceptor(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateIccData(t *testing.T) {
+	// The data objects of an ARQC, with spaces between them
+	const iccData = "9F2608 0102030405060708 9F2701 80 9F1007 06010A03A00000 9F3704 12345678 9F3602 0001 9505 0000000000 " +
+		"9A03 261019 9C01 00 9F0206 000000001000 5F2A02 0978 8202 1980 9F1A02 0528 9F3403 1F0302"
+
+	// withLength pads the data objects to length bytes with a proprietary one, its length in the long form
+	withLength := func(length int) string {
+		pad := length - len(strings.ReplaceAll(iccData, " ", ""))/2 - 4
+		return iccData + fmt.Sprintf(" DF0181%02X", pad) + strings.Repeat("00", pad)
+	}
+
+	tests := []struct {
+		name    string
+		scheme  string
+		iccData string
+		wanted  map[string][]string
+	}{
+		{
+			name:    "valid icc data",
+			iccData: iccData,
+			wanted:  nil,
+		},
+		{
+			name:    "longest visa icc data",
+			scheme:  entity.Visa,
+			iccData: withLength(252),
+			wanted:  nil,
+		},
+		{
+			name:    "visa icc data too long",
+			scheme:  entity.Visa,
+			iccData: withLength(253),
+			wanted: map[string][]string{
+				"iccData": {
+					0: "icc data must be max 252 bytes long",
+				},
+			},
+		},
+		{
+			name:    "longest mastercard icc data",
+			scheme:  entity.Mastercard,
+			iccData: withLength(255),
+			wanted:  nil,
+		},
+		{
+			name:    "mastercard icc data too long",
+			scheme:  entity.Mastercard,
+			iccData: withLength(256),
+			wanted: map[string][]string{
+				"iccData": {
+					0: "icc data must be max 255 bytes long",
+				},
+			},
+		},
+		{
+			name:    "missing cvm results",
+			iccData: strings.TrimSuffix(iccData, " 9F3403 1F0302"),
+			wanted: map[string][]string{
+				"iccData": {
+					0: "icc data must have tag 9F34 (cardholder verification method results)",
+				},
+			},
+		},
+		{
+			name:    "not hex encoded",
+			iccData: "9F2608XYZ",
+			wanted: map[string][]string{
+				"iccData": {
+					0: "icc data must be hex encoded",
+				},
+			},
+		},
+		{
+			name:    "truncated data object",
+			iccData: "9F260801020304",
+			wanted: map[string][]string{
+				"iccData": {
+					0: "invalid icc data: data object at 0: tag 9F26 has length 8, 4 bytes left",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			validateIccData(strings.ReplaceAll(tt.iccData, " ", ""), tt.scheme, v)
+			if tt.wanted == nil {
+				tt.wanted = make(map[string][]string)
+			}
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("validateIccData(v) = %v, wanted: %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestValidateTrack2Data(t *testing.T) {
+	card := func(track2Data string) Card {
+		return Card{
+			Number:     "5204740000001002",
+			Expiry:     Expiry{Month: "12", Year: "25"},
+			Track2Data: track2Data,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		c      Card
+		wanted map[string][]string
+	}{
+		{
+			name:   "valid track 2 data",
+			c:      card("5204740000001002=25122010000012300000"),
+			wanted: nil,
+		},
+		{
+			name: "other card number",
+			c:    card("5204740000001010=25122010000012300000"),
+			wanted: map[string][]string{
+				"card.track2Data": {
+					0: "track 2 data must be of the card number",
+				},
+			},
+		},
+		{
+			name: "invalid service code",
+			c:    card("5204740000001002=25123010000012300000"),
+			wanted: map[string][]string{
+				"card.track2Data": {
+					0: "invalid service code",
+				},
+			},
+		},
+		{
+			name: "no field separator",
+			c:    card("5204740000001002D25122010000012300000"),
+			wanted: map[string][]string{
+				"card.track2Data": {
+					0: "track 2 data must be the card number, =, expiry, service code and discretionary data, max 37 digits",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			tt.c.validateTrack2Data(v)
+			if tt.wanted == nil {
+				tt.wanted = make(map[string][]string)
+			}
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("validateTrack2Data(v) = %v, wanted: %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestValidateOnlinePin(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      OnlinePin
+		wanted map[string][]string
+	}{
+		{
+			name:   "tdes dukpt",
+			p:      OnlinePin{PinBlock: "1B9C1845EB993A7A", KeySerialNumber: "FFFF9876543210E00001", translated: true},
+			wanted: nil,
+		},
+		{
+			name:   "aes dukpt",
+			p:      OnlinePin{PinBlock: "1B9C1845EB993A7A1B9C1845EB993A7A", KeySerialNumber: "123456789012345600000001", translated: true},
+			wanted: nil,
+		},
+		{
+			name: "iso format 0 pin block for aes dukpt",
+			p:    OnlinePin{PinBlock: "1B9C1845EB993A7A", KeySerialNumber: "123456789012345600000001", translated: true},
+			wanted: map[string][]string{
+				"onlinePin.pinBlock": {
+					0: "pin block must be 32 hex digits for the key serial number",
+				},
+			},
+		},
+		{
+			name: "invalid key serial number",
+			p:    OnlinePin{PinBlock: "1B9C1845EB993A7A", KeySerialNumber: "FFFF9876543210E0", translated: true},
+			wanted: map[string][]string{
+				"onlinePin.keySerialNumber": {
+					0: "key serial number must be 20 hex digits for TDES or 24 hex digits for AES DUKPT",
+				},
+			},
+		},
+		{
+			name: "no pin translation",
+			p:    OnlinePin{PinBlock: "1B9C1845EB993A7A", KeySerialNumber: "FFFF9876543210E00001"},
+			wanted: map[string][]string{
+				"onlinePin": {
+					0: "online pin is not supported",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			tt.p.validate(v)
+			if tt.wanted == nil {
+				tt.wanted = make(map[string][]string)
+			}
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("validate(v) = %v, wanted: %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestValidateAdditionalAmounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      authorizationRequest
+		wanted map[string][]string
+	}{
+		{
+			name: "cashback",
+			a: authorizationRequest{Amount: 5000, Currency: "EUR", Source: "cardPresent", AdditionalAmounts: []AdditionalAmount{
+				{Type: "cashback", Amount: 2000, Currency: "EUR"},
+			}},
+			wanted: nil,
+		},
+		{
+			name: "cashback not card present",
+			a: authorizationRequest{Amount: 5000, Currency: "EUR", Source: "ecommerce", AdditionalAmounts: []AdditionalAmount{
+				{Type: "cashback", Amount: 2000, Currency: "EUR"},
+			}},
+			wanted: map[string][]string{
+				"additionalAmounts[0].type": {
+					0: "cashback is only allowed for card present authorizations",
+				},
+			},
+		},
+		{
+			name: "invalid type and currency",
+			a: authorizationRequest{Amount: 5000, Currency: "EUR", Source: "ecommerce", AdditionalAmounts: []AdditionalAmount{
+				{Type: "tip", Amount: 150, Currency: "GBP"},
+			}},
+			wanted: map[string][]string{
+				"additionalAmounts[0].type": {
+					0: "type must be cashback, surcharge or gratuity",
+				},
+				"additionalAmounts[0].currency": {
+					0: "currency must be the currency of the authorization",
+				},
+			},
+		},
+		{
+			name: "duplicate type",
+			a: authorizationRequest{Amount: 5000, Currency: "EUR", Source: "ecommerce", AdditionalAmounts: []AdditionalAmount{
+				{Type: "gratuity", Amount: 150, Currency: "EUR"},
+				{Type: "gratuity", Amount: 150, Currency: "EUR"},
+			}},
+			wanted: map[string][]string{
+				"additionalAmounts[1].type": {
+					0: "only one additional amount per type is allowed",
+				},
+			},
+		},
+		{
+			name: "total amount",
+			a: authorizationRequest{Amount: 5000, Currency: "EUR", Source: "cardPresent", AdditionalAmounts: []AdditionalAmount{
+				{Type: "cashback", Amount: 4000, Currency: "EUR"},
+				{Type: "surcharge", Amount: 1000, Currency: "EUR"},
+			}},
+			wanted: map[string][]string{
+				"additionalAmounts": {
+					0: "additional amounts must be less than the amount",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			tt.a.validateAdditionalAmounts(v)
+			if tt.wanted == nil {
+				tt.wanted = make(map[string][]string)
+			}
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("validateAdditionalAmounts(v) = %v, wanted: %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
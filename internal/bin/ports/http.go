@@ -0,0 +1,93 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"gitlab.cmpayments.local/creditcard/platform"
+	platformErr "gitlab.cmpayments.local/creditcard/platform/http/errors"
+	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/cardinfo"
+)
+
+type binHandler struct {
+	logger     platform.Logger
+	cardRanges *cardinfo.Collection
+}
+
+func NewBinHandler(logger platform.Logger, cardRanges *cardinfo.Collection) *binHandler {
+	return &binHandler{
+		logger:     logger,
+		cardRanges: cardRanges,
+	}
+}
+
+func (h *binHandler) GetBin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	bin := params.ByName("bin")
+
+	v := validator.New()
+
+	validateBin(v, bin)
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return
+	}
+
+	cardRange, ok := h.cardRanges.FindBin(bin)
+	if !ok {
+		platformErr.NotFoundResponse(ctx, w, h.logger)
+		return
+	}
+
+	if err := platformhandler.WriteJSON(w, http.StatusOK, mapBinResponse(bin, cardRange), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *binHandler) GetLoadStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	response := LoadStatusesResponse{
+		Tables: []loadStatusResponse{},
+	}
+
+	for _, s := range h.cardRanges.LoadStatuses() {
+		response.Tables = append(response.Tables, mapLoadStatusResponse(s))
+	}
+
+	if err := platformhandler.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+// RollbackTable restores the BIN range table of the scheme that was loaded before the current one
+func (h *binHandler) RollbackTable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	status, err := h.cardRanges.Rollback(params.ByName("scheme"))
+	if err != nil {
+		switch {
+		case errors.Is(err, cardinfo.ErrNoPreviousGeneration):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	h.logger.Info(ctx, fmt.Sprintf("rolled back %s BIN range table to %s", status.Scheme, status.File))
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, mapLoadStatusResponse(status), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"regexp"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/cardinfo"
+)
+
+var binRX = regexp.MustCompile("^[0-9]{6,11}$")
+
+func validateBin(v *validator.Validator, bin string) {
+	v.Check(binRX.MatchString(bin), "bin", []string{"bin must be 6 to 11 digits"})
+}
+
+type binResponse struct {
+	Bin               string `json:"bin"`
+	Scheme            string `json:"scheme"`
+	ProductID         string `json:"productId,omitempty"`
+	ProductName       string `json:"productName,omitempty"`
+	ProgramID         string `json:"programId,omitempty"`
+	IssuerName        string `json:"issuerName,omitempty"`
+	IssuerCountryCode string `json:"issuerCountryCode,omitempty"`
+	Blocked           bool   `json:"blocked"`
+}
+
+type loadStatusResponse struct {
+	Scheme   string `json:"scheme"`
+	FileType string `json:"fileType"`
+	File     string `json:"file,omitempty"`
+	LoadedAt string `json:"loadedAt"`
+	Ranges   int    `json:"ranges"`
+}
+
+type LoadStatusesResponse struct {
+	Tables []loadStatusResponse `json:"tables"`
+}
+
+func mapBinResponse(bin string, r cardinfo.Range) binResponse {
+	return binResponse{
+		Bin:               bin,
+		Scheme:            r.Scheme,
+		ProductID:         r.ProductID,
+		ProductName:       r.ProductName,
+		ProgramID:         r.ProgramID,
+		IssuerName:        r.IssuerName,
+		IssuerCountryCode: r.IssuerCountryCode,
+		Blocked:           r.IsBlocked,
+	}
+}
+
+func mapLoadStatusResponse(s cardinfo.LoadStatus) loadStatusResponse {
+	return loadStatusResponse{
+		Scheme:   s.Scheme,
+		FileType: s.Source,
+		File:     s.File,
+		LoadedAt: s.LoadedAt.Format(time.RFC3339),
+		Ranges:   s.Ranges,
+	}
+}
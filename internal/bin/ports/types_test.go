@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+)
+
+func Test_validateBin(t *testing.T) {
+	tests := []struct {
+		name string
+		bin  string
+		want bool
+	}{
+		{name: "six_digits", bin: "520474", want: true},
+		{name: "eleven_digits", bin: "52047400000", want: true},
+		{name: "five_digits", bin: "52047", want: false},
+		{name: "twelve_digits", bin: "520474000000", want: false},
+		{name: "not_numeric", bin: "52047A", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			validateBin(v, tt.bin)
+			if v.Valid() != tt.want {
+				t.Errorf("validateBin() valid = %v, want %v", v.Valid(), tt.want)
+			}
+		})
+	}
+}
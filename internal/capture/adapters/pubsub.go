@@ -48,6 +48,7 @@ func CreatePublishAuthorizationRequest(a entity.Authorization, c entity.Capture)
 			FromAccountTypeCode: a.CardSchemeData.Request.ProcessingCode.FromAccountTypeCode,
 			ToAccountTypeCode:   a.CardSchemeData.Request.ProcessingCode.ToAccountTypeCode,
 		},
+		AdditionalAmounts: mapAdditionalAmounts(a.AdditionalAmounts),
 	}
 
 	switch a.Card.Info.Scheme {
@@ -58,6 +59,23 @@ func CreatePublishAuthorizationRequest(a entity.Authorization, c entity.Capture)
 	return message
 }
 
+func mapAdditionalAmounts(amounts []entity.AdditionalAmount) []events.AdditionalAmount {
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	additionalAmounts := make([]events.AdditionalAmount, 0, len(amounts))
+	for _, aa := range amounts {
+		additionalAmounts = append(additionalAmounts, events.AdditionalAmount{
+			Type:     string(aa.Type),
+			Amount:   aa.Amount,
+			Currency: aa.Currency.Alpha3(),
+		})
+	}
+
+	return additionalAmounts
+}
+
 func CreatePublishRefundRequest(r entity.Refund, c entity.RefundCapture) interface{} {
 	message := events.RefundCaptureMessageV1{
 		RefundID:  r.ID.String(),
@@ -3,14 +3,22 @@ package config
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 )
 
 //go:generate mockgen -package=storage_mocks -source=./config.go -destination=mocks/config.go
 type ConfigService interface {
-	FetchConfig(ctx context.Context, merchantID string) (entity.CardAcceptor, error)
+	FetchConfig(ctx context.Context, pspID, merchantID uuid.UUID) (entity.MerchantSnapshot, error)
 }
 
 type ConfigSnapshotter interface {
-	SnapshotEffectiveConfig(merchantID string) (EffectiveConfig, error)
+	SnapshotEffectiveConfig(ctx context.Context, pspID, merchantID uuid.UUID) (EffectiveConfig, error)
+}
+
+// MerchantStore provides the configured values of the Merchant and Tenant levels.
+type MerchantStore interface {
+	GetMerchant(ctx context.Context, pspID, merchantID uuid.UUID) (entity.Merchant, error)
+	GetMerchantDefaults(ctx context.Context, pspID uuid.UUID) (entity.CardAcceptor, error)
 }
@@ -1,53 +1,129 @@
 package config
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+var ErrMerchantNotFound = errors.New("merchant not found")
+
+// Level is the point where a configuration value has been set.
+type Level string
 
-	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
+const (
+	LevelMerchant Level = "merchant"
+	LevelTenant   Level = "tenant"
+	LevelDefault  Level = "default"
 )
 
 // Snapshotter can create a snapshot of effective Merchant configuration.
 type Snapshotter struct {
-	TargetTable string
+	store    MerchantStore
+	defaults entity.CardAcceptor
+}
+
+// NewSnapshotter returns a Snapshotter resolving the Merchant and Tenant levels from the store,
+// falling back to the passed service wide defaults.
+func NewSnapshotter(store MerchantStore, defaults entity.CardAcceptor) Snapshotter {
+	return Snapshotter{
+		store:    store,
+		defaults: defaults,
+	}
 }
 
 // SnapshotEffectiveConfig creates a snapshot of the current, effective configuration that applies
-// to a Merchant and returns it.
-// The snapshot is stored in the DB table indicated by the Snapshotter.TargetTable field.
-func (s Snapshotter) SnapshotEffectiveConfig(merchantID string) (EffectiveConfig, error) {
-	if merchantID != "2ef864ea-5f4c-439d-8e99-32d286d89553" {
-		return EffectiveConfig{}, processing.ErrPspNotFound
-	}
-	return EffectiveConfig{Merchant: entity.CardAcceptor{
-		CategoryCode: "5691",
-		ID:           "0987654321",
-		Name:         "MaxCorp Inc.",
-		Address: entity.CardAcceptorAddress{
-			PostalCode:  "4825 AN",
-			City:        "Breda",
-			CountryCode: "NLD",
-		},
-	}}, nil
+// to a Merchant of the PSP and returns it.
+// The snapshot is meant to be stored next to the transaction it was created for.
+func (s Snapshotter) SnapshotEffectiveConfig(ctx context.Context, pspID, merchantID uuid.UUID) (EffectiveConfig, error) {
+	merchant, err := s.store.GetMerchant(ctx, pspID, merchantID)
+	if err != nil {
+		if errors.Is(err, entity.ErrRecordNotFound) {
+			return EffectiveConfig{}, ErrMerchantNotFound
+		}
+		return EffectiveConfig{}, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	// A PSP without defaults simply falls through to the service wide defaults
+	tenant, err := s.store.GetMerchantDefaults(ctx, pspID)
+	if err != nil && !errors.Is(err, entity.ErrRecordNotFound) {
+		return EffectiveConfig{}, fmt.Errorf("failed to get merchant defaults: %w", err)
+	}
+
+	return resolve(merchant, tenant, s.defaults), nil
 }
 
 // EffectiveConfig contains the effective, resolved configuration of a Merchant.
 // It has a property for each configuration value and takes the most specific point where it has
 // been set (Merchant > Tenant > Default)
 type EffectiveConfig struct {
-	Merchant entity.CardAcceptor
+	MerchantID uuid.UUID
+	PspID      uuid.UUID
+	Merchant   entity.CardAcceptor
+	// Origins holds the Level each card acceptor value was resolved from
+	Origins    map[string]Level
+	ResolvedAt time.Time
+}
+
+// Snapshot returns the effective configuration as it is stored next to a transaction.
+func (ec EffectiveConfig) Snapshot() entity.MerchantSnapshot {
+	origins := make(map[string]string, len(ec.Origins))
+	for field, level := range ec.Origins {
+		origins[field] = string(level)
+	}
+
+	return entity.MerchantSnapshot{
+		MerchantID:   ec.MerchantID,
+		CardAcceptor: ec.Merchant,
+		Origins:      origins,
+		CreatedAt:    ec.ResolvedAt,
+	}
+}
+
+type layer struct {
+	level Level
+	ca    entity.CardAcceptor
 }
 
-// Merchant This is a DEMO structure for store merchant configuration, should be
-// replaced when fetchconfig service is implemented
-type Merchant struct {
-	ID                       string
-	MCC                      string
-	CountryCode              string
-	RouteBy                  string
-	DefaultAuthorizationType string
-	AutomaticCapture         bool
-	CaptureDelay             time.Duration
-	TenantID                 string
+func resolve(merchant entity.Merchant, tenant, defaults entity.CardAcceptor) EffectiveConfig {
+	ec := EffectiveConfig{
+		MerchantID: merchant.ID,
+		PspID:      merchant.PspID,
+		Origins:    map[string]Level{},
+		ResolvedAt: time.Now(),
+	}
+
+	layers := []layer{
+		{level: LevelMerchant, ca: merchant.CardAcceptor},
+		{level: LevelTenant, ca: tenant},
+		{level: LevelDefault, ca: defaults},
+	}
+
+	pick := func(field string, value func(entity.CardAcceptor) string) string {
+		for _, l := range layers {
+			if v := value(l.ca); v != "" {
+				ec.Origins[field] = l.level
+				return v
+			}
+		}
+		return ""
+	}
+
+	ec.Merchant = entity.CardAcceptor{
+		ID:           pick("id", func(ca entity.CardAcceptor) string { return ca.ID }),
+		Name:         pick("name", func(ca entity.CardAcceptor) string { return ca.Name }),
+		CategoryCode: pick("categoryCode", func(ca entity.CardAcceptor) string { return ca.CategoryCode }),
+		Address: entity.CardAcceptorAddress{
+			PostalCode:  pick("postalCode", func(ca entity.CardAcceptor) string { return ca.Address.PostalCode }),
+			City:        pick("city", func(ca entity.CardAcceptor) string { return ca.Address.City }),
+			CountryCode: pick("country", func(ca entity.CardAcceptor) string { return ca.Address.CountryCode }),
+		},
+	}
+
+	return ec
 }
@@ -0,0 +1,118 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/config"
+	storage_mocks "gitlab.cmpayments.local/creditcard/authorization/internal/config/mocks"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+func TestSnapshotter_SnapshotEffectiveConfig(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		pspID      = uuid.New()
+		merchantID = uuid.New()
+		defaults   = entity.CardAcceptor{
+			CategoryCode: "5999",
+			Address:      entity.CardAcceptorAddress{CountryCode: "NLD"},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		merchant    entity.Merchant
+		merchantErr error
+		tenant      entity.CardAcceptor
+		tenantErr   error
+		want        entity.CardAcceptor
+		wantOrigins map[string]config.Level
+		wantErr     error
+	}{
+		{
+			name: "merchant_overrides_tenant_and_default",
+			merchant: entity.Merchant{ID: merchantID, PspID: pspID, CardAcceptor: entity.CardAcceptor{
+				ID:           "0987654321",
+				Name:         "MaxCorp Inc.",
+				CategoryCode: "5691",
+				Address:      entity.CardAcceptorAddress{City: "Breda", PostalCode: "4825 AN", CountryCode: "BEL"},
+			}},
+			tenant: entity.CardAcceptor{Name: "Tenant", Address: entity.CardAcceptorAddress{City: "Amsterdam"}},
+			want: entity.CardAcceptor{
+				ID:           "0987654321",
+				Name:         "MaxCorp Inc.",
+				CategoryCode: "5691",
+				Address:      entity.CardAcceptorAddress{City: "Breda", PostalCode: "4825 AN", CountryCode: "BEL"},
+			},
+			wantOrigins: map[string]config.Level{
+				"id": config.LevelMerchant, "name": config.LevelMerchant, "categoryCode": config.LevelMerchant,
+				"city": config.LevelMerchant, "postalCode": config.LevelMerchant, "country": config.LevelMerchant,
+			},
+		},
+		{
+			name: "empty_values_fall_through",
+			merchant: entity.Merchant{ID: merchantID, PspID: pspID, CardAcceptor: entity.CardAcceptor{
+				ID: "0987654321",
+			}},
+			tenant: entity.CardAcceptor{Name: "Tenant", Address: entity.CardAcceptorAddress{City: "Amsterdam"}},
+			want: entity.CardAcceptor{
+				ID:           "0987654321",
+				Name:         "Tenant",
+				CategoryCode: "5999",
+				Address:      entity.CardAcceptorAddress{City: "Amsterdam", CountryCode: "NLD"},
+			},
+			wantOrigins: map[string]config.Level{
+				"id": config.LevelMerchant, "name": config.LevelTenant, "categoryCode": config.LevelDefault,
+				"city": config.LevelTenant, "country": config.LevelDefault,
+			},
+		},
+		{
+			name:      "psp_without_defaults",
+			merchant:  entity.Merchant{ID: merchantID, PspID: pspID, CardAcceptor: entity.CardAcceptor{ID: "0987654321"}},
+			tenantErr: entity.ErrRecordNotFound,
+			want: entity.CardAcceptor{
+				ID:           "0987654321",
+				CategoryCode: "5999",
+				Address:      entity.CardAcceptorAddress{CountryCode: "NLD"},
+			},
+			wantOrigins: map[string]config.Level{
+				"id": config.LevelMerchant, "categoryCode": config.LevelDefault, "country": config.LevelDefault,
+			},
+		},
+		{
+			name:        "unknown_merchant",
+			merchantErr: entity.ErrRecordNotFound,
+			wantErr:     config.ErrMerchantNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := storage_mocks.NewMockMerchantStore(gomock.NewController(t))
+			store.EXPECT().GetMerchant(ctx, pspID, merchantID).Return(tt.merchant, tt.merchantErr)
+			if tt.merchantErr == nil {
+				store.EXPECT().GetMerchantDefaults(ctx, pspID).Return(tt.tenant, tt.tenantErr)
+			}
+
+			got, err := config.NewSnapshotter(store, defaults).SnapshotEffectiveConfig(ctx, pspID, merchantID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SnapshotEffectiveConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Merchant, tt.want) {
+				t.Errorf("SnapshotEffectiveConfig() got = %v, want %v", got.Merchant, tt.want)
+			}
+			if !reflect.DeepEqual(got.Origins, tt.wantOrigins) {
+				t.Errorf("SnapshotEffectiveConfig() origins = %v, want %v", got.Origins, tt.wantOrigins)
+			}
+		})
+	}
+}
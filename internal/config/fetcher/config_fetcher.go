@@ -3,6 +3,8 @@ package fetcher
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/config"
@@ -20,11 +22,11 @@ func NewConfigFetcher(snapshotter config.ConfigSnapshotter) *configFetcher {
 	}
 }
 
-func (pt configFetcher) FetchConfig(_ context.Context, merchantID string) (entity.CardAcceptor, error) {
-	conf, err := pt.snapshotter.SnapshotEffectiveConfig(merchantID)
+func (pt configFetcher) FetchConfig(ctx context.Context, pspID, merchantID uuid.UUID) (entity.MerchantSnapshot, error) {
+	conf, err := pt.snapshotter.SnapshotEffectiveConfig(ctx, pspID, merchantID)
 	if err != nil {
-		return entity.CardAcceptor{}, err
+		return entity.MerchantSnapshot{}, err
 	}
 
-	return conf.Merchant, nil
+	return conf.Snapshot(), nil
 }
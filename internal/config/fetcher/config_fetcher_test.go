@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/uuid"
+
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 
 	"github.com/golang/mock/gomock"
@@ -16,32 +18,34 @@ import (
 func Test_configFetcher_FetchConfig(t *testing.T) {
 	var (
 		ctx        = context.Background()
-		merchantID = "merchantID"
-		merchant   = entity.CardAcceptor{ID: merchantID}
+		pspID      = uuid.New()
+		merchantID = uuid.New()
+		merchant   = entity.CardAcceptor{ID: "merchantID"}
+		snapshot   = entity.MerchantSnapshot{MerchantID: merchantID, CardAcceptor: merchant, Origins: map[string]string{"id": "merchant"}}
 	)
 
 	tests := []struct {
 		name    string
 		cf      configFetcher
-		want    entity.CardAcceptor
+		want    entity.MerchantSnapshot
 		wantErr bool
 	}{
 		{
-			name:    "should return error",
-			cf:      buildConfigFetcher(t, merchant, false),
-			want:    merchant,
+			name:    "should return snapshot",
+			cf:      buildConfigFetcher(t, merchantID, merchant, false),
+			want:    snapshot,
 			wantErr: false,
 		},
 		{
 			name:    "should return error",
-			cf:      buildConfigFetcher(t, merchant, true),
-			want:    entity.CardAcceptor{},
+			cf:      buildConfigFetcher(t, merchantID, merchant, true),
+			want:    entity.MerchantSnapshot{},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.cf.FetchConfig(ctx, merchantID)
+			got, err := tt.cf.FetchConfig(ctx, pspID, merchantID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchConfig() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -53,14 +57,18 @@ func Test_configFetcher_FetchConfig(t *testing.T) {
 	}
 }
 
-func buildConfigFetcher(t *testing.T, merchant entity.CardAcceptor, wantErr bool) configFetcher {
+func buildConfigFetcher(t *testing.T, merchantID uuid.UUID, merchant entity.CardAcceptor, wantErr bool) configFetcher {
 	snapshotter := storage_mocks.NewMockConfigSnapshotter(gomock.NewController(t))
 	if wantErr {
-		snapshotter.EXPECT().SnapshotEffectiveConfig(gomock.Any()).
+		snapshotter.EXPECT().SnapshotEffectiveConfig(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(config.EffectiveConfig{}, errors.New("dummy error"))
 	} else {
-		snapshotter.EXPECT().SnapshotEffectiveConfig(gomock.Any()).
-			Return(config.EffectiveConfig{Merchant: merchant}, nil)
+		snapshotter.EXPECT().SnapshotEffectiveConfig(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(config.EffectiveConfig{
+				MerchantID: merchantID,
+				Merchant:   merchant,
+				Origins:    map[string]config.Level{"id": config.LevelMerchant},
+			}, nil)
 	}
 
 	return configFetcher{snapshotter: snapshotter}
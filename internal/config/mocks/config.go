@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	config "gitlab.cmpayments.local/creditcard/authorization/internal/config"
 	entity "gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 )
@@ -37,18 +38,18 @@ func (m *MockConfigService) EXPECT() *MockConfigServiceMockRecorder {
 }
 
 // FetchConfig mocks base method.
-func (m *MockConfigService) FetchConfig(ctx context.Context, merchantID string) (entity.CardAcceptor, error) {
+func (m *MockConfigService) FetchConfig(ctx context.Context, pspID, merchantID uuid.UUID) (entity.MerchantSnapshot, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchConfig", ctx, merchantID)
-	ret0, _ := ret[0].(entity.CardAcceptor)
+	ret := m.ctrl.Call(m, "FetchConfig", ctx, pspID, merchantID)
+	ret0, _ := ret[0].(entity.MerchantSnapshot)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchConfig indicates an expected call of FetchConfig.
-func (mr *MockConfigServiceMockRecorder) FetchConfig(ctx, merchantID interface{}) *gomock.Call {
+func (mr *MockConfigServiceMockRecorder) FetchConfig(ctx, pspID, merchantID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchConfig", reflect.TypeOf((*MockConfigService)(nil).FetchConfig), ctx, merchantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchConfig", reflect.TypeOf((*MockConfigService)(nil).FetchConfig), ctx, pspID, merchantID)
 }
 
 // MockConfigSnapshotter is a mock of ConfigSnapshotter interface.
@@ -75,16 +76,69 @@ func (m *MockConfigSnapshotter) EXPECT() *MockConfigSnapshotterMockRecorder {
 }
 
 // SnapshotEffectiveConfig mocks base method.
-func (m *MockConfigSnapshotter) SnapshotEffectiveConfig(merchantID string) (config.EffectiveConfig, error) {
+func (m *MockConfigSnapshotter) SnapshotEffectiveConfig(ctx context.Context, pspID, merchantID uuid.UUID) (config.EffectiveConfig, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SnapshotEffectiveConfig", merchantID)
+	ret := m.ctrl.Call(m, "SnapshotEffectiveConfig", ctx, pspID, merchantID)
 	ret0, _ := ret[0].(config.EffectiveConfig)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SnapshotEffectiveConfig indicates an expected call of SnapshotEffectiveConfig.
-func (mr *MockConfigSnapshotterMockRecorder) SnapshotEffectiveConfig(merchantID interface{}) *gomock.Call {
+func (mr *MockConfigSnapshotterMockRecorder) SnapshotEffectiveConfig(ctx, pspID, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotEffectiveConfig", reflect.TypeOf((*MockConfigSnapshotter)(nil).SnapshotEffectiveConfig), ctx, pspID, merchantID)
+}
+
+// MockMerchantStore is a mock of MerchantStore interface.
+type MockMerchantStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantStoreMockRecorder
+}
+
+// MockMerchantStoreMockRecorder is the mock recorder for MockMerchantStore.
+type MockMerchantStoreMockRecorder struct {
+	mock *MockMerchantStore
+}
+
+// NewMockMerchantStore creates a new mock instance.
+func NewMockMerchantStore(ctrl *gomock.Controller) *MockMerchantStore {
+	mock := &MockMerchantStore{ctrl: ctrl}
+	mock.recorder = &MockMerchantStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantStore) EXPECT() *MockMerchantStoreMockRecorder {
+	return m.recorder
+}
+
+// GetMerchant mocks base method.
+func (m *MockMerchantStore) GetMerchant(ctx context.Context, pspID, merchantID uuid.UUID) (entity.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, pspID, merchantID)
+	ret0, _ := ret[0].(entity.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockMerchantStoreMockRecorder) GetMerchant(ctx, pspID, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockMerchantStore)(nil).GetMerchant), ctx, pspID, merchantID)
+}
+
+// GetMerchantDefaults mocks base method.
+func (m *MockMerchantStore) GetMerchantDefaults(ctx context.Context, pspID uuid.UUID) (entity.CardAcceptor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantDefaults", ctx, pspID)
+	ret0, _ := ret[0].(entity.CardAcceptor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantDefaults indicates an expected call of GetMerchantDefaults.
+func (mr *MockMerchantStoreMockRecorder) GetMerchantDefaults(ctx, pspID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotEffectiveConfig", reflect.TypeOf((*MockConfigSnapshotter)(nil).SnapshotEffectiveConfig), merchantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantDefaults", reflect.TypeOf((*MockMerchantStore)(nil).GetMerchantDefaults), ctx, pspID)
 }
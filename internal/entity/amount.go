@@ -0,0 +1,53 @@
+package entity
+
+import "gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+type AdditionalAmountType string
+
+const (
+	CashbackAmount  AdditionalAmountType = `cashback`
+	SurchargeAmount AdditionalAmountType = `surcharge`
+	GratuityAmount  AdditionalAmountType = `gratuity`
+)
+
+var (
+	additionalAmountTypeMap = map[string]AdditionalAmountType{
+		`cashback`:  CashbackAmount,
+		`surcharge`: SurchargeAmount,
+		`gratuity`:  GratuityAmount,
+	}
+)
+
+func IsValidAdditionalAmountType(t string) bool {
+	_, ok := additionalAmountTypeMap[t]
+	return ok
+}
+
+// AdditionalAmount is a part of the amount of an authorization, such as the cash the cardholder gets back of a
+// purchase. The amount of the authorization includes the additional amounts.
+type AdditionalAmount struct {
+	Type     AdditionalAmountType
+	Amount   int
+	Currency currencycode.Currency
+}
+
+// TotalAdditionalAmount is the sum of the additional amounts of an authorization
+func (a Authorization) TotalAdditionalAmount() int {
+	var total int
+	for _, aa := range a.AdditionalAmounts {
+		total += aa.Amount
+	}
+
+	return total
+}
+
+// HasCashback tells whether the cardholder gets cash back, which makes the authorization a purchase with cash back
+func (a Authorization) HasCashback() bool {
+	for _, aa := range a.AdditionalAmounts {
+		if aa.Type == CashbackAmount {
+			return true
+		}
+	}
+
+	return false
+}
@@ -44,6 +44,7 @@ type Authorization struct {
 	Recurring                Recurring
 	Card                     Card
 	CardAcceptor             CardAcceptor
+	Merchant                 MerchantSnapshot
 	Psp                      PSP
 	Exemption                ExemptionType
 	ThreeDSecure             ThreeDSecure
@@ -52,6 +53,28 @@ type Authorization struct {
 	MastercardSchemeData     MastercardSchemeData
 	VisaSchemeData           VisaSchemeData
 	Terminal                 Terminal
+	PartialApprovalSupported bool // The PSP accepts an approval of a part of the amount
+	AdditionalAmounts        []AdditionalAmount
+}
+
+// ApprovedAmount is the amount the issuer approved, which is less than the amount of a partial approval. A partial
+// approval without a valid approved amount approved nothing.
+func (a Authorization) ApprovedAmount() int {
+	approved := a.CardSchemeData.Response.ApprovedAmount
+	if a.InvalidPartialApproval() {
+		return 0
+	}
+	if ValidPartialApproval(approved, a.Amount) {
+		return approved
+	}
+
+	return a.Amount
+}
+
+// InvalidPartialApproval tells whether the issuer responded with a partial approval without a valid approved amount
+func (a Authorization) InvalidPartialApproval() bool {
+	return a.CardSchemeData.Response.ResponseCode.Value == PartialApprovalResponseCode &&
+		!ValidPartialApproval(a.CardSchemeData.Response.ApprovedAmount, a.Amount)
 }
 
 type AuthorizationType string
@@ -71,6 +94,15 @@ func IsValidAuthorizationType(at string) bool {
 	return ok
 }
 
+// PartialApprovalResponseCode is the response code of an issuer that approved a part of the amount
+const PartialApprovalResponseCode = "10"
+
+// ValidPartialApproval tells whether the approved amount of a partial approval is above zero and at most the
+// requested amount, a partial approval with another amount is a failed response
+func ValidPartialApproval(approved, requested int) bool {
+	return approved > 0 && approved <= requested
+}
+
 type AuthorizationStatus int
 
 const (
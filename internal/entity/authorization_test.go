@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestAuthorization_ApprovedAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		response CardSchemeResponse
+		want     int
+	}{
+		{
+			name:     "approved",
+			response: CardSchemeResponse{ResponseCode: ResponseCode{Value: "00"}},
+			want:     1000,
+		},
+		{
+			name:     "partially_approved",
+			response: CardSchemeResponse{ResponseCode: ResponseCode{Value: "10"}, ApprovedAmount: 600},
+			want:     600,
+		},
+		{
+			name:     "partially_approved_whole_amount",
+			response: CardSchemeResponse{ResponseCode: ResponseCode{Value: "10"}, ApprovedAmount: 1000},
+			want:     1000,
+		},
+		{
+			name:     "partially_approved_without_amount",
+			response: CardSchemeResponse{ResponseCode: ResponseCode{Value: "10"}},
+			want:     0,
+		},
+		{
+			name:     "partially_approved_more_than_requested",
+			response: CardSchemeResponse{ResponseCode: ResponseCode{Value: "10"}, ApprovedAmount: 1200},
+			want:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Authorization{Amount: 1000, CardSchemeData: CardSchemeData{Response: tt.response}}
+			if got := a.ApprovedAmount(); got != tt.want {
+				t.Errorf("ApprovedAmount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
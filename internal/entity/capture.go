@@ -82,7 +82,7 @@ func (c CaptureSummary) ValidateExtraCapture(amount int) error {
 		return ErrFinalCaptureExists
 	}
 
-	if c.Authorization.Amount < c.TotalCapturedAmount+amount {
+	if c.Authorization.ApprovedAmount() < c.TotalCapturedAmount+amount {
 		return ErrAuthorizedAmountExceeded
 	}
 
@@ -90,7 +90,7 @@ func (c CaptureSummary) ValidateExtraCapture(amount int) error {
 }
 
 func (c CaptureSummary) IsFinalizedWith(amount int) bool {
-	return c.Authorization.Amount == c.TotalCapturedAmount+amount
+	return c.Authorization.ApprovedAmount() == c.TotalCapturedAmount+amount
 }
 
 type CaptureRefundSummary struct {
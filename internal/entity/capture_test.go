@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCaptureSummary_ValidateExtraCapture(t *testing.T) {
+	tests := []struct {
+		name          string
+		summary       CaptureSummary
+		amount        int
+		wantErr       error
+		wantFinalized bool
+	}{
+		{
+			name:          "full_amount",
+			summary:       CaptureSummary{Authorization: Authorization{Amount: 1000}},
+			amount:        1000,
+			wantFinalized: true,
+		},
+		{
+			name:    "more_than_the_amount",
+			summary: CaptureSummary{Authorization: Authorization{Amount: 1000}, TotalCapturedAmount: 600},
+			amount:  600,
+			wantErr: ErrAuthorizedAmountExceeded,
+		},
+		{
+			name: "partially_approved_amount",
+			summary: CaptureSummary{Authorization: Authorization{
+				Amount:         1000,
+				CardSchemeData: CardSchemeData{Response: CardSchemeResponse{ApprovedAmount: 600}},
+			}},
+			amount:        600,
+			wantFinalized: true,
+		},
+		{
+			name: "more_than_the_partially_approved_amount",
+			summary: CaptureSummary{Authorization: Authorization{
+				Amount:         1000,
+				CardSchemeData: CardSchemeData{Response: CardSchemeResponse{ApprovedAmount: 600}},
+			}},
+			amount:  1000,
+			wantErr: ErrAuthorizedAmountExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.summary.ValidateExtraCapture(tt.amount); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateExtraCapture() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := tt.summary.IsFinalizedWith(tt.amount); got != tt.wantFinalized {
+				t.Errorf("IsFinalizedWith() = %v, want %v", got, tt.wantFinalized)
+			}
+		})
+	}
+}
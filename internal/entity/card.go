@@ -24,6 +24,8 @@ type Card struct {
 	Info           cardinfo.Range
 	SequenceNumber string
 	Track2Data     string
+	IccData        []byte // The EMV data objects of the chip, BER-TLV encoded
+	OnlinePin      OnlinePin
 }
 
 func (c Card) CardholderName() string {
@@ -54,6 +54,8 @@ type POSEntryMode struct {
 // PANEntryChip          		 				= PAN auto-entry via chip, code send to mastercard/visa: 05
 // PANEntryContactless   		 				= PAN auto-entry via contactless M/Chip, code send to mastercard/visa: 07
 // PANEntryMagneticStrip 		 				= PAN auto-entry via magnetic strip, code send to mastercard/visa: 90
+// PANEntryContactlessMagneticStrip			= PAN auto-entry via contactless magnetic stripe, code send to mastercard/visa: 91
+// PANEntryChipFallback					= PAN auto-entry via magnetic strip of a chip card whose chip could not be read, code send to mastercard: 80
 // PANEntryViaEcomWithOpId  				= PAN/Token entry via electronic commerce with optional Identity Check-AAV or DSRP cryptogram in UCAF, codes send to mastercard: 81
 // PANEntryCredentialOnFile 		 				= Credential on File, codes send to mastercard/visa: 10
 type PANEntry string
@@ -75,6 +77,11 @@ const (
 	// PAN/Token entry via electronic commerce with optional Identity Check-AAV or DSRP cryptogram in UCAF, value send to Mastercard: 81
 	PANEntryViaEcomWithOpId PANEntry = "entryViaEcomWithOpId"
 	PANEntryMagneticStrip   PANEntry = "magneticStrip"
+	// PANEntryContactlessMagneticStrip a contactless card read in magnetic stripe mode, it gives track 2 data
+	PANEntryContactlessMagneticStrip PANEntry = "contactlessMagneticStrip"
+	// PANEntryChipFallback is not requested, a magnetic strip read becomes a fallback when a chip card is swiped at a
+	// terminal that reads chips. Visa has no code for it, its F060.3 tells about the failed chip read.
+	PANEntryChipFallback PANEntry = "chipFallback"
 )
 
 var (
@@ -87,6 +94,8 @@ var (
 		`credentialOnFile`:                 PANEntryCredentialOnFile,
 		`entryViaEcomWithOpId`:             PANEntryViaEcomWithOpId,
 		`magneticStrip`:                    PANEntryMagneticStrip,
+		`contactlessMagneticStrip`:         PANEntryContactlessMagneticStrip,
+		`chipFallback`:                     PANEntryChipFallback,
 	}
 )
 
@@ -325,6 +334,8 @@ type CardSchemeResponse struct {
 	AuthorizationIDResponse string
 	EcommerceIndicator      int
 	TraceId                 string
+	IccData                 []byte // The EMV data objects of the issuer for the chip, like the ARPC and scripts
+	ApprovedAmount          int    // The amount of a partial approval, zero when the full amount is approved
 }
 
 func ResponseDescriptionFromCode(code string) string {
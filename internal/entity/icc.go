@@ -0,0 +1,37 @@
+package entity
+
+// IccTag is the tag of an EMV data object in the ICC data of a chip or contactless transaction
+type IccTag struct {
+	Tag  string
+	Name string
+}
+
+// MandatoryIccTags are the data objects the ICC data of a card present authorization must hold, Mastercard DE55
+// and Visa F055 require them for an ARQC the issuer can verify
+var MandatoryIccTags = []IccTag{
+	{Tag: "9F26", Name: "application cryptogram"},
+	{Tag: "9F27", Name: "cryptogram information data"},
+	{Tag: "9F10", Name: "issuer application data"},
+	{Tag: "9F37", Name: "unpredictable number"},
+	{Tag: "9F36", Name: "application transaction counter"},
+	{Tag: "95", Name: "terminal verification results"},
+	{Tag: "9A", Name: "transaction date"},
+	{Tag: "9C", Name: "transaction type"},
+	{Tag: "9F02", Name: "amount, authorized"},
+	{Tag: "5F2A", Name: "transaction currency code"},
+	{Tag: "82", Name: "application interchange profile"},
+	{Tag: "9F1A", Name: "terminal country code"},
+	{Tag: "9F34", Name: "cardholder verification method results"},
+}
+
+// IccCardholderDataTags are the data objects with cardholder data a terminal may put in the ICC data: the PAN,
+// the track equivalent data and the cardholder name. They are left out of the message journal.
+var IccCardholderDataTags = []string{
+	"5A",   // Application PAN
+	"57",   // Track 2 equivalent data
+	"56",   // Track 1 data
+	"9F6B", // Track 2 data of contactless magnetic stripe mode
+	"9F1F", // Track 1 discretionary data
+	"9F20", // Track 2 discretionary data
+	"5F20", // Cardholder name
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransactionType string
+
+const (
+	AuthorizationTransaction TransactionType = "authorization"
+	RefundTransaction        TransactionType = "refund"
+	PayoutTransaction        TransactionType = "payout"
+	ReversalTransaction      TransactionType = "reversal"
+)
+
+type MessageDirection string
+
+const (
+	Outbound MessageDirection = "outbound"
+	Inbound  MessageDirection = "inbound"
+)
+
+// JournalMessage is an ISO 8583 message exchanged with a scheme for a transaction. Elements holds the
+// decoded data elements with the cardholder data masked or dropped, never the raw message.
+type JournalMessage struct {
+	ID              uuid.UUID
+	TransactionID   uuid.UUID
+	TransactionType TransactionType
+	Scheme          string
+	Direction       MessageDirection
+	Mti             string
+	Stan            string
+	Elements        json.RawMessage
+	CreatedAt       time.Time
+}
+
+// JournalElements encodes the data elements of a message for the journal, elements that are not
+// present are left out
+func JournalElements(elements interface{}) (json.RawMessage, error) {
+	encoded, err := json.Marshal(elements)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err = json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+
+	pruned, _ := prune(decoded)
+	if pruned == nil {
+		pruned = map[string]interface{}{}
+	}
+
+	return json.Marshal(pruned)
+}
+
+// JournalError takes the place of the elements of a message that could not be encoded
+func JournalError(err error) json.RawMessage {
+	encoded, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return encoded
+}
+
+// prune removes the empty values, it returns false when nothing is left of the value
+func prune(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, element := range v {
+			pruned, ok := prune(element)
+			if !ok {
+				delete(v, key)
+				continue
+			}
+			v[key] = pruned
+		}
+		return v, len(v) > 0
+	case []interface{}:
+		return v, len(v) > 0
+	case string:
+		return v, v != ""
+	case float64:
+		return v, v != 0
+	case bool:
+		return v, v
+	default:
+		return v, v != nil
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestJournalElements(t *testing.T) {
+	type subElements struct {
+		SE43_UniversalCardholderAuthenticationField string
+		SE92_CardholderVerificationCode             string
+	}
+	type elements struct {
+		DE2_PrimaryAccountNumber string
+		DE4_Amount               int64
+		DE39_ResponseCode        string
+		DE48_AdditionalData      *subElements
+		DE61_PosData             subElements
+	}
+
+	tests := []struct {
+		name     string
+		elements elements
+		want     string
+	}{
+		{
+			name: "empty_elements_left_out",
+			elements: elements{
+				DE2_PrimaryAccountNumber: "52000000####0008",
+				DE4_Amount:               1000,
+				DE48_AdditionalData:      &subElements{SE92_CardholderVerificationCode: ""},
+			},
+			want: `{"DE2_PrimaryAccountNumber":"52000000####0008","DE4_Amount":1000}`,
+		},
+		{
+			name: "sub_elements",
+			elements: elements{
+				DE39_ResponseCode:   "00",
+				DE48_AdditionalData: &subElements{SE43_UniversalCardholderAuthenticationField: "kAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+			},
+			want: `{"DE39_ResponseCode":"00","DE48_AdditionalData":{"SE43_UniversalCardholderAuthenticationField":"kAAAAAAAAAAAAAAAAAAAAAAAAAA="}}`,
+		},
+		{
+			name: "no_elements",
+			want: `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JournalElements(tt.elements)
+			if err != nil {
+				t.Fatalf("JournalElements() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("JournalElements() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Merchant is a card acceptor registered by a PSP. Empty card acceptor values are
+// resolved from the PSP (tenant) defaults and the service wide defaults.
+type Merchant struct {
+	ID           uuid.UUID
+	PspID        uuid.UUID
+	CardAcceptor CardAcceptor
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// MerchantSnapshot is the effective merchant configuration at the moment a transaction was created.
+// Origins holds, per card acceptor field, the level the value was taken from (merchant, tenant or default).
+type MerchantSnapshot struct {
+	MerchantID   uuid.UUID         `json:"merchantId"`
+	CardAcceptor CardAcceptor      `json:"cardAcceptor"`
+	Origins      map[string]string `json:"origins"`
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+func (ms MerchantSnapshot) IsSet() bool {
+	return ms.MerchantID != uuid.Nil
+}
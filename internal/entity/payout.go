@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+	"github.com/google/uuid"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
+)
+
+// Payout pushes funds to the card of the recipient, a Mastercard MoneySend payment transaction or a Visa original
+// credit. The sender is the person or business the funds come from.
+type Payout struct {
+	ID                       uuid.UUID
+	LogID                    uuid.UUID
+	Amount                   int
+	Currency                 currencycode.Currency
+	CustomerReference        string
+	LocalTransactionDateTime data.LocalTransactionDateTime
+	Status                   Status
+	Stan                     int
+	ProcessingDate           time.Time
+	CreatedAt                time.Time
+	Card                     Card
+	CardAcceptor             CardAcceptor
+	Psp                      PSP
+	Sender                   PayoutParty
+	Recipient                PayoutParty
+	CardSchemeData           CardSchemeData
+	MastercardSchemeData     MastercardSchemeData
+	VisaSchemeData           VisaSchemeData
+}
+
+// PayoutParty is the sender or the recipient of a payout. The country code is an ISO 3166 alpha-3 code.
+type PayoutParty struct {
+	FirstName     string
+	LastName      string
+	Address       string
+	City          string
+	PostalCode    string
+	CountryCode   string
+	AccountNumber string
+}
+
+// Name is the first and last name of the party
+func (p PayoutParty) Name() string {
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
@@ -0,0 +1,21 @@
+package entity
+
+// OnlinePin is the PIN block a terminal encrypted under a DUKPT key, with the key serial number the key is derived
+// with. A 10 byte key serial number is of TDES DUKPT and an ISO format 0 PIN block, a 12 byte one of AES DUKPT and
+// an ISO format 4 PIN block.
+type OnlinePin struct {
+	PinBlock        []byte
+	KeySerialNumber []byte
+}
+
+// Empty tells whether the cardholder entered no online PIN
+func (p OnlinePin) Empty() bool {
+	return len(p.PinBlock) == 0
+}
+
+// ZonePinBlock is an ISO format 0 PIN block encrypted under the zone PIN key shared with a card scheme, and the
+// index of that key the scheme knows it by
+type ZonePinBlock struct {
+	PinBlock []byte
+	KeyIndex string
+}
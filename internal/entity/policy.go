@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Policy holds the processing limits and allowed uses of a PSP. Amounts are in minor units of
+// the transaction currency, a zero amount or an empty list means no restriction.
+type Policy struct {
+	PspID                uuid.UUID
+	MaxAmount            int
+	DailyVolumeCap       int
+	AllowedCurrencies    []string
+	AllowedCountries     []string
+	AllowedSources       []Source
+	AllowedCategoryCodes []string
+	RefundsAllowed       bool
+	PayoutsAllowed       bool
+	MaxPayoutAmount      int
+	DailyPayoutCap       int
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// PolicyViolation is returned when a transaction is not allowed by the policy of the PSP.
+// Field refers to the request field that violates the policy.
+type PolicyViolation struct {
+	Field  string
+	Reason string
+}
+
+func (pv PolicyViolation) Error() string {
+	return fmt.Sprintf("policy violation on %s: %s", pv.Field, pv.Reason)
+}
@@ -10,6 +10,9 @@ import (
 var (
 	ErrAuthorizationNotApproved = errors.New("failed to reverse authorization, authorization was not approved")
 	ErrDupValOnIndex            = errors.New("already exists")
+	// ErrInvalidPartialApproval is the reason of the reversal of a partial approval without a valid approved
+	// amount, the issuer may hold the requested amount while the authorization failed
+	ErrInvalidPartialApproval = errors.New("partial approval without a valid approved amount")
 )
 
 type ReversalStatus string
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+)
+
+// maxTrack2Length is the most characters of track 2 data without its start and end sentinels and LRC
+const maxTrack2Length = 37
+
+// ErrInvalidTrack2Data does not tell what is wrong with the data, so the data never ends up in an error or a log
+var ErrInvalidTrack2Data = errors.New("invalid track 2 data")
+
+var (
+	track2Pattern      = regexp.MustCompile(`^([0-9]{12,19})=([0-9]{2})([0-9]{2})([0-9]{3})([0-9]*)$`)
+	serviceCodePattern = regexp.MustCompile(`^[125679][024][0-7]$`)
+)
+
+// Track2 is the track 2 equivalent data of a card read by its magnetic stripe, or by a contactless terminal in
+// magnetic stripe mode: the PAN, a field separator, the expiry as YYMM, the service code and discretionary data
+type Track2 struct {
+	PAN               string
+	Expiry            Expiry
+	ServiceCode       string
+	DiscretionaryData string
+}
+
+// ParseTrack2 reads track 2 data without its start and end sentinels
+func ParseTrack2(data string) (Track2, error) {
+	m := track2Pattern.FindStringSubmatch(data)
+	if m == nil || len(data) > maxTrack2Length {
+		return Track2{}, ErrInvalidTrack2Data
+	}
+
+	return Track2{
+		PAN:               m[1],
+		Expiry:            Expiry{Year: m[2], Month: m[3]},
+		ServiceCode:       m[4],
+		DiscretionaryData: m[5],
+	}, nil
+}
+
+// IsValidServiceCode checks the three digits of a service code:
+// the interchange and technology (1, 2, 5, 6, 7 or 9), the authorization processing (0, 2 or 4) and the allowed
+// services and PIN requirements (0 to 7)
+func IsValidServiceCode(code string) bool {
+	return serviceCodePattern.MatchString(code)
+}
+
+// ChipCard tells whether the service code says the card has a chip, 2xx and 6xx, which must be used when the
+// terminal can read it
+func (t Track2) ChipCard() bool {
+	return t.ServiceCode != "" && (t.ServiceCode[0] == '2' || t.ServiceCode[0] == '6')
+}
+
+// ChipFallback tells whether the magnetic stripe of a chip card was read at a terminal that reads chips, which
+// happens only when the chip could not be read
+func (a Authorization) ChipFallback() bool {
+	if a.CardSchemeData.Request.POSEntryMode.PanEntryMode != PANEntryMagneticStrip ||
+		a.Terminal.TerminalCapability != TerminalEMVContactChipAndMagneticStripeAndKeyEntry {
+		return false
+	}
+
+	track, err := ParseTrack2(a.Card.Track2Data)
+	return err == nil && track.ChipCard()
+}
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestParseTrack2(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Track2
+		wantErr bool
+	}{
+		{
+			name: "magnetic stripe card",
+			data: "5204740000001002=25121010000012300000",
+			want: Track2{
+				PAN:               "5204740000001002",
+				Expiry:            Expiry{Year: "25", Month: "12"},
+				ServiceCode:       "101",
+				DiscretionaryData: "0000012300000",
+			},
+		},
+		{
+			name: "no discretionary data",
+			data: "4111111111111111=2512201",
+			want: Track2{
+				PAN:         "4111111111111111",
+				Expiry:      Expiry{Year: "25", Month: "12"},
+				ServiceCode: "201",
+			},
+		},
+		{
+			name:    "with sentinels",
+			data:    ";4111111111111111=2512201?",
+			wantErr: true,
+		},
+		{
+			name:    "no service code",
+			data:    "4111111111111111=25",
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			data:    "4111111111111111=2512201123456789012345",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTrack2(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTrack2() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTrack2() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChipFallback(t *testing.T) {
+	swiped := func(track2 string, capability TerminalCapability) Authorization {
+		var a Authorization
+		a.Card.Track2Data = track2
+		a.Terminal.TerminalCapability = capability
+		a.CardSchemeData.Request.POSEntryMode.PanEntryMode = PANEntryMagneticStrip
+		return a
+	}
+
+	tests := []struct {
+		name string
+		a    Authorization
+		want bool
+	}{
+		{
+			name: "chip card at chip terminal",
+			a:    swiped("4111111111111111=2512201", TerminalEMVContactChipAndMagneticStripeAndKeyEntry),
+			want: true,
+		},
+		{
+			name: "chip card at magnetic stripe terminal",
+			a:    swiped("4111111111111111=2512201", MagneticStripeRead),
+			want: false,
+		},
+		{
+			name: "magnetic stripe card at chip terminal",
+			a:    swiped("4111111111111111=2512101", TerminalEMVContactChipAndMagneticStripeAndKeyEntry),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.ChipFallback(); got != tt.want {
+				t.Errorf("ChipFallback() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
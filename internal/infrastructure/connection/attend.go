@@ -2,8 +2,6 @@ package connection
 
 import (
 	"context"
-	"encoding/hex"
-	"fmt"
 	"sync"
 	"time"
 
@@ -31,6 +29,13 @@ func (c *connection) selectAttendantBehavior() func(context.Context) bool {
 }
 
 func (c *connection) connectedIdleAttendantBehavior(ctx context.Context) bool {
+	// A requested failback goes before the next job, so a busy connection still moves back
+	select {
+	case <-c.failbackCmdSignal:
+		return c.onAttendantFailback(ctx)
+	default:
+	}
+
 	select {
 	case <-c.shutdownSignal:
 		return c.onAttendantShutdown(ctx)
@@ -55,6 +60,8 @@ func (c *connection) connectedBusyAttendantBehavior(ctx context.Context) bool {
 		return c.onAttendantReceived(ctx, packet)
 	case <-c.pendingJob.context.Done():
 		return c.onAttendantJobCanceled()
+	case job := <-c.abandonSignal:
+		return c.onAttendantAbandon(job)
 	case <-time.After(c.responseTimeout):
 		return c.onAttendantRequestTimeout()
 	}
@@ -69,6 +76,24 @@ func (c *connection) disconnectedAttendantBehavior(ctx context.Context) bool {
 	}
 }
 
+// onAttendantFailback disconnects the idle connection so it reconnects to its preferred endpoint. The
+// attendant stops taking jobs first, so no request is sent over the connection that is closed.
+func (c *connection) onAttendantFailback(ctx context.Context) bool {
+	if c.endpoints.pick(c.index) == c.stats.currentEndpoint() {
+		c.logger.Debug(ctx, "ATTEND: already connected to the preferred endpoint")
+		return true
+	}
+
+	c.logger.Debug(ctx, "ATTEND: failback")
+	c.attendantConnected = false
+	select {
+	case c.disconnectCmdSignal <- struct{}{}:
+		return true
+	case <-c.shutdownSignal:
+		return c.onAttendantShutdown(ctx)
+	}
+}
+
 func (c *connection) onAttendantShutdown(ctx context.Context) bool {
 	c.logger.Debug(ctx, "ATTEND: shutdown")
 	c.finishPendingJob(c.receivedFactory(nil, ErrRequestAbandoned))
@@ -111,6 +136,7 @@ func (c *connection) finishPendingJob(received Received) {
 	c.pendingJob.responseSignal <- received
 	close(c.pendingJob.responseSignal)
 	c.pendingJob = nil
+	c.stats.idle()
 }
 
 func (c *connection) onAttendantJob(ctx context.Context, job *job) bool {
@@ -123,6 +149,7 @@ func (c *connection) onAttendantJob(ctx context.Context, job *job) bool {
 		c.finishPendingJob(c.receivedFactory(nil, ErrRequestAbandoned))
 	}
 	c.pendingJob = job
+	c.stats.attending(job)
 
 	packet, err := c.pendingJob.request.Packet()
 	if err != nil {
@@ -133,8 +160,8 @@ func (c *connection) onAttendantJob(ctx context.Context, job *job) bool {
 		return true
 	}
 
-	if !c.trySend(sendCommand{context: loggingCtx, packet: packet, notifyError: true}) {
-		c.logger.Warning(loggingCtx, "ATTENDER: try send would block")
+	if !c.sendWithin(sendCommand{context: loggingCtx, packet: packet, notifyError: true}, c.writeTimeout) {
+		c.logger.Warning(loggingCtx, "ATTENDER: send timed out")
 	}
 
 	return true
@@ -147,7 +174,7 @@ func (c *connection) onAttendantReceived(ctx context.Context, packet []byte) boo
 	} else {
 		loggingCtx = ctx
 	}
-	loggingCtx = logging.ContextWithValue(loggingCtx, packetLoggingKey, hex.EncodeToString(packet))
+	loggingCtx = logging.ContextWithValue(loggingCtx, packetLengthLoggingKey, len(packet))
 	c.logger.Debug(loggingCtx, "ATTEND: received packet")
 
 	received := c.receivedFactory(packet, nil)
@@ -159,7 +186,6 @@ func (c *connection) onAttendantReceived(ctx context.Context, packet []byte) boo
 		return true
 	}
 
-	loggingCtx = logging.ContextWithValue(loggingCtx, "message", fmt.Sprintf("%#v", received))
 	c.logger.Debug(loggingCtx, "ATTEND: received message")
 
 	if c.pendingJob != nil && received.IsRequestResponse(c.pendingJob.request) {
@@ -195,6 +221,19 @@ func (c *connection) onAttendantJobCanceled() bool {
 func (c *connection) onAttendantRequestTimeout() bool {
 	loggingCtx := c.initializeLoggingCtx(c.pendingJob.context)
 	c.logger.Debug(loggingCtx, "ATTEND: request timeout")
+	c.stats.timeout()
 	c.finishPendingJob(c.receivedFactory(nil, ErrRequestTimeout))
 	return true
 }
+
+func (c *connection) onAttendantAbandon(job *job) bool {
+	// The job may have been finished after the pool decided to abandon it
+	if job != c.pendingJob {
+		return true
+	}
+
+	loggingCtx := c.initializeLoggingCtx(c.pendingJob.context)
+	c.logger.Warning(loggingCtx, "ATTEND: request abandoned at the shutdown deadline")
+	c.finishPendingJob(c.receivedFactory(nil, ErrShutdownDeadline))
+	return true
+}
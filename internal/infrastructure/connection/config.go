@@ -13,4 +13,12 @@ type PoolConfiguration struct {
 	WriteTimeout    time.Duration `yaml:"write_timeout"`
 	ResponseTimeout time.Duration `yaml:"response_timeout"`
 	TickDelay       time.Duration `yaml:"tick_delay"`
+	MaxWaiting      int           `yaml:"max_waiting"`
+
+	// Endpoints are the interfaces of the scheme in order of preference, Address is used when there are none
+	Endpoints                []EndpointConfiguration `yaml:"endpoints"`
+	EndpointFailureThreshold int                     `yaml:"endpoint_failure_threshold"`
+	EndpointRecheckDelay     time.Duration           `yaml:"endpoint_recheck_delay"`
+
+	TLS TLSConfiguration `yaml:"tls"`
 }
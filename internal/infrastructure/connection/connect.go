@@ -2,7 +2,6 @@ package connection
 
 import (
 	"context"
-	"net"
 	"sync"
 	"time"
 
@@ -16,12 +15,20 @@ func (c *connection) connect(wg *sync.WaitGroup) {
 
 	reconnectTicker := time.NewTicker(c.redialDelay)
 
+	// With more than one endpoint the connections check whether they should move back to their
+	// preferred endpoint, a nil channel never fires
+	if c.endpoints.multiple() {
+		failbackTicker := time.NewTicker(c.endpoints.recheckDelay)
+		defer failbackTicker.Stop()
+		c.failbackSignal = failbackTicker.C
+	}
+
 	for c.selectConnectorBehavior()(ctx, reconnectTicker) {
 	}
 }
 
 func (c *connection) selectConnectorBehavior() func(context.Context, *time.Ticker) bool {
-	if c.isConnected {
+	if c.isConnected.Load() {
 		return c.connectedConnectorBehavior
 	}
 	return c.disconnectedConnectorBehavior
@@ -33,6 +40,8 @@ func (c *connection) connectedConnectorBehavior(ctx context.Context, reconnectTi
 		return c.onConnectorShutdown(ctx)
 	case <-c.disconnectCmdSignal:
 		return c.onConnectorDisconnect(ctx, reconnectTicker)
+	case <-c.failbackSignal:
+		return c.onConnectorFailbackCheck(ctx)
 	}
 }
 
@@ -49,8 +58,8 @@ func (c *connection) disconnectedConnectorBehavior(ctx context.Context, reconnec
 
 func (c *connection) onConnectorShutdown(ctx context.Context) bool {
 	c.logger.Debug(ctx, "CONNECT: shutdown")
-	if c.isConnected {
-		c.isConnected = false
+	if c.isConnected.Load() {
+		c.isConnected.Store(false)
 		c.logger.Debug(ctx, "CONNECT: closing connection")
 		err := c.conn.Close()
 		if err != nil {
@@ -59,14 +68,14 @@ func (c *connection) onConnectorShutdown(ctx context.Context) bool {
 				"CONNECT: failed to close connection")
 		}
 	}
-	c.isConnected = false
+	c.isConnected.Store(false)
 	return false
 }
 
 func (c *connection) onConnectorDisconnect(ctx context.Context, reconnectTicker *time.Ticker) bool {
 	c.logger.Debug(ctx, "CONNECT: disconnect command")
-	if c.isConnected {
-		c.isConnected = false
+	if c.isConnected.Load() {
+		c.isConnected.Store(false)
 		c.logger.Debug(ctx, "CONNECT: closing connection")
 		err := c.conn.Close()
 		if err != nil {
@@ -95,18 +104,23 @@ func (c *connection) onConnectorDisconnect(ctx context.Context, reconnectTicker
 
 func (c *connection) onConnectorReconnectAttempt(ctx context.Context, reconnectTicker *time.Ticker) bool {
 	time.Sleep(c.redialDelay)
+	address := c.endpoints.pick(c.index)
+	ctx = logging.ContextWithValue(ctx, connectionAddressLoggingKey, address)
 	c.logger.Debug(ctx, "CONNECT: trying to connect")
 	var err error
-	c.conn, err = net.DialTimeout("tcp", c.address, c.dialTimeout)
+	c.conn, err = c.dial(address)
 	if err != nil {
 		c.logger.Error(
 			logging.ContextWithError(ctx, err),
 			"CONNECT: failed to connect")
+		c.endpoints.failed(address)
 		return true
 	}
 	c.logger.Debug(ctx, "CONNECT: connected")
 	reconnectTicker.Stop()
-	c.isConnected = true
+	c.endpoints.succeeded(address)
+	c.stats.connected(address)
+	c.isConnected.Store(true)
 	if !trySignal(c.receiverConnectedSignal) {
 		c.logger.Debug(ctx, "CONNECT: receiver disconnect would block")
 	}
@@ -118,3 +132,33 @@ func (c *connection) onConnectorReconnectAttempt(ctx context.Context, reconnectT
 	}
 	return true
 }
+
+// onConnectorFailbackCheck moves the connection back to its preferred endpoint once that endpoint
+// accepts connections again. The endpoint is dialed first, so a connection is not dropped for an
+// endpoint that is still down. The attendant makes the switch when it is idle, only it knows that no
+// request is in flight.
+func (c *connection) onConnectorFailbackCheck(ctx context.Context) bool {
+	current := c.stats.currentEndpoint()
+	preferred := c.endpoints.pick(c.index)
+	if preferred == current {
+		return true
+	}
+
+	ctx = logging.ContextWithValue(ctx, "preferred-address", preferred)
+	probe, err := c.dial(preferred)
+	if err != nil {
+		c.logger.Debug(logging.ContextWithError(ctx, err), "CONNECT: preferred endpoint still down")
+		c.endpoints.failed(preferred)
+		return true
+	}
+	if err := probe.Close(); err != nil {
+		c.logger.Warning(logging.ContextWithError(ctx, err), "CONNECT: failed to close probe connection")
+	}
+
+	c.logger.Info(ctx, "CONNECT: preferred endpoint recovered, moving back when idle")
+	c.endpoints.succeeded(preferred)
+	if !trySignal(c.failbackCmdSignal) {
+		c.logger.Debug(ctx, "CONNECT: failback already requested")
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package connection
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+type fakeRequest []byte
+
+func (r fakeRequest) Packet() ([]byte, error) { return r, nil }
+
+// echoScheme answers every message with the same message after delay, it leaves zero probes unanswered
+func echoScheme(t *testing.T, listener net.Listener, delay time.Duration) {
+	t.Helper()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				for {
+					length := make([]byte, 2)
+					if _, err := io.ReadFull(conn, length); err != nil {
+						return
+					}
+					if binary.BigEndian.Uint16(length) == 0 {
+						continue
+					}
+					payload := make([]byte, binary.BigEndian.Uint16(length))
+					if _, err := io.ReadFull(conn, payload); err != nil {
+						return
+					}
+					time.Sleep(delay)
+					if _, err := conn.Write(append(length, payload...)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+}
+
+func TestPool_failbackWhileSending(t *testing.T) {
+	standby, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer standby.Close()
+	echoScheme(t, standby, time.Millisecond)
+
+	// The preferred endpoint is down until the connection is connected to the standby endpoint
+	reserved, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	preferredAddress := reserved.Addr().String()
+	_ = reserved.Close()
+
+	p := NewPool(PoolConfiguration{
+		Name:                     "mastercard",
+		MaxConnections:           1,
+		DialTimeout:              time.Second,
+		RedialDelay:              5 * time.Millisecond,
+		KeepAliveDelay:           time.Second,
+		ReadTimeout:              time.Second,
+		WriteTimeout:             time.Second,
+		ResponseTimeout:          time.Second,
+		TickDelay:                time.Second,
+		Endpoints:                []EndpointConfiguration{{Address: preferredAddress, Weight: 1}, {Address: standby.Addr().String()}},
+		EndpointFailureThreshold: 1,
+		EndpointRecheckDelay:     20 * time.Millisecond,
+	}, fakeReceivedFactory, logging.Logger{}, 0)
+	p.Start()
+	defer p.Stop()
+
+	c := p.connections[0]
+	waitFor(t, "connected to the standby endpoint", func() bool {
+		return c.isConnected.Load() && c.stats.currentEndpoint() == standby.Addr().String()
+	})
+
+	preferred, err := net.Listen("tcp", preferredAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer preferred.Close()
+	echoScheme(t, preferred, time.Millisecond)
+
+	// Requests are sent all the time the connection moves back to the preferred endpoint, none of them
+	// may be sent over the connection that is closed. While it reconnects the pool refuses requests.
+	var done atomic.Bool
+	var sent, failed atomic.Int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !done.Load() {
+				err := p.Send(context.Background(), fakeRequest{0x00, 0x03, 'a', 'b', 'c'}).Error()
+				switch {
+				case err == nil:
+					sent.Add(1)
+				case errors.Is(err, ErrNotConnected), errors.Is(err, ErrNoFreeConnections):
+				default:
+					t.Logf("request failed: %s", err)
+					failed.Add(1)
+				}
+			}
+		}()
+	}
+
+	waitFor(t, "connected to the preferred endpoint", func() bool {
+		return c.isConnected.Load() && c.stats.currentEndpoint() == preferredAddress
+	})
+	done.Store(true)
+	wg.Wait()
+
+	if failed.Load() != 0 {
+		t.Errorf("%d requests failed by the failback", failed.Load())
+	}
+	if sent.Load() == 0 {
+		t.Error("no request answered")
+	}
+}
+
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("not %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -2,8 +2,11 @@ package connection
 
 import (
 	"context"
+	"io"
 	"net"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gitlab.cmpayments.local/libraries-go/logging"
@@ -15,7 +18,8 @@ const (
 	connectionIdLoggingKey      = "connection-id"
 	poolNameLoggingKey          = "pool-name"
 	connectionAddressLoggingKey = "address"
-	packetLoggingKey            = "packet"
+	// The packets carry cardholder data, only their length is logged. The messages are in the journal.
+	packetLengthLoggingKey = "packet-length"
 )
 
 type sendCommand struct {
@@ -25,7 +29,9 @@ type sendCommand struct {
 }
 
 type connection struct {
-	address                     string
+	index                       int
+	endpoints                   *endpoints
+	tls                         *tlsCredentials
 	dialTimeout                 time.Duration
 	redialDelay                 time.Duration
 	keepAliveDelay              time.Duration
@@ -44,10 +50,14 @@ type connection struct {
 	senderConnectedSignal       chan struct{}
 	sendCmdSignal               chan sendCommand
 	disconnectCmdSignal         chan struct{}
+	failbackCmdSignal           chan struct{}
 	errorSignal                 chan error
 	jobSignal                   chan *job
+	abandonSignal               chan *job
+	failbackSignal              <-chan time.Time
 	receivedSignal              chan []byte
-	isConnected                 bool
+	isConnected                 atomic.Bool
+	stats                       *connectionStats
 	receivedFactory             ReceivedFactoryFunc
 	logger                      platform.Logger
 
@@ -62,14 +72,16 @@ type connection struct {
 }
 
 func newConnection(
-	id, name, address string,
+	index int, name string, endpoints *endpoints, tls *tlsCredentials,
 	dialTimeout, redialDelay, keepAliveDelay, readTimeout, writeTimeout, responseTimeout time.Duration,
 	shutdown chan struct{}, jobSignal chan *job, receivedFactory ReceivedFactoryFunc, logger platform.Logger,
 	msgLengthSurplus int) *connection {
 	return &connection{
-		id:                          id,
+		index:                       index,
+		id:                          strconv.Itoa(index),
 		name:                        name,
-		address:                     address,
+		endpoints:                   endpoints,
+		tls:                         tls,
 		dialTimeout:                 dialTimeout,
 		redialDelay:                 redialDelay,
 		keepAliveDelay:              keepAliveDelay,
@@ -84,20 +96,31 @@ func newConnection(
 		senderDisconnectedSignal:    make(chan struct{}),
 		senderConnectedSignal:       make(chan struct{}),
 		disconnectCmdSignal:         make(chan struct{}),
+		failbackCmdSignal:           make(chan struct{}, 1),
 		shutdownSignal:              shutdown,
 		sendCmdSignal:               make(chan sendCommand),
 		jobSignal:                   jobSignal,
+		abandonSignal:               make(chan *job),
 		receivedSignal:              make(chan []byte),
 		receivedFactory:             receivedFactory,
 		logger:                      logger,
 		msgLengthSurplus:            msgLengthSurplus,
+		stats:                       &connectionStats{},
 	}
 }
 
 func (c *connection) initializeLoggingCtx(ctx context.Context) context.Context {
 	loggingCtx := logging.ContextWithValue(ctx, connectionIdLoggingKey, c.id)
 	loggingCtx = logging.ContextWithValue(loggingCtx, poolNameLoggingKey, c.name)
-	return logging.ContextWithValue(loggingCtx, connectionAddressLoggingKey, c.address)
+	return logging.ContextWithValue(loggingCtx, connectionAddressLoggingKey, c.stats.currentEndpoint())
+}
+
+// endpointFailed counts a failure of a connected socket against its endpoint, a socket we closed
+// ourselves is not a failure
+func (c *connection) endpointFailed() {
+	if c.isConnected.Load() {
+		c.endpoints.failed(c.stats.currentEndpoint())
+	}
 }
 
 func (c *connection) tryDisconnect() bool {
@@ -131,6 +154,22 @@ func (c *connection) trySend(cmd sendCommand) bool {
 	}
 }
 
+// sendWithin hands the command to the sender, waiting up to timeout for it. Right after a reconnect the
+// attendant can take a job before the sender noticed the connection, a request must not be dropped then.
+func (c *connection) sendWithin(cmd sendCommand, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case c.sendCmdSignal <- cmd:
+		return true
+	case <-c.shutdownSignal:
+		return false
+	case <-timer.C:
+		return false
+	}
+}
+
 func (c *connection) start(stopWG *sync.WaitGroup) {
 	go c.run(stopWG)
 }
@@ -157,13 +196,15 @@ func trySignal(signal chan struct{}) bool {
 	}
 }
 
-func readWithTimeout(conn net.Conn, readTimeout time.Duration, buffer []byte) error {
+// readWithTimeout fills buffer and returns the number of bytes read, which is less than its length when
+// it fails
+func readWithTimeout(conn net.Conn, readTimeout time.Duration, buffer []byte) (int, error) {
 	err := conn.SetReadDeadline(time.Now().Add(readTimeout))
 	if err != nil {
-		return err
+		return 0, err
 	}
-	_, err = conn.Read(buffer)
-	return err
+	// A message can arrive in more than one read, TLS returns at most a record per read
+	return io.ReadFull(conn, buffer)
 }
 
 func writeWithTimeout(conn net.Conn, writeTimeout time.Duration, buffer []byte) error {
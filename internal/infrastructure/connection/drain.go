@@ -0,0 +1,88 @@
+package connection
+
+import (
+	"context"
+	"time"
+)
+
+// idlePollInterval is how often a draining pool checks whether its connections finished their requests
+const idlePollInterval = 50 * time.Millisecond
+
+type drainBypassKey struct{}
+
+// WithDrainBypass marks a request that is sent even when the pool drains, like the reversal of a
+// request that was abandoned at the shutdown deadline
+func WithDrainBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, drainBypassKey{}, true)
+}
+
+func drainBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(drainBypassKey{}).(bool)
+	return bypass
+}
+
+// Drain makes the pool refuse new requests, requests that are being attended still get their response.
+// The connections stay connected so network management requests of the scheme are still answered.
+func (p *Pool) Drain() {
+	p.draining.Store(true)
+}
+
+// Resume makes a drained pool accept requests again
+func (p *Pool) Resume() {
+	p.draining.Store(false)
+}
+
+// WaitIdle waits until no connection attends a request or ctx is done. The requests that are still
+// attended then are finished with ErrShutdownDeadline, so the caller can reverse them. It returns the
+// number of requests that were abandoned.
+func (p *Pool) WaitIdle(ctx context.Context) int {
+	ticker := time.NewTicker(idlePollInterval)
+	defer ticker.Stop()
+
+	for p.attending() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return p.abandon()
+		}
+	}
+
+	return 0
+}
+
+func (p *Pool) attending() int {
+	var attending int
+	for _, c := range p.connections {
+		if c.stats.pending() != nil {
+			attending++
+		}
+	}
+	return attending
+}
+
+func (p *Pool) abandon() int {
+	var abandoned int
+	for _, c := range p.connections {
+		if c.abandon() {
+			abandoned++
+		}
+	}
+	return abandoned
+}
+
+// abandon hands the attendant the job it is attending, the attendant only listens for it while it is
+// busy so this retries until the job is taken or finished
+func (c *connection) abandon() bool {
+	for {
+		job := c.stats.pending()
+		if job == nil {
+			return false
+		}
+
+		select {
+		case c.abandonSignal <- job:
+			return true
+		case <-time.After(idlePollInterval):
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+type fakeReceived struct {
+	err error
+}
+
+func (r fakeReceived) IsRequestResponse(Request) bool { return true }
+
+func (r fakeReceived) PacketToSend() ([]byte, error) { return nil, nil }
+
+func (r fakeReceived) Error() error { return r.err }
+
+func fakeReceivedFactory(_ []byte, err error) Received {
+	return fakeReceived{err: err}
+}
+
+// busyConnection returns a connection of p that attends a job until the returned channel receives the
+// response of that job
+func busyConnection(p *Pool) <-chan Received {
+	c := newConnection(len(p.connections), p.name, p.endpoints, nil, 0, 0, 0, 0, 0, time.Minute,
+		p.shutdownSignal, p.jobSignal, fakeReceivedFactory, logging.Logger{}, 0)
+	p.connections = append(p.connections, c)
+
+	j := &job{responseSignal: make(chan Received, 1), context: context.Background()}
+	c.attendantConnected = true
+	c.pendingJob = j
+	c.stats.attending(j)
+
+	go c.connectedBusyAttendantBehavior(context.Background())
+
+	return j.responseSignal
+}
+
+func TestPool_WaitIdle(t *testing.T) {
+	t.Run("idle", func(t *testing.T) {
+		p := NewPool(PoolConfiguration{Name: "visa"}, fakeReceivedFactory, logging.Logger{}, 0)
+
+		if got := p.WaitIdle(context.Background()); got != 0 {
+			t.Errorf("WaitIdle() = %d, want 0", got)
+		}
+	})
+
+	t.Run("finished_before_deadline", func(t *testing.T) {
+		p := NewPool(PoolConfiguration{Name: "visa"}, fakeReceivedFactory, logging.Logger{}, 0)
+		responses := busyConnection(p)
+		c := p.connections[0]
+
+		go func() {
+			time.Sleep(2 * idlePollInterval)
+			c.stats.idle()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if got := p.WaitIdle(ctx); got != 0 {
+			t.Errorf("WaitIdle() = %d, want 0", got)
+		}
+		select {
+		case r := <-responses:
+			t.Errorf("response %v of a finished request, want none", r.Error())
+		default:
+		}
+	})
+
+	t.Run("abandoned_at_deadline", func(t *testing.T) {
+		p := NewPool(PoolConfiguration{Name: "visa"}, fakeReceivedFactory, logging.Logger{}, 0)
+		first := busyConnection(p)
+		second := busyConnection(p)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*idlePollInterval)
+		defer cancel()
+
+		if got := p.WaitIdle(ctx); got != 2 {
+			t.Errorf("WaitIdle() = %d, want 2", got)
+		}
+		for _, responses := range []<-chan Received{first, second} {
+			select {
+			case r := <-responses:
+				if !errors.Is(r.Error(), ErrShutdownDeadline) {
+					t.Errorf("response error = %v, want %v", r.Error(), ErrShutdownDeadline)
+				}
+			case <-time.After(time.Second):
+				t.Error("abandoned request got no response")
+			}
+		}
+		// The attendant marks itself idle right after it answered
+		waitFor(t, "idle", func() bool { return p.attending() == 0 })
+	})
+}
+
+func TestConnection_abandon(t *testing.T) {
+	p := NewPool(PoolConfiguration{Name: "mastercard"}, fakeReceivedFactory, logging.Logger{}, 0)
+	c := newConnection(0, p.name, p.endpoints, nil, 0, 0, 0, 0, 0, time.Minute,
+		p.shutdownSignal, p.jobSignal, fakeReceivedFactory, logging.Logger{}, 0)
+
+	if c.abandon() {
+		t.Error("abandon() of an idle connection = true, want false")
+	}
+}
@@ -0,0 +1,151 @@
+package connection
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultEndpointFailureThreshold = 3
+	defaultEndpointRecheckDelay     = time.Minute
+)
+
+type EndpointConfiguration struct {
+	Address string `yaml:"address"`
+	// Weight is the share of the connections the endpoint gets. Endpoints with weight 0 are standby, they
+	// only get connections when all weighted endpoints are down.
+	Weight int `yaml:"weight"`
+}
+
+// EndpointStatus is a snapshot of an endpoint of a pool
+type EndpointStatus struct {
+	Address  string
+	Weight   int
+	Down     bool
+	Failures int
+}
+
+type endpoint struct {
+	address   string
+	weight    int
+	failures  int
+	downUntil time.Time
+}
+
+func (e *endpoint) isDown(now time.Time) bool {
+	return now.Before(e.downUntil)
+}
+
+// endpoints spreads the connections of a pool over the endpoints of the scheme. An endpoint is marked
+// down after failureThreshold consecutive dial or keep-alive failures, the connections fail over to the
+// other endpoints until recheckDelay has passed and the endpoint is tried again.
+type endpoints struct {
+	mu               sync.Mutex
+	endpoints        []*endpoint
+	failureThreshold int
+	recheckDelay     time.Duration
+}
+
+func newEndpoints(cfg PoolConfiguration) *endpoints {
+	e := &endpoints{
+		failureThreshold: cfg.EndpointFailureThreshold,
+		recheckDelay:     cfg.EndpointRecheckDelay,
+	}
+	if e.failureThreshold == 0 {
+		e.failureThreshold = defaultEndpointFailureThreshold
+	}
+	if e.recheckDelay == 0 {
+		e.recheckDelay = defaultEndpointRecheckDelay
+	}
+
+	// A pool with a single address is a pool with a single endpoint
+	configured := cfg.Endpoints
+	if len(configured) == 0 {
+		configured = []EndpointConfiguration{{Address: cfg.Address, Weight: 1}}
+	}
+	for _, c := range configured {
+		e.endpoints = append(e.endpoints, &endpoint{address: c.Address, weight: c.Weight})
+	}
+
+	return e
+}
+
+// pick returns the address the connection with the index should connect to. The connections are spread
+// by weight over the weighted endpoints that are up, when none is up the first standby endpoint that is
+// up is used, and when all endpoints are down the first endpoint is tried.
+func (e *endpoints) pick(index int) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+
+	var slots []*endpoint
+	for _, ep := range e.endpoints {
+		if ep.isDown(now) {
+			continue
+		}
+		for i := 0; i < ep.weight; i++ {
+			slots = append(slots, ep)
+		}
+	}
+	if len(slots) > 0 {
+		return slots[index%len(slots)].address
+	}
+
+	for _, ep := range e.endpoints {
+		if !ep.isDown(now) {
+			return ep.address
+		}
+	}
+
+	return e.endpoints[0].address
+}
+
+func (e *endpoints) failed(address string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, ep := range e.endpoints {
+		if ep.address != address {
+			continue
+		}
+		ep.failures++
+		if ep.failures >= e.failureThreshold {
+			ep.downUntil = time.Now().Add(e.recheckDelay)
+		}
+	}
+}
+
+func (e *endpoints) succeeded(address string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, ep := range e.endpoints {
+		if ep.address == address {
+			ep.failures = 0
+			ep.downUntil = time.Time{}
+		}
+	}
+}
+
+func (e *endpoints) multiple() bool {
+	return len(e.endpoints) > 1
+}
+
+func (e *endpoints) status() []EndpointStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]EndpointStatus, 0, len(e.endpoints))
+	for _, ep := range e.endpoints {
+		statuses = append(statuses, EndpointStatus{
+			Address:  ep.address,
+			Weight:   ep.weight,
+			Down:     ep.isDown(now),
+			Failures: ep.failures,
+		})
+	}
+
+	return statuses
+}
@@ -0,0 +1,89 @@
+package connection
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEndpoints_pick(t *testing.T) {
+	cfg := PoolConfiguration{
+		Endpoints: []EndpointConfiguration{
+			{Address: "primary:7043", Weight: 2},
+			{Address: "secondary:7043", Weight: 1},
+			{Address: "standby:7043"},
+		},
+		EndpointFailureThreshold: 2,
+		EndpointRecheckDelay:     time.Minute,
+	}
+
+	tests := []struct {
+		name string
+		down []string
+		want []string
+	}{
+		{
+			name: "spread_by_weight",
+			want: []string{"primary:7043", "primary:7043", "secondary:7043"},
+		},
+		{
+			name: "primary_down",
+			down: []string{"primary:7043"},
+			want: []string{"secondary:7043", "secondary:7043", "secondary:7043"},
+		},
+		{
+			name: "weighted_down",
+			down: []string{"primary:7043", "secondary:7043"},
+			want: []string{"standby:7043", "standby:7043", "standby:7043"},
+		},
+		{
+			name: "all_down",
+			down: []string{"primary:7043", "secondary:7043", "standby:7043"},
+			want: []string{"primary:7043", "primary:7043", "primary:7043"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEndpoints(cfg)
+			for _, address := range tt.down {
+				for i := 0; i < cfg.EndpointFailureThreshold; i++ {
+					e.failed(address)
+				}
+			}
+
+			for index, want := range tt.want {
+				if got := e.pick(index); got != want {
+					t.Errorf("pick(%d) = %s, want %s", index, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEndpoints_recovers(t *testing.T) {
+	e := newEndpoints(PoolConfiguration{
+		Endpoints:                []EndpointConfiguration{{Address: "primary:7043", Weight: 1}, {Address: "secondary:7043"}},
+		EndpointFailureThreshold: 1,
+		EndpointRecheckDelay:     10 * time.Millisecond,
+	})
+
+	e.failed("primary:7043")
+	if got := e.pick(0); got != "secondary:7043" {
+		t.Fatalf("pick() = %s after the primary failed, want secondary:7043", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if got := e.pick(0); got != "primary:7043" {
+		t.Errorf("pick() = %s after the recheck delay, want primary:7043", got)
+	}
+}
+
+func TestEndpoints_singleAddress(t *testing.T) {
+	e := newEndpoints(PoolConfiguration{Address: "mip:7043"})
+
+	if e.multiple() {
+		t.Errorf("multiple() = true for a single address")
+	}
+	if got := e.pick(3); got != "mip:7043" {
+		t.Errorf("pick() = %s, want mip:7043", got)
+	}
+}
@@ -7,4 +7,12 @@ var (
 	ErrRequestTimeout    = errors.New("request timeout")
 	ErrRequestAbandoned  = errors.New("request abandoned")
 	ErrNoFreeConnections = errors.New("no free connections in the pool")
+	ErrNotConnected      = errors.New("no connected connections in the pool")
+	ErrPoolDraining      = errors.New("pool is draining")
+	ErrPoolSaturated     = errors.New("too many requests waiting for a free connection")
+	ErrShutdownDeadline  = errors.New("request unanswered at the shutdown deadline")
+	ErrUnknownConnection = errors.New("unknown connection")
+	ErrReconnectRefused  = errors.New("connection is busy connecting, try again")
+	ErrNoCertificates    = errors.New("no certificates found")
+	ErrTLSDisabled       = errors.New("TLS is not enabled for the pool")
 )
@@ -2,8 +2,8 @@ package connection
 
 import (
 	"context"
-	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gitlab.cmpayments.local/creditcard/platform"
@@ -28,8 +28,14 @@ type job struct {
 type ReceivedFactoryFunc func([]byte, error) Received
 
 type Pool struct {
+	name            string
+	endpoints       *endpoints
+	tls             *tlsCredentials
+	draining        atomic.Bool
 	connections     []*connection
 	tickDelay       time.Duration
+	maxWaiting      int32
+	waiting         atomic.Int32
 	stopWG          sync.WaitGroup
 	shutdownSignal  chan struct{}
 	jobSignal       chan *job
@@ -44,7 +50,11 @@ func NewPool(cfg PoolConfiguration, receivedFactory ReceivedFactoryFunc, logger
 	}
 
 	pool := Pool{
+		name:            cfg.Name,
+		endpoints:       newEndpoints(cfg),
+		tls:             newTLSCredentials(cfg.TLS),
 		tickDelay:       tickDelay,
+		maxWaiting:      int32(cfg.MaxWaiting),
 		jobSignal:       make(chan *job),
 		shutdownSignal:  make(chan struct{}),
 		receivedFactory: receivedFactory,
@@ -53,7 +63,7 @@ func NewPool(cfg PoolConfiguration, receivedFactory ReceivedFactoryFunc, logger
 
 	var connections []*connection
 	for i := 0; i < cfg.MaxConnections; i++ {
-		connection := newConnection(fmt.Sprintf("%d", i), cfg.Name, cfg.Address, cfg.DialTimeout, cfg.RedialDelay, cfg.KeepAliveDelay, cfg.ReadTimeout, cfg.WriteTimeout, cfg.ResponseTimeout, pool.shutdownSignal, pool.jobSignal, receivedFactory, pool.logger, msgLengthSurplus)
+		connection := newConnection(i, cfg.Name, pool.endpoints, pool.tls, cfg.DialTimeout, cfg.RedialDelay, cfg.KeepAliveDelay, cfg.ReadTimeout, cfg.WriteTimeout, cfg.ResponseTimeout, pool.shutdownSignal, pool.jobSignal, receivedFactory, pool.logger, msgLengthSurplus)
 
 		connections = append(connections, connection)
 	}
@@ -72,24 +82,70 @@ func (p *Pool) Start() {
 }
 
 func (p *Pool) Send(ctx context.Context, request Request) Received {
+	if p.draining.Load() && !drainBypassed(ctx) {
+		return p.receivedFactory(nil, ErrPoolDraining)
+	}
+	// Without a connected connection no connection takes the job, so the scheme is reported unreachable
+	// instead of waiting for the tick delay
+	if p.Connected() == 0 {
+		return p.receivedFactory(nil, ErrNotConnected)
+	}
+
 	job := job{
 		request:        request,
 		responseSignal: make(chan Received),
 		context:        ctx,
 	}
+	if !p.enqueue() {
+		return p.receivedFactory(nil, ErrPoolSaturated)
+	}
+
 	ticker := time.NewTicker(p.tickDelay)
 	defer ticker.Stop()
 
 	select {
 	case p.jobSignal <- &job:
+		p.waiting.Add(-1)
 		response := <-job.responseSignal
 		return response
 	case <-ticker.C:
+		p.waiting.Add(-1)
 		close(job.responseSignal)
 		return p.receivedFactory(nil, ErrNoFreeConnections)
 	}
 }
 
+// enqueue counts a request that waits for a free connection, it refuses the request when maxWaiting
+// requests are waiting already so saturation is reported instead of piling up
+func (p *Pool) enqueue() bool {
+	if waiting := p.waiting.Add(1); p.maxWaiting > 0 && waiting > p.maxWaiting {
+		p.waiting.Add(-1)
+		return false
+	}
+	return true
+}
+
+// Waiting returns the number of requests that wait for a free connection
+func (p *Pool) Waiting() int {
+	return int(p.waiting.Load())
+}
+
+// Size returns the number of connections the pool maintains
+func (p *Pool) Size() int {
+	return len(p.connections)
+}
+
+// Connected returns the number of connections that are connected to the scheme
+func (p *Pool) Connected() int {
+	var connected int
+	for _, c := range p.connections {
+		if c.isConnected.Load() {
+			connected++
+		}
+	}
+	return connected
+}
+
 func (p *Pool) Stop() {
 	close(p.shutdownSignal)
 	p.stopWG.Wait()
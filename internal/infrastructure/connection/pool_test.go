@@ -0,0 +1,27 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+func TestPool_Send_notConnected(t *testing.T) {
+	p := NewPool(PoolConfiguration{Name: "mastercard", MaxConnections: 2, TickDelay: time.Minute}, fakeReceivedFactory, logging.Logger{}, 0)
+
+	start := time.Now()
+	got := p.Send(context.Background(), nil)
+
+	if !errors.Is(got.Error(), ErrNotConnected) {
+		t.Errorf("Send() error = %v, want %v", got.Error(), ErrNotConnected)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("Send() waited %s for a pool without connected connections", waited)
+	}
+	if p.Waiting() != 0 {
+		t.Errorf("Waiting() = %d, want 0", p.Waiting())
+	}
+}
@@ -67,11 +67,14 @@ func (c *connection) onReceiverConnected(ctx context.Context) bool {
 
 func (c *connection) onReceiverConnectedIdle(ctx context.Context) bool {
 	lengthBuffer := make([]byte, 2)
-	err := readWithTimeout(c.conn, c.keepAliveDelay, lengthBuffer)
+	n, err := readWithTimeout(c.conn, c.keepAliveDelay, lengthBuffer)
+	if n > 0 && isTimeout(err) {
+		// The keep alive delay passed while the length arrived, the rest of it is part of the same message
+		_, err = readWithTimeout(c.conn, c.readTimeout, lengthBuffer[n:])
+	}
 	if err != nil {
-		var netError net.Error
 		switch {
-		case errors.As(err, &netError) && netError.Timeout():
+		case n == 0 && isTimeout(err):
 			c.logger.Debug(ctx, "RECEIVER - sending keep alive")
 			if !c.trySend(sendCommand{context: ctx, packet: []byte{0x00, 0x00}, notifyError: false}) {
 				c.logger.Warning(ctx, "RECEIVER - try send would block")
@@ -80,6 +83,7 @@ func (c *connection) onReceiverConnectedIdle(ctx context.Context) bool {
 			c.logger.Error(
 				logging.ContextWithError(ctx, err),
 				"RECEIVER - failed to receive packet length")
+			c.endpointFailed()
 			if !c.tryNotifyError(err) {
 				c.logger.Warning(ctx, "RECEIVER - notify error would block")
 			}
@@ -101,12 +105,13 @@ func (c *connection) onReceiverConnectedIdle(ctx context.Context) bool {
 	length += c.msgLengthSurplus
 
 	payload := make([]byte, length)
-	err = readWithTimeout(c.conn, c.readTimeout, payload)
+	_, err = readWithTimeout(c.conn, c.readTimeout, payload)
 	if err != nil {
 		c.logger.Error(
 			logging.ContextWithError(ctx, err),
 			"RECEIVER - failed to receive packet payload")
 		c.logger.Debug(ctx, "RECEIVER - exiting")
+		c.endpointFailed()
 		if !c.tryNotifyError(err) {
 			c.logger.Warning(ctx, "RECEIVER - notify error would block")
 		}
@@ -116,6 +121,7 @@ func (c *connection) onReceiverConnectedIdle(ctx context.Context) bool {
 		return true
 	}
 	c.logger.Debug(ctx, "RECEIVER - packet received")
+	c.stats.received()
 
 	if !c.tryNotifyReceived(append(lengthBuffer, payload...)) {
 		c.logger.Warning(ctx, "RECEIVER - notify receive would block")
@@ -123,3 +129,8 @@ func (c *connection) onReceiverConnectedIdle(ctx context.Context) bool {
 
 	return true
 }
+
+func isTimeout(err error) bool {
+	var netError net.Error
+	return errors.As(err, &netError) && netError.Timeout()
+}
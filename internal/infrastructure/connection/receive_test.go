@@ -0,0 +1,82 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+func TestConnection_onReceiverConnectedIdle(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes [][]byte
+		want   []byte
+	}{
+		{
+			name:   "whole_message",
+			writes: [][]byte{{0x00, 0x03, 'a', 'b', 'c'}},
+			want:   []byte{0x00, 0x03, 'a', 'b', 'c'},
+		},
+		{
+			name:   "length_split_over_keep_alive_delay",
+			writes: [][]byte{{0x00}, {0x03, 'a', 'b', 'c'}},
+			want:   []byte{0x00, 0x03, 'a', 'b', 'c'},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			defer server.Close()
+
+			c := newConnection(0, "visa", nil, nil, 0, 0, 20*time.Millisecond, time.Second, 0, 0,
+				make(chan struct{}), make(chan *job), fakeReceivedFactory, logging.Logger{}, 0)
+			c.conn = client
+			c.receivedSignal = make(chan []byte, 1)
+
+			go func() {
+				for _, w := range tt.writes {
+					_, _ = server.Write(w)
+					time.Sleep(3 * c.keepAliveDelay)
+				}
+			}()
+
+			c.onReceiverConnectedIdle(context.Background())
+
+			select {
+			case got := <-c.receivedSignal:
+				if !bytes.Equal(got, tt.want) {
+					t.Errorf("received %x, want %x", got, tt.want)
+				}
+			default:
+				t.Error("no message received")
+			}
+		})
+	}
+}
+
+func TestConnection_onReceiverConnectedIdle_keepAlive(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	c := newConnection(0, "visa", nil, nil, 0, 0, 20*time.Millisecond, time.Second, 0, 0,
+		make(chan struct{}), make(chan *job), fakeReceivedFactory, logging.Logger{}, 0)
+	c.conn = client
+	c.sendCmdSignal = make(chan sendCommand, 1)
+
+	c.onReceiverConnectedIdle(context.Background())
+
+	select {
+	case cmd := <-c.sendCmdSignal:
+		if !bytes.Equal(cmd.packet, []byte{0x00, 0x00}) {
+			t.Errorf("sent %x, want a keep alive", cmd.packet)
+		}
+	default:
+		t.Error("no keep alive sent")
+	}
+}
@@ -2,7 +2,6 @@ package connection
 
 import (
 	"context"
-	"encoding/hex"
 	"sync"
 
 	"gitlab.cmpayments.local/libraries-go/logging"
@@ -59,13 +58,14 @@ func (c *connection) onSenderDisconnected(ctx context.Context) bool {
 
 func (c *connection) onSenderCommand(ctx context.Context, cmd sendCommand) bool {
 	c.logger.Debug(ctx, "SENDER - command")
-	loggingCtx := logging.ContextWithValue(cmd.context, packetLoggingKey, hex.EncodeToString(cmd.packet))
+	loggingCtx := logging.ContextWithValue(cmd.context, packetLengthLoggingKey, len(cmd.packet))
 	c.logger.Debug(loggingCtx, "SENDER - send packet")
 	err := writeWithTimeout(c.conn, c.writeTimeout, cmd.packet)
 	if err != nil {
 		c.logger.Error(
 			logging.ContextWithError(loggingCtx, err),
 			"SENDER - failed to send packet")
+		c.endpointFailed()
 		if cmd.notifyError {
 			if !c.tryNotifyError(err) {
 				c.logger.Warning(ctx, "SENDER - notify error would block")
@@ -75,7 +75,9 @@ func (c *connection) onSenderCommand(ctx context.Context, cmd sendCommand) bool
 			c.logger.Warning(ctx, "SENDER - notify disconnection would block")
 		}
 		c.senderConnected = false
+		return true
 	}
+	c.stats.sent()
 	return true
 }
 
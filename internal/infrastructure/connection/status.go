@@ -0,0 +1,182 @@
+package connection
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	StateDisconnected State = "disconnected"
+	StateConnected    State = "connected"
+	StateBusy         State = "busy"
+)
+
+// ConnectionStatus is a snapshot of the state and counters of one connection of a pool
+type ConnectionStatus struct {
+	ID             string
+	State          State
+	Endpoint       string
+	ConnectedAt    time.Time
+	LastSentAt     time.Time
+	LastReceivedAt time.Time
+	Reconnects     int
+	Timeouts       int
+	InFlightStan   string
+}
+
+// PoolStatus is a snapshot of a pool and its connections
+type PoolStatus struct {
+	Name        string
+	Endpoints   []EndpointStatus
+	Draining    bool
+	Connections []ConnectionStatus
+}
+
+// stanRequest is implemented by requests that can tell their system trace audit number, it is only
+// used to show which request a connection is attending
+type stanRequest interface {
+	Stan() string
+}
+
+// connectionStats is written by the goroutines of a connection and read by the admin endpoints,
+// so unlike the rest of the connection state it is guarded by a mutex
+type connectionStats struct {
+	mu             sync.RWMutex
+	connects       int
+	endpoint       string
+	connectedAt    time.Time
+	lastSentAt     time.Time
+	lastReceivedAt time.Time
+	timeouts       int
+	pendingJob     *job
+	inFlightStan   string
+}
+
+func (s *connectionStats) connected(endpoint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.connects++
+	s.endpoint = endpoint
+	s.connectedAt = time.Now()
+}
+
+// currentEndpoint returns the address of the endpoint the connection is or was last connected to
+func (s *connectionStats) currentEndpoint() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.endpoint
+}
+
+func (s *connectionStats) sent() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSentAt = time.Now()
+}
+
+func (s *connectionStats) received() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastReceivedAt = time.Now()
+}
+
+func (s *connectionStats) timeout() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.timeouts++
+}
+
+func (s *connectionStats) attending(job *job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pendingJob = job
+	s.inFlightStan = ""
+	if r, ok := job.request.(stanRequest); ok {
+		s.inFlightStan = r.Stan()
+	}
+}
+
+func (s *connectionStats) idle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pendingJob = nil
+	s.inFlightStan = ""
+}
+
+// pending returns the job the connection is attending, nil when it is idle
+func (s *connectionStats) pending() *job {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.pendingJob
+}
+
+func (c *connection) status() ConnectionStatus {
+	c.stats.mu.RLock()
+	defer c.stats.mu.RUnlock()
+
+	state := StateDisconnected
+	switch {
+	case c.isConnected.Load() && c.stats.pendingJob != nil:
+		state = StateBusy
+	case c.isConnected.Load():
+		state = StateConnected
+	}
+
+	// The first connect is not a reconnect
+	reconnects := c.stats.connects - 1
+	if reconnects < 0 {
+		reconnects = 0
+	}
+
+	return ConnectionStatus{
+		ID:             c.id,
+		State:          state,
+		Endpoint:       c.stats.endpoint,
+		ConnectedAt:    c.stats.connectedAt,
+		LastSentAt:     c.stats.lastSentAt,
+		LastReceivedAt: c.stats.lastReceivedAt,
+		Reconnects:     reconnects,
+		Timeouts:       c.stats.timeouts,
+		InFlightStan:   c.stats.inFlightStan,
+	}
+}
+
+func (p *Pool) Status() PoolStatus {
+	status := PoolStatus{
+		Name:      p.name,
+		Endpoints: p.endpoints.status(),
+		Draining:  p.draining.Load(),
+	}
+
+	for _, c := range p.connections {
+		status.Connections = append(status.Connections, c.status())
+	}
+
+	return status
+}
+
+// Reconnect closes the socket of the connection, it reconnects after the redial delay. A request the
+// connection is attending is abandoned.
+func (p *Pool) Reconnect(id string) error {
+	for _, c := range p.connections {
+		if c.id != id {
+			continue
+		}
+		if !c.tryDisconnect() {
+			return fmt.Errorf("connection %s of %s: %w", id, p.name, ErrReconnectRefused)
+		}
+		return nil
+	}
+
+	return ErrUnknownConnection
+}
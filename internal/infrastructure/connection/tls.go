@@ -0,0 +1,238 @@
+package connection
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+type TLSConfiguration struct {
+	Enabled bool `yaml:"enabled"`
+	// CAFile is the PEM bundle the server certificate is verified with, the system roots when empty
+	CAFile string `yaml:"ca_file"`
+	// CertFile and KeyFile are the PEM client certificate and key for mutual TLS, no client certificate when empty
+	CertFile   string `yaml:"cert_file"`
+	KeyFile    string `yaml:"key_file"`
+	ServerName string `yaml:"server_name"`
+	MinVersion string `yaml:"min_version"`
+}
+
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsCredentials loads the CA bundle and client certificate of a pool. The files are checked before
+// every dial and reloaded when they changed, so renewed certificates are used without a restart. When
+// a reload fails the previous credentials are kept and the error is reported by expiry.
+type tlsCredentials struct {
+	mu        sync.Mutex
+	conf      TLSConfiguration
+	modTimes  []time.Time
+	config    *tls.Config
+	notAfter  time.Time
+	reloadErr error
+}
+
+func newTLSCredentials(conf TLSConfiguration) *tlsCredentials {
+	if !conf.Enabled {
+		return nil
+	}
+	return &tlsCredentials{conf: conf}
+}
+
+func (t *tlsCredentials) current() (*tls.Config, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	modTimes, err := t.stat()
+	if err == nil && t.config != nil && equalTimes(modTimes, t.modTimes) {
+		return t.config, nil
+	}
+
+	var config *tls.Config
+	var notAfter time.Time
+	if err == nil {
+		config, notAfter, err = t.load()
+	}
+	if err != nil {
+		t.reloadErr = err
+		if t.config != nil {
+			return t.config, nil
+		}
+		return nil, err
+	}
+
+	t.config = config
+	t.notAfter = notAfter
+	t.modTimes = modTimes
+	t.reloadErr = nil
+
+	return t.config, nil
+}
+
+// expiry returns when the first of the client and CA certificates expires, ErrNoCertificates when the
+// pool verifies the scheme with the system roots and has no client certificate
+func (t *tlsCredentials) expiry() (time.Time, error) {
+	if len(t.files()) == 0 {
+		return time.Time{}, ErrNoCertificates
+	}
+	if _, err := t.current(); err != nil {
+		return time.Time{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.reloadErr != nil {
+		return t.notAfter, fmt.Errorf("reloading certificates: %w", t.reloadErr)
+	}
+	return t.notAfter, nil
+}
+
+func (t *tlsCredentials) files() []string {
+	var files []string
+	for _, file := range []string{t.conf.CAFile, t.conf.CertFile, t.conf.KeyFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func (t *tlsCredentials) stat() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, file := range t.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+func (t *tlsCredentials) load() (*tls.Config, time.Time, error) {
+	minVersion, ok := tlsVersions[t.conf.MinVersion]
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("unsupported TLS version %q", t.conf.MinVersion)
+	}
+
+	config := &tls.Config{
+		ServerName: t.conf.ServerName,
+		MinVersion: minVersion,
+	}
+
+	var notAfter time.Time
+	expires := func(cert *x509.Certificate) {
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+
+	if t.conf.CAFile != "" {
+		bundle, err := os.ReadFile(t.conf.CAFile)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		cas, err := parseCertificates(bundle)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%s: %w", t.conf.CAFile, err)
+		}
+		if len(cas) == 0 {
+			return nil, time.Time{}, fmt.Errorf("%s: %w", t.conf.CAFile, ErrNoCertificates)
+		}
+		config.RootCAs = x509.NewCertPool()
+		for _, ca := range cas {
+			config.RootCAs.AddCert(ca)
+			expires(ca)
+		}
+	}
+
+	if t.conf.CertFile != "" || t.conf.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.conf.CertFile, t.conf.KeyFile)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		expires(leaf)
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, notAfter, nil
+}
+
+func parseCertificates(bundle []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// dial connects to the endpoint, over TLS when the pool has TLS enabled. The handshake is part of the
+// dial timeout, the length-prefixed framing then runs over the TLS connection like over plain TCP.
+func (c *connection) dial(address string) (net.Conn, error) {
+	if c.tls == nil {
+		return net.DialTimeout("tcp", address, c.dialTimeout)
+	}
+
+	config, err := c.tls.current()
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS credentials: %w", err)
+	}
+
+	return tls.DialWithDialer(&net.Dialer{Timeout: c.dialTimeout}, "tcp", address, config)
+}
+
+// TLSEnabled tells whether the pool connects over TLS
+func (p *Pool) TLSEnabled() bool {
+	return p.tls != nil
+}
+
+// HasCertificates tells whether the pool connects over TLS with a CA bundle or client certificate of its
+// own, which expire, instead of only the system roots
+func (p *Pool) HasCertificates() bool {
+	return p.tls != nil && len(p.tls.files()) > 0
+}
+
+// CertificateExpiry returns when the first of the client and CA certificates of the pool expires, it
+// returns an error when the certificates cannot be loaded
+func (p *Pool) CertificateExpiry() (time.Time, error) {
+	if p.tls == nil {
+		return time.Time{}, ErrTLSDisabled
+	}
+	return p.tls.expiry()
+}
@@ -0,0 +1,217 @@
+package connection
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCertificate(t *testing.T, name string, notAfter time.Time, parent *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testCertificate{cert: cert, key: key, der: der}
+}
+
+func (c testCertificate) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if keyFile == "" {
+		return
+	}
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConnection_dialMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+
+	ca := newTestCertificate(t, "scheme-ca", time.Now().Add(24*time.Hour), nil)
+	ca.write(t, caFile, "")
+	server := newTestCertificate(t, "mip.test", time.Now().Add(24*time.Hour), &ca)
+	clientExpiry := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	newTestCertificate(t, "authorization", clientExpiry, &ca).write(t, certFile, keyFile)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.der}, PrivateKey: server.key}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    roots,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// The server answers the zero probe with a zero probe
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		probe := make([]byte, 2)
+		if _, err := conn.Read(probe); err == nil {
+			_, _ = conn.Write(probe)
+		}
+	}()
+
+	credentials := newTLSCredentials(TLSConfiguration{
+		Enabled:    true,
+		CAFile:     caFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "mip.test",
+		MinVersion: "1.2",
+	})
+	c := &connection{tls: credentials, dialTimeout: time.Second}
+
+	conn, err := c.dial(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	if err := writeWithTimeout(conn, time.Second, []byte{0x00, 0x00}); err != nil {
+		t.Fatalf("writeWithTimeout() error = %v", err)
+	}
+	probe := make([]byte, 2)
+	if _, err := readWithTimeout(conn, time.Second, probe); err != nil {
+		t.Fatalf("readWithTimeout() error = %v", err)
+	}
+
+	expiry, err := credentials.expiry()
+	if err != nil {
+		t.Fatalf("expiry() error = %v", err)
+	}
+	if !expiry.Equal(clientExpiry) {
+		t.Errorf("expiry() = %s, want the client certificate expiry %s", expiry, clientExpiry)
+	}
+}
+
+func TestTLSCredentials_reload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+
+	ca := newTestCertificate(t, "scheme-ca", time.Now().Add(24*time.Hour), nil)
+	first := time.Now().Add(time.Hour).Truncate(time.Second)
+	newTestCertificate(t, "authorization", first, &ca).write(t, certFile, keyFile)
+
+	credentials := newTLSCredentials(TLSConfiguration{Enabled: true, CertFile: certFile, KeyFile: keyFile})
+	if expiry, err := credentials.expiry(); err != nil || !expiry.Equal(first) {
+		t.Fatalf("expiry() = %s, %v, want %s", expiry, err, first)
+	}
+
+	renewed := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	newTestCertificate(t, "authorization", renewed, &ca).write(t, certFile, keyFile)
+	touch(t, time.Now().Add(time.Minute), certFile, keyFile)
+	if expiry, err := credentials.expiry(); err != nil || !expiry.Equal(renewed) {
+		t.Fatalf("expiry() = %s, %v after renewal, want %s", expiry, err, renewed)
+	}
+
+	// A broken certificate keeps the previous credentials and is reported
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, time.Now().Add(2*time.Minute), certFile)
+	if _, err := credentials.current(); err != nil {
+		t.Errorf("current() error = %v, want the previous credentials", err)
+	}
+	if expiry, err := credentials.expiry(); err == nil || !expiry.Equal(renewed) {
+		t.Errorf("expiry() = %s, %v after a failed reload, want %s and an error", expiry, err, renewed)
+	}
+}
+
+func TestPool_systemRoots(t *testing.T) {
+	p := NewPool(PoolConfiguration{Name: "visa", TLS: TLSConfiguration{Enabled: true, ServerName: "eas.visa.com"}}, fakeReceivedFactory, logging.Logger{}, 0)
+
+	if !p.TLSEnabled() {
+		t.Error("TLSEnabled() = false, want true")
+	}
+	if p.HasCertificates() {
+		t.Error("HasCertificates() = true for the system roots, want false")
+	}
+	if expiry, err := p.CertificateExpiry(); !errors.Is(err, ErrNoCertificates) {
+		t.Errorf("CertificateExpiry() = %s, %v, want %v", expiry, err, ErrNoCertificates)
+	}
+	if _, err := p.tls.current(); err != nil {
+		t.Errorf("current() error = %v, want the system roots", err)
+	}
+}
+
+func TestTLSCredentials_disabled(t *testing.T) {
+	if credentials := newTLSCredentials(TLSConfiguration{CAFile: "ca.pem"}); credentials != nil {
+		t.Errorf("newTLSCredentials() = %v, want nil when TLS is disabled", credentials)
+	}
+}
+
+func touch(t *testing.T, modTime time.Time, files ...string) {
+	t.Helper()
+
+	for _, file := range files {
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
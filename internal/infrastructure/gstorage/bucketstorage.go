@@ -27,7 +27,8 @@ func NewBucket(logger platform.Logger, handle *gstorage.BucketHandle) (bucket, e
 	}, nil
 }
 
-func (m bucket) LastFile(ctx context.Context, scheme string, fileType string) (io.ReadCloser, error) {
+// LastFile returns a reader of the newest file of the type, and its name
+func (m bucket) LastFile(ctx context.Context, scheme string, fileType string) (io.ReadCloser, string, error) {
 	day := time.Now()
 	lookbackLimit := day.Add(-1 * time.Hour * 24 * 365)
 
@@ -50,7 +51,7 @@ func (m bucket) LastFile(ctx context.Context, scheme string, fileType string) (i
 			//m.logger.Info(ctx, fmt.Sprintf("BucketItem: %#v", attrs))
 
 			if err != nil {
-				return nil, fmt.Errorf("Bucket().Objects(%v): %w", q, err)
+				return nil, "", fmt.Errorf("Bucket().Objects(%v): %w", q, err)
 			}
 
 			if newestAttrs == nil || attrs.Created.After(newestAttrs.Created) {
@@ -62,16 +63,16 @@ func (m bucket) LastFile(ctx context.Context, scheme string, fileType string) (i
 			obj := m.handle.Object(newestAttrs.Name)
 			r, err := obj.NewReader(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("Bucket().Object(%s).NewReader(): %w", newestAttrs.Name, err)
+				return nil, "", fmt.Errorf("Bucket().Object(%s).NewReader(): %w", newestAttrs.Name, err)
 			}
-			return r, nil
+			return r, newestAttrs.Name, nil
 		}
 
 		// If nothing was found, go back one day.
 		day = day.Add(-1 * time.Hour * 24)
 
 		if day.Before(lookbackLimit) {
-			return nil, nil
+			return nil, "", nil
 		}
 	}
 }
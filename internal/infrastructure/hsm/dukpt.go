@@ -0,0 +1,133 @@
+package hsm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des" //nolint:gosec // DUKPT and the zone PIN keys of the schemes are TDES
+	"encoding/binary"
+)
+
+const (
+	keyLength                 = 16
+	tdesKeySerialNumberLength = 10
+	aesKeySerialNumberLength  = 12
+	tdesCounterMask           = 0x1fffff // The 21 bit transaction counter at the end of a TDES key serial number
+)
+
+// AES DUKPT key usage indicators [ANSI X9.24-3-2017 6.3.2]
+const (
+	keyUsageInitialKey    = 0x8001
+	keyUsageKeyDerivation = 0x8000
+	keyUsagePinEncryption = 0x1000
+)
+
+var (
+	// keyRegisterMask derives the left half of a TDES DUKPT key [ANSI X9.24-1-2009 A.6]
+	keyRegisterMask = []byte{0xc0, 0xc0, 0xc0, 0xc0, 0, 0, 0, 0, 0xc0, 0xc0, 0xc0, 0xc0, 0, 0, 0, 0}
+	// pinVariant turns a TDES DUKPT key into the PIN encryption key of the transaction
+	pinVariant = []byte{0, 0, 0, 0, 0, 0, 0, 0xff, 0, 0, 0, 0, 0, 0, 0, 0xff}
+)
+
+// tdesPinKey derives the PIN encryption key of the transaction counter of a 10 byte key serial number
+func tdesPinKey(bdk, ksn []byte) []byte {
+	// The initial key is derived from the key serial number without its counter
+	register := make([]byte, 8)
+	copy(register, ksn[:8])
+	register[7] &= 0xe0
+
+	key := make([]byte, 0, keyLength)
+	left, right := make([]byte, 8), make([]byte, 8)
+	tdes(bdk).Encrypt(left, register)
+	tdes(xor(bdk, keyRegisterMask)).Encrypt(right, register)
+	key = append(append(key, left...), right...)
+
+	// The future keys are derived for every bit set in the counter, from the highest bit down
+	counter := binary.BigEndian.Uint64(ksn[2:]) & tdesCounterMask
+	shiftRegister := binary.BigEndian.Uint64(ksn[2:]) &^ tdesCounterMask
+	for bit := uint64(1) << 20; bit > 0; bit >>= 1 {
+		if counter&bit == 0 {
+			continue
+		}
+		shiftRegister |= bit
+		key = nonReversibleKey(key, shiftRegister)
+	}
+
+	return xor(key, pinVariant)
+}
+
+// nonReversibleKey derives the next TDES DUKPT key from a key and the shift register
+func nonReversibleKey(key []byte, shiftRegister uint64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, shiftRegister)
+
+	next := make([]byte, 0, keyLength)
+	next = append(next, nonReversibleHalf(xor(key, keyRegisterMask), data)...)
+	return append(next, nonReversibleHalf(key, data)...)
+}
+
+func nonReversibleHalf(key, data []byte) []byte {
+	c, _ := des.NewCipher(key[:8]) //nolint:gosec // The key is 8 bytes, the DES key size
+	out := xor(data, key[8:])
+	c.Encrypt(out, out)
+	return xor(out, key[8:])
+}
+
+// aesPinKey derives the AES-128 PIN encryption key of the transaction counter of a 12 byte key serial number, the
+// initial key ID and a 32 bit counter
+func aesPinKey(bdk, ksn []byte) []byte {
+	key := aesDerive(bdk, aesDerivationData(keyUsageInitialKey, ksn[:8]))
+
+	// The intermediate derivation keys are derived for every bit set in the counter, from the highest bit down
+	counter := binary.BigEndian.Uint32(ksn[8:])
+	id := make([]byte, 8)
+	copy(id, ksn[4:8])
+	var working uint32
+	for bit := uint32(1) << 31; bit > 0; bit >>= 1 {
+		if counter&bit == 0 {
+			continue
+		}
+		working |= bit
+		binary.BigEndian.PutUint32(id[4:], working)
+		key = aesDerive(key, aesDerivationData(keyUsageKeyDerivation, id))
+	}
+
+	binary.BigEndian.PutUint32(id[4:], counter)
+	return aesDerive(key, aesDerivationData(keyUsagePinEncryption, id))
+}
+
+// aesDerivationData is the derivation data of an AES-128 key: version 1, key block counter 1, the key usage, the
+// AES-128 algorithm indicator, the 128 bit key length and the initial key ID, or the derivation ID and the counter
+func aesDerivationData(usage uint16, id []byte) []byte {
+	data := []byte{0x01, 0x01, byte(usage >> 8), byte(usage), 0x00, 0x02, 0x00, 0x80}
+	return append(data, id...)
+}
+
+func aesDerive(key, data []byte) []byte {
+	c, _ := aes.NewCipher(key) // The key is 16 bytes, an AES-128 key
+	out := make([]byte, aes.BlockSize)
+	c.Encrypt(out, data)
+	return out
+}
+
+// tdes returns the cipher of a double length TDES key, the first key is used as third key as well
+func tdes(key []byte) cipher.Block {
+	k := make([]byte, 0, 24)
+	k = append(append(k, key...), key[:8]...)
+	c, _ := des.NewTripleDESCipher(k) //nolint:gosec // The key is 24 bytes, the TDES key size
+	return c
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+// wipe overwrites key material and PINs once they are used
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,138 @@
+package hsm
+
+import (
+	"crypto/aes"
+	"strings"
+)
+
+const (
+	minPinLength = 4
+	maxPinLength = 12
+
+	iso0Format = 0x0
+	iso0Fill   = 0xf
+	iso4Format = 0x4
+	iso4Fill   = 0xa
+)
+
+// decryptISO0 returns the PIN digits of an ISO format 0 PIN block encrypted under a TDES key
+func decryptISO0(key, block []byte, pan string) ([]byte, error) {
+	defer wipe(key)
+	if len(block) != 8 {
+		return nil, ErrInvalidPinBlock
+	}
+
+	field := make([]byte, 8)
+	defer wipe(field)
+	tdes(key).Decrypt(field, block)
+	for i, b := range iso0PanField(pan) {
+		field[i] ^= b
+	}
+
+	return pinDigits(field, iso0Format, iso0Fill)
+}
+
+// decryptISO4 returns the PIN digits of an ISO format 4 PIN block encrypted under an AES key. The block is the PIN
+// field encrypted, xored with the PAN field and encrypted again.
+func decryptISO4(key, block []byte, pan string) ([]byte, error) {
+	defer wipe(key)
+	if len(block) != aes.BlockSize {
+		return nil, ErrInvalidPinBlock
+	}
+
+	c, _ := aes.NewCipher(key) // The key is 16 bytes, an AES-128 key
+	intermediate := make([]byte, aes.BlockSize)
+	c.Decrypt(intermediate, block)
+	field := xor(intermediate, iso4PanField(pan))
+	defer wipe(field)
+	c.Decrypt(field, field)
+
+	// The last 8 bytes of the PIN field are random
+	return pinDigits(field[:8], iso4Format, iso4Fill)
+}
+
+// iso0PinBlock returns the clear ISO format 0 PIN block of the PIN digits: the format, the PIN length, the PIN padded
+// with F, xored with the PAN field
+func iso0PinBlock(digits []byte, pan string) []byte {
+	field := make([]byte, 8)
+	defer wipe(field)
+	nibbles := append([]byte{iso0Format, byte(len(digits))}, digits...)
+	for i := 0; i < 16; i++ {
+		n := byte(iso0Fill)
+		if i < len(nibbles) {
+			n = nibbles[i]
+		}
+		field[i/2] |= n << (4 * (1 - i%2))
+	}
+	wipe(nibbles)
+
+	return xor(field, iso0PanField(pan))
+}
+
+// pinDigits reads the PIN digits of the first 8 bytes of a clear PIN field, which start with the format and the PIN
+// length and fill the PIN up with the fill nibble
+func pinDigits(field []byte, format, fill byte) ([]byte, error) {
+	length := int(field[0] & 0x0f)
+	if field[0]>>4 != format || length < minPinLength || length > maxPinLength {
+		return nil, ErrInvalidPinBlock
+	}
+
+	digits := make([]byte, 0, length)
+	for i := 2; i < 16; i++ {
+		n := field[i/2] >> (4 * (1 - i%2)) & 0x0f
+		switch {
+		case i < length+2 && n <= 9:
+			digits = append(digits, n)
+		case i >= length+2 && n == fill:
+		default:
+			wipe(digits)
+			return nil, ErrInvalidPinBlock
+		}
+	}
+
+	return digits, nil
+}
+
+// iso0PanField is four zeros and the rightmost 12 digits of the PAN without its check digit
+func iso0PanField(pan string) []byte {
+	digits := pan[:len(pan)-1]
+	if len(digits) > 12 {
+		digits = digits[len(digits)-12:]
+	}
+
+	return packDigits(strings.Repeat("0", 16-len(digits)) + digits)
+}
+
+// iso4PanField is the PAN length minus 12, the PAN padded to 12 digits and zeros up to 32 digits
+func iso4PanField(pan string) []byte {
+	m := len(pan) - 12
+	if m < 0 {
+		m = 0
+		pan += strings.Repeat("0", 12-len(pan))
+	}
+
+	return packDigits(string(rune('0'+m)) + pan + strings.Repeat("0", 31-len(pan)))
+}
+
+func packDigits(digits string) []byte {
+	packed := make([]byte, len(digits)/2)
+	for i := range packed {
+		packed[i] = (digits[2*i]-'0')<<4 | (digits[2*i+1] - '0')
+	}
+
+	return packed
+}
+
+func isPan(pan string) bool {
+	if len(pan) < 12 || len(pan) > 19 {
+		return false
+	}
+
+	for _, c := range pan {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,126 @@
+package hsm
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+// The errors never hold the PIN block, the PIN or the keys
+var (
+	ErrInvalidKeySerialNumber = errors.New("key serial number must be 10 bytes for TDES or 12 bytes for AES DUKPT")
+	ErrNoBaseDerivationKey    = errors.New("no base derivation key for the DUKPT of the key serial number")
+	ErrInvalidPinBlock        = errors.New("invalid PIN block")
+	ErrInvalidPan             = errors.New("invalid PAN")
+)
+
+var keyIndexPattern = regexp.MustCompile(`^[0-9]{2}$`)
+
+// Config holds the hex encoded base derivation keys the DUKPT keys of the terminals are derived from. A base
+// derivation key that is left empty makes the PIN blocks of that DUKPT fail.
+type Config struct {
+	Enabled               bool   `yaml:"enabled"`
+	TDESBaseDerivationKey string `yaml:"tdes_base_derivation_key"` // Double length TDES key, 32 hex digits
+	AESBaseDerivationKey  string `yaml:"aes_base_derivation_key"`  // AES-128 key, 32 hex digits
+}
+
+// ZoneConfig holds the zone PIN key shared with a card scheme and the index the scheme knows it by
+type ZoneConfig struct {
+	ZonePinKey      string `yaml:"zone_pin_key"`       // Double length TDES key, 32 hex digits
+	ZonePinKeyIndex string `yaml:"zone_pin_key_index"` // 2 digits
+}
+
+// Software is a security module in software for local development and tests. It holds the keys in memory, so it
+// must not be used with production keys.
+type Software struct {
+	tdesBaseDerivationKey []byte
+	aesBaseDerivationKey  []byte
+	zonePinKey            cipher.Block
+	zonePinKeyIndex       string
+}
+
+func NewSoftware(conf Config, zone ZoneConfig) (*Software, error) {
+	tdesBDK, err := parseKey(conf.TDESBaseDerivationKey, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TDES base derivation key: %w", err)
+	}
+
+	aesBDK, err := parseKey(conf.AESBaseDerivationKey, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AES base derivation key: %w", err)
+	}
+
+	zpk, err := parseKey(zone.ZonePinKey, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone PIN key: %w", err)
+	}
+
+	if !keyIndexPattern.MatchString(zone.ZonePinKeyIndex) {
+		return nil, errors.New("zone PIN key index must be 2 digits")
+	}
+
+	return &Software{
+		tdesBaseDerivationKey: tdesBDK,
+		aesBaseDerivationKey:  aesBDK,
+		zonePinKey:            tdes(zpk),
+		zonePinKeyIndex:       zone.ZonePinKeyIndex,
+	}, nil
+}
+
+// parseKey decodes a hex encoded key of 16 bytes, the size of a double length TDES key and an AES-128 key
+func parseKey(key string, optional bool) ([]byte, error) {
+	if key == "" && optional {
+		return nil, nil
+	}
+
+	k, err := hex.DecodeString(key)
+	if err != nil || len(k) != keyLength {
+		return nil, errors.New("must be 32 hex digits")
+	}
+
+	return k, nil
+}
+
+// TranslatePin decrypts the PIN block of a terminal with the DUKPT key of its key serial number and encrypts the
+// PIN as an ISO format 0 PIN block under the zone PIN key
+func (s *Software) TranslatePin(_ context.Context, pin entity.OnlinePin, pan string) (entity.ZonePinBlock, error) {
+	if !isPan(pan) {
+		return entity.ZonePinBlock{}, ErrInvalidPan
+	}
+
+	var (
+		digits []byte
+		err    error
+	)
+	switch len(pin.KeySerialNumber) {
+	case tdesKeySerialNumberLength:
+		if s.tdesBaseDerivationKey == nil {
+			return entity.ZonePinBlock{}, ErrNoBaseDerivationKey
+		}
+		digits, err = decryptISO0(tdesPinKey(s.tdesBaseDerivationKey, pin.KeySerialNumber), pin.PinBlock, pan)
+	case aesKeySerialNumberLength:
+		if s.aesBaseDerivationKey == nil {
+			return entity.ZonePinBlock{}, ErrNoBaseDerivationKey
+		}
+		digits, err = decryptISO4(aesPinKey(s.aesBaseDerivationKey, pin.KeySerialNumber), pin.PinBlock, pan)
+	default:
+		return entity.ZonePinBlock{}, ErrInvalidKeySerialNumber
+	}
+	if err != nil {
+		return entity.ZonePinBlock{}, err
+	}
+	defer wipe(digits)
+
+	block := iso0PinBlock(digits, pan)
+	s.zonePinKey.Encrypt(block, block)
+
+	return entity.ZonePinBlock{
+		PinBlock: block,
+		KeyIndex: s.zonePinKeyIndex,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package hsm
+
+import (
+	"context"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+const (
+	testTDESBaseDerivationKey = "0123456789ABCDEFFEDCBA9876543210"
+	testAESBaseDerivationKey  = "FEDCBA9876543210F1F1F1F1F1F1F1F1"
+	testZonePinKey            = "89ABCDEF0123456776543210FEDCBA98"
+	testPan                   = "4012345678909"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("invalid hex %s: %v", s, err)
+	}
+	return b
+}
+
+// The test vectors of ANSI X9.24-1-2009 A.4 and ANSI X9.24-3-2017 B
+func TestDerivedKeys(t *testing.T) {
+	tdesKey := tdesPinKey(mustHex(t, testTDESBaseDerivationKey), mustHex(t, "FFFF9876543210E00001"))
+	if got := strings.ToUpper(hex.EncodeToString(tdesKey)); got != "042666B49184CF5C68DE9628D0397B36" {
+		t.Errorf("tdesPinKey() = %s, want 042666B49184CF5C68DE9628D0397B36", got)
+	}
+
+	initialKey := aesDerive(mustHex(t, testAESBaseDerivationKey), aesDerivationData(keyUsageInitialKey, mustHex(t, "1234567890123456")))
+	if got := strings.ToUpper(hex.EncodeToString(initialKey)); got != "1273671EA26AC29AFA4D1084127652A1" {
+		t.Errorf("initial key = %s, want 1273671EA26AC29AFA4D1084127652A1", got)
+	}
+}
+
+// encryptISO4 is what the PIN pad of a terminal does for AES DUKPT
+func encryptISO4(t *testing.T, key []byte, pin, pan string) []byte {
+	t.Helper()
+	field := mustHex(t, "4"+string(rune('0'+len(pin)))+pin+strings.Repeat("A", 14-len(pin))+"0123456789ABCDEF")
+	c, _ := aes.NewCipher(key)
+	c.Encrypt(field, field)
+	block := xor(field, iso4PanField(pan))
+	c.Encrypt(block, block)
+	return block
+}
+
+func TestSoftware_TranslatePin(t *testing.T) {
+	s, err := NewSoftware(
+		Config{TDESBaseDerivationKey: testTDESBaseDerivationKey, AESBaseDerivationKey: testAESBaseDerivationKey},
+		ZoneConfig{ZonePinKey: testZonePinKey, ZonePinKeyIndex: "01"},
+	)
+	if err != nil {
+		t.Fatalf("NewSoftware() error = %v", err)
+	}
+
+	aesKSN := mustHex(t, "123456789012345600000003")
+	aesKey := aesPinKey(mustHex(t, testAESBaseDerivationKey), aesKSN)
+
+	tests := []struct {
+		name    string
+		pin     entity.OnlinePin
+		pan     string
+		wantErr error
+	}{
+		{
+			name: "tdes_dukpt_iso0",
+			pin:  entity.OnlinePin{PinBlock: mustHex(t, "1B9C1845EB993A7A"), KeySerialNumber: mustHex(t, "FFFF9876543210E00001")},
+			pan:  testPan,
+		},
+		{
+			name: "aes_dukpt_iso4",
+			pin:  entity.OnlinePin{PinBlock: encryptISO4(t, aesKey, "1234", testPan), KeySerialNumber: aesKSN},
+			pan:  testPan,
+		},
+		{
+			name:    "other_pan",
+			pin:     entity.OnlinePin{PinBlock: mustHex(t, "1B9C1845EB993A7A"), KeySerialNumber: mustHex(t, "FFFF9876543210E00001")},
+			pan:     "5555555555554444",
+			wantErr: ErrInvalidPinBlock,
+		},
+		{
+			name:    "other_key_serial_number",
+			pin:     entity.OnlinePin{PinBlock: mustHex(t, "1B9C1845EB993A7A"), KeySerialNumber: mustHex(t, "FFFF9876543210E00002")},
+			pan:     testPan,
+			wantErr: ErrInvalidPinBlock,
+		},
+		{
+			name:    "invalid_key_serial_number",
+			pin:     entity.OnlinePin{PinBlock: mustHex(t, "1B9C1845EB993A7A"), KeySerialNumber: mustHex(t, "FFFF9876543210E0")},
+			pan:     testPan,
+			wantErr: ErrInvalidKeySerialNumber,
+		},
+		{
+			name:    "invalid_pan",
+			pin:     entity.OnlinePin{PinBlock: mustHex(t, "1B9C1845EB993A7A"), KeySerialNumber: mustHex(t, "FFFF9876543210E00001")},
+			pan:     "40123456789A9",
+			wantErr: ErrInvalidPan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.TranslatePin(context.Background(), tt.pin, tt.pan)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TranslatePin() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if strings.Contains(err.Error(), "1234") {
+					t.Errorf("TranslatePin() error contains the PIN: %v", err)
+				}
+				return
+			}
+
+			if got.KeyIndex != "01" {
+				t.Errorf("TranslatePin() key index = %s, want 01", got.KeyIndex)
+			}
+
+			block := make([]byte, 8)
+			tdes(mustHex(t, testZonePinKey)).Decrypt(block, got.PinBlock)
+			want := xor(mustHex(t, "041234FFFFFFFFFF"), iso0PanField(tt.pan))
+			if hex.EncodeToString(block) != hex.EncodeToString(want) {
+				t.Errorf("TranslatePin() clear PIN block = %X, want %X", block, want)
+			}
+		})
+	}
+}
+
+func TestNewSoftware(t *testing.T) {
+	tests := []struct {
+		name string
+		conf Config
+		zone ZoneConfig
+	}{
+		{
+			name: "short_base_derivation_key",
+			conf: Config{TDESBaseDerivationKey: "0123456789ABCDEF"},
+			zone: ZoneConfig{ZonePinKey: testZonePinKey, ZonePinKeyIndex: "01"},
+		},
+		{
+			name: "no_zone_pin_key",
+			conf: Config{TDESBaseDerivationKey: testTDESBaseDerivationKey},
+			zone: ZoneConfig{ZonePinKeyIndex: "01"},
+		},
+		{
+			name: "invalid_key_index",
+			conf: Config{TDESBaseDerivationKey: testTDESBaseDerivationKey},
+			zone: ZoneConfig{ZonePinKey: testZonePinKey, ZonePinKeyIndex: "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSoftware(tt.conf, tt.zone); err == nil {
+				t.Errorf("NewSoftware() error = nil, want an error")
+			}
+		})
+	}
+}
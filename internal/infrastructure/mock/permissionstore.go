@@ -22,6 +22,12 @@ var permissions = []processing.Permission{
 		Label:  "Create new refund",
 		ApiKey: "6247c10c-84a0-4fa1-b330-77eea1e944d3",
 	},
+	{
+		ID:     uuid.New().String(),
+		Code:   "create_payout",
+		Label:  "Create new payout",
+		ApiKey: "6247c10c-84a0-4fa1-b330-77eea1e944d3",
+	},
 	{
 		ID:     uuid.New().String(),
 		Code:   "create_reversal",
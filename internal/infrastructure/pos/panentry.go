@@ -12,8 +12,10 @@ var (
 		`05`: entity.PANEntryChip,
 		`07`: entity.PANEntryContactless,
 		`10`: entity.PANEntryCredentialOnFile,
+		`80`: entity.PANEntryChipFallback,
 		`81`: entity.PANEntryViaEcomWithOpId,
 		`90`: entity.PANEntryMagneticStrip,
+		`91`: entity.PANEntryContactlessMagneticStrip,
 	}
 	toPanEntryCodeMap = map[entity.PANEntry]string{
 		entity.PANEntryUnknown:                  `00`,
@@ -22,8 +24,10 @@ var (
 		entity.PANEntryChip:                     `05`,
 		entity.PANEntryContactless:              `07`,
 		entity.PANEntryCredentialOnFile:         `10`,
+		entity.PANEntryChipFallback:             `80`,
 		entity.PANEntryViaEcomWithOpId:          `81`,
 		entity.PANEntryMagneticStrip:            `90`,
+		entity.PANEntryContactlessMagneticStrip: `91`,
 	}
 )
 
@@ -47,6 +47,16 @@ func TestPANEntry_Value(t *testing.T) {
 			want: `90`,
 			PanEntry: entity.PANEntryMagneticStrip,
 		},
+		{
+			name: "91_is_contactlessMagneticStrip",
+			want: `91`,
+			PanEntry: entity.PANEntryContactlessMagneticStrip,
+		},
+		{
+			name: "80_is_chipFallback",
+			want: `80`,
+			PanEntry: entity.PANEntryChipFallback,
+		},
 		{
 			name:"nothing",
 			want: "",
@@ -103,6 +113,16 @@ func TestPanEntryString(t *testing.T) {
 			panEntryValue: `90`,
 			want:          entity.PANEntryMagneticStrip,
 		},
+		{
+			name:          "contactlessMagneticStrip_is_91",
+			panEntryValue: `91`,
+			want:          entity.PANEntryContactlessMagneticStrip,
+		},
+		{
+			name:          "chipFallback_is_80",
+			panEntryValue: `80`,
+			want:          entity.PANEntryChipFallback,
+		},
 		{
 			name:          "nothing",
 			panEntryValue: "",
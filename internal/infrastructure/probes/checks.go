@@ -0,0 +1,76 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// degradedError is the failure of a check that degrades the instance but never takes it down
+type degradedError struct {
+	err error
+}
+
+func (e degradedError) Error() string {
+	return e.err.Error()
+}
+
+func (e degradedError) Unwrap() error {
+	return e.err
+}
+
+// Degraded reports a failure of check as degraded instead of down, for a state the instance handles
+// itself, like an open circuit breaker that fails requests fast until the scheme answers again
+func Degraded(check Check) Check {
+	return func(ctx context.Context) error {
+		if err := check(ctx); err != nil {
+			return degradedError{err: err}
+		}
+		return nil
+	}
+}
+
+func isDegraded(err error) bool {
+	var degraded degradedError
+	return errors.As(err, &degraded)
+}
+
+// MinCount fails when count returns less than min, like a pool without enough connected connections
+func MinCount(what string, count func() int, min int) Check {
+	return func(ctx context.Context) error {
+		if n := count(); n < min {
+			return fmt.Errorf("%d %s, want at least %d", n, what, min)
+		}
+		return nil
+	}
+}
+
+// MinValidity fails when expiry returns an error or a time less than minValidity from now, like a
+// certificate that is about to expire
+func MinValidity(what string, expiry func() (time.Time, error), minValidity time.Duration) Check {
+	return func(ctx context.Context) error {
+		t, err := expiry()
+		if err != nil {
+			return fmt.Errorf("%s: %w", what, err)
+		}
+		if left := time.Until(t); left < minValidity {
+			return fmt.Errorf("%s expires at %s, want valid for at least %s", what, t.Format(time.RFC3339), minValidity)
+		}
+		return nil
+	}
+}
+
+// MaxAge fails when last never happened or happened longer than maxAge ago
+func MaxAge(what string, last func() time.Time, maxAge time.Duration) Check {
+	return func(ctx context.Context) error {
+		t := last()
+		if t.IsZero() {
+			return fmt.Errorf("no %s yet", what)
+		}
+		if age := time.Since(t); age > maxAge {
+			return fmt.Errorf("last %s %s ago, want within %s", what, age.Round(time.Second), maxAge)
+		}
+		return nil
+	}
+}
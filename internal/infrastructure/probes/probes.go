@@ -1,8 +1,13 @@
 package probes
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"gitlab.cmpayments.local/creditcard/platform"
@@ -10,15 +15,79 @@ import (
 	"gitlab.cmpayments.local/libraries-go/logging"
 )
 
+const (
+	statusUp       = "up"
+	statusDown     = "down"
+	statusDegraded = "degraded"
+
+	defaultCheckTimeout = 2 * time.Second
+)
+
+// Check returns why a dependency is not healthy, or nil when it is
+type Check func(ctx context.Context) error
+
+// Registry is where the dependencies register the checks that decide the readiness of the instance
+type Registry interface {
+	// Register adds a check of a dependency every request needs, like Spanner
+	Register(name string, check Check)
+	// RegisterScheme adds a check of a scheme. The instance stays ready while one scheme passes all its
+	// checks, so a scheme that is down doesn't take the traffic of the other schemes with it.
+	RegisterScheme(scheme, name string, check Check)
+}
+
+type namedCheck struct {
+	name   string
+	scheme string
+	check  Check
+}
+
+type checkResult struct {
+	Name   string `json:"name"`
+	Scheme string `json:"scheme,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type readinessResponse struct {
+	Status string        `json:"status"`
+	Checks []checkResult `json:"checks"`
+}
+
 type probesController struct {
-	logger platform.Logger
+	logger       platform.Logger
+	checkTimeout time.Duration
+	mu           sync.RWMutex
+	checks       []namedCheck
 }
 
-func NewProbesController(logger platform.Logger) probesController {
-	return probesController{logger: logger}
+func NewProbesController(logger platform.Logger, checkTimeout time.Duration) *probesController {
+	if checkTimeout == 0 {
+		checkTimeout = defaultCheckTimeout
+	}
+
+	return &probesController{logger: logger, checkTimeout: checkTimeout}
+}
+
+// Register adds a check to the readiness probe, the checks run in the order they were registered
+func (p *probesController) Register(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, namedCheck{name: name, check: check})
 }
 
-func (p probesController) Ready(isReady *atomic.Value) http.HandlerFunc {
+// RegisterScheme adds a check of a scheme to the readiness probe
+func (p *probesController) RegisterScheme(scheme, name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, namedCheck{name: name, scheme: scheme, check: check})
+}
+
+// Ready reports the instance ready once it has started, all checks of the dependencies pass and at least
+// one scheme passes its checks. The result of every check is in the response, so it is clear why an
+// instance doesn't receive traffic or why a scheme is degraded.
+func (p *probesController) Ready(isReady *atomic.Value) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !isReady.Load().(bool) {
 			id := uuid.New().String()
@@ -27,13 +96,101 @@ func (p probesController) Ready(isReady *atomic.Value) http.HandlerFunc {
 			jsonresult.ServiceUnavailable(w, id)
 			return
 		}
-		jsonresult.Ok(w, nil)
+
+		response := p.run(r.Context())
+		status := http.StatusOK
+		switch response.Status {
+		case statusDown:
+			status = http.StatusServiceUnavailable
+			p.logger.Error(r.Context(), fmt.Sprintf("not ready: %s", failedChecks(response.Checks)))
+		case statusDegraded:
+			p.logger.Warning(r.Context(), fmt.Sprintf("degraded: %s", failedChecks(response.Checks)))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(response)
 	}
 }
 
-func (p probesController) Health() http.HandlerFunc {
+// Health reports the process is alive, it doesn't look at the dependencies so a broken dependency
+// makes the instance unready instead of getting it restarted
+func (p *probesController) Health() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		jsonresult.Ok(w, nil)
 	}
 }
+
+// run runs the checks concurrently, each with its own timeout
+func (p *probesController) run(ctx context.Context) readinessResponse {
+	p.mu.RLock()
+	checks := make([]namedCheck, len(p.checks))
+	copy(checks, p.checks)
+	p.mu.RUnlock()
+
+	response := readinessResponse{Status: statusUp, Checks: make([]checkResult, len(checks))}
+
+	wg := sync.WaitGroup{}
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c namedCheck) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, p.checkTimeout)
+			defer cancel()
+
+			result := checkResult{Name: c.name, Scheme: c.scheme, Status: statusUp}
+			if err := c.check(ctx); err != nil {
+				result.Status = statusDown
+				if isDegraded(err) {
+					result.Status = statusDegraded
+				}
+				result.Error = err.Error()
+			}
+			response.Checks[i] = result
+		}(i, c)
+	}
+	wg.Wait()
+
+	schemes := map[string]bool{}
+	failedSchemes := map[string]bool{}
+	degraded := false
+	for _, c := range response.Checks {
+		if c.Scheme != "" {
+			schemes[c.Scheme] = true
+		}
+		switch {
+		case c.Status == statusDegraded:
+			degraded = true
+		case c.Status == statusDown && c.Scheme != "":
+			failedSchemes[c.Scheme] = true
+		case c.Status == statusDown:
+			response.Status = statusDown
+		}
+	}
+
+	if response.Status == statusUp && (degraded || len(failedSchemes) > 0) {
+		response.Status = statusDegraded
+		if len(failedSchemes) > 0 && len(failedSchemes) == len(schemes) {
+			response.Status = statusDown
+		}
+	}
+
+	return response
+}
+
+func failedChecks(results []checkResult) string {
+	failed := ""
+	for _, c := range results {
+		if c.Status == statusUp {
+			continue
+		}
+		if failed != "" {
+			failed += ", "
+		}
+		failed += c.Name + ": " + c.Error
+	}
+
+	return failed
+}
@@ -0,0 +1,186 @@
+package probes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+func TestProbesController_Ready(t *testing.T) {
+	pass := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("no connected connections") }
+	open := Degraded(func(ctx context.Context) error { return errors.New("circuit breaker open") })
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name         string
+		checks       map[string]Check
+		schemeChecks map[string]map[string]Check
+		wantCode     int
+		wantReady    string
+		wantStatus   map[string]string
+	}{
+		{
+			name:       "all_checks_pass",
+			checks:     map[string]Check{"spanner": pass, "visa_pool": pass},
+			wantCode:   http.StatusOK,
+			wantReady:  statusUp,
+			wantStatus: map[string]string{"spanner": statusUp, "visa_pool": statusUp},
+		},
+		{
+			name:       "failing_check",
+			checks:     map[string]Check{"spanner": pass, "visa_pool": fail},
+			wantCode:   http.StatusServiceUnavailable,
+			wantReady:  statusDown,
+			wantStatus: map[string]string{"spanner": statusUp, "visa_pool": statusDown},
+		},
+		{
+			name:       "check_times_out",
+			checks:     map[string]Check{"spanner": hang},
+			wantCode:   http.StatusServiceUnavailable,
+			wantReady:  statusDown,
+			wantStatus: map[string]string{"spanner": statusDown},
+		},
+		{
+			name:   "one_scheme_down",
+			checks: map[string]Check{"spanner": pass},
+			schemeChecks: map[string]map[string]Check{
+				"mastercard": {"mastercard_pool": fail, "mastercard_stan": pass},
+				"visa":       {"visa_pool": pass, "visa_stan": pass},
+			},
+			wantCode:  http.StatusOK,
+			wantReady: statusDegraded,
+			wantStatus: map[string]string{"spanner": statusUp, "mastercard_pool": statusDown, "mastercard_stan": statusUp,
+				"visa_pool": statusUp, "visa_stan": statusUp},
+		},
+		{
+			name:   "all_schemes_down",
+			checks: map[string]Check{"spanner": pass},
+			schemeChecks: map[string]map[string]Check{
+				"mastercard": {"mastercard_pool": fail},
+				"visa":       {"visa_pool": pass, "visa_stan": fail},
+			},
+			wantCode:   http.StatusServiceUnavailable,
+			wantReady:  statusDown,
+			wantStatus: map[string]string{"spanner": statusUp, "mastercard_pool": statusDown, "visa_pool": statusUp, "visa_stan": statusDown},
+		},
+		{
+			name:   "one_scheme_degraded",
+			checks: map[string]Check{"spanner": pass},
+			schemeChecks: map[string]map[string]Check{
+				"mastercard": {"mastercard_pool": pass, "mastercard_circuit_breaker": open},
+				"visa":       {"visa_pool": pass, "visa_circuit_breaker": pass},
+			},
+			wantCode:  http.StatusOK,
+			wantReady: statusDegraded,
+			wantStatus: map[string]string{"spanner": statusUp, "mastercard_pool": statusUp, "mastercard_circuit_breaker": statusDegraded,
+				"visa_pool": statusUp, "visa_circuit_breaker": statusUp},
+		},
+		{
+			name:   "all_schemes_degraded",
+			checks: map[string]Check{"spanner": pass},
+			schemeChecks: map[string]map[string]Check{
+				"mastercard": {"mastercard_circuit_breaker": open},
+				"visa":       {"visa_circuit_breaker": open},
+			},
+			wantCode:   http.StatusOK,
+			wantReady:  statusDegraded,
+			wantStatus: map[string]string{"spanner": statusUp, "mastercard_circuit_breaker": statusDegraded, "visa_circuit_breaker": statusDegraded},
+		},
+		{
+			name:         "dependency_down_with_schemes_up",
+			checks:       map[string]Check{"spanner": fail},
+			schemeChecks: map[string]map[string]Check{"visa": {"visa_pool": pass}},
+			wantCode:     http.StatusServiceUnavailable,
+			wantReady:    statusDown,
+			wantStatus:   map[string]string{"spanner": statusDown, "visa_pool": statusUp},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProbesController(logging.Logger{}, 10*time.Millisecond)
+			for name, check := range tt.checks {
+				p.Register(name, check)
+			}
+			for scheme, checks := range tt.schemeChecks {
+				for name, check := range checks {
+					p.RegisterScheme(scheme, name, check)
+				}
+			}
+
+			isReady := &atomic.Value{}
+			isReady.Store(true)
+
+			w := httptest.NewRecorder()
+			p.Ready(isReady)(w, httptest.NewRequest(http.MethodGet, "/v1/probe/readiness", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Ready() code = %d, want %d", w.Code, tt.wantCode)
+			}
+
+			var got readinessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Ready() cannot parse response: %v", err)
+			}
+			if got.Status != tt.wantReady {
+				t.Errorf("Ready() status = %s, want %s", got.Status, tt.wantReady)
+			}
+			for _, c := range got.Checks {
+				if c.Status != tt.wantStatus[c.Name] {
+					t.Errorf("Ready() check %s = %s, want %s", c.Name, c.Status, tt.wantStatus[c.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		last    time.Time
+		wantErr bool
+	}{
+		{name: "recent", last: time.Now().Add(-time.Second)},
+		{name: "too_old", last: time.Now().Add(-time.Hour), wantErr: true},
+		{name: "never", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := MaxAge("answered echo", func() time.Time { return tt.last }, time.Minute)
+			if err := check(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("MaxAge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMinValidity(t *testing.T) {
+	tests := []struct {
+		name    string
+		expiry  time.Time
+		err     error
+		wantErr bool
+	}{
+		{name: "valid", expiry: time.Now().Add(30 * 24 * time.Hour)},
+		{name: "expires_soon", expiry: time.Now().Add(time.Hour), wantErr: true},
+		{name: "cannot_load", err: errors.New("no such file"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := MinValidity("certificates", func() (time.Time, error) { return tt.expiry, tt.err }, 14*24*time.Hour)
+			if err := check(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("MinValidity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package spanner
 
 import (
 	"context"
+	"fmt"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/api/option"
@@ -15,3 +16,15 @@ func NewSpannerClient(ctx context.Context, db string, poolSize int) (*spanner.Cl
 
 	return client, nil
 }
+
+// Ping runs a trivial query, it fails when the client cannot get a session or reach the database
+func Ping(ctx context.Context, client *spanner.Client) error {
+	iter := client.Single().Query(ctx, spanner.Statement{SQL: `SELECT 1`})
+	defer iter.Stop()
+
+	if _, err := iter.Next(); err != nil {
+		return fmt.Errorf("cannot query spanner: %w", err)
+	}
+
+	return nil
+}
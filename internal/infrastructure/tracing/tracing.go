@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "gitlab.cmpayments.local/creditcard/authorization"
+
+type Config struct {
+	Enabled     bool    `yaml:"enabled"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Setup installs the W3C trace context propagator and, when enabled, a tracer provider that exports
+// the spans over OTLP. The returned func flushes and stops the exporter.
+func Setup(ctx context.Context, conf Config, serviceName, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !conf.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(conf.Endpoint)}
+	if conf.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as child of the span in the context. Attributes must never hold card data.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Transport propagates the trace context on the requests of an HTTP client this service builds. A nil
+// base sends the requests over the default transport.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
+
+// HostTransport propagates the trace context on the requests to the hosts of baseURLs and sends the other
+// requests over base as they are. It is for the clients that cannot be given a transport and send their
+// requests over the default transport, without tracing every other user of that transport.
+func HostTransport(base http.RoundTripper, baseURLs ...string) (http.RoundTripper, error) {
+	hosts := map[string]bool{}
+	for _, baseURL := range baseURLs {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+		}
+		hosts[u.Host] = true
+	}
+
+	return hostTransport{hosts: hosts, base: base, traced: otelhttp.NewTransport(base)}, nil
+}
+
+type hostTransport struct {
+	hosts  map[string]bool
+	base   http.RoundTripper
+	traced http.RoundTripper
+}
+
+func (t hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.hosts[req.URL.Host] {
+		return t.traced.RoundTrip(req)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// Handler starts a server span for every request, continuing the trace of the caller. The span is
+// renamed to the route by the timing tracker.
+func Handler(handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, "authorization")
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHostTransport(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceparents := make(chan string, 1)
+	stub := func(w http.ResponseWriter, r *http.Request) {
+		traceparents <- r.Header.Get("traceparent")
+	}
+	tokenization := httptest.NewServer(http.HandlerFunc(stub))
+	defer tokenization.Close()
+	other := httptest.NewServer(http.HandlerFunc(stub))
+	defer other.Close()
+
+	transport, err := HostTransport(http.DefaultTransport, tokenization.URL+"/v1")
+	if err != nil {
+		t.Fatalf("HostTransport() error = %v", err)
+	}
+	client := http.Client{Transport: transport}
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	tests := []struct {
+		name      string
+		url       string
+		wantTrace bool
+	}{
+		{name: "platform_service", url: tokenization.URL + "/v1/tokens", wantTrace: true},
+		{name: "other_service", url: other.URL, wantTrace: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, tt.url, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			_ = resp.Body.Close()
+
+			got := <-traceparents
+			if (got != "") != tt.wantTrace {
+				t.Fatalf("traceparent = %q, want trace %t", got, tt.wantTrace)
+			}
+			if tt.wantTrace && got[3:35] != traceID.String() {
+				t.Errorf("traceparent = %q, want trace ID %s", got, traceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type JournalRepository struct {
+	client       *spanner.Client
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func NewJournalRepository(
+	client *spanner.Client,
+	readTimeout time.Duration,
+	writeTimeout time.Duration) *JournalRepository {
+	return &JournalRepository{
+		client:       client,
+		readTimeout:  readTimeout,
+		writeTimeout: writeTimeout,
+	}
+}
+
+func (jr JournalRepository) SaveMessages(ctx context.Context, messages []entity.JournalMessage) error {
+	statements := make([]spanner.Statement, 0, len(messages))
+	for _, m := range messages {
+		statements = append(statements, spanner.Statement{
+			SQL: `INSERT INTO message_journal (
+						transaction_id, message_id, transaction_type,
+						scheme, direction, mti,
+						stan, elements, created_at
+					) VALUES (
+						@transaction_id, @message_id, @transaction_type,
+						@scheme, @direction, @mti,
+						@stan, @elements, @created_at
+					)`,
+			Params: map[string]interface{}{
+				"transaction_id":   m.TransactionID.String(),
+				"message_id":       m.ID.String(),
+				"transaction_type": string(m.TransactionType),
+				"scheme":           m.Scheme,
+				"direction":        string(m.Direction),
+				"mti":              m.Mti,
+				"stan":             m.Stan,
+				"elements":         spanner.NullJSON{Value: m.Elements, Valid: true},
+				"created_at":       m.CreatedAt,
+			},
+		})
+	}
+
+	_, err := jr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, jr.writeTimeout)
+		defer cancel()
+
+		if _, err := txn.BatchUpdate(ctx, statements); err != nil {
+			return fmt.Errorf("failed to insert journal messages: %w", err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (jr JournalRepository) GetMessages(ctx context.Context, transactionID uuid.UUID) ([]entity.JournalMessage, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT transaction_id, message_id, transaction_type,
+			       scheme, direction, mti,
+			       stan, elements, created_at
+			FROM message_journal
+			WHERE transaction_id = @transaction_id
+			ORDER BY created_at
+		`,
+		Params: map[string]interface{}{
+			"transaction_id": transactionID.String(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jr.readTimeout)
+	defer cancel()
+
+	iter := jr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var messages []entity.JournalMessage
+	for {
+		row, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return messages, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var m messageRecord
+		if err = row.ToStruct(&m); err != nil {
+			return nil, err
+		}
+
+		message, err := mapMessageRecordToEntity(m)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+}
+
+type messageRecord struct {
+	TransactionID   string             `spanner:"transaction_id"`
+	MessageID       string             `spanner:"message_id"`
+	TransactionType string             `spanner:"transaction_type"`
+	Scheme          string             `spanner:"scheme"`
+	Direction       string             `spanner:"direction"`
+	Mti             string             `spanner:"mti"`
+	Stan            spanner.NullString `spanner:"stan"`
+	Elements        spanner.NullJSON   `spanner:"elements"`
+	CreatedAt       time.Time          `spanner:"created_at"`
+}
+
+func mapMessageRecordToEntity(m messageRecord) (entity.JournalMessage, error) {
+	elements, err := json.Marshal(m.Elements.Value)
+	if err != nil {
+		return entity.JournalMessage{}, err
+	}
+
+	return entity.JournalMessage{
+		ID:              uuid.MustParse(m.MessageID),
+		TransactionID:   uuid.MustParse(m.TransactionID),
+		TransactionType: entity.TransactionType(m.TransactionType),
+		Scheme:          m.Scheme,
+		Direction:       entity.MessageDirection(m.Direction),
+		Mti:             m.Mti,
+		Stan:            m.Stan.StringVal,
+		Elements:        elements,
+		CreatedAt:       m.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+func TestJournalRepository_GetMessages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spanner: skipping integration test")
+	}
+
+	client := spanner.NewTestDB(t, "./testdata/insert_journal")
+
+	repo := NewJournalRepository(
+		client,
+		3*time.Second,
+		5*time.Second,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	got, err := repo.GetMessages(ctx, uuid.MustParse("3c9e1a7d-5b42-4f8e-9d06-a1b2c3d4e5f6"))
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GetMessages() returned %d messages, want 2", len(got))
+	}
+	if got[0].Mti != "0100" || got[0].Direction != entity.Outbound || got[1].Mti != "0110" {
+		t.Errorf("GetMessages() = %s %s, %s, want the request before the response", got[0].Mti, got[0].Direction, got[1].Mti)
+	}
+}
+
+func TestJournalRepository_SaveMessages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spanner: skipping integration test")
+	}
+
+	client := spanner.NewTestDB(t, "./testdata/insert_journal")
+
+	repo := NewJournalRepository(
+		client,
+		3*time.Second,
+		5*time.Second,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	transactionID := uuid.MustParse("9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a")
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	messages := []entity.JournalMessage{
+		{
+			ID:              uuid.New(),
+			TransactionID:   transactionID,
+			TransactionType: entity.RefundTransaction,
+			Scheme:          entity.Visa,
+			Direction:       entity.Outbound,
+			Mti:             "0200",
+			Stan:            "654321",
+			Elements:        json.RawMessage(`{"F002_PrimaryAccountNumber":"41111111####1111"}`),
+			CreatedAt:       now,
+		},
+	}
+
+	if err := repo.SaveMessages(ctx, messages); err != nil {
+		t.Fatalf("SaveMessages() error = %v", err)
+	}
+
+	got, err := repo.GetMessages(ctx, transactionID)
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != messages[0].ID || string(got[0].Elements) != string(messages[0].Elements) {
+		t.Errorf("GetMessages() = %+v, want %+v", got, messages)
+	}
+}
@@ -0,0 +1 @@
+DELETE FROM message_journal WHERE transaction_id IN ("3c9e1a7d-5b42-4f8e-9d06-a1b2c3d4e5f6", "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a");
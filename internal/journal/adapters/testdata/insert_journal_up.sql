@@ -0,0 +1,4 @@
+INSERT INTO message_journal (transaction_id, message_id, transaction_type, scheme, direction, mti, stan, elements, created_at)
+VALUES ("3c9e1a7d-5b42-4f8e-9d06-a1b2c3d4e5f6", "7a1f2e3d-4c5b-4a69-8e7d-0f1e2d3c4b5a", "authorization", "mastercard", "inbound", "0110", "123456", JSON '{"DE2_PrimaryAccountNumber": "52000000####0008", "DE39_ResponseCode": "00"}', "2023-07-01T10:00:01Z");
+INSERT INTO message_journal (transaction_id, message_id, transaction_type, scheme, direction, mti, stan, elements, created_at)
+VALUES ("3c9e1a7d-5b42-4f8e-9d06-a1b2c3d4e5f6", "1b2c3d4e-5f6a-4b7c-8d9e-0a1b2c3d4e5f", "authorization", "mastercard", "outbound", "0100", "123456", JSON '{"DE2_PrimaryAccountNumber": "52000000####0008"}', "2023-07-01T10:00:00Z");
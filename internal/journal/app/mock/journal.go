@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type JournalRepo struct{}
+
+func (JournalRepo) SaveMessages(ctx context.Context, messages []entity.JournalMessage) error {
+	return nil
+}
+
+func (JournalRepo) GetMessages(ctx context.Context, transactionID uuid.UUID) ([]entity.JournalMessage, error) {
+	return nil, nil
+}
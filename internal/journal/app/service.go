@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cmpayments.local/creditcard/platform"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type Repository interface {
+	SaveMessages(ctx context.Context, messages []entity.JournalMessage) error
+	GetMessages(ctx context.Context, transactionID uuid.UUID) ([]entity.JournalMessage, error)
+}
+
+// JournalService keeps the ISO 8583 messages exchanged with the schemes for audit and disputes
+type JournalService struct {
+	log  platform.Logger
+	repo Repository
+}
+
+func NewJournalService(logger platform.Logger, repo Repository) JournalService {
+	return JournalService{
+		log:  logger,
+		repo: repo,
+	}
+}
+
+// Record stores the messages of an exchange. The transaction was sent to the scheme already, so a
+// failure to store the messages is logged and does not fail it.
+func (js JournalService) Record(ctx context.Context, messages ...entity.JournalMessage) {
+	now := time.Now()
+	for i := range messages {
+		messages[i].ID = uuid.New()
+		// The exchange is stored at once, the request is ordered before the response
+		messages[i].CreatedAt = now.Add(time.Duration(i) * time.Microsecond)
+	}
+
+	if err := js.repo.SaveMessages(ctx, messages); err != nil {
+		js.log.Error(ctx, fmt.Sprintf("failed to journal %d scheme messages: %s", len(messages), err.Error()))
+	}
+}
+
+// GetMessages returns the messages exchanged for the authorization, refund or reversal in the order
+// they were exchanged
+func (js JournalService) GetMessages(ctx context.Context, transactionID uuid.UUID) ([]entity.JournalMessage, error) {
+	messages, err := js.repo.GetMessages(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, entity.ErrRecordNotFound
+	}
+
+	return messages, nil
+}
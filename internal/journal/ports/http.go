@@ -0,0 +1,56 @@
+package ports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"gitlab.cmpayments.local/creditcard/platform"
+	platformErr "gitlab.cmpayments.local/creditcard/platform/http/errors"
+	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/journal/app"
+)
+
+// journalHandler shows the scheme messages of any transaction, regardless of the PSP, so the routes
+// must only be available to administrators.
+type journalHandler struct {
+	logger         platform.Logger
+	journalService app.JournalService
+}
+
+func NewJournalHandler(logger platform.Logger, journalService app.JournalService) *journalHandler {
+	return &journalHandler{
+		logger:         logger,
+		journalService: journalService,
+	}
+}
+
+func (h *journalHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	transactionID, err := uuid.Parse(params.ByName("transactionID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	messages, err := h.journalService.GetMessages(ctx, transactionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRecordNotFound):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, mapJournalResponse(messages), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
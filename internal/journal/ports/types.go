@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"encoding/json"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type messageResponse struct {
+	ID        string          `json:"id"`
+	Direction string          `json:"direction"`
+	Mti       string          `json:"mti"`
+	Stan      string          `json:"stan,omitempty"`
+	Elements  json.RawMessage `json:"elements"`
+	CreatedAt string          `json:"createdAt"`
+}
+
+type journalResponse struct {
+	TransactionID   string            `json:"transactionId"`
+	TransactionType string            `json:"transactionType"`
+	Scheme          string            `json:"scheme"`
+	Messages        []messageResponse `json:"messages"`
+}
+
+func mapJournalResponse(messages []entity.JournalMessage) journalResponse {
+	response := journalResponse{
+		TransactionID:   messages[0].TransactionID.String(),
+		TransactionType: string(messages[0].TransactionType),
+		Scheme:          messages[0].Scheme,
+		Messages:        make([]messageResponse, 0, len(messages)),
+	}
+
+	for _, m := range messages {
+		response.Messages = append(response.Messages, messageResponse{
+			ID:        m.ID.String(),
+			Direction: string(m.Direction),
+			Mti:       m.Mti,
+			Stan:      m.Stan,
+			Elements:  m.Elements,
+			CreatedAt: m.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,361 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	sql "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+type MerchantRepository struct {
+	client       *spanner.Client
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func NewMerchantRepository(
+	client *spanner.Client,
+	readTimeout time.Duration,
+	writeTimeout time.Duration) *MerchantRepository {
+	return &MerchantRepository{
+		client:       client,
+		readTimeout:  readTimeout,
+		writeTimeout: writeTimeout,
+	}
+}
+
+func (mr MerchantRepository) CreateMerchant(ctx context.Context, m entity.Merchant) error {
+	stmt := spanner.Statement{
+		SQL: `INSERT INTO merchants (
+					psp_id, merchant_id, card_acceptor_id,
+					name, city, postal_code,
+					country, category_code, created_at
+				) VALUES (
+					@psp_id, @merchant_id, @card_acceptor_id,
+					@name, @city, @postal_code,
+					@country, @category_code, @created_at
+				)`,
+		Params: mapMerchantParams(m),
+	}
+
+	_, err := mr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, mr.writeTimeout)
+		defer cancel()
+
+		_, err := txn.Update(ctx, stmt)
+
+		return err
+	})
+
+	return err
+}
+
+func (mr MerchantRepository) UpdateMerchant(ctx context.Context, m entity.Merchant) error {
+	stmt := spanner.Statement{
+		SQL: `UPDATE merchants
+				SET card_acceptor_id = @card_acceptor_id,
+					name = @name,
+					city = @city,
+					postal_code = @postal_code,
+					country = @country,
+					category_code = @category_code,
+					updated_at = @updated_at
+				WHERE psp_id = @psp_id
+				  AND merchant_id = @merchant_id`,
+		Params: mapMerchantParams(m),
+	}
+
+	_, err := mr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, mr.writeTimeout)
+		defer cancel()
+
+		rowCount, err := txn.Update(ctx, stmt)
+		if err != nil {
+			return fmt.Errorf("failed to update merchant: %w", err)
+		}
+		if rowCount != 1 {
+			return entity.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func mapMerchantParams(m entity.Merchant) map[string]interface{} {
+	return map[string]interface{}{
+		"psp_id":           m.PspID.String(),
+		"merchant_id":      m.ID.String(),
+		"card_acceptor_id": sql.NewNullString(m.CardAcceptor.ID),
+		"name":             sql.NewNullString(m.CardAcceptor.Name),
+		"city":             sql.NewNullString(m.CardAcceptor.Address.City),
+		"postal_code":      sql.NewNullString(m.CardAcceptor.Address.PostalCode),
+		"country":          sql.NewNullString(m.CardAcceptor.Address.CountryCode),
+		"category_code":    sql.NewNullString(m.CardAcceptor.CategoryCode),
+		"created_at":       m.CreatedAt,
+		"updated_at":       sql.NewNullTime(m.UpdatedAt),
+	}
+}
+
+func (mr MerchantRepository) DeleteMerchant(ctx context.Context, pspID, merchantID uuid.UUID) error {
+	stmt := spanner.Statement{
+		SQL: `DELETE FROM merchants
+				WHERE psp_id = @psp_id
+				  AND merchant_id = @merchant_id`,
+		Params: map[string]interface{}{
+			"psp_id":      pspID.String(),
+			"merchant_id": merchantID.String(),
+		},
+	}
+
+	_, err := mr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, mr.writeTimeout)
+		defer cancel()
+
+		rowCount, err := txn.Update(ctx, stmt)
+		if err != nil {
+			return fmt.Errorf("failed to delete merchant: %w", err)
+		}
+		if rowCount != 1 {
+			return entity.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (mr MerchantRepository) GetMerchant(ctx context.Context, pspID, merchantID uuid.UUID) (entity.Merchant, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT psp_id, merchant_id, card_acceptor_id,
+			       name, city, postal_code,
+			       country, category_code, created_at,
+			       updated_at
+			FROM merchants
+			WHERE psp_id = @psp_id
+			  AND merchant_id = @merchant_id
+		`,
+		Params: map[string]interface{}{
+			"psp_id":      pspID.String(),
+			"merchant_id": merchantID.String(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mr.readTimeout)
+	defer cancel()
+
+	iter := mr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return entity.Merchant{}, entity.ErrRecordNotFound
+		}
+		return entity.Merchant{}, err
+	}
+
+	var m merchantRecord
+	if err = row.ToStruct(&m); err != nil {
+		return entity.Merchant{}, err
+	}
+
+	return mapMerchantRecordToEntity(m), nil
+}
+
+func (mr MerchantRepository) GetAllMerchants(ctx context.Context, pspID uuid.UUID) ([]entity.Merchant, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT psp_id, merchant_id, card_acceptor_id,
+			       name, city, postal_code,
+			       country, category_code, created_at,
+			       updated_at
+			FROM merchants
+			WHERE psp_id = @psp_id
+			ORDER BY created_at
+		`,
+		Params: map[string]interface{}{
+			"psp_id": pspID.String(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mr.readTimeout)
+	defer cancel()
+
+	iter := mr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var merchants []entity.Merchant
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return merchants, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var m merchantRecord
+		if err = row.ToStruct(&m); err != nil {
+			return nil, err
+		}
+
+		merchants = append(merchants, mapMerchantRecordToEntity(m))
+	}
+}
+
+func (mr MerchantRepository) GetMerchantDefaults(ctx context.Context, pspID uuid.UUID) (entity.CardAcceptor, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT card_acceptor_id, name, city,
+			       postal_code, country, category_code
+			FROM merchant_defaults
+			WHERE psp_id = @psp_id
+		`,
+		Params: map[string]interface{}{
+			"psp_id": pspID.String(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mr.readTimeout)
+	defer cancel()
+
+	iter := mr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return entity.CardAcceptor{}, entity.ErrRecordNotFound
+		}
+		return entity.CardAcceptor{}, err
+	}
+
+	var ca cardAcceptorRecord
+	if err = row.ToStruct(&ca); err != nil {
+		return entity.CardAcceptor{}, err
+	}
+
+	return mapCardAcceptorRecordToEntity(ca), nil
+}
+
+// SaveMerchantDefaults updates the defaults of the PSP, or inserts them when the PSP has none yet
+func (mr MerchantRepository) SaveMerchantDefaults(ctx context.Context, pspID uuid.UUID, ca entity.CardAcceptor) error {
+	params := map[string]interface{}{
+		"psp_id":           pspID.String(),
+		"card_acceptor_id": sql.NewNullString(ca.ID),
+		"name":             sql.NewNullString(ca.Name),
+		"city":             sql.NewNullString(ca.Address.City),
+		"postal_code":      sql.NewNullString(ca.Address.PostalCode),
+		"country":          sql.NewNullString(ca.Address.CountryCode),
+		"category_code":    sql.NewNullString(ca.CategoryCode),
+		"now":              time.Now(),
+	}
+
+	update := spanner.Statement{
+		SQL: `UPDATE merchant_defaults
+				SET card_acceptor_id = @card_acceptor_id,
+					name = @name,
+					city = @city,
+					postal_code = @postal_code,
+					country = @country,
+					category_code = @category_code,
+					updated_at = @now
+				WHERE psp_id = @psp_id`,
+		Params: params,
+	}
+
+	insert := spanner.Statement{
+		SQL: `INSERT INTO merchant_defaults (
+					psp_id, card_acceptor_id, name,
+					city, postal_code, country,
+					category_code, created_at
+				) VALUES (
+					@psp_id, @card_acceptor_id, @name,
+					@city, @postal_code, @country,
+					@category_code, @now
+				)`,
+		Params: params,
+	}
+
+	_, err := mr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, mr.writeTimeout)
+		defer cancel()
+
+		rowCount, err := txn.Update(ctx, update)
+		if err != nil {
+			return fmt.Errorf("failed to update merchant defaults: %w", err)
+		}
+		if rowCount == 1 {
+			return nil
+		}
+
+		_, err = txn.Update(ctx, insert)
+
+		return err
+	})
+
+	return err
+}
+
+type cardAcceptorRecord struct {
+	CardAcceptorID spanner.NullString `spanner:"card_acceptor_id"`
+	Name           spanner.NullString `spanner:"name"`
+	City           spanner.NullString `spanner:"city"`
+	PostalCode     spanner.NullString `spanner:"postal_code"`
+	Country        spanner.NullString `spanner:"country"`
+	CategoryCode   spanner.NullString `spanner:"category_code"`
+}
+
+type merchantRecord struct {
+	PspID          string             `spanner:"psp_id"`
+	MerchantID     string             `spanner:"merchant_id"`
+	CardAcceptorID spanner.NullString `spanner:"card_acceptor_id"`
+	Name           spanner.NullString `spanner:"name"`
+	City           spanner.NullString `spanner:"city"`
+	PostalCode     spanner.NullString `spanner:"postal_code"`
+	Country        spanner.NullString `spanner:"country"`
+	CategoryCode   spanner.NullString `spanner:"category_code"`
+	CreatedAt      time.Time          `spanner:"created_at"`
+	UpdatedAt      spanner.NullTime   `spanner:"updated_at"`
+}
+
+func mapMerchantRecordToEntity(m merchantRecord) entity.Merchant {
+	return entity.Merchant{
+		ID:    uuid.MustParse(m.MerchantID),
+		PspID: uuid.MustParse(m.PspID),
+		CardAcceptor: mapCardAcceptorRecordToEntity(cardAcceptorRecord{
+			CardAcceptorID: m.CardAcceptorID,
+			Name:           m.Name,
+			City:           m.City,
+			PostalCode:     m.PostalCode,
+			Country:        m.Country,
+			CategoryCode:   m.CategoryCode,
+		}),
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt.Time,
+	}
+}
+
+func mapCardAcceptorRecordToEntity(ca cardAcceptorRecord) entity.CardAcceptor {
+	return entity.CardAcceptor{
+		CategoryCode: ca.CategoryCode.StringVal,
+		ID:           ca.CardAcceptorID.StringVal,
+		Name:         ca.Name.StringVal,
+		Address: entity.CardAcceptorAddress{
+			PostalCode:  ca.PostalCode.StringVal,
+			City:        ca.City.StringVal,
+			CountryCode: ca.Country.StringVal,
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+func TestMerchantRepository_GetMerchant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spanner: skipping integration test")
+	}
+
+	client := spanner.NewTestDB(t, "./testdata/insert_merchant")
+
+	repo := NewMerchantRepository(
+		client,
+		3*time.Second,
+		5*time.Second,
+	)
+
+	tests := []struct {
+		name       string
+		pspID      uuid.UUID
+		merchantID uuid.UUID
+		wantName   string
+		wantedErr  error
+	}{
+		{
+			name:       "get_merchant",
+			pspID:      uuid.MustParse("4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2"),
+			merchantID: uuid.MustParse("2ef864ea-5f4c-439d-8e99-32d286d89553"),
+			wantName:   "MaxCorp Inc.",
+			wantedErr:  nil,
+		},
+		{
+			name:       "merchant_of_other_psp",
+			pspID:      uuid.MustParse("1779edcd-4f14-4c97-a61e-29a827e7ed89"),
+			merchantID: uuid.MustParse("2ef864ea-5f4c-439d-8e99-32d286d89553"),
+			wantedErr:  entity.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			m, err := repo.GetMerchant(ctx, tt.pspID, tt.merchantID)
+			if !errors.Is(err, tt.wantedErr) {
+				t.Fatalf("got error %v, wanted %v", err, tt.wantedErr)
+			}
+			if m.CardAcceptor.Name != tt.wantName {
+				t.Errorf("got name %q, wanted %q", m.CardAcceptor.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestMerchantRepository_SaveMerchantDefaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spanner: skipping integration test")
+	}
+
+	client := spanner.NewTestDB(t, "./testdata/insert_merchant")
+
+	repo := NewMerchantRepository(
+		client,
+		3*time.Second,
+		5*time.Second,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pspID := uuid.MustParse("4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2")
+	defaults := entity.CardAcceptor{
+		CategoryCode: "5411",
+		Address:      entity.CardAcceptorAddress{CountryCode: "NLD"},
+	}
+
+	if err := repo.SaveMerchantDefaults(ctx, pspID, defaults); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.GetMerchantDefaults(ctx, pspID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != defaults {
+		t.Errorf("got %v, wanted %v", got, defaults)
+	}
+}
@@ -0,0 +1,6 @@
+DELETE FROM merchant_defaults
+WHERE psp_id = '4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2';
+DELETE FROM merchants
+WHERE psp_id = '4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2';
+DELETE FROM psp
+WHERE psp_id = '4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2';
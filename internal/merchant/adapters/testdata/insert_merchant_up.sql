@@ -0,0 +1,6 @@
+INSERT INTO psp (psp_id, name, prefix)
+VALUES ('4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2', 'merchant_test_psp', 'mtp');
+INSERT INTO merchants (psp_id, merchant_id, card_acceptor_id, name, city, postal_code, country, category_code, created_at)
+VALUES ('4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2', '2ef864ea-5f4c-439d-8e99-32d286d89553', '0987654321', 'MaxCorp Inc.', 'Breda', '4825 AN', 'NLD', '5691', '2023-07-01 12:00:00');
+INSERT INTO merchant_defaults (psp_id, name, category_code, created_at)
+VALUES ('4b5a4a4a-0c26-4a3c-9c3f-38d1bfb1a7f2', 'MaxCorp', '5999', '2023-07-01 12:00:00');
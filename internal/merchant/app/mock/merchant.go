@@ -0,0 +1,55 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type MerchantRepo struct{}
+
+func (MerchantRepo) CreateMerchant(ctx context.Context, m entity.Merchant) error {
+	return nil
+}
+
+func (MerchantRepo) GetMerchant(ctx context.Context, pspID, merchantID uuid.UUID) (entity.Merchant, error) {
+	return entity.Merchant{
+		ID:    merchantID,
+		PspID: pspID,
+		CardAcceptor: entity.CardAcceptor{
+			CategoryCode: "5691",
+			ID:           "0987654321",
+			Name:         "MaxCorp Inc.",
+			Address: entity.CardAcceptorAddress{
+				PostalCode:  "4825 AN",
+				City:        "Breda",
+				CountryCode: "NLD",
+			},
+		},
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (r MerchantRepo) GetAllMerchants(ctx context.Context, pspID uuid.UUID) ([]entity.Merchant, error) {
+	m, _ := r.GetMerchant(ctx, pspID, uuid.New())
+	return []entity.Merchant{m}, nil
+}
+
+func (MerchantRepo) UpdateMerchant(ctx context.Context, m entity.Merchant) error {
+	return nil
+}
+
+func (MerchantRepo) DeleteMerchant(ctx context.Context, pspID, merchantID uuid.UUID) error {
+	return nil
+}
+
+func (MerchantRepo) GetMerchantDefaults(ctx context.Context, pspID uuid.UUID) (entity.CardAcceptor, error) {
+	return entity.CardAcceptor{}, entity.ErrRecordNotFound
+}
+
+func (MerchantRepo) SaveMerchantDefaults(ctx context.Context, pspID uuid.UUID, ca entity.CardAcceptor) error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cmpayments.local/creditcard/platform"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type Repository interface {
+	CreateMerchant(ctx context.Context, m entity.Merchant) error
+	GetMerchant(ctx context.Context, pspID, merchantID uuid.UUID) (entity.Merchant, error)
+	GetAllMerchants(ctx context.Context, pspID uuid.UUID) ([]entity.Merchant, error)
+	UpdateMerchant(ctx context.Context, m entity.Merchant) error
+	DeleteMerchant(ctx context.Context, pspID, merchantID uuid.UUID) error
+	GetMerchantDefaults(ctx context.Context, pspID uuid.UUID) (entity.CardAcceptor, error)
+	SaveMerchantDefaults(ctx context.Context, pspID uuid.UUID, ca entity.CardAcceptor) error
+}
+
+type MerchantService struct {
+	log  platform.Logger
+	repo Repository
+}
+
+func NewMerchantService(logger platform.Logger, repo Repository) MerchantService {
+	return MerchantService{
+		log:  logger,
+		repo: repo,
+	}
+}
+
+func (ms MerchantService) CreateMerchant(ctx context.Context, m *entity.Merchant) error {
+	m.ID = uuid.New()
+	m.CreatedAt = time.Now()
+
+	if err := ms.repo.CreateMerchant(ctx, *m); err != nil {
+		return fmt.Errorf("failed to store merchant: %w", err)
+	}
+
+	ms.log.Info(ctx, "merchant created")
+
+	return nil
+}
+
+func (ms MerchantService) GetMerchant(ctx context.Context, pspID, merchantID uuid.UUID) (entity.Merchant, error) {
+	return ms.repo.GetMerchant(ctx, pspID, merchantID)
+}
+
+func (ms MerchantService) GetMerchants(ctx context.Context, pspID uuid.UUID) ([]entity.Merchant, error) {
+	return ms.repo.GetAllMerchants(ctx, pspID)
+}
+
+func (ms MerchantService) UpdateMerchant(ctx context.Context, m *entity.Merchant) error {
+	current, err := ms.repo.GetMerchant(ctx, m.PspID, m.ID)
+	if err != nil {
+		return err
+	}
+
+	m.CreatedAt = current.CreatedAt
+	m.UpdatedAt = time.Now()
+
+	if err = ms.repo.UpdateMerchant(ctx, *m); err != nil {
+		return fmt.Errorf("failed to update merchant: %w", err)
+	}
+
+	ms.log.Info(ctx, "merchant updated")
+
+	return nil
+}
+
+func (ms MerchantService) DeleteMerchant(ctx context.Context, pspID, merchantID uuid.UUID) error {
+	if err := ms.repo.DeleteMerchant(ctx, pspID, merchantID); err != nil {
+		return err
+	}
+
+	ms.log.Info(ctx, "merchant deleted")
+
+	return nil
+}
+
+func (ms MerchantService) GetMerchantDefaults(ctx context.Context, pspID uuid.UUID) (entity.CardAcceptor, error) {
+	return ms.repo.GetMerchantDefaults(ctx, pspID)
+}
+
+func (ms MerchantService) SaveMerchantDefaults(ctx context.Context, pspID uuid.UUID, ca entity.CardAcceptor) error {
+	if err := ms.repo.SaveMerchantDefaults(ctx, pspID, ca); err != nil {
+		return fmt.Errorf("failed to store merchant defaults: %w", err)
+	}
+
+	ms.log.Info(ctx, "merchant defaults saved")
+
+	return nil
+}
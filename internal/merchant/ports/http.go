@@ -0,0 +1,239 @@
+package ports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"gitlab.cmpayments.local/creditcard/platform"
+	platformErr "gitlab.cmpayments.local/creditcard/platform/http/errors"
+	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/merchant/app"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
+)
+
+type merchantHandler struct {
+	logger          platform.Logger
+	merchantService app.MerchantService
+}
+
+func NewMerchantHandler(logger platform.Logger, merchantService app.MerchantService) *merchantHandler {
+	return &merchantHandler{
+		logger:          logger,
+		merchantService: merchantService,
+	}
+}
+
+func (h *merchantHandler) CreateMerchant(w http.ResponseWriter, r *http.Request) {
+	input := merchantRequest{}
+	ctx := r.Context()
+
+	if err := platformhandler.ReadJSON(w, r, &input); err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	v := validator.New()
+
+	input.validate(v)
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return
+	}
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	merchant := entity.Merchant{
+		PspID:        psp.ID,
+		CardAcceptor: mapCardAcceptor(input.CardAcceptor),
+	}
+
+	if err := h.merchantService.CreateMerchant(ctx, &merchant); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	if err := platformhandler.WriteJSON(w, http.StatusCreated, mapMerchantResponse(merchant), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *merchantHandler) GetMerchant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	merchantID, err := uuid.Parse(params.ByName("merchantID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	merchant, err := h.merchantService.GetMerchant(ctx, psp.ID, merchantID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRecordNotFound):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, mapMerchantResponse(merchant), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *merchantHandler) GetMerchants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	merchants, err := h.merchantService.GetMerchants(ctx, psp.ID)
+	if err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	response := MerchantsResponse{
+		Merchants: []merchantResponse{},
+	}
+
+	for _, m := range merchants {
+		response.Merchants = append(response.Merchants, mapMerchantResponse(m))
+	}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *merchantHandler) UpdateMerchant(w http.ResponseWriter, r *http.Request) {
+	input := merchantRequest{}
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	merchantID, err := uuid.Parse(params.ByName("merchantID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	if err = platformhandler.ReadJSON(w, r, &input); err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	v := validator.New()
+
+	input.validate(v)
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return
+	}
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	merchant := entity.Merchant{
+		ID:           merchantID,
+		PspID:        psp.ID,
+		CardAcceptor: mapCardAcceptor(input.CardAcceptor),
+	}
+
+	if err = h.merchantService.UpdateMerchant(ctx, &merchant); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRecordNotFound):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, mapMerchantResponse(merchant), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *merchantHandler) DeleteMerchant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	merchantID, err := uuid.Parse(params.ByName("merchantID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	if err = h.merchantService.DeleteMerchant(ctx, psp.ID, merchantID); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRecordNotFound):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *merchantHandler) GetMerchantDefaults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	defaults, err := h.merchantService.GetMerchantDefaults(ctx, psp.ID)
+	if err != nil && !errors.Is(err, entity.ErrRecordNotFound) {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	response := merchantDefaultsResponse{CardAcceptor: mapCardAcceptorResponse(defaults)}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *merchantHandler) UpdateMerchantDefaults(w http.ResponseWriter, r *http.Request) {
+	input := merchantDefaultsRequest{}
+	ctx := r.Context()
+
+	if err := platformhandler.ReadJSON(w, r, &input); err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	v := validator.New()
+
+	input.validate(v)
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return
+	}
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	if err := h.merchantService.SaveMerchantDefaults(ctx, psp.ID, mapCardAcceptor(input.CardAcceptor)); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	response := merchantDefaultsResponse{CardAcceptor: input.CardAcceptor}
+
+	if err := platformhandler.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
@@ -0,0 +1,112 @@
+package ports
+
+import (
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/categorycode"
+	"gitlab.cmpayments.local/creditcard/platform/countrycode"
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+// CardAcceptor holds the card acceptor values configured on a level. Empty values are
+// resolved from the less specific levels when an authorization is made.
+type CardAcceptor struct {
+	ID           string `json:"id,omitempty"`
+	CategoryCode string `json:"categoryCode,omitempty"`
+	Name         string `json:"name,omitempty"`
+	City         string `json:"city,omitempty"`
+	Country      string `json:"country,omitempty"`
+	PostalCode   string `json:"postalCode,omitempty"`
+}
+
+func (ca CardAcceptor) validate(v *validator.Validator) {
+	if ca.CategoryCode != "" {
+		_, ok := categorycode.MCCS[ca.CategoryCode]
+		v.Check(ok, "cardAcceptor.categoryCode", []string{"merchant category code not found."})
+	}
+	v.Check(len(ca.ID) <= 12, "cardAcceptor.id", []string{"cardAcceptor id must be max 12 characters long"})
+	v.Check(len(ca.Name) <= 22, "cardAcceptor.name", []string{"cardAcceptor name must be max 22 characters long"})
+	v.Check(len(ca.City) <= 13, "cardAcceptor.city", []string{"cardAcceptor city must be max 13 characters long"})
+	v.Check(len(ca.PostalCode) <= 10, "cardAcceptor.postalCode", []string{"postal code must be max 10 characters long"})
+	if ca.Country != "" {
+		c, err := countrycode.GetCountry(ca.Country)
+		if err != nil {
+			v.AddError("cardAcceptor.country", []string{"invalid cardAcceptor country"})
+		}
+
+		if !c.EEACountry() && err == nil {
+			v.AddError("cardAcceptor.country", []string{"cardAcceptor country not allowed, must be in EEA"})
+		}
+	}
+}
+
+type merchantRequest struct {
+	CardAcceptor CardAcceptor `json:"cardAcceptor"`
+}
+
+func (m merchantRequest) validate(v *validator.Validator) {
+	m.CardAcceptor.validate(v)
+}
+
+type merchantResponse struct {
+	ID           string       `json:"id"`
+	CardAcceptor CardAcceptor `json:"cardAcceptor"`
+	CreatedAt    string       `json:"createdAt"`
+	UpdatedAt    string       `json:"updatedAt,omitempty"`
+}
+
+type MerchantsResponse struct {
+	Merchants []merchantResponse `json:"merchants"`
+}
+
+type merchantDefaultsRequest struct {
+	CardAcceptor CardAcceptor `json:"cardAcceptor"`
+}
+
+func (m merchantDefaultsRequest) validate(v *validator.Validator) {
+	m.CardAcceptor.validate(v)
+}
+
+type merchantDefaultsResponse struct {
+	CardAcceptor CardAcceptor `json:"cardAcceptor"`
+}
+
+func mapCardAcceptor(ca CardAcceptor) entity.CardAcceptor {
+	return entity.CardAcceptor{
+		CategoryCode: ca.CategoryCode,
+		ID:           ca.ID,
+		Name:         ca.Name,
+		Address: entity.CardAcceptorAddress{
+			PostalCode:  ca.PostalCode,
+			City:        ca.City,
+			CountryCode: ca.Country,
+		},
+	}
+}
+
+func mapCardAcceptorResponse(ca entity.CardAcceptor) CardAcceptor {
+	return CardAcceptor{
+		ID:           ca.ID,
+		CategoryCode: ca.CategoryCode,
+		Name:         ca.Name,
+		City:         ca.Address.City,
+		Country:      ca.Address.CountryCode,
+		PostalCode:   ca.Address.PostalCode,
+	}
+}
+
+func mapMerchantResponse(m entity.Merchant) merchantResponse {
+	response := merchantResponse{
+		ID:           m.ID.String(),
+		CardAcceptor: mapCardAcceptorResponse(m.CardAcceptor),
+		CreatedAt:    m.CreatedAt.Format(time.RFC3339),
+	}
+
+	if !m.UpdatedAt.IsZero() {
+		response.UpdatedAt = m.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+)
+
+func TestValidateMerchantCardAcceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      CardAcceptor
+		wanted map[string][]string
+	}{
+		{
+			name: "complete card acceptor",
+			c: CardAcceptor{
+				ID:           "987288762212",
+				CategoryCode: "0742",
+				Name:         "Max Crop",
+				City:         "Breda",
+				Country:      "NLD",
+				PostalCode:   "4876CA",
+			},
+			wanted: nil,
+		},
+		{
+			name:   "empty values are resolved from defaults",
+			c:      CardAcceptor{Name: "Max Crop"},
+			wanted: nil,
+		},
+		{
+			name: "country outside EEA",
+			c:    CardAcceptor{Country: "USA"},
+			wanted: map[string][]string{
+				"cardAcceptor.country": {
+					0: "cardAcceptor country not allowed, must be in EEA",
+				},
+			},
+		},
+		{
+			name: "unknown category code",
+			c:    CardAcceptor{CategoryCode: "0000"},
+			wanted: map[string][]string{
+				"cardAcceptor.categoryCode": {
+					0: "merchant category code not found.",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			tt.c.validate(v)
+
+			if tt.wanted == nil && v.Valid() {
+				return
+			}
+
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("got %v, wanted %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/pos"
+	mapping "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+type PayoutRepository struct {
+	client       *spanner.Client
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func NewPayoutRepository(
+	client *spanner.Client,
+	readTimeout time.Duration,
+	writeTimeout time.Duration) *PayoutRepository {
+	return &PayoutRepository{
+		client:       client,
+		readTimeout:  readTimeout,
+		writeTimeout: writeTimeout,
+	}
+}
+
+// CreatePayout stores the payout before it is sent. The account numbers of the sender and the recipient are not
+// stored.
+func (pr PayoutRepository) CreatePayout(ctx context.Context, p entity.Payout) error {
+	_, err := pr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		statement := spanner.Statement{
+			SQL: `
+				INSERT INTO payouts (
+					payout_id, masked_pan, pan_token_id,
+					amount, currency, localdatetime,
+					customer_reference, psp_id, card_acceptor_id,
+					card_acceptor_name, card_acceptor_postal_code, card_acceptor_city,
+					card_acceptor_country, card_acceptor_category_code, created_at,
+					status, card_scheme, card_issuer_id,
+					card_issuer_name, card_issuer_countrycode, sender_name,
+					sender_country, recipient_name
+				) VALUES (
+					@payout_id, @masked_pan, @pan_token_id,
+					@amount, @currency, @localdatetime,
+					@customer_reference, @psp_id, @card_acceptor_id,
+					@card_acceptor_name, @card_acceptor_postal_code, @card_acceptor_city,
+					@card_acceptor_country, @card_acceptor_category_code, @created_at,
+					'new', @card_scheme, @card_issuer_id,
+					@card_issuer_name, @card_issuer_countrycode, @sender_name,
+					@sender_country, @recipient_name
+				)`,
+			Params: mapCreatePayoutParams(p),
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, pr.writeTimeout)
+		defer cancel()
+
+		_, err := txn.Update(ctx, statement)
+
+		return err
+	})
+
+	return err
+}
+
+func mapCreatePayoutParams(p entity.Payout) map[string]interface{} {
+	return map[string]interface{}{
+		"payout_id":                   p.ID.String(),
+		"masked_pan":                  p.Card.MaskedPan,
+		"pan_token_id":                p.Card.PanTokenID,
+		"card_scheme":                 p.Card.Info.Scheme,
+		"amount":                      p.Amount,
+		"currency":                    p.Currency.Alpha3(),
+		"localdatetime":               p.LocalTransactionDateTime,
+		"created_at":                  time.Now(),
+		"customer_reference":          mapping.NewNullString(p.CustomerReference),
+		"psp_id":                      p.Psp.ID.String(),
+		"card_issuer_id":              mapping.NewNullString(p.Card.Info.IssuerID),
+		"card_issuer_name":            mapping.NewNullString(p.Card.Info.IssuerName),
+		"card_issuer_countrycode":     mapping.NewNullString(p.Card.Info.IssuerCountryCode),
+		"card_acceptor_name":          p.CardAcceptor.Name,
+		"card_acceptor_city":          p.CardAcceptor.Address.City,
+		"card_acceptor_country":       p.CardAcceptor.Address.CountryCode,
+		"card_acceptor_id":            p.CardAcceptor.ID,
+		"card_acceptor_postal_code":   p.CardAcceptor.Address.PostalCode,
+		"card_acceptor_category_code": p.CardAcceptor.CategoryCode,
+		"sender_name":                 p.Sender.Name(),
+		"sender_country":              p.Sender.CountryCode,
+		"recipient_name":              p.Recipient.Name(),
+	}
+}
+
+func (pr PayoutRepository) CreateMastercardPayout(ctx context.Context, p entity.Payout) error {
+	stmt := spanner.NewStatement(`
+		INSERT INTO mastercard_payouts (
+			payout_id, created_at, network_reporting_date,
+			financial_network_code, banknet_reference_number, authorization_id_response
+		) VALUES (
+			@payout_id, @created_at, @network_reporting_date,
+			@financial_network_code, @banknet_reference_number, @authorization_id_response
+		)
+	`)
+
+	stmt.Params["payout_id"] = p.ID.String()
+	stmt.Params["created_at"] = time.Now()
+	stmt.Params["network_reporting_date"] = mapping.NewNullString(p.MastercardSchemeData.Response.TraceID.NetworkReportingDate)
+	stmt.Params["financial_network_code"] = mapping.NewNullString(p.MastercardSchemeData.Response.TraceID.FinancialNetworkCode)
+	stmt.Params["banknet_reference_number"] = mapping.NewNullString(p.MastercardSchemeData.Response.TraceID.BanknetReferenceNumber)
+	stmt.Params["authorization_id_response"] = mapping.NewNullString(p.CardSchemeData.Response.AuthorizationIDResponse)
+
+	_, err := pr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, pr.writeTimeout)
+		defer cancel()
+
+		_, err := txn.Update(ctx, stmt)
+
+		return err
+	})
+
+	return err
+}
+
+func (pr PayoutRepository) CreateVisaPayout(ctx context.Context, p entity.Payout) error {
+	stmt := spanner.NewStatement(`
+		INSERT INTO visa_payouts (payout_id, created_at, transaction_identifier)
+		VALUES
+		    (@payout_id, @created_at, @transaction_identifier)
+	`)
+
+	stmt.Params["payout_id"] = p.ID.String()
+	stmt.Params["created_at"] = time.Now()
+	stmt.Params["transaction_identifier"] = p.VisaSchemeData.Response.TransactionId
+
+	_, err := pr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, pr.writeTimeout)
+		defer cancel()
+
+		_, err := txn.Update(ctx, stmt)
+
+		return err
+	})
+
+	return err
+}
+
+func (pr PayoutRepository) UpdatePayoutResponse(ctx context.Context, p entity.Payout) error {
+	stmt := spanner.Statement{
+		SQL: `UPDATE payouts
+				SET status = @status,
+					system_trace_audit_number = @system_trace_audit_number,
+					updated_at = @updated_at,
+					authorization_id_response = @authorization_id_response,
+					retrieval_reference_number = @retrieval_reference_number,
+					response_code = @response_code,
+					transmitted_at = @transmitted_at,
+					cardholder_transaction_type_code = @cardholder_transaction_type_code,
+					point_of_service_pan_entry_mode = @point_of_service_pan_entry_mode
+				WHERE payout_id = @payout_id`,
+		Params: mapUpdatePayoutResponseParams(p),
+	}
+
+	_, err := pr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, pr.writeTimeout)
+		defer cancel()
+
+		rowCount, err := txn.Update(ctx, stmt)
+		if err != nil {
+			return fmt.Errorf("failed to update payout: %w", err)
+		}
+		if rowCount != 1 {
+			return fmt.Errorf("no record found with ID: %s", p.ID.String())
+		}
+
+		return err
+	})
+
+	return err
+}
+
+func mapUpdatePayoutResponseParams(p entity.Payout) map[string]interface{} {
+	return map[string]interface{}{
+		"payout_id":                        p.ID.String(),
+		"status":                           p.CardSchemeData.Response.Status.String(),
+		"system_trace_audit_number":        p.Stan,
+		"updated_at":                       time.Now(),
+		"response_code":                    mapping.NewNullString(p.CardSchemeData.Response.ResponseCode.Value),
+		"authorization_id_response":        mapping.NewNullString(p.CardSchemeData.Response.AuthorizationIDResponse),
+		"retrieval_reference_number":       mapping.NewNullString(p.CardSchemeData.Request.RetrievalReferenceNumber),
+		"transmitted_at":                   p.ProcessingDate,
+		"cardholder_transaction_type_code": p.CardSchemeData.Request.ProcessingCode.TransactionTypeCode,
+		"point_of_service_pan_entry_mode":  pos.PanEntryCode(p.CardSchemeData.Request.POSEntryMode.PanEntryMode),
+	}
+}
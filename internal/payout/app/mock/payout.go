@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type PayoutRepo struct{}
+
+func (PayoutRepo) CreatePayout(ctx context.Context, p entity.Payout) error {
+	return nil
+}
+
+func (PayoutRepo) CreateMastercardPayout(ctx context.Context, p entity.Payout) error {
+	return nil
+}
+
+func (PayoutRepo) CreateVisaPayout(ctx context.Context, p entity.Payout) error {
+	return nil
+}
+
+func (PayoutRepo) UpdatePayoutResponse(ctx context.Context, p entity.Payout) error {
+	return nil
+}
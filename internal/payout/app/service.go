@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"gitlab.cmpayments.local/creditcard/platform"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
+)
+
+type Tokenizer interface {
+	Tokenize(ctx context.Context, merchantID string, card entity.Card) (string, error)
+}
+
+type Repository interface {
+	CreatePayout(ctx context.Context, p entity.Payout) error
+	CreateMastercardPayout(ctx context.Context, p entity.Payout) error
+	CreateVisaPayout(ctx context.Context, p entity.Payout) error
+	UpdatePayoutResponse(ctx context.Context, p entity.Payout) error
+}
+
+type PolicyEvaluator interface {
+	EvaluatePayout(ctx context.Context, p entity.Payout) error
+}
+
+type PayoutService struct {
+	log       platform.Logger
+	repo      Repository
+	tokenizer Tokenizer
+	policy    PolicyEvaluator
+	mapper    *authorization.Mapper
+}
+
+func NewPayoutService(
+	logger platform.Logger,
+	repo Repository,
+	tokenizer Tokenizer,
+	policy PolicyEvaluator,
+	mapper *authorization.Mapper,
+) PayoutService {
+	return PayoutService{
+		log:       logger,
+		repo:      repo,
+		tokenizer: tokenizer,
+		policy:    policy,
+		mapper:    mapper,
+	}
+}
+
+// Authorize sends the payout to the card scheme of the recipient's card and stores it with the response
+func (ps PayoutService) Authorize(ctx context.Context, p *entity.Payout) error {
+	err := ps.policy.EvaluatePayout(ctx, *p)
+	if err != nil {
+		return fmt.Errorf("payout not allowed by policy: %w", err)
+	}
+
+	p.Card.PanTokenID, err = ps.tokenizer.Tokenize(ctx, p.Psp.ID.String(), p.Card)
+	if err != nil {
+		return fmt.Errorf("failed to tokenize consumer token: %w", err)
+	}
+
+	ps.log.Info(ctx, "tokenized")
+
+	err = ps.repo.CreatePayout(ctx, *p)
+	if err != nil {
+		return fmt.Errorf("failed to store payout: %w", err)
+	}
+
+	ps.log.Info(ctx, "record inserted")
+
+	err = ps.mapper.SendPayout(ctx, p)
+	if err != nil {
+		return fmt.Errorf("failed to send payout to card scheme: %w", err)
+	}
+
+	ps.log.Info(ctx, "payout send")
+
+	switch p.Card.Info.Scheme {
+	case entity.Mastercard:
+		err = ps.repo.CreateMastercardPayout(ctx, *p)
+		if err != nil {
+			return fmt.Errorf("failed to store mastercard payout: %w", err)
+		}
+	case entity.Visa:
+		err = ps.repo.CreateVisaPayout(ctx, *p)
+		if err != nil {
+			return fmt.Errorf("failed to store visa payout: %w", err)
+		}
+	}
+
+	err = ps.repo.UpdatePayoutResponse(ctx, *p)
+	if err != nil {
+		return fmt.Errorf("failed to update payout with response: %w", err)
+	}
+
+	return nil
+}
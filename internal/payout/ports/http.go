@@ -0,0 +1,191 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	liblogging "gitlab.cmpayments.local/libraries-go/logging"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tokenization"
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+	"github.com/google/uuid"
+	"gitlab.cmpayments.local/creditcard/platform"
+	platformErr "gitlab.cmpayments.local/creditcard/platform/http/errors"
+	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
+	"gitlab.cmpayments.local/creditcard/platform/http/logging"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/payout/app"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/cardinfo"
+	httpErrors "gitlab.cmpayments.local/creditcard/authorization/pkg/web/errors"
+)
+
+type payoutHandler struct {
+	logger          platform.Logger
+	cardNumberGuard *cardinfo.CardNumberGuard
+	payoutService   app.PayoutService
+	cardRanges      *cardinfo.Collection
+}
+
+func NewPayoutHandler(cardNumberGuard *cardinfo.CardNumberGuard, logger platform.Logger, payoutService app.PayoutService, cardRanges *cardinfo.Collection) *payoutHandler {
+	return &payoutHandler{
+		cardNumberGuard: cardNumberGuard,
+		logger:          logger,
+		payoutService:   payoutService,
+		cardRanges:      cardRanges,
+	}
+}
+
+func (h *payoutHandler) CreatePayout(w http.ResponseWriter, r *http.Request) {
+	input := payoutRequest{}
+	ctx := r.Context()
+
+	h.logger.Info(ctx, "create payout")
+
+	if err := platformhandler.ReadJSON(w, r, &input); err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	cardInfo, ok := h.cardRanges.Find(input.Card.Number)
+	if ok {
+		input.Card.scheme = cardInfo.Scheme
+	}
+
+	v := validator.New()
+
+	input.validate(v)
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return
+	}
+
+	if err := h.cardNumberGuard.Check(ctx, input.Card.Number); err != nil {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"card.number": {err.Error()}})
+		return
+	}
+
+	if !ok {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"card.number": {"unknown card range"}})
+		return
+	}
+
+	psp, _ := processing.PSPFromContext(ctx)
+
+	payout := mapPayoutRequest(ctx, psp, input, cardInfo)
+
+	err := h.payoutService.Authorize(ctx, &payout)
+	if err != nil {
+		var violation entity.PolicyViolation
+		var unavailable *authorization.UnavailableError
+		switch {
+		case errors.As(err, &violation):
+			h.logger.Info(ctx, violation.Error())
+			platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{violation.Field: {violation.Reason}})
+			return
+		case errors.As(err, &unavailable):
+			httpErrors.ServiceUnavailableResponse(ctx, w, h.logger, err, unavailable.RetryAfter)
+			return
+		case errors.Is(err, tokenization.ErrFailedTokenize):
+			h.logger.Error(liblogging.ContextWithError(ctx, err), "internal server error")
+			platformErr.ServerErrorResponse(ctx, w, h.logger, tokenization.ErrFailedTokenize)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	if err := platformhandler.WriteJSON(w, http.StatusOK, mapPayoutResponse(payout), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func mapParty(p Party) entity.PayoutParty {
+	return entity.PayoutParty{
+		FirstName:     p.FirstName,
+		LastName:      p.LastName,
+		Address:       p.Address,
+		City:          p.City,
+		PostalCode:    p.PostalCode,
+		CountryCode:   p.Country,
+		AccountNumber: p.AccountNumber,
+	}
+}
+
+func mapPayoutRequest(ctx context.Context, psp entity.PSP, input payoutRequest, info cardinfo.Range) entity.Payout {
+	return entity.Payout{
+		ID:                       uuid.New(),
+		LogID:                    uuid.MustParse(ctx.Value(logging.LogIDKey).(string)),
+		Amount:                   input.Amount,
+		Currency:                 currencycode.Must(input.Currency),
+		CustomerReference:        input.Reference,
+		LocalTransactionDateTime: input.LocalTransactionDateTime,
+		Card: entity.Card{
+			Number: input.Card.Number,
+			Expiry: entity.Expiry{
+				Year:  input.Card.Expiry.Year,
+				Month: input.Card.Expiry.Month,
+			},
+			MaskedPan: entity.MaskPan(input.Card.Number),
+			Info:      info,
+		},
+		CardAcceptor: entity.CardAcceptor{
+			CategoryCode: input.CardAcceptor.CategoryCode,
+			ID:           input.CardAcceptor.ID,
+			Name:         input.CardAcceptor.Name,
+			Address: entity.CardAcceptorAddress{
+				PostalCode:  input.CardAcceptor.PostalCode,
+				City:        input.CardAcceptor.City,
+				CountryCode: input.CardAcceptor.Country,
+			},
+		},
+		Psp: entity.PSP{
+			ID:     psp.ID,
+			Prefix: psp.Prefix,
+		},
+		Sender:    mapParty(input.Sender),
+		Recipient: mapParty(input.Recipient),
+	}
+}
+
+func mapPayoutResponse(p entity.Payout) payoutResponse {
+	return payoutResponse{
+		ID:                       p.ID.String(),
+		LogID:                    p.LogID.String(),
+		Amount:                   p.Amount,
+		Currency:                 p.Currency.Alpha3(),
+		Reference:                p.CustomerReference,
+		LocalTransactionDateTime: &p.LocalTransactionDateTime,
+		ProcessingDate:           p.ProcessingDate.Format(time.RFC3339),
+		Card: CardResponse{
+			Scheme: p.Card.Info.Scheme,
+			Number: p.Card.MaskedPan,
+		},
+		CardAcceptor: CardAcceptor{
+			ID:           p.CardAcceptor.ID,
+			CategoryCode: p.CardAcceptor.CategoryCode,
+			Name:         p.CardAcceptor.Name,
+			City:         p.CardAcceptor.Address.City,
+			Country:      p.CardAcceptor.Address.CountryCode,
+			PostalCode:   p.CardAcceptor.Address.PostalCode,
+		},
+		Recipient: Party{
+			FirstName: p.Recipient.FirstName,
+			LastName:  p.Recipient.LastName,
+		},
+		CardSchemeResponse: CardSchemeResponse{
+			Status:  p.CardSchemeData.Response.Status.String(),
+			Code:    p.CardSchemeData.Response.ResponseCode.Value,
+			Message: p.CardSchemeData.Response.ResponseCode.Description,
+			TraceID: p.CardSchemeData.Response.TraceId,
+		},
+	}
+}
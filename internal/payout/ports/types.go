@@ -0,0 +1,159 @@
+package ports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/categorycode"
+	"gitlab.cmpayments.local/creditcard/platform/countrycode"
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+)
+
+type Card struct {
+	Number string `json:"number"`
+	Expiry Expiry `json:"expiry"`
+	scheme string
+}
+
+type Expiry struct {
+	Month string `json:"month"`
+	Year  string `json:"year"`
+}
+
+func (c Card) validate(v *validator.Validator) {
+	v.Check(len(c.Number) >= 12 && len(c.Number) <= 19, "card.number", []string{"card number must be min 12 and max 19 characters long"})
+	c.Expiry.validate(v)
+}
+
+func (e Expiry) validate(v *validator.Validator) {
+	t, err := time.Parse("0601", fmt.Sprintf("%s%s", e.Year, e.Month))
+	v.Check(err == nil, "card.expiry", []string{"invalid expiry format"})
+	v.Check(t.After(time.Now()), "card.expiry", []string{"card expired"})
+}
+
+type CardResponse struct {
+	Scheme string `json:"scheme"`
+	Number string `json:"number"`
+}
+
+type CardAcceptor struct {
+	ID           string `json:"id"`
+	CategoryCode string `json:"categoryCode"`
+	Name         string `json:"name"`
+	City         string `json:"city"`
+	Country      string `json:"country"`
+	PostalCode   string `json:"postalCode"`
+}
+
+func (ca CardAcceptor) validate(v *validator.Validator) {
+	_, ok := categorycode.MCCS[ca.CategoryCode]
+	v.Check(ca.ID != "", "cardAcceptor.id", []string{"cardAcceptor id cannot be empty"})
+	v.Check(len(ca.ID) <= 12, "cardAcceptor.id", []string{"cardAcceptor id must be max 12 characters long"})
+	v.Check(ok, "cardAcceptor.categoryCode", []string{"merchant category code not found."})
+	v.Check(ca.Name != "", "cardAcceptor.name", []string{"cardAcceptor name cannot be empty"})
+	v.Check(len(ca.Name) <= 22, "cardAcceptor.name", []string{"cardAcceptor name must be max 22 characters long"})
+	v.Check(len(ca.City) <= 13, "cardAcceptor.city", []string{"cardAcceptor city must be max 13 characters long"})
+	c, err := countrycode.GetCountry(ca.Country)
+	if err != nil {
+		v.AddError("cardAcceptor.country", []string{"invalid cardAcceptor country"})
+	}
+	if !c.EEACountry() {
+		v.AddError("cardAcceptor.country", []string{"cardAcceptor country not part of EEA"})
+	}
+
+	if countrycode.CountryHasPostalCode(ca.Country) {
+		v.Check(ca.PostalCode != "", "cardAcceptor.postalCode", []string{fmt.Sprintf("postal code cannot be empty for %s", ca.Country)})
+		v.Check(len(ca.PostalCode) <= 10, "cardAcceptor.postalCode", []string{"postal code must be max 10 characters long"})
+	}
+}
+
+// Party is the sender or the recipient of a payout
+type Party struct {
+	FirstName     string `json:"firstName"`
+	LastName      string `json:"lastName"`
+	Address       string `json:"address,omitempty"`
+	City          string `json:"city,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty"`
+	Country       string `json:"country,omitempty"`
+	AccountNumber string `json:"accountNumber,omitempty"`
+}
+
+// Name is the first and last name of the party, the way it is sent to the card schemes
+func (p Party) Name() string {
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
+
+// validate checks the party against the lengths of the Visa sender data, the shortest of both card schemes. The
+// key is the name of the party in the request.
+func (p Party) validate(v *validator.Validator, key string) {
+	v.Check(p.FirstName != "", key+".firstName", []string{"first name cannot be empty"})
+	v.Check(p.LastName != "", key+".lastName", []string{"last name cannot be empty"})
+	v.Check(len(p.Name()) <= 30, key, []string{"name must be max 30 characters long"})
+	v.Check(len(p.Address) <= 35, key+".address", []string{"address must be max 35 characters long"})
+	v.Check(len(p.City) <= 25, key+".city", []string{"city must be max 25 characters long"})
+	v.Check(len(p.PostalCode) <= 10, key+".postalCode", []string{"postal code must be max 10 characters long"})
+	v.Check(len(p.AccountNumber) <= 34, key+".accountNumber", []string{"account number must be max 34 characters long"})
+	if p.Country != "" {
+		_, err := countrycode.GetCountry(p.Country)
+		v.Check(err == nil, key+".country", []string{"invalid " + key + " country"})
+	}
+}
+
+type payoutRequest struct {
+	Amount                   int                           `json:"amount"`
+	Currency                 string                        `json:"currency"`
+	Reference                string                        `json:"reference"`
+	LocalTransactionDateTime data.LocalTransactionDateTime `json:"localTransactionDateTime"`
+	Card                     Card                          `json:"card"`
+	CardAcceptor             CardAcceptor                  `json:"cardAcceptor"`
+	Sender                   Party                         `json:"sender"`
+	Recipient                Party                         `json:"recipient"`
+}
+
+func (p payoutRequest) validate(v *validator.Validator) {
+	v.Check(p.Amount > 0, "amount", []string{"amount must be greater than 0"})
+	v.Check(p.Amount < 3000000, "amount", []string{"amount must be less than 3000000"})
+	c, err := currencycode.GetCurrency(p.Currency)
+	v.Check(err == nil, "currency", []string{"unsupported currency"})
+	if p.Card.scheme == entity.Visa && !c.AllowedByVisa() {
+		v.AddError("currency", []string{"currency is not allowed by visa"})
+	}
+	if p.Card.scheme == entity.Mastercard && !c.AllowedByMastercard() {
+		v.AddError("currency", []string{"currency is not allowed by mastercard"})
+	}
+	v.Check(len(p.Reference) <= 100, "reference", []string{"reference max length 100"})
+	p.Card.validate(v)
+	p.CardAcceptor.validate(v)
+
+	// The card schemes require the country and the account number of the sender
+	p.Sender.validate(v, "sender")
+	v.Check(p.Sender.Country != "", "sender.country", []string{"sender country cannot be empty"})
+	v.Check(p.Sender.AccountNumber != "", "sender.accountNumber", []string{"sender account number cannot be empty"})
+	p.Recipient.validate(v, "recipient")
+}
+
+type CardSchemeResponse struct {
+	Status  string `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	TraceID string `json:"traceId,omitempty"`
+}
+
+type payoutResponse struct {
+	ID                       string                         `json:"id"`
+	LogID                    string                         `json:"logID,omitempty"`
+	Amount                   int                            `json:"amount"`
+	Currency                 string                         `json:"currency"`
+	Reference                string                         `json:"reference"`
+	LocalTransactionDateTime *data.LocalTransactionDateTime `json:"localTransactionDateTime"`
+	ProcessingDate           string                         `json:"processingDate"`
+	Card                     CardResponse                   `json:"card"`
+	CardAcceptor             CardAcceptor                   `json:"cardAcceptor"`
+	Recipient                Party                          `json:"recipient"`
+	CardSchemeResponse       CardSchemeResponse             `json:"cardSchemeResponse"`
+}
@@ -0,0 +1,152 @@
+package ports
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+)
+
+func TestValidateSender(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Party
+		wanted map[string][]string
+	}{
+		{
+			name: "valid sender",
+			p: Party{
+				FirstName:     "John",
+				LastName:      "Smith",
+				Address:       "Main Street 1",
+				City:          "Breda",
+				Country:       "NLD",
+				AccountNumber: "NL91ABNA0417164300",
+			},
+			wanted: nil,
+		},
+		{
+			name: "sender without name",
+			p: Party{
+				Country:       "NLD",
+				AccountNumber: "NL91ABNA0417164300",
+			},
+			wanted: map[string][]string{
+				"sender.firstName": {
+					0: "first name cannot be empty",
+				},
+				"sender.lastName": {
+					0: "last name cannot be empty",
+				},
+			},
+		},
+		{
+			name: "sender name too long",
+			p: Party{
+				FirstName:     "Johnathan Alexander",
+				LastName:      "Smithsonian",
+				Country:       "NLD",
+				AccountNumber: "NL91ABNA0417164300",
+			},
+			wanted: map[string][]string{
+				"sender": {
+					0: "name must be max 30 characters long",
+				},
+			},
+		},
+		{
+			name: "sender name of max length",
+			p: Party{
+				FirstName:     "Johnathan Alexander",
+				LastName:      "Smithsonia",
+				Country:       "NLD",
+				AccountNumber: "NL91ABNA0417164300",
+			},
+			wanted: nil,
+		},
+		{
+			name: "invalid sender country",
+			p: Party{
+				FirstName:     "John",
+				LastName:      "Smith",
+				Country:       "NL",
+				AccountNumber: "NL91ABNA0417164300",
+			},
+			wanted: map[string][]string{
+				"sender.country": {
+					0: "invalid sender country",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			tt.p.validate(v, "sender")
+			if tt.wanted == nil {
+				tt.wanted = make(map[string][]string)
+			}
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("validate(v) = %v, wanted: %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestValidateCard(t *testing.T) {
+	nextYear := strconv.Itoa(time.Now().Year() + 1)[2:]
+	lastYear := strconv.Itoa(time.Now().Year() - 1)[2:]
+
+	tests := []struct {
+		name   string
+		c      Card
+		wanted map[string][]string
+	}{
+		{
+			name:   "valid card",
+			c:      Card{Number: "5555555555554444", Expiry: Expiry{Month: "12", Year: nextYear}},
+			wanted: nil,
+		},
+		{
+			name: "card number too short",
+			c:    Card{Number: "55555555555", Expiry: Expiry{Month: "12", Year: nextYear}},
+			wanted: map[string][]string{
+				"card.number": {
+					0: "card number must be min 12 and max 19 characters long",
+				},
+			},
+		},
+		{
+			name: "invalid expiry",
+			c:    Card{Number: "5555555555554444", Expiry: Expiry{Month: "13", Year: nextYear}},
+			wanted: map[string][]string{
+				"card.expiry": {
+					0: "invalid expiry format",
+				},
+			},
+		},
+		{
+			name: "card expired",
+			c:    Card{Number: "5555555555554444", Expiry: Expiry{Month: "12", Year: lastYear}},
+			wanted: map[string][]string{
+				"card.expiry": {
+					0: "card expired",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New()
+			tt.c.validate(v)
+			if tt.wanted == nil {
+				tt.wanted = make(map[string][]string)
+			}
+			if !reflect.DeepEqual(v.Errors, tt.wanted) {
+				t.Errorf("validate(v) = %v, wanted: %v", v.Errors, tt.wanted)
+			}
+		})
+	}
+}
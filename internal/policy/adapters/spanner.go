@@ -0,0 +1,296 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	sql "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+type PolicyRepository struct {
+	client       *spanner.Client
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func NewPolicyRepository(
+	client *spanner.Client,
+	readTimeout time.Duration,
+	writeTimeout time.Duration) *PolicyRepository {
+	return &PolicyRepository{
+		client:       client,
+		readTimeout:  readTimeout,
+		writeTimeout: writeTimeout,
+	}
+}
+
+func (pr PolicyRepository) GetPolicy(ctx context.Context, pspID uuid.UUID) (entity.Policy, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT psp_id, max_amount, daily_volume_cap,
+			       allowed_currencies, allowed_countries, allowed_sources,
+			       allowed_category_codes, refunds_allowed, payouts_allowed,
+			       max_payout_amount, daily_payout_cap, created_at,
+			       updated_at
+			FROM psp_policies
+			WHERE psp_id = @psp_id
+		`,
+		Params: map[string]interface{}{
+			"psp_id": pspID.String(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pr.readTimeout)
+	defer cancel()
+
+	iter := pr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		if errors.Is(err, iterator.Done) {
+			return entity.Policy{}, entity.ErrRecordNotFound
+		}
+		return entity.Policy{}, err
+	}
+
+	var p policyRecord
+	if err = row.ToStruct(&p); err != nil {
+		return entity.Policy{}, err
+	}
+
+	return mapPolicyRecordToEntity(p), nil
+}
+
+// SavePolicy updates the policy of the PSP, or inserts it when the PSP has none yet
+func (pr PolicyRepository) SavePolicy(ctx context.Context, p entity.Policy) error {
+	sources := make([]string, 0, len(p.AllowedSources))
+	for _, s := range p.AllowedSources {
+		sources = append(sources, string(s))
+	}
+
+	params := map[string]interface{}{
+		"psp_id":                 p.PspID.String(),
+		"max_amount":             spanner.NullInt64{Int64: int64(p.MaxAmount), Valid: p.MaxAmount > 0},
+		"daily_volume_cap":       spanner.NullInt64{Int64: int64(p.DailyVolumeCap), Valid: p.DailyVolumeCap > 0},
+		"allowed_currencies":     p.AllowedCurrencies,
+		"allowed_countries":      p.AllowedCountries,
+		"allowed_sources":        sources,
+		"allowed_category_codes": p.AllowedCategoryCodes,
+		"refunds_allowed":        p.RefundsAllowed,
+		"payouts_allowed":        p.PayoutsAllowed,
+		"max_payout_amount":      spanner.NullInt64{Int64: int64(p.MaxPayoutAmount), Valid: p.MaxPayoutAmount > 0},
+		"daily_payout_cap":       spanner.NullInt64{Int64: int64(p.DailyPayoutCap), Valid: p.DailyPayoutCap > 0},
+		"created_at":             p.CreatedAt,
+		"updated_at":             sql.NewNullTime(p.UpdatedAt),
+	}
+
+	update := spanner.Statement{
+		SQL: `UPDATE psp_policies
+				SET max_amount = @max_amount,
+					daily_volume_cap = @daily_volume_cap,
+					allowed_currencies = @allowed_currencies,
+					allowed_countries = @allowed_countries,
+					allowed_sources = @allowed_sources,
+					allowed_category_codes = @allowed_category_codes,
+					refunds_allowed = @refunds_allowed,
+					payouts_allowed = @payouts_allowed,
+					max_payout_amount = @max_payout_amount,
+					daily_payout_cap = @daily_payout_cap,
+					updated_at = @updated_at
+				WHERE psp_id = @psp_id`,
+		Params: params,
+	}
+
+	insert := spanner.Statement{
+		SQL: `INSERT INTO psp_policies (
+					psp_id, max_amount, daily_volume_cap,
+					allowed_currencies, allowed_countries, allowed_sources,
+					allowed_category_codes, refunds_allowed, payouts_allowed,
+					max_payout_amount, daily_payout_cap, created_at
+				) VALUES (
+					@psp_id, @max_amount, @daily_volume_cap,
+					@allowed_currencies, @allowed_countries, @allowed_sources,
+					@allowed_category_codes, @refunds_allowed, @payouts_allowed,
+					@max_payout_amount, @daily_payout_cap, @created_at
+				)`,
+		Params: params,
+	}
+
+	_, err := pr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, pr.writeTimeout)
+		defer cancel()
+
+		rowCount, err := txn.Update(ctx, update)
+		if err != nil {
+			return fmt.Errorf("failed to update policy: %w", err)
+		}
+		if rowCount == 1 {
+			return nil
+		}
+
+		_, err = txn.Update(ctx, insert)
+
+		return err
+	})
+
+	return err
+}
+
+func (pr PolicyRepository) DeletePolicy(ctx context.Context, pspID uuid.UUID) error {
+	stmt := spanner.Statement{
+		SQL: `DELETE FROM psp_policies
+				WHERE psp_id = @psp_id`,
+		Params: map[string]interface{}{
+			"psp_id": pspID.String(),
+		},
+	}
+
+	_, err := pr.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		ctx, cancel := context.WithTimeout(ctx, pr.writeTimeout)
+		defer cancel()
+
+		rowCount, err := txn.Update(ctx, stmt)
+		if err != nil {
+			return fmt.Errorf("failed to delete policy: %w", err)
+		}
+		if rowCount != 1 {
+			return entity.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// GetDailyVolume returns the summed amount of the authorizations of the PSP in the currency since the given time,
+// declined and failed authorizations are not counted
+func (pr PolicyRepository) GetDailyVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT COALESCE(SUM(amount), 0)
+			FROM authorizations
+			WHERE psp_id = @psp_id
+			  AND currency = @currency
+			  AND created_at >= @since
+			  AND status NOT IN UNNEST(@excluded_statuses)
+		`,
+		Params: map[string]interface{}{
+			"psp_id":   pspID.String(),
+			"currency": currency,
+			"since":    since,
+			"excluded_statuses": []string{
+				string(entity.Declined),
+				string(entity.Failed),
+				string(entity.RiskDeclined),
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pr.readTimeout)
+	defer cancel()
+
+	iter := pr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		return 0, err
+	}
+
+	var volume int64
+	if err = row.Column(0, &volume); err != nil {
+		return 0, err
+	}
+
+	return int(volume), nil
+}
+
+// GetDailyPayoutVolume returns the summed amount of the payouts of the PSP in the currency since the given time,
+// declined and failed payouts are not counted
+func (pr PolicyRepository) GetDailyPayoutVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error) {
+	stmt := spanner.Statement{
+		SQL: `
+			SELECT COALESCE(SUM(amount), 0)
+			FROM payouts
+			WHERE psp_id = @psp_id
+			  AND currency = @currency
+			  AND created_at >= @since
+			  AND status NOT IN UNNEST(@excluded_statuses)
+		`,
+		Params: map[string]interface{}{
+			"psp_id":   pspID.String(),
+			"currency": currency,
+			"since":    since,
+			"excluded_statuses": []string{
+				string(entity.Declined),
+				string(entity.Failed),
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pr.readTimeout)
+	defer cancel()
+
+	iter := pr.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err != nil {
+		return 0, err
+	}
+
+	var volume int64
+	if err = row.Column(0, &volume); err != nil {
+		return 0, err
+	}
+
+	return int(volume), nil
+}
+
+type policyRecord struct {
+	PspID                string            `spanner:"psp_id"`
+	MaxAmount            spanner.NullInt64 `spanner:"max_amount"`
+	DailyVolumeCap       spanner.NullInt64 `spanner:"daily_volume_cap"`
+	AllowedCurrencies    []string          `spanner:"allowed_currencies"`
+	AllowedCountries     []string          `spanner:"allowed_countries"`
+	AllowedSources       []string          `spanner:"allowed_sources"`
+	AllowedCategoryCodes []string          `spanner:"allowed_category_codes"`
+	RefundsAllowed       bool              `spanner:"refunds_allowed"`
+	PayoutsAllowed       bool              `spanner:"payouts_allowed"`
+	MaxPayoutAmount      spanner.NullInt64 `spanner:"max_payout_amount"`
+	DailyPayoutCap       spanner.NullInt64 `spanner:"daily_payout_cap"`
+	CreatedAt            time.Time         `spanner:"created_at"`
+	UpdatedAt            spanner.NullTime  `spanner:"updated_at"`
+}
+
+func mapPolicyRecordToEntity(p policyRecord) entity.Policy {
+	var sources []entity.Source
+	for _, s := range p.AllowedSources {
+		sources = append(sources, entity.Source(s))
+	}
+
+	return entity.Policy{
+		PspID:                uuid.MustParse(p.PspID),
+		MaxAmount:            int(p.MaxAmount.Int64),
+		DailyVolumeCap:       int(p.DailyVolumeCap.Int64),
+		AllowedCurrencies:    p.AllowedCurrencies,
+		AllowedCountries:     p.AllowedCountries,
+		AllowedSources:       sources,
+		AllowedCategoryCodes: p.AllowedCategoryCodes,
+		RefundsAllowed:       p.RefundsAllowed,
+		PayoutsAllowed:       p.PayoutsAllowed,
+		MaxPayoutAmount:      int(p.MaxPayoutAmount.Int64),
+		DailyPayoutCap:       int(p.DailyPayoutCap.Int64),
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt.Time,
+	}
+}
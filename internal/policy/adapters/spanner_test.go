@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/spanner"
+)
+
+func TestPolicyRepository_GetPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spanner: skipping integration test")
+	}
+
+	client := spanner.NewTestDB(t, "./testdata/insert_policy")
+
+	repo := NewPolicyRepository(
+		client,
+		3*time.Second,
+		5*time.Second,
+	)
+
+	tests := []struct {
+		name      string
+		pspID     uuid.UUID
+		want      entity.Policy
+		wantedErr error
+	}{
+		{
+			name:  "get_policy",
+			pspID: uuid.MustParse("0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20"),
+			want: entity.Policy{
+				PspID:             uuid.MustParse("0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20"),
+				MaxAmount:         50000,
+				AllowedCurrencies: []string{"EUR", "GBP"},
+				AllowedSources:    []entity.Source{entity.Ecommerce},
+				RefundsAllowed:    false,
+			},
+		},
+		{
+			name:      "psp_without_policy",
+			pspID:     uuid.MustParse("1779edcd-4f14-4c97-a61e-29a827e7ed89"),
+			wantedErr: entity.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			got, err := repo.GetPolicy(ctx, tt.pspID)
+			if !errors.Is(err, tt.wantedErr) {
+				t.Fatalf("got error %v, wanted %v", err, tt.wantedErr)
+			}
+
+			got.CreatedAt = time.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyRepository_SavePolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spanner: skipping integration test")
+	}
+
+	client := spanner.NewTestDB(t, "./testdata/insert_policy")
+
+	repo := NewPolicyRepository(
+		client,
+		3*time.Second,
+		5*time.Second,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	policy := entity.Policy{
+		PspID:            uuid.MustParse("0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20"),
+		DailyVolumeCap:   1000000,
+		AllowedCountries: []string{"NLD"},
+		RefundsAllowed:   true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := repo.SavePolicy(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := repo.GetPolicy(ctx, policy.PspID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.MaxAmount != 0 || got.DailyVolumeCap != policy.DailyVolumeCap || !got.RefundsAllowed {
+		t.Errorf("got %v, wanted %v", got, policy)
+	}
+	if !reflect.DeepEqual(got.AllowedCountries, policy.AllowedCountries) {
+		t.Errorf("got countries %v, wanted %v", got.AllowedCountries, policy.AllowedCountries)
+	}
+}
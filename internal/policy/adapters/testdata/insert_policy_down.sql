@@ -0,0 +1,4 @@
+DELETE FROM psp_policies
+WHERE psp_id = '0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20';
+DELETE FROM psp
+WHERE psp_id = '0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20';
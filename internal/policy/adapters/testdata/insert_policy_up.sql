@@ -0,0 +1,4 @@
+INSERT INTO psp (psp_id, name, prefix)
+VALUES ('0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20', 'policy_test_psp', 'ptp');
+INSERT INTO psp_policies (psp_id, max_amount, allowed_currencies, allowed_sources, refunds_allowed, created_at)
+VALUES ('0b6a1f3e-8d4c-4f7e-a2b9-5c1d3e7f9a20', 50000, ['EUR', 'GBP'], ['ecommerce'], false, '2023-07-01 12:00:00');
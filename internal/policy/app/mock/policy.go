@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type PolicyRepo struct{}
+
+func (PolicyRepo) GetPolicy(ctx context.Context, pspID uuid.UUID) (entity.Policy, error) {
+	return entity.Policy{}, entity.ErrRecordNotFound
+}
+
+func (PolicyRepo) SavePolicy(ctx context.Context, p entity.Policy) error {
+	return nil
+}
+
+func (PolicyRepo) DeletePolicy(ctx context.Context, pspID uuid.UUID) error {
+	return nil
+}
+
+func (PolicyRepo) GetDailyVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (PolicyRepo) GetDailyPayoutVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error) {
+	return 0, nil
+}
@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cmpayments.local/creditcard/platform"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type Repository interface {
+	GetPolicy(ctx context.Context, pspID uuid.UUID) (entity.Policy, error)
+	SavePolicy(ctx context.Context, p entity.Policy) error
+	DeletePolicy(ctx context.Context, pspID uuid.UUID) error
+	GetDailyVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error)
+	GetDailyPayoutVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error)
+}
+
+type PolicyService struct {
+	log  platform.Logger
+	repo Repository
+}
+
+func NewPolicyService(logger platform.Logger, repo Repository) PolicyService {
+	return PolicyService{
+		log:  logger,
+		repo: repo,
+	}
+}
+
+func (ps PolicyService) GetPolicy(ctx context.Context, pspID uuid.UUID) (entity.Policy, error) {
+	return ps.repo.GetPolicy(ctx, pspID)
+}
+
+func (ps PolicyService) SavePolicy(ctx context.Context, p *entity.Policy) error {
+	current, err := ps.repo.GetPolicy(ctx, p.PspID)
+	switch {
+	case errors.Is(err, entity.ErrRecordNotFound):
+		p.CreatedAt = time.Now()
+	case err != nil:
+		return err
+	default:
+		p.CreatedAt = current.CreatedAt
+		p.UpdatedAt = time.Now()
+	}
+
+	if err = ps.repo.SavePolicy(ctx, *p); err != nil {
+		return fmt.Errorf("failed to store policy: %w", err)
+	}
+
+	ps.log.Info(ctx, "policy saved")
+
+	return nil
+}
+
+func (ps PolicyService) DeletePolicy(ctx context.Context, pspID uuid.UUID) error {
+	if err := ps.repo.DeletePolicy(ctx, pspID); err != nil {
+		return err
+	}
+
+	ps.log.Info(ctx, "policy deleted")
+
+	return nil
+}
+
+// EvaluateAuthorization returns an entity.PolicyViolation when the authorization is not allowed by
+// the policy of the PSP. A PSP without a policy is not restricted.
+func (ps PolicyService) EvaluateAuthorization(ctx context.Context, a entity.Authorization) error {
+	p, err := ps.policy(ctx, a.Psp.ID)
+	if err != nil || p == nil {
+		return err
+	}
+
+	err = evaluate(*p, a.Amount, a.Currency.Alpha3(), a.Source, a.CardAcceptor)
+	if err != nil {
+		return err
+	}
+
+	if p.DailyVolumeCap > 0 {
+		volume, err := ps.repo.GetDailyVolume(ctx, a.Psp.ID, a.Currency.Alpha3(), startOfDay(time.Now()))
+		if err != nil {
+			return fmt.Errorf("failed to get daily volume: %w", err)
+		}
+		if volume+a.Amount > p.DailyVolumeCap {
+			return entity.PolicyViolation{Field: "amount", Reason: "daily volume cap exceeded"}
+		}
+	}
+
+	return nil
+}
+
+// EvaluateRefund returns an entity.PolicyViolation when the refund is not allowed by the policy of the PSP.
+// Refunds do not count towards the daily volume cap.
+func (ps PolicyService) EvaluateRefund(ctx context.Context, r entity.Refund) error {
+	p, err := ps.policy(ctx, r.Psp.ID)
+	if err != nil || p == nil {
+		return err
+	}
+
+	if !p.RefundsAllowed {
+		return entity.PolicyViolation{Field: "refund", Reason: "refunds are not allowed"}
+	}
+
+	return evaluate(*p, r.Amount, r.Currency.Alpha3(), r.Source, r.CardAcceptor)
+}
+
+// EvaluatePayout returns an entity.PolicyViolation when the payout is not allowed by the policy of the PSP. Payouts
+// have a maximum amount and daily cap of their own, they do not count towards those of authorizations. A PSP without
+// a policy is not allowed to make payouts.
+func (ps PolicyService) EvaluatePayout(ctx context.Context, po entity.Payout) error {
+	p, err := ps.policy(ctx, po.Psp.ID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case p == nil || !p.PayoutsAllowed:
+		return entity.PolicyViolation{Field: "payout", Reason: "payouts are not allowed"}
+	case p.MaxPayoutAmount > 0 && po.Amount > p.MaxPayoutAmount:
+		return entity.PolicyViolation{Field: "amount", Reason: "amount must be less than or equal to " + strconv.Itoa(p.MaxPayoutAmount)}
+	case !allowed(p.AllowedCurrencies, po.Currency.Alpha3()):
+		return entity.PolicyViolation{Field: "currency", Reason: "currency not allowed"}
+	case !allowed(p.AllowedCountries, po.CardAcceptor.Address.CountryCode):
+		return entity.PolicyViolation{Field: "cardAcceptor.country", Reason: "cardAcceptor country not allowed"}
+	case !allowed(p.AllowedCategoryCodes, po.CardAcceptor.CategoryCode):
+		return entity.PolicyViolation{Field: "cardAcceptor.categoryCode", Reason: "merchant category code not allowed"}
+	}
+
+	if p.DailyPayoutCap > 0 {
+		volume, err := ps.repo.GetDailyPayoutVolume(ctx, po.Psp.ID, po.Currency.Alpha3(), startOfDay(time.Now()))
+		if err != nil {
+			return fmt.Errorf("failed to get daily payout volume: %w", err)
+		}
+		if volume+po.Amount > p.DailyPayoutCap {
+			return entity.PolicyViolation{Field: "amount", Reason: "daily payout cap exceeded"}
+		}
+	}
+
+	return nil
+}
+
+func (ps PolicyService) policy(ctx context.Context, pspID uuid.UUID) (*entity.Policy, error) {
+	p, err := ps.repo.GetPolicy(ctx, pspID)
+	if err != nil {
+		if errors.Is(err, entity.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	return &p, nil
+}
+
+func evaluate(p entity.Policy, amount int, currency string, source entity.Source, ca entity.CardAcceptor) error {
+	switch {
+	case p.MaxAmount > 0 && amount > p.MaxAmount:
+		return entity.PolicyViolation{Field: "amount", Reason: "amount must be less than or equal to " + strconv.Itoa(p.MaxAmount)}
+	case !allowed(p.AllowedCurrencies, currency):
+		return entity.PolicyViolation{Field: "currency", Reason: "currency not allowed"}
+	case !allowedSource(p.AllowedSources, source):
+		return entity.PolicyViolation{Field: "source", Reason: "source not allowed"}
+	case !allowed(p.AllowedCountries, ca.Address.CountryCode):
+		return entity.PolicyViolation{Field: "cardAcceptor.country", Reason: "cardAcceptor country not allowed"}
+	case !allowed(p.AllowedCategoryCodes, ca.CategoryCode):
+		return entity.PolicyViolation{Field: "cardAcceptor.categoryCode", Reason: "merchant category code not allowed"}
+	}
+
+	return nil
+}
+
+func allowed(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func allowedSource(sources []entity.Source, source entity.Source) bool {
+	if len(sources) == 0 {
+		return true
+	}
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+	"gitlab.cmpayments.local/libraries-go/logging"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/policy/app/mock"
+)
+
+type policyRepo struct {
+	mock.PolicyRepo
+	policy       entity.Policy
+	volume       int
+	payoutVolume int
+}
+
+func (r policyRepo) GetPolicy(ctx context.Context, pspID uuid.UUID) (entity.Policy, error) {
+	if r.policy.PspID != pspID {
+		return entity.Policy{}, entity.ErrRecordNotFound
+	}
+	return r.policy, nil
+}
+
+func (r policyRepo) GetDailyVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error) {
+	return r.volume, nil
+}
+
+func (r policyRepo) GetDailyPayoutVolume(ctx context.Context, pspID uuid.UUID, currency string, since time.Time) (int, error) {
+	return r.payoutVolume, nil
+}
+
+func TestPolicyService_EvaluateAuthorization(t *testing.T) {
+	pspID := uuid.New()
+	policy := entity.Policy{
+		PspID:                pspID,
+		MaxAmount:            10000,
+		DailyVolumeCap:       50000,
+		AllowedCurrencies:    []string{"EUR"},
+		AllowedCountries:     []string{"NLD", "BEL"},
+		AllowedSources:       []entity.Source{entity.Ecommerce},
+		AllowedCategoryCodes: []string{"5691"},
+	}
+
+	authorization := func(modify func(a *entity.Authorization)) entity.Authorization {
+		a := entity.Authorization{
+			Amount:   2500,
+			Currency: currencycode.Must("EUR"),
+			Source:   entity.Ecommerce,
+			CardAcceptor: entity.CardAcceptor{
+				CategoryCode: "5691",
+				Address:      entity.CardAcceptorAddress{CountryCode: "NLD"},
+			},
+			Psp: entity.PSP{ID: pspID},
+		}
+		modify(&a)
+		return a
+	}
+
+	tests := []struct {
+		name          string
+		authorization entity.Authorization
+		volume        int
+		wantField     string
+	}{
+		{
+			name:          "allowed",
+			authorization: authorization(func(a *entity.Authorization) {}),
+		},
+		{
+			name:          "psp_without_policy",
+			authorization: authorization(func(a *entity.Authorization) { a.Psp.ID = uuid.New(); a.Amount = 20000 }),
+		},
+		{
+			name:          "amount_exceeds_max",
+			authorization: authorization(func(a *entity.Authorization) { a.Amount = 10001 }),
+			wantField:     "amount",
+		},
+		{
+			name:          "currency_not_allowed",
+			authorization: authorization(func(a *entity.Authorization) { a.Currency = currencycode.Must("GBP") }),
+			wantField:     "currency",
+		},
+		{
+			name:          "source_not_allowed",
+			authorization: authorization(func(a *entity.Authorization) { a.Source = entity.Moto }),
+			wantField:     "source",
+		},
+		{
+			name:          "country_not_allowed",
+			authorization: authorization(func(a *entity.Authorization) { a.CardAcceptor.Address.CountryCode = "DEU" }),
+			wantField:     "cardAcceptor.country",
+		},
+		{
+			name:          "category_code_not_allowed",
+			authorization: authorization(func(a *entity.Authorization) { a.CardAcceptor.CategoryCode = "5999" }),
+			wantField:     "cardAcceptor.categoryCode",
+		},
+		{
+			name:          "daily_volume_cap_exceeded",
+			authorization: authorization(func(a *entity.Authorization) {}),
+			volume:        48000,
+			wantField:     "amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewPolicyService(logging.Logger{}, policyRepo{policy: policy, volume: tt.volume})
+
+			err := service.EvaluateAuthorization(context.Background(), tt.authorization)
+
+			var violation entity.PolicyViolation
+			if errors.As(err, &violation) != (tt.wantField != "") {
+				t.Fatalf("EvaluateAuthorization() error = %v, want violation on %q", err, tt.wantField)
+			}
+			if violation.Field != tt.wantField {
+				t.Errorf("EvaluateAuthorization() field = %q, want %q", violation.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestPolicyService_EvaluateRefund(t *testing.T) {
+	pspID := uuid.New()
+
+	tests := []struct {
+		name      string
+		policy    entity.Policy
+		wantField string
+	}{
+		{
+			name:   "refunds_allowed",
+			policy: entity.Policy{PspID: pspID, RefundsAllowed: true},
+		},
+		{
+			name:      "refunds_not_allowed",
+			policy:    entity.Policy{PspID: pspID},
+			wantField: "refund",
+		},
+		{
+			name:      "refund_amount_exceeds_max",
+			policy:    entity.Policy{PspID: pspID, RefundsAllowed: true, MaxAmount: 1000},
+			wantField: "amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewPolicyService(logging.Logger{}, policyRepo{policy: tt.policy})
+
+			err := service.EvaluateRefund(context.Background(), entity.Refund{
+				Amount:   2500,
+				Currency: currencycode.Must("EUR"),
+				Psp:      entity.PSP{ID: pspID},
+			})
+
+			var violation entity.PolicyViolation
+			if errors.As(err, &violation) != (tt.wantField != "") {
+				t.Fatalf("EvaluateRefund() error = %v, want violation on %q", err, tt.wantField)
+			}
+			if violation.Field != tt.wantField {
+				t.Errorf("EvaluateRefund() field = %q, want %q", violation.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestPolicyService_EvaluatePayout(t *testing.T) {
+	pspID := uuid.New()
+	policy := entity.Policy{
+		PspID:           pspID,
+		MaxAmount:       1000,
+		PayoutsAllowed:  true,
+		MaxPayoutAmount: 10000,
+		DailyPayoutCap:  50000,
+	}
+
+	tests := []struct {
+		name         string
+		policy       entity.Policy
+		amount       int
+		payoutVolume int
+		wantField    string
+	}{
+		{
+			name:   "allowed",
+			policy: policy,
+			amount: 2500,
+		},
+		{
+			name:      "no_policy",
+			amount:    2500,
+			wantField: "payout",
+		},
+		{
+			name:      "payouts_not_allowed",
+			policy:    entity.Policy{PspID: pspID},
+			amount:    2500,
+			wantField: "payout",
+		},
+		{
+			name:      "amount_exceeds_max",
+			policy:    policy,
+			amount:    10001,
+			wantField: "amount",
+		},
+		{
+			name:         "daily_payout_cap_exceeded",
+			policy:       policy,
+			amount:       2500,
+			payoutVolume: 48000,
+			wantField:    "amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewPolicyService(logging.Logger{}, policyRepo{policy: tt.policy, payoutVolume: tt.payoutVolume})
+
+			err := service.EvaluatePayout(context.Background(), entity.Payout{
+				Amount:   tt.amount,
+				Currency: currencycode.Must("EUR"),
+				Psp:      entity.PSP{ID: pspID},
+			})
+
+			var violation entity.PolicyViolation
+			if errors.As(err, &violation) != (tt.wantField != "") {
+				t.Fatalf("EvaluatePayout() error = %v, want violation on %q", err, tt.wantField)
+			}
+			if violation.Field != tt.wantField {
+				t.Errorf("EvaluatePayout() field = %q, want %q", violation.Field, tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package ports
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"gitlab.cmpayments.local/creditcard/platform"
+	platformErr "gitlab.cmpayments.local/creditcard/platform/http/errors"
+	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/policy/app"
+)
+
+// policyHandler manages the policies of all PSPs, the PSP is taken from the path and not from the
+// authenticated PSP so the routes must only be available to administrators.
+type policyHandler struct {
+	logger        platform.Logger
+	policyService app.PolicyService
+}
+
+func NewPolicyHandler(logger platform.Logger, policyService app.PolicyService) *policyHandler {
+	return &policyHandler{
+		logger:        logger,
+		policyService: policyService,
+	}
+}
+
+func (h *policyHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	pspID, err := uuid.Parse(params.ByName("pspID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	policy, err := h.policyService.GetPolicy(ctx, pspID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRecordNotFound):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, mapPolicyResponse(policy), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *policyHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	input := policyRequest{}
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	pspID, err := uuid.Parse(params.ByName("pspID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	if err = platformhandler.ReadJSON(w, r, &input); err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	v := validator.New()
+
+	input.validate(v)
+	if !v.Valid() {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, v.Errors)
+		return
+	}
+
+	policy := mapPolicyRequest(input)
+	policy.PspID = pspID
+
+	if err = h.policyService.SavePolicy(ctx, &policy); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	if err = platformhandler.WriteJSON(w, http.StatusOK, mapPolicyResponse(policy), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *policyHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	pspID, err := uuid.Parse(params.ByName("pspID"))
+	if err != nil {
+		platformErr.BadRequestResponse(ctx, w, h.logger, err)
+		return
+	}
+
+	if err = h.policyService.DeletePolicy(ctx, pspID); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRecordNotFound):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
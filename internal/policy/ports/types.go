@@ -0,0 +1,118 @@
+package ports
+
+import (
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/categorycode"
+	"gitlab.cmpayments.local/creditcard/platform/countrycode"
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+	"gitlab.cmpayments.local/creditcard/platform/http/validator"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type policyRequest struct {
+	MaxAmount            int      `json:"maxAmount"`
+	DailyVolumeCap       int      `json:"dailyVolumeCap"`
+	AllowedCurrencies    []string `json:"allowedCurrencies"`
+	AllowedCountries     []string `json:"allowedCountries"`
+	AllowedSources       []string `json:"allowedSources"`
+	AllowedCategoryCodes []string `json:"allowedCategoryCodes"`
+	RefundsAllowed       bool     `json:"refundsAllowed"`
+	PayoutsAllowed       bool     `json:"payoutsAllowed"`
+	MaxPayoutAmount      int      `json:"maxPayoutAmount"`
+	DailyPayoutCap       int      `json:"dailyPayoutCap"`
+}
+
+func (p policyRequest) validate(v *validator.Validator) {
+	v.Check(p.MaxAmount >= 0, "maxAmount", []string{"max amount cannot be negative"})
+	v.Check(p.DailyVolumeCap >= 0, "dailyVolumeCap", []string{"daily volume cap cannot be negative"})
+	v.Check(p.MaxPayoutAmount >= 0, "maxPayoutAmount", []string{"max payout amount cannot be negative"})
+	v.Check(p.DailyPayoutCap >= 0, "dailyPayoutCap", []string{"daily payout cap cannot be negative"})
+	for _, c := range p.AllowedCurrencies {
+		_, err := currencycode.GetCurrency(c)
+		v.Check(err == nil, "allowedCurrencies", []string{"unsupported currency " + c})
+	}
+	for _, c := range p.AllowedCountries {
+		_, err := countrycode.GetCountry(c)
+		v.Check(err == nil, "allowedCountries", []string{"invalid country " + c})
+	}
+	for _, s := range p.AllowedSources {
+		v.Check(entity.IsValidSource(s), "allowedSources", []string{"invalid source " + s})
+	}
+	for _, mcc := range p.AllowedCategoryCodes {
+		_, ok := categorycode.MCCS[mcc]
+		v.Check(ok, "allowedCategoryCodes", []string{"merchant category code " + mcc + " not found."})
+	}
+}
+
+type policyResponse struct {
+	PspID                string   `json:"pspId"`
+	MaxAmount            int      `json:"maxAmount,omitempty"`
+	DailyVolumeCap       int      `json:"dailyVolumeCap,omitempty"`
+	AllowedCurrencies    []string `json:"allowedCurrencies"`
+	AllowedCountries     []string `json:"allowedCountries"`
+	AllowedSources       []string `json:"allowedSources"`
+	AllowedCategoryCodes []string `json:"allowedCategoryCodes"`
+	RefundsAllowed       bool     `json:"refundsAllowed"`
+	PayoutsAllowed       bool     `json:"payoutsAllowed"`
+	MaxPayoutAmount      int      `json:"maxPayoutAmount,omitempty"`
+	DailyPayoutCap       int      `json:"dailyPayoutCap,omitempty"`
+	CreatedAt            string   `json:"createdAt"`
+	UpdatedAt            string   `json:"updatedAt,omitempty"`
+}
+
+func mapPolicyRequest(p policyRequest) entity.Policy {
+	var sources []entity.Source
+	for _, s := range p.AllowedSources {
+		source, _ := entity.MapSource(s)
+		sources = append(sources, source)
+	}
+
+	return entity.Policy{
+		MaxAmount:            p.MaxAmount,
+		DailyVolumeCap:       p.DailyVolumeCap,
+		AllowedCurrencies:    p.AllowedCurrencies,
+		AllowedCountries:     p.AllowedCountries,
+		AllowedSources:       sources,
+		AllowedCategoryCodes: p.AllowedCategoryCodes,
+		RefundsAllowed:       p.RefundsAllowed,
+		PayoutsAllowed:       p.PayoutsAllowed,
+		MaxPayoutAmount:      p.MaxPayoutAmount,
+		DailyPayoutCap:       p.DailyPayoutCap,
+	}
+}
+
+func mapPolicyResponse(p entity.Policy) policyResponse {
+	response := policyResponse{
+		PspID:                p.PspID.String(),
+		MaxAmount:            p.MaxAmount,
+		DailyVolumeCap:       p.DailyVolumeCap,
+		AllowedCurrencies:    emptyIfNil(p.AllowedCurrencies),
+		AllowedCountries:     emptyIfNil(p.AllowedCountries),
+		AllowedSources:       []string{},
+		AllowedCategoryCodes: emptyIfNil(p.AllowedCategoryCodes),
+		RefundsAllowed:       p.RefundsAllowed,
+		PayoutsAllowed:       p.PayoutsAllowed,
+		MaxPayoutAmount:      p.MaxPayoutAmount,
+		DailyPayoutCap:       p.DailyPayoutCap,
+		CreatedAt:            p.CreatedAt.Format(time.RFC3339),
+	}
+
+	for _, s := range p.AllowedSources {
+		response.AllowedSources = append(response.AllowedSources, string(s))
+	}
+
+	if !p.UpdatedAt.IsZero() {
+		response.UpdatedAt = p.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
+
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
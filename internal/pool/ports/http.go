@@ -0,0 +1,111 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+	"gitlab.cmpayments.local/creditcard/platform"
+	platformErr "gitlab.cmpayments.local/creditcard/platform/http/errors"
+	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+)
+
+// poolHandler shows and manages the scheme connection pools, the routes must only be available to
+// administrators
+type poolHandler struct {
+	logger platform.Logger
+	pools  map[string]*connection.Pool
+}
+
+func NewPoolHandler(logger platform.Logger, pools map[string]*connection.Pool) *poolHandler {
+	return &poolHandler{
+		logger: logger,
+		pools:  pools,
+	}
+}
+
+func (h *poolHandler) GetConnections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	schemes := make([]string, 0, len(h.pools))
+	for scheme := range h.pools {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+
+	response := PoolsResponse{
+		Pools: []poolResponse{},
+	}
+	for _, scheme := range schemes {
+		response.Pools = append(response.Pools, mapPoolResponse(scheme, h.pools[scheme].Status()))
+	}
+
+	if err := platformhandler.WriteJSON(w, http.StatusOK, response, nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
+
+func (h *poolHandler) Reconnect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	pool, ok := h.pools[params.ByName("scheme")]
+	if !ok {
+		platformErr.NotFoundResponse(ctx, w, h.logger)
+		return
+	}
+
+	if err := pool.Reconnect(params.ByName("connectionID")); err != nil {
+		switch {
+		case errors.Is(err, connection.ErrUnknownConnection):
+			platformErr.NotFoundResponse(ctx, w, h.logger)
+			return
+		case errors.Is(err, connection.ErrReconnectRefused):
+			platformErr.BadRequestResponse(ctx, w, h.logger, err)
+			return
+		default:
+			platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+			return
+		}
+	}
+
+	h.logger.Info(ctx, fmt.Sprintf("reconnecting %s connection %s", params.ByName("scheme"), params.ByName("connectionID")))
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *poolHandler) Drain(w http.ResponseWriter, r *http.Request) {
+	h.setDraining(w, r, true)
+}
+
+func (h *poolHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.setDraining(w, r, false)
+}
+
+func (h *poolHandler) setDraining(w http.ResponseWriter, r *http.Request, draining bool) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	scheme := params.ByName("scheme")
+	pool, ok := h.pools[scheme]
+	if !ok {
+		platformErr.NotFoundResponse(ctx, w, h.logger)
+		return
+	}
+
+	if draining {
+		pool.Drain()
+	} else {
+		pool.Resume()
+	}
+
+	h.logger.Info(ctx, fmt.Sprintf("%s pool draining: %t", scheme, draining))
+
+	if err := platformhandler.WriteJSON(w, http.StatusOK, mapPoolResponse(scheme, pool.Status()), nil); err != nil {
+		platformErr.ServerErrorResponse(ctx, w, h.logger, err)
+	}
+}
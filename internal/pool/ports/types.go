@@ -0,0 +1,80 @@
+package ports
+
+import (
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+)
+
+type connectionResponse struct {
+	ID             string `json:"id"`
+	State          string `json:"state"`
+	Endpoint       string `json:"endpoint,omitempty"`
+	ConnectedAt    string `json:"connectedAt,omitempty"`
+	LastSentAt     string `json:"lastSentAt,omitempty"`
+	LastReceivedAt string `json:"lastReceivedAt,omitempty"`
+	Reconnects     int    `json:"reconnects"`
+	Timeouts       int    `json:"timeouts"`
+	InFlightStan   string `json:"inFlightStan,omitempty"`
+}
+
+type endpointResponse struct {
+	Address  string `json:"address"`
+	Weight   int    `json:"weight"`
+	Down     bool   `json:"down"`
+	Failures int    `json:"failures"`
+}
+
+type poolResponse struct {
+	Scheme      string               `json:"scheme"`
+	Name        string               `json:"name"`
+	Endpoints   []endpointResponse   `json:"endpoints"`
+	Draining    bool                 `json:"draining"`
+	Connections []connectionResponse `json:"connections"`
+}
+
+type PoolsResponse struct {
+	Pools []poolResponse `json:"pools"`
+}
+
+func mapPoolResponse(scheme string, s connection.PoolStatus) poolResponse {
+	response := poolResponse{
+		Scheme:      scheme,
+		Name:        s.Name,
+		Endpoints:   []endpointResponse{},
+		Draining:    s.Draining,
+		Connections: []connectionResponse{},
+	}
+
+	for _, e := range s.Endpoints {
+		response.Endpoints = append(response.Endpoints, endpointResponse{
+			Address:  e.Address,
+			Weight:   e.Weight,
+			Down:     e.Down,
+			Failures: e.Failures,
+		})
+	}
+
+	for _, c := range s.Connections {
+		response.Connections = append(response.Connections, connectionResponse{
+			ID:             c.ID,
+			State:          string(c.State),
+			Endpoint:       c.Endpoint,
+			ConnectedAt:    formatTime(c.ConnectedAt),
+			LastSentAt:     formatTime(c.LastSentAt),
+			LastReceivedAt: formatTime(c.LastReceivedAt),
+			Reconnects:     c.Reconnects,
+			Timeouts:       c.Timeouts,
+			InFlightStan:   c.InFlightStan,
+		})
+	}
+
+	return response
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,229 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/platform"
+)
+
+const (
+	defaultOpenDuration = 30 * time.Second
+	defaultProbeTimeout = 5 * time.Second
+
+	// saturatedRetryAfter is the retry hint when the pool refuses a request because too many wait
+	saturatedRetryAfter = time.Second
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// UnavailableError is returned when a request is not sent because the scheme is unavailable, the
+// caller can retry after RetryAfter
+type UnavailableError struct {
+	Scheme     string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("%s unavailable, retry after %s: %s", e.Scheme, e.RetryAfter, e.Err)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive transport failures that opens the breaker, 0 never opens it
+	FailureThreshold int           `yaml:"failure_threshold"`
+	OpenDuration     time.Duration `yaml:"open_duration"`
+	ProbeTimeout     time.Duration `yaml:"probe_timeout"`
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerHalfOpen
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker is a circuit breaker around the connection of a scheme. It opens on consecutive transport
+// failures and then fails fast, after the open duration it probes the scheme with an echo and closes
+// when the echo is answered.
+type Breaker struct {
+	scheme   string
+	conn     SchemeConnection
+	conf     BreakerConfig
+	logger   platform.Logger
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	probeAt  time.Time
+}
+
+func NewBreaker(scheme string, conn SchemeConnection, conf BreakerConfig, logger platform.Logger) *Breaker {
+	if conf.OpenDuration == 0 {
+		conf.OpenDuration = defaultOpenDuration
+	}
+	if conf.ProbeTimeout == 0 {
+		conf.ProbeTimeout = defaultProbeTimeout
+	}
+
+	return &Breaker{
+		scheme: scheme,
+		conn:   conn,
+		conf:   conf,
+		logger: logger,
+	}
+}
+
+func (b *Breaker) Authorize(ctx context.Context, a *entity.Authorization) error {
+	return b.call(ctx, func(ctx context.Context) error {
+		return b.conn.Authorize(ctx, a)
+	})
+}
+
+// Reverse is always sent, the reversal releases the funds the issuer holds for an authorization
+func (b *Breaker) Reverse(ctx context.Context, r *entity.Reversal) error {
+	return b.conn.Reverse(ctx, r)
+}
+
+func (b *Breaker) Refund(ctx context.Context, r *entity.Refund) error {
+	return b.call(ctx, func(ctx context.Context) error {
+		return b.conn.Refund(ctx, r)
+	})
+}
+
+func (b *Breaker) Payout(ctx context.Context, p *entity.Payout) error {
+	return b.call(ctx, func(ctx context.Context) error {
+		return b.conn.Payout(ctx, p)
+	})
+}
+
+// Echo is always sent, so the scheme can be checked by hand while the breaker is open
+func (b *Breaker) Echo(ctx context.Context) error {
+	return b.conn.Echo(ctx)
+}
+
+// State returns the state of the breaker
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) call(ctx context.Context, send func(ctx context.Context) error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := send(ctx)
+	b.record(ctx, err)
+
+	if errors.Is(err, connection.ErrPoolSaturated) {
+		return &UnavailableError{Scheme: b.scheme, RetryAfter: saturatedRetryAfter, Err: err}
+	}
+
+	return err
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerClosed {
+		return nil
+	}
+
+	retryAfter := time.Until(b.probeAt)
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+
+	return &UnavailableError{Scheme: b.scheme, RetryAfter: retryAfter.Round(time.Second), Err: ErrCircuitOpen}
+}
+
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isTransportFailure(err) {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerClosed && b.conf.FailureThreshold > 0 && b.failures >= b.conf.FailureThreshold {
+		b.logger.Warning(ctx, fmt.Sprintf("%s circuit breaker open after %d transport failures: %s", b.scheme, b.failures, err))
+		b.open()
+	}
+}
+
+// open fails the requests fast until the probe, the caller must hold the mutex
+func (b *Breaker) open() {
+	b.state = BreakerOpen
+	b.probeAt = time.Now().Add(b.conf.OpenDuration)
+	time.AfterFunc(b.conf.OpenDuration, b.probe)
+}
+
+func (b *Breaker) probe() {
+	b.mu.Lock()
+	b.state = BreakerHalfOpen
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.conf.ProbeTimeout)
+	defer cancel()
+
+	err := b.conn.Echo(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil {
+		b.logger.Warning(ctx, fmt.Sprintf("%s circuit breaker probe failed: %s", b.scheme, err))
+		b.open()
+		return
+	}
+
+	b.logger.Info(ctx, fmt.Sprintf("%s circuit breaker closed, echo answered", b.scheme))
+	b.state = BreakerClosed
+	b.failures = 0
+}
+
+// isTransportFailure tells whether the scheme could not be reached, as opposed to a request that was
+// refused or canceled by us
+func isTransportFailure(err error) bool {
+	var netErr net.Error
+
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, connection.ErrRequestTimeout),
+		errors.Is(err, connection.ErrRequestAbandoned),
+		errors.Is(err, connection.ErrNotConnected):
+		return true
+	case errors.As(err, &netErr):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,159 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+type fakeConnection struct {
+	err     error
+	echoErr error
+	calls   int
+}
+
+func (f *fakeConnection) Authorize(ctx context.Context, a *entity.Authorization) error {
+	f.calls++
+	return f.err
+}
+
+func (f *fakeConnection) Reverse(ctx context.Context, r *entity.Reversal) error {
+	f.calls++
+	return f.err
+}
+
+func (f *fakeConnection) Refund(ctx context.Context, r *entity.Refund) error {
+	f.calls++
+	return f.err
+}
+
+func (f *fakeConnection) Payout(ctx context.Context, p *entity.Payout) error {
+	f.calls++
+	return f.err
+}
+
+func (f *fakeConnection) Echo(ctx context.Context) error {
+	return f.echoErr
+}
+
+func TestBreaker(t *testing.T) {
+	conn := &fakeConnection{err: connection.ErrRequestTimeout}
+	b := NewBreaker("visa", conn, BreakerConfig{FailureThreshold: 2, OpenDuration: 20 * time.Millisecond}, logging.Logger{})
+
+	for i := 0; i < 2; i++ {
+		if err := b.Authorize(context.Background(), &entity.Authorization{}); !errors.Is(err, connection.ErrRequestTimeout) {
+			t.Fatalf("Authorize() error = %v, want %v", err, connection.ErrRequestTimeout)
+		}
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("State() = %s, want %s", b.State(), BreakerOpen)
+	}
+
+	var unavailable *UnavailableError
+	if err := b.Authorize(context.Background(), &entity.Authorization{}); !errors.As(err, &unavailable) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Authorize() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if conn.calls != 2 {
+		t.Errorf("Authorize() sent %d requests while open, want 2", conn.calls)
+	}
+	if unavailable.RetryAfter < time.Second {
+		t.Errorf("Authorize() retry after %s, want at least a second", unavailable.RetryAfter)
+	}
+	if err := b.Reverse(context.Background(), &entity.Reversal{}); !errors.Is(err, connection.ErrRequestTimeout) {
+		t.Errorf("Reverse() error = %v while open, want %v", err, connection.ErrRequestTimeout)
+	}
+	if conn.calls != 3 {
+		t.Errorf("Reverse() was not sent while open")
+	}
+
+	conn.err = nil
+	deadline := time.Now().Add(time.Second)
+	for b.State() != BreakerClosed && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("State() = %s after the probe echo, want %s", b.State(), BreakerClosed)
+	}
+	if err := b.Authorize(context.Background(), &entity.Authorization{}); err != nil {
+		t.Errorf("Authorize() error = %v", err)
+	}
+}
+
+// poolConnection sends every request to a connection pool, like the scheme connections do
+type poolConnection struct {
+	pool *connection.Pool
+}
+
+type received struct {
+	err error
+}
+
+func (r received) IsRequestResponse(connection.Request) bool { return true }
+
+func (r received) PacketToSend() ([]byte, error) { return nil, nil }
+
+func (r received) Error() error { return r.err }
+
+func (c poolConnection) send(ctx context.Context) error {
+	return c.pool.Send(ctx, nil).Error()
+}
+
+func (c poolConnection) Authorize(ctx context.Context, a *entity.Authorization) error {
+	return c.send(ctx)
+}
+
+func (c poolConnection) Reverse(ctx context.Context, r *entity.Reversal) error { return c.send(ctx) }
+
+func (c poolConnection) Refund(ctx context.Context, r *entity.Refund) error { return c.send(ctx) }
+
+func (c poolConnection) Payout(ctx context.Context, p *entity.Payout) error { return c.send(ctx) }
+
+func (c poolConnection) Echo(ctx context.Context) error { return c.send(ctx) }
+
+func TestBreaker_opensWithoutConnectedConnections(t *testing.T) {
+	// The pool is not started, so none of its connections is connected, like when the scheme is down
+	pool := connection.NewPool(connection.PoolConfiguration{Name: "mastercard", MaxConnections: 2, TickDelay: time.Minute},
+		func(_ []byte, err error) connection.Received { return received{err: err} }, logging.Logger{}, 0)
+	b := NewBreaker("mastercard", poolConnection{pool: pool}, BreakerConfig{FailureThreshold: 2, OpenDuration: time.Minute}, logging.Logger{})
+
+	for i := 0; i < 2; i++ {
+		if err := b.Authorize(context.Background(), &entity.Authorization{}); !errors.Is(err, connection.ErrNotConnected) {
+			t.Fatalf("Authorize() error = %v, want %v", err, connection.ErrNotConnected)
+		}
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("State() = %s, want %s", b.State(), BreakerOpen)
+	}
+	if err := b.Authorize(context.Background(), &entity.Authorization{}); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Authorize() error = %v, want %v", err, ErrCircuitOpen)
+	}
+}
+
+func TestBreaker_ignoresRefusedRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "canceled", err: connection.ErrRequestCanceled},
+		{name: "draining", err: connection.ErrPoolDraining},
+		{name: "saturated", err: connection.ErrPoolSaturated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker("mastercard", &fakeConnection{err: tt.err}, BreakerConfig{FailureThreshold: 1}, logging.Logger{})
+
+			err := b.Refund(context.Background(), &entity.Refund{})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Refund() error = %v, want %v", err, tt.err)
+			}
+			if b.State() != BreakerClosed {
+				t.Errorf("State() = %s, want %s", b.State(), BreakerClosed)
+			}
+		})
+	}
+}
@@ -7,12 +7,18 @@ import (
 	"strings"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
 	"gitlab.cmpayments.local/creditcard/platform"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
 )
 
-var FormatError = errors.New("message format error")
+var (
+	FormatError = errors.New("message format error")
+	// ErrUnanswered is returned for an authorization that was sent to the scheme but abandoned before
+	// the response arrived, the issuer may have approved it so it must be reversed
+	ErrUnanswered = errors.New("authorization unanswered")
+)
 
 type SchemeConnections map[string]SchemeConnection
 
@@ -34,6 +40,7 @@ type SchemeConnection interface {
 	Authorize(ctx context.Context, authorization *entity.Authorization) error
 	Reverse(ctx context.Context, reversal *entity.Reversal) error
 	Refund(ctx context.Context, refund *entity.Refund) error
+	Payout(ctx context.Context, payout *entity.Payout) error
 	Echo(ctx context.Context) error
 }
 
@@ -58,6 +65,9 @@ func (m Mapper) SendAuthorization(ctx context.Context, a *entity.Authorization)
 
 	// this function should only return an error and should enrich the passed in authorize
 	err := sc.Authorize(ctx, a)
+	if errors.Is(err, connection.ErrShutdownDeadline) {
+		return fmt.Errorf("failed to authorize with the cardscheme: %w: %w", ErrUnanswered, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to authorize with the cardscheme: %w", err)
 	}
@@ -105,3 +115,21 @@ func (m Mapper) SendRefund(ctx context.Context, r *entity.Refund) error {
 
 	return nil
 }
+
+func (m Mapper) SendPayout(ctx context.Context, p *entity.Payout) error {
+	sc := m.sc.For(p.Card.Info.Scheme)
+	if sc == nil {
+		return errors.New("no connection for scheme " + p.Card.Info.Scheme)
+	}
+
+	err := sc.Payout(ctx, p)
+	if err != nil {
+		return fmt.Errorf("failed to pay out with the cardscheme: %w", err)
+	}
+
+	if p.CardSchemeData.Response.ResponseCode.Value == processing.FormatError {
+		return FormatError
+	}
+
+	return nil
+}
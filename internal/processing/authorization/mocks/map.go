@@ -63,6 +63,20 @@ func (mr *MockSchemeConnectionMockRecorder) Echo(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Echo", reflect.TypeOf((*MockSchemeConnection)(nil).Echo), ctx)
 }
 
+// Payout mocks base method.
+func (m *MockSchemeConnection) Payout(ctx context.Context, payout *entity.Payout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Payout", ctx, payout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Payout indicates an expected call of Payout.
+func (mr *MockSchemeConnectionMockRecorder) Payout(ctx, payout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Payout", reflect.TypeOf((*MockSchemeConnection)(nil).Payout), ctx, payout)
+}
+
 // Refund mocks base method.
 func (m *MockSchemeConnection) Refund(ctx context.Context, refund *entity.Refund) error {
 	m.ctrl.T.Helper()
@@ -20,31 +20,71 @@ type visaBinTableRecord struct {
 	brandProductCode        string
 }
 
-func parseVisaBinTableResourceFileLine(l string) visaBinTableRecord {
+// ardefRecordLength is the minimum length of an ARDEF record, up to the product code
+const ardefRecordLength = 60
+
+func parseVisaBinTableResourceFileLine(l string) (visaBinTableRecord, error) {
+	if len(l) < ardefRecordLength {
+		return visaBinTableRecord{}, fmt.Errorf("record is %d characters, expected at least %d", len(l), ardefRecordLength)
+	}
+
 	btr := visaBinTableRecord{}
 
-	btr.lowPrimaryAccountRange, _ = strconv.ParseUint(strings.TrimSpace(l[12:21]), 10, 64)
-	btr.highPrimaryAccountRange, _ = strconv.ParseUint(strings.TrimSpace(l[0:9]), 10, 64)
+	var err error
+	if btr.lowPrimaryAccountRange, err = strconv.ParseUint(strings.TrimSpace(l[12:21]), 10, 64); err != nil {
+		return visaBinTableRecord{}, fmt.Errorf("invalid low account range: %w", err)
+	}
+	if btr.highPrimaryAccountRange, err = strconv.ParseUint(strings.TrimSpace(l[0:9]), 10, 64); err != nil {
+		return visaBinTableRecord{}, fmt.Errorf("invalid high account range: %w", err)
+	}
 	btr.brandProductCode = strings.TrimSpace(l[58:60])
 	btr.customerID = strings.TrimSpace(l[25:30])
 	btr.issuingCountry = strings.TrimSpace(l[43:45])
 
-	return btr
+	return btr, nil
 }
 
+// ParseArdef parses the Visa account range definition file. Like the Mastercard BIN table resource file,
+// the file must start with a header and end with a trailer holding the number of detail records, so a
+// truncated file is refused instead of loaded.
 func ParseArdef(ctx context.Context, logger platform.Logger, r io.Reader, addVisaTestPans bool) ([]Range, error) {
 	scanner := bufio.NewScanner(r)
 
 	var btrs []visaBinTableRecord
-	for scanner.Scan() {
-		line := scanner.Text()
-		btrs = append(btrs, parseVisaBinTableResourceFileLine(line))
+	var headers, trailers int
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		switch {
+		case trailers > 0:
+			return nil, fmt.Errorf("ParseArdef(): line %d: record after trailer", line)
+		case strings.HasPrefix(text, "H"):
+			headers++
+		case strings.HasPrefix(text, "T"):
+			trailers++
+			count, err := parseTrailerCount(text)
+			if err != nil {
+				return nil, fmt.Errorf("ParseArdef(): line %d: %w", line, err)
+			}
+			if count != len(btrs) {
+				return nil, fmt.Errorf("ParseArdef(): trailer counts %d records, file has %d", count, len(btrs))
+			}
+		default:
+			btr, err := parseVisaBinTableResourceFileLine(text)
+			if err != nil {
+				return nil, fmt.Errorf("ParseArdef(): line %d: %w", line, err)
+			}
+			btrs = append(btrs, btr)
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("ParseArdef(): %w", err)
 	}
 
+	if headers != 1 || trailers != 1 {
+		return nil, fmt.Errorf("ParseArdef(): expected 1 header and 1 trailer, found %d and %d", headers, trailers)
+	}
+
 	ranges := map[uint64]Range{}
 	var rng Range
 	var rngs []Range
@@ -72,6 +112,11 @@ func ParseArdef(ctx context.Context, logger platform.Logger, r io.Reader, addVis
 		rngs = append(rngs, rng)
 	}
 
+	// The certification ranges fall within the production ranges, so they are added after validating
+	if err := validateRanges(rngs); err != nil {
+		return nil, fmt.Errorf("ParseArdef(): %w", err)
+	}
+
 	if addVisaTestPans {
 		rngs = addCertificationRanges(rngs)
 	}
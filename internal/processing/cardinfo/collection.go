@@ -2,8 +2,11 @@ package cardinfo
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/btree"
 )
@@ -32,13 +35,35 @@ func NewCollection(blockedBins []string) *Collection {
 		blockedBins: blockedBins,
 		btree:       btree.New(2), //nolint:gomnd
 		setMutex:    &sync.Mutex{},
+		statuses:    map[string]LoadStatus{},
+		statusMutex: &sync.RWMutex{},
+		previous:    map[string]generation{},
 	}
 }
 
 type Collection struct {
 	blockedBins []string
 	btree       *btree.BTree
+	widest      uint64
 	setMutex    *sync.Mutex
+	statuses    map[string]LoadStatus
+	statusMutex *sync.RWMutex
+	previous    map[string]generation
+}
+
+// generation is a BIN range table that was replaced, it is kept so a bad table can be rolled back
+type generation struct {
+	status LoadStatus
+	ranges []Range
+}
+
+// LoadStatus describes the last BIN range table that was loaded for a scheme
+type LoadStatus struct {
+	Scheme   string
+	Source   string
+	File     string
+	LoadedAt time.Time
+	Ranges   int
 }
 
 func (c *Collection) Set(source string, ranges []Range) {
@@ -46,28 +71,141 @@ func (c *Collection) Set(source string, ranges []Range) {
 	c.setMutex.Lock()
 	defer c.setMutex.Unlock()
 
-	// Clone the tree, so the change-over is atomic
-	bt := c.btree.Clone()
+	c.set(source, ranges)
+}
 
-	// Delete everything for the scheme that is being updated
-	bt.Ascend(func(i btree.Item) bool {
-		if i.(Range).Source == source {
-			bt.Delete(i)
+// Replace swaps in the ranges of the table described by status, unless the table has more than
+// maxShrinkPercentage percent fewer ranges than the loaded table of the source. The loaded table
+// is kept as the previous generation of the scheme.
+func (c *Collection) Replace(status LoadStatus, ranges []Range, maxShrinkPercentage int) (RangeDiff, error) {
+	c.setMutex.Lock()
+	defer c.setMutex.Unlock()
+
+	current := c.ranges(status.Source)
+	if err := checkShrink(len(current), len(ranges), maxShrinkPercentage); err != nil {
+		return RangeDiff{}, err
+	}
+
+	next := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		next = append(next, c.normalize(status.Source, r))
+	}
+
+	diff := diffRanges(current, next)
+
+	if len(current) > 0 {
+		c.statusMutex.RLock()
+		c.previous[status.Scheme] = generation{status: c.statuses[status.Scheme], ranges: current}
+		c.statusMutex.RUnlock()
+	}
+
+	c.set(status.Source, next)
+	c.Loaded(status)
+
+	return diff, nil
+}
+
+// Rollback restores the previous generation of the table of the scheme. The table that is rolled
+// back becomes the previous generation, so a rollback can be undone by rolling back again.
+func (c *Collection) Rollback(scheme string) (LoadStatus, error) {
+	c.setMutex.Lock()
+	defer c.setMutex.Unlock()
+
+	prev, ok := c.previous[scheme]
+	if !ok {
+		return LoadStatus{}, ErrNoPreviousGeneration
+	}
+
+	c.statusMutex.RLock()
+	current := generation{status: c.statuses[scheme], ranges: c.ranges(prev.status.Source)}
+	c.statusMutex.RUnlock()
+
+	c.set(prev.status.Source, prev.ranges)
+	c.Loaded(prev.status)
+	c.previous[scheme] = current
+
+	return prev.status, nil
+}
+
+// ranges returns the ranges of the source in the tree, the caller must hold the setMutex
+func (c *Collection) ranges(source string) []Range {
+	var ranges []Range
+	c.btree.Ascend(func(i btree.Item) bool {
+		if r := i.(Range); r.Source == source {
+			ranges = append(ranges, r)
 		}
 		return true
 	})
 
+	return ranges
+}
+
+func (c *Collection) normalize(source string, r Range) Range {
+	// Ensure all the data is as we need it
+	r.Low = normalizePAN(r.Low)
+	r.High = normalizePAN(r.High)
+	r.Source = source
+	r.IsBlocked = c.isBlocked(r.Low)
+
+	return r
+}
+
+// set swaps in the ranges of the source, the caller must hold the setMutex
+func (c *Collection) set(source string, ranges []Range) {
+	// Clone the tree, so the change-over is atomic
+	bt := c.btree.Clone()
+
+	// Delete everything for the scheme that is being updated, the tree must not be changed while
+	// iterating over it
+	for _, r := range c.ranges(source) {
+		bt.Delete(r)
+	}
+
 	// Add all scheme nodes
 	for _, r := range ranges {
-		// Ensure all the data is as we need it
-		r.Low = normalizePAN(r.Low)
-		r.High = normalizePAN(r.High)
-		r.Source = source
-		r.IsBlocked = c.isBlocked(r.Low)
-		bt.ReplaceOrInsert(r)
+		bt.ReplaceOrInsert(c.normalize(source, r))
 	}
 
+	var widest uint64
+	bt.Ascend(func(i btree.Item) bool {
+		if w := i.(Range).width(); w > widest {
+			widest = w
+		}
+		return true
+	})
+
+	// A lookup in the old tree must not stop before a range of the old tree that holds the bin, so the
+	// widest range only shrinks after the change-over
+	if widest > c.widest {
+		c.widest = widest
+	}
 	c.btree = bt
+	c.widest = widest
+}
+
+// Loaded records the status of the last table that was loaded for the scheme
+func (c *Collection) Loaded(status LoadStatus) {
+	c.statusMutex.Lock()
+	defer c.statusMutex.Unlock()
+
+	c.statuses[status.Scheme] = status
+}
+
+// LoadStatuses returns the status of the last table of every scheme, ordered by scheme
+func (c *Collection) LoadStatuses() []LoadStatus {
+	c.statusMutex.RLock()
+	defer c.statusMutex.RUnlock()
+
+	statuses := make([]LoadStatus, 0, len(c.statuses))
+	for _, s := range c.statuses {
+		statuses = append(statuses, s)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Scheme < statuses[j].Scheme
+	})
+
+	return statuses
 }
 
 func (c Collection) isBlocked(low string) bool {
@@ -94,6 +232,37 @@ func (c Collection) Find(pan string) (r Range, ok bool) {
 	return
 }
 
+// FindBin returns the range that holds the card numbers starting with the bin. When ranges are nested,
+// the narrowest range that holds all card numbers of the bin is returned. Without such a range, the
+// narrowest range that holds some of them is returned.
+func (c Collection) FindBin(bin string) (r Range, ok bool) {
+	low := normalizePAN(bin)
+	high := padPAN(bin, '9')
+	lowNumber := number(low)
+
+	var holdsBin bool
+	c.btree.DescendLessOrEqual(Range{Low: high}, func(i btree.Item) bool {
+		fr := i.(Range)
+		if fr.High < low {
+			// A wider range that starts before this one may still hold the bin, unless it starts further
+			// below the bin than the widest range is wide
+			return lowNumber-number(fr.Low) <= c.widest
+		}
+
+		frHoldsBin := normalizePAN(fr.Low) <= low && prefix(fr.High, len(bin)) >= bin
+		if !ok || (frHoldsBin && !holdsBin) || (frHoldsBin == holdsBin && fr.width() < r.width()) {
+			r = fr
+			ok = true
+			holdsBin = frHoldsBin
+		}
+
+		// The ranges that start before a range that holds the whole bin are wider
+		return !frHoldsBin
+	})
+
+	return
+}
+
 type Range struct {
 	Low               string
 	High              string
@@ -108,6 +277,24 @@ type Range struct {
 	IsBlocked         bool
 }
 
+func prefix(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// width is the number of card numbers in the range
+func (r Range) width() uint64 {
+	return number(r.High) - number(r.Low)
+}
+
+// number is the card number as a 19 digit number
+func number(pan string) uint64 {
+	n, _ := strconv.ParseUint(normalizePAN(pan), 10, 64)
+	return n
+}
+
 func (r Range) Less(than btree.Item) bool {
 	return than.(Range).Low > r.Low
 }
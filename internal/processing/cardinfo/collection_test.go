@@ -1,6 +1,7 @@
 package cardinfo
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -35,3 +36,98 @@ func TestCollection_isBlocked(t *testing.T) {
 		})
 	}
 }
+
+func TestCollection_FindBin(t *testing.T) {
+	c := NewCollection([]string{"520475"})
+	c.Set("test", []Range{
+		{Low: "5204740000000000", High: "5204749999999999", Scheme: "mastercard"},
+		{Low: "5204750000000000", High: "5204759999999999", Scheme: "mastercard"},
+		{Low: "4111111111111110", High: "4111111145551143", Scheme: "visa"},
+	})
+
+	tests := []struct {
+		name        string
+		bin         string
+		wantOk      bool
+		wantScheme  string
+		wantBlocked bool
+	}{
+		{name: "six_digit_bin", bin: "520474", wantOk: true, wantScheme: "mastercard"},
+		{name: "blocked_bin", bin: "520475", wantOk: true, wantScheme: "mastercard", wantBlocked: true},
+		{name: "bin_within_range", bin: "41111111222", wantOk: true, wantScheme: "visa"},
+		{name: "unknown_bin", bin: "520476", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.FindBin(tt.bin)
+			if ok != tt.wantOk {
+				t.Fatalf("FindBin() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got.Scheme != tt.wantScheme || got.IsBlocked != tt.wantBlocked {
+				t.Errorf("FindBin() = %v, want scheme %s blocked %v", got, tt.wantScheme, tt.wantBlocked)
+			}
+		})
+	}
+}
+
+func TestCollection_FindBin_NestedRanges(t *testing.T) {
+	c := NewCollection(nil)
+	c.Set("test", []Range{
+		{Low: "4000000000000000", High: "4999999999999999", ProductID: "wide"},
+		{Low: "4111110000000000", High: "4111119999999999", ProductID: "nested"},
+		{Low: "4111115000000000", High: "4111115999999999", ProductID: "innermost"},
+	})
+
+	tests := []struct {
+		name          string
+		bin           string
+		wantProductID string
+	}{
+		{name: "bin_in_innermost_range", bin: "41111155", wantProductID: "innermost"},
+		{name: "bin_holding_innermost_range", bin: "411111", wantProductID: "nested"},
+		{name: "bin_after_innermost_range", bin: "41111170", wantProductID: "nested"},
+		{name: "bin_before_nested_range", bin: "411100", wantProductID: "wide"},
+		{name: "bin_after_nested_range", bin: "422222", wantProductID: "wide"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.FindBin(tt.bin)
+			if !ok {
+				t.Fatalf("FindBin() ok = false, want true")
+			}
+			if got.ProductID != tt.wantProductID {
+				t.Errorf("FindBin() = %v, want product %s", got, tt.wantProductID)
+			}
+		})
+	}
+}
+
+func TestCollection_FindBin_UnknownBinBelowManyRanges(t *testing.T) {
+	ranges := make([]Range, 0, 10000)
+	for i := 0; i < 10000; i++ {
+		ranges = append(ranges, Range{
+			Low:  fmt.Sprintf("51%06d00000000", i),
+			High: fmt.Sprintf("51%06d99999999", i),
+		})
+	}
+
+	c := NewCollection(nil)
+	c.Set("narrow", ranges)
+	c.Set("wide", []Range{{Low: "3000000000000000", High: "3999999999999999", ProductID: "wide"}})
+
+	if _, ok := c.FindBin("520000"); ok {
+		t.Errorf("FindBin(520000) ok = true, want false")
+	}
+	if got, ok := c.FindBin("350000"); !ok || got.ProductID != "wide" {
+		t.Errorf("FindBin(350000) = %v, %v, want the wide range", got, ok)
+	}
+
+	// Without the wide range the descent stops after the narrow ranges that can hold the bin
+	c.Set("wide", nil)
+	if want := ranges[0].width(); c.widest != want {
+		t.Errorf("widest = %d, want %d", c.widest, want)
+	}
+	if _, ok := c.FindBin("520000"); ok {
+		t.Errorf("FindBin(520000) ok = true, want false")
+	}
+}
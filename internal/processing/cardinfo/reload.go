@@ -0,0 +1,120 @@
+package cardinfo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrTableShrunk          = errors.New("BIN range table shrinks too much")
+	ErrNoPreviousGeneration = errors.New("no previous BIN range table to roll back to")
+)
+
+// RangeDiff holds the ranges that a new BIN range table adds, removes and changes compared to the
+// table that is loaded. Ranges are matched on their low card number.
+type RangeDiff struct {
+	Added   []Range
+	Removed []Range
+	Changed []Range
+}
+
+func diffRanges(current, next []Range) RangeDiff {
+	var diff RangeDiff
+
+	byLow := make(map[string]Range, len(current))
+	for _, r := range current {
+		byLow[r.Low] = r
+	}
+
+	for _, r := range next {
+		c, ok := byLow[r.Low]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, r)
+		case c != r:
+			diff.Changed = append(diff.Changed, r)
+		}
+		delete(byLow, r.Low)
+	}
+
+	for _, r := range current {
+		if _, ok := byLow[r.Low]; ok {
+			diff.Removed = append(diff.Removed, r)
+		}
+	}
+
+	return diff
+}
+
+// checkShrink refuses a table that has more than maxShrinkPercentage percent fewer ranges than the
+// table that is loaded. A first load is always accepted.
+func checkShrink(current, next, maxShrinkPercentage int) error {
+	if current == 0 || next >= current {
+		return nil
+	}
+
+	shrink := (current - next) * 100 / current //nolint:gomnd
+	if shrink > maxShrinkPercentage {
+		return fmt.Errorf("%w: %d to %d ranges (%d%%, max %d%%)", ErrTableShrunk, current, next, shrink, maxShrinkPercentage)
+	}
+
+	return nil
+}
+
+// validateRanges checks that every range has a low below its high and that ranges either do not overlap
+// or are nested, like a range of a product within the range of its issuer. Low and High are treated as
+// prefixes, like the test ranges.
+func validateRanges(ranges []Range) error {
+	sorted := make([]Range, len(ranges))
+	copy(sorted, ranges)
+
+	// A range sorts before the ranges nested in it
+	sort.Slice(sorted, func(i, j int) bool {
+		if low, other := padPAN(sorted[i].Low, '0'), padPAN(sorted[j].Low, '0'); low != other {
+			return low < other
+		}
+		return padPAN(sorted[i].High, '9') > padPAN(sorted[j].High, '9')
+	})
+
+	// The ranges that hold the current range, the innermost last
+	var outer []Range
+	for _, r := range sorted {
+		low, high := padPAN(r.Low, '0'), padPAN(r.High, '9')
+		if low > high {
+			return fmt.Errorf("range %s-%s: low is above high", r.Low, r.High)
+		}
+
+		for len(outer) > 0 && padPAN(outer[len(outer)-1].High, '9') < low {
+			outer = outer[:len(outer)-1]
+		}
+
+		if len(outer) > 0 {
+			o := outer[len(outer)-1]
+			if padPAN(o.High, '9') < high || (padPAN(o.Low, '0') == low && padPAN(o.High, '9') == high) {
+				return fmt.Errorf("range %s-%s overlaps range %s-%s", r.Low, r.High, o.Low, o.High)
+			}
+		}
+
+		outer = append(outer, r)
+	}
+
+	return nil
+}
+
+// parseTrailerCount returns the number of detail records, which follows the record type of a trailer
+func parseTrailerCount(l string) (int, error) {
+	digits := strings.TrimSpace(l[1:])
+	if i := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		digits = digits[:i]
+	}
+
+	count, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid trailer record count: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,184 @@
+package cardinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+func tr54Record(low, high string) string {
+	record := fmt.Sprintf("D%-19s%-19sMCC%-11s", low, high, "00000999675")
+	return record + strings.Repeat(" ", tr54RecordLength-len(record))
+}
+
+func TestParseTr54(t *testing.T) {
+	header := "H20230701"
+	first := tr54Record("5204740000000000", "5204749999999999")
+	second := tr54Record("5204750000000000", "5204759999999999")
+
+	tests := []struct {
+		name    string
+		lines   []string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "valid_file",
+			lines: []string{header, first, second, "T000000002"},
+			want:  2,
+		},
+		{
+			name:    "missing_trailer",
+			lines:   []string{header, first, second},
+			wantErr: true,
+		},
+		{
+			name:    "trailer_count_mismatch",
+			lines:   []string{header, first, "T000000002"},
+			wantErr: true,
+		},
+		{
+			name:    "truncated_record",
+			lines:   []string{header, first, second[:100], "T000000002"},
+			wantErr: true,
+		},
+		{
+			name:    "overlapping_ranges",
+			lines:   []string{header, first, tr54Record("5204745000000000", "5204755000000000"), "T000000002"},
+			wantErr: true,
+		},
+		{
+			name:  "nested_ranges",
+			lines: []string{header, first, tr54Record("5204745000000000", "5204745999999999"), second, "T000000003"},
+			want:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTr54(context.Background(), logging.Logger{}, strings.NewReader(strings.Join(tt.lines, "\n")))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTr54() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParseTr54() got %d ranges, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func ardefRecord(low, high string) string {
+	record := fmt.Sprintf("%-9s   %-9s    %-5s             NL             A", high, low, "10001")
+	return record + strings.Repeat(" ", ardefRecordLength-len(record))
+}
+
+func TestParseArdef(t *testing.T) {
+	header := "H20230701"
+	first := ardefRecord("400000000", "400009999")
+	second := ardefRecord("400010000", "400019999")
+
+	tests := []struct {
+		name    string
+		lines   []string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "valid_file",
+			lines: []string{header, first, second, "T000000002"},
+			want:  2,
+		},
+		{
+			name:    "missing_header",
+			lines:   []string{first, second, "T000000002"},
+			wantErr: true,
+		},
+		{
+			name:    "missing_trailer",
+			lines:   []string{header, first, second},
+			wantErr: true,
+		},
+		{
+			name:    "trailer_count_mismatch",
+			lines:   []string{header, first, "T000000002"},
+			wantErr: true,
+		},
+		{
+			name:    "record_after_trailer",
+			lines:   []string{header, first, "T000000001", second},
+			wantErr: true,
+		},
+		{
+			name:  "nested_ranges",
+			lines: []string{header, first, ardefRecord("400005000", "400005999"), second, "T000000003"},
+			want:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArdef(context.Background(), logging.Logger{}, strings.NewReader(strings.Join(tt.lines, "\n")), false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArdef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParseArdef() got %d ranges, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestCollection_Replace(t *testing.T) {
+	ranges := func(n int) []Range {
+		var rs []Range
+		for i := 0; i < n; i++ {
+			rs = append(rs, Range{Low: fmt.Sprintf("52%04d", i), High: fmt.Sprintf("52%04d", i), Scheme: "mastercard"})
+		}
+		return rs
+	}
+	status := func(file string) LoadStatus {
+		return LoadStatus{Scheme: "mastercard", Source: "YTF.AR.TR54", File: file}
+	}
+
+	c := NewCollection(nil)
+	if _, err := c.Replace(status("first"), ranges(10), 10); err != nil {
+		t.Fatalf("Replace() first load error = %v", err)
+	}
+
+	if _, err := c.Replace(status("truncated"), ranges(8), 10); !errors.Is(err, ErrTableShrunk) {
+		t.Fatalf("Replace() error = %v, want %v", err, ErrTableShrunk)
+	}
+
+	next := ranges(9)
+	next[0].IssuerName = "Changed"
+	next = append(next, Range{Low: "530000", High: "530000", Scheme: "mastercard"})
+
+	diff, err := c.Replace(status("second"), next, 10)
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if len(diff.Added) != 1 || len(diff.Removed) != 1 || len(diff.Changed) != 1 {
+		t.Errorf("Replace() diff = %d added, %d removed, %d changed, want 1 of each",
+			len(diff.Added), len(diff.Removed), len(diff.Changed))
+	}
+
+	rolledBack, err := c.Rollback("mastercard")
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if rolledBack.File != "first" {
+		t.Errorf("Rollback() file = %s, want first", rolledBack.File)
+	}
+	if _, ok := c.FindBin("520009"); !ok {
+		t.Errorf("Rollback() range of the first table not found")
+	}
+	if _, ok := c.FindBin("530000"); ok {
+		t.Errorf("Rollback() range of the second table still found")
+	}
+
+	if _, err = c.Rollback("visa"); !errors.Is(err, ErrNoPreviousGeneration) {
+		t.Errorf("Rollback() error = %v, want %v", err, ErrNoPreviousGeneration)
+	}
+}
@@ -2,70 +2,110 @@ package cardinfo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"gitlab.cmpayments.local/creditcard/platform"
 )
 
-func NewService(c *Collection, s source, l platform.Logger, filetypes map[string]string, addVisaTestPans bool) Service {
+func NewService(c *Collection, s source, l platform.Logger, filetypes map[string]string, addVisaTestPans bool, maxShrinkPercentage int) Service {
 	return Service{
-		collection:      c,
-		source:          s,
-		log:             l,
-		filetypes:       filetypes,
-		addVisaTestPans: addVisaTestPans,
+		collection:          c,
+		source:              s,
+		log:                 l,
+		filetypes:           filetypes,
+		addVisaTestPans:     addVisaTestPans,
+		maxShrinkPercentage: maxShrinkPercentage,
 	}
 }
 
 type Service struct {
-	collection      *Collection
-	source          source
-	log             platform.Logger
-	filetypes       map[string]string
-	addVisaTestPans bool
+	collection          *Collection
+	source              source
+	log                 platform.Logger
+	filetypes           map[string]string
+	addVisaTestPans     bool
+	maxShrinkPercentage int
 }
 
+// LoadBinRanges loads the BIN range table of every scheme. The schemes are loaded independently,
+// a scheme whose table cannot be loaded keeps its current table.
 func (s Service) LoadBinRanges(ctx context.Context) error {
 	s.log.Info(ctx, "start loading BIN range tables")
 
+	var errs []error
 	for scheme, fileType := range s.filetypes {
-		rc, err := s.source.LastFile(ctx, scheme, fileType)
-		if err != nil {
-			return fmt.Errorf("LoadBinRanges(): %w", err)
+		if err := s.loadBinRange(ctx, scheme, fileType); err != nil {
+			errs = append(errs, fmt.Errorf("LoadBinRanges(): %s: %w", scheme, err))
 		}
+	}
 
-		// If there is no new file, don't do anything
-		if rc == nil {
-			return fmt.Errorf("%s BIN range table not found", scheme)
-		}
+	return errors.Join(errs...)
+}
 
-		defer rc.Close()
+func (s Service) loadBinRange(ctx context.Context, scheme, fileType string) error {
+	rc, file, err := s.source.LastFile(ctx, scheme, fileType)
+	if err != nil {
+		return err
+	}
 
-		s.log.Debug(ctx, fmt.Sprintf("found %s file, start parsing", s.filetypes))
+	if rc == nil {
+		return fmt.Errorf("%s BIN range table not found", scheme)
+	}
 
-		binRange := make([]Range, 0)
+	defer rc.Close()
 
-		switch scheme {
-		case "mastercard":
-			binRange, err = ParseTr54(ctx, s.log, rc)
-			if err != nil {
-				return fmt.Errorf("LoadBinRanges(): %w", err)
-			}
-		case "visa":
-			binRange, err = ParseArdef(ctx, s.log, rc, s.addVisaTestPans)
-			if err != nil {
-				return fmt.Errorf("ParseArdef(): %w", err)
-			}
-		}
+	s.log.Debug(ctx, fmt.Sprintf("found %s file %s, start parsing", scheme, file))
+
+	binRange := make([]Range, 0)
 
-		s.log.Info(ctx, fmt.Sprintf("Found %d card range rules", len(binRange)))
-		s.collection.Set(fileType, binRange)
+	switch scheme {
+	case "mastercard":
+		binRange, err = ParseTr54(ctx, s.log, rc)
+	case "visa":
+		binRange, err = ParseArdef(ctx, s.log, rc, s.addVisaTestPans)
+	}
+	if err != nil {
+		return err
 	}
 
+	s.log.Info(ctx, fmt.Sprintf("Found %d card range rules", len(binRange)))
+
+	diff, err := s.collection.Replace(LoadStatus{
+		Scheme:   scheme,
+		Source:   fileType,
+		File:     file,
+		LoadedAt: time.Now(),
+		Ranges:   len(binRange),
+	}, binRange, s.maxShrinkPercentage)
+	if err != nil {
+		return fmt.Errorf("%s refused: %w", file, err)
+	}
+
+	s.logDiff(ctx, scheme, file, diff)
+
 	return nil
 }
 
+// logDiff logs the number of added, removed and changed ranges, the ranges themselves are logged
+// at debug level
+func (s Service) logDiff(ctx context.Context, scheme, file string, diff RangeDiff) {
+	s.log.Info(ctx, fmt.Sprintf("loaded %s BIN range table %s: %d added, %d removed, %d changed",
+		scheme, file, len(diff.Added), len(diff.Removed), len(diff.Changed)))
+
+	for _, r := range diff.Added {
+		s.log.Debug(ctx, fmt.Sprintf("%s range added: %s-%s", scheme, r.Low, r.High))
+	}
+	for _, r := range diff.Removed {
+		s.log.Debug(ctx, fmt.Sprintf("%s range removed: %s-%s", scheme, r.Low, r.High))
+	}
+	for _, r := range diff.Changed {
+		s.log.Debug(ctx, fmt.Sprintf("%s range changed: %s-%s", scheme, r.Low, r.High))
+	}
+}
+
 func (s Service) LoadTest() error {
 	testCol := []Range{
 		{
@@ -179,10 +219,16 @@ func (s Service) LoadTest() error {
 	}
 
 	s.collection.Set("test", testCol)
+	s.collection.Loaded(LoadStatus{
+		Scheme:   "test",
+		Source:   "test",
+		LoadedAt: time.Now(),
+		Ranges:   len(testCol),
+	})
 
 	return nil
 }
 
 type source interface {
-	LastFile(ctx context.Context, scheme string, fileType string) (io.ReadCloser, error)
+	LastFile(ctx context.Context, scheme string, fileType string) (io.ReadCloser, string, error)
 }
@@ -0,0 +1,165 @@
+package cardinfo
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"gitlab.cmpayments.local/creditcard/platform"
+)
+
+var (
+	ErrTestCardNumber       = errors.New("test card numbers are not allowed")
+	ErrProductionCardNumber = errors.New("only test card numbers are allowed")
+)
+
+// TestRange is a range of card numbers that the schemes reserve for testing. Low and High
+// may be shorter than a card number, in which case they are treated as a prefix.
+type TestRange struct {
+	Low    string
+	High   string
+	Scheme string
+}
+
+func (r TestRange) contains(pan string) bool {
+	p := padPAN(pan, '0')
+	return padPAN(r.Low, '0') <= p && p <= padPAN(r.High, '9')
+}
+
+func padPAN(pan string, c byte) string {
+	if len(pan) >= 19 { //nolint:gomnd
+		return pan
+	}
+	return pan + strings.Repeat(string(c), 19-len(pan))
+}
+
+// TestRanges is the collection of scheme test ranges. It is kept apart from the Collection, which
+// holds the ranges of the BIN tables, because the test ranges are needed in every environment.
+type TestRanges struct {
+	ranges []TestRange
+	mutex  *sync.RWMutex
+}
+
+func NewTestRanges() *TestRanges {
+	return &TestRanges{
+		mutex: &sync.RWMutex{},
+	}
+}
+
+func (t *TestRanges) Set(ranges []TestRange) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.ranges = ranges
+}
+
+func (t *TestRanges) Contains(pan string) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, r := range t.ranges {
+		if r.contains(pan) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTestRanges returns the Visa certification card numbers that ParseArdef adds to the BIN
+// table and the Mastercard MTF card numbers. The MTF card numbers share their BINs with production
+// cards, so only the card numbers themselves are listed.
+func DefaultTestRanges() []TestRange {
+	var ranges []TestRange
+	for _, r := range addCertificationRanges(nil) {
+		ranges = append(ranges, TestRange{Low: r.Low, High: r.High, Scheme: r.Scheme})
+	}
+
+	return append(ranges,
+		TestRange{Low: "4111111111111110", High: "4111111145551143", Scheme: "visa"},
+		TestRange{Low: "2223001760002700", High: "2223001760002709", Scheme: "mastercard"},
+		TestRange{Low: "5204740000001000", High: "5204740000001009", Scheme: "mastercard"},
+	)
+}
+
+// ParseTestRanges reads test ranges with one "scheme,low,high" range per line. Empty lines and
+// lines starting with # are skipped.
+func ParseTestRanges(r io.Reader) ([]TestRange, error) {
+	scanner := bufio.NewScanner(r)
+
+	var ranges []TestRange
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) != 3 { //nolint:gomnd
+			return nil, fmt.Errorf("ParseTestRanges(): line %d: expected scheme,low,high", line)
+		}
+
+		tr := TestRange{
+			Scheme: strings.TrimSpace(fields[0]),
+			Low:    strings.TrimSpace(fields[1]),
+			High:   strings.TrimSpace(fields[2]),
+		}
+		if tr.Low == "" || tr.High == "" || padPAN(tr.Low, '0') > padPAN(tr.High, '9') {
+			return nil, fmt.Errorf("ParseTestRanges(): line %d: invalid range", line)
+		}
+
+		ranges = append(ranges, tr)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ParseTestRanges(): %w", err)
+	}
+
+	return ranges, nil
+}
+
+// CardNumberGuard decides whether a card number may be processed in this environment. Test environments
+// only accept card numbers in the test ranges, production environments refuse them.
+type CardNumberGuard struct {
+	allowProductionCardNumbers bool
+	testRanges                 *TestRanges
+	logger                     platform.Logger
+}
+
+func NewCardNumberGuard(allowProductionCardNumbers bool, testRanges *TestRanges, logger platform.Logger) *CardNumberGuard {
+	return &CardNumberGuard{
+		allowProductionCardNumbers: allowProductionCardNumbers,
+		testRanges:                 testRanges,
+		logger:                     logger,
+	}
+}
+
+func (g *CardNumberGuard) Check(ctx context.Context, pan string) error {
+	isTest := g.testRanges.Contains(pan)
+
+	var err error
+	switch {
+	case g.allowProductionCardNumbers && isTest:
+		err = ErrTestCardNumber
+	case !g.allowProductionCardNumbers && !isTest:
+		err = ErrProductionCardNumber
+	}
+
+	// Only the BIN is logged, the card number must never end up in the logs
+	bin := pan
+	if len(bin) > 6 { //nolint:gomnd
+		bin = bin[:6]
+	}
+
+	if err != nil {
+		g.logger.Info(ctx, fmt.Sprintf("card number with bin %s refused: %s", bin, err.Error()))
+		return err
+	}
+
+	g.logger.Debug(ctx, fmt.Sprintf("card number with bin %s allowed (test range: %t)", bin, isTest))
+
+	return nil
+}
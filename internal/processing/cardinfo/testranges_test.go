@@ -0,0 +1,116 @@
+package cardinfo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gitlab.cmpayments.local/libraries-go/logging"
+)
+
+func TestCardNumberGuard_Check(t *testing.T) {
+	testRanges := NewTestRanges()
+	testRanges.Set(DefaultTestRanges())
+
+	tests := []struct {
+		name                       string
+		allowProductionCardNumbers bool
+		pan                        string
+		want                       error
+	}{
+		{
+			name: "test_environment_visa_certification_pan",
+			pan:  "4761349999000039",
+		},
+		{
+			name: "test_environment_mastercard_mtf_pan",
+			pan:  "5204740000001003",
+		},
+		{
+			name: "test_environment_mastercard_mtf_2_series_pan",
+			pan:  "2223001760002704",
+		},
+		{
+			name: "test_environment_production_pan",
+			pan:  "5555341244441115",
+			want: ErrProductionCardNumber,
+		},
+		{
+			name: "test_environment_production_pan_in_mtf_bin",
+			pan:  "5204741234567891",
+			want: ErrProductionCardNumber,
+		},
+		{
+			name:                       "production_environment_production_pan_in_mtf_bin",
+			allowProductionCardNumbers: true,
+			pan:                        "5204741234567891",
+		},
+		{
+			name:                       "production_environment_production_pan_in_mtf_2_series_bin",
+			allowProductionCardNumbers: true,
+			pan:                        "2223009876543210",
+		},
+		{
+			name:                       "production_environment_mtf_pan",
+			allowProductionCardNumbers: true,
+			pan:                        "5204740000001002",
+			want:                       ErrTestCardNumber,
+		},
+		{
+			name:                       "production_environment_production_pan",
+			allowProductionCardNumbers: true,
+			pan:                        "5555341244441115",
+		},
+		{
+			name:                       "production_environment_test_pan",
+			allowProductionCardNumbers: true,
+			pan:                        "4111111111111111",
+			want:                       ErrTestCardNumber,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewCardNumberGuard(tt.allowProductionCardNumbers, testRanges, logging.Logger{})
+			if err := g.Check(context.Background(), tt.pan); !errors.Is(err, tt.want) {
+				t.Errorf("Check() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTestRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "ranges_with_comments",
+			input: "# MTF\nmastercard,520474,520474\n\nvisa,4111111111111110,4111111145551143\n",
+			want:  2,
+		},
+		{
+			name:    "missing_high",
+			input:   "mastercard,520474\n",
+			wantErr: true,
+		},
+		{
+			name:    "low_after_high",
+			input:   "visa,4111111145551143,4111111111111110\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTestRanges(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTestRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParseTestRanges() got %d ranges, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -11,19 +11,39 @@ import (
 	"gitlab.cmpayments.local/creditcard/platform"
 )
 
+// tr54RecordLength is the length of a detail record of the Mastercard BIN table resource file
+const tr54RecordLength = 334
+
+// ParseTr54 parses the Mastercard BIN table resource file. The file must start with a header,
+// end with a trailer holding the number of detail records and ranges may only overlap when they are
+// nested, so a truncated or corrupt file is refused instead of loaded.
 func ParseTr54(ctx context.Context, logger platform.Logger, r io.Reader) ([]Range, error) {
 	scanner := bufio.NewScanner(r)
 
 	var btrs []binTableRecord
-	for scanner.Scan() {
-		line := scanner.Text()
+	var headers, trailers int
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
 		switch {
-		case strings.HasPrefix(line, "H"):
-			continue
-		case strings.HasPrefix(line, "T"):
-			break
+		case trailers > 0:
+			return nil, fmt.Errorf("ParseTr54(): line %d: record after trailer", line)
+		case strings.HasPrefix(text, "H"):
+			headers++
+		case strings.HasPrefix(text, "T"):
+			trailers++
+			count, err := parseTrailerCount(text)
+			if err != nil {
+				return nil, fmt.Errorf("ParseTr54(): line %d: %w", line, err)
+			}
+			if count != len(btrs) {
+				return nil, fmt.Errorf("ParseTr54(): trailer counts %d records, file has %d", count, len(btrs))
+			}
 		default:
-			btrs = append(btrs, parseMastercardBINTableResourceFileLine(line))
+			btr, err := parseMastercardBINTableResourceFileLine(text)
+			if err != nil {
+				return nil, fmt.Errorf("ParseTr54(): line %d: %w", line, err)
+			}
+			btrs = append(btrs, btr)
 		}
 	}
 
@@ -31,6 +51,10 @@ func ParseTr54(ctx context.Context, logger platform.Logger, r io.Reader) ([]Rang
 		return nil, fmt.Errorf("ParseTr54(): %w", err)
 	}
 
+	if headers != 1 || trailers != 1 {
+		return nil, fmt.Errorf("ParseTr54(): expected 1 header and 1 trailer, found %d and %d", headers, trailers)
+	}
+
 	ranges := map[uint64]Range{}
 	var rng Range
 	var rngs []Range
@@ -58,6 +82,10 @@ func ParseTr54(ctx context.Context, logger platform.Logger, r io.Reader) ([]Rang
 		rngs = append(rngs, rng)
 	}
 
+	if err := validateRanges(rngs); err != nil {
+		return nil, fmt.Errorf("ParseTr54(): %w", err)
+	}
+
 	return rngs, nil
 }
 
@@ -81,13 +109,21 @@ func NewBINTableRecord() binTableRecord {
 	return binTableRecord{}
 }
 
-func parseMastercardBINTableResourceFileLine(l string) binTableRecord {
+func parseMastercardBINTableResourceFileLine(l string) (binTableRecord, error) {
+	if len(l) < tr54RecordLength {
+		return binTableRecord{}, fmt.Errorf("record is %d characters, expected %d", len(l), tr54RecordLength)
+	}
 
 	btr := NewBINTableRecord()
 
+	var err error
 	btr.recordTypeIdentifier = strings.TrimSpace(l[0:1])
-	btr.lowPrimaryAccountRange, _ = strconv.ParseUint(strings.TrimSpace(l[1:20]), 10, 64)
-	btr.highPrimaryAccountRange, _ = strconv.ParseUint(strings.TrimSpace(l[20:39]), 10, 64)
+	if btr.lowPrimaryAccountRange, err = strconv.ParseUint(strings.TrimSpace(l[1:20]), 10, 64); err != nil {
+		return binTableRecord{}, fmt.Errorf("invalid low account range: %w", err)
+	}
+	if btr.highPrimaryAccountRange, err = strconv.ParseUint(strings.TrimSpace(l[20:39]), 10, 64); err != nil {
+		return binTableRecord{}, fmt.Errorf("invalid high account range: %w", err)
+	}
 	btr.acceptanceBrand = strings.TrimSpace(l[39:42])
 	btr.customerID = strings.TrimSpace(l[42:53])
 	btr.customerName = strings.TrimSpace(l[53:123])
@@ -99,5 +135,5 @@ func parseMastercardBINTableResourceFileLine(l string) binTableRecord {
 	btr.nonReloadableIndicator, _ = strconv.Atoi(strings.TrimSpace(l[331:333]))
 	btr.anonymousPrepaidIndicator = strings.TrimSpace(l[333:334])
 
-	return btr
+	return btr, nil
 }
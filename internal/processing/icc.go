@@ -0,0 +1,12 @@
+package processing
+
+import (
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+// IssuerIccData returns the data objects the issuer sent for the chip, like the issuer authentication data with
+// the ARPC and the issuer scripts. They were read from the response, so they are written again.
+func IssuerIccData(tlvs iso8583.TLVs) []byte {
+	data, _ := tlvs.Marshal(iso8583.BerTLV)
+	return data
+}
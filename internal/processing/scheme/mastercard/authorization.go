@@ -8,6 +8,7 @@ import (
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/pos"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
 )
@@ -25,8 +26,21 @@ func authorizationSchemeData(a *entity.Authorization) {
 		FromAccountTypeCode: "00", // Default Account
 		ToAccountTypeCode:   "00", // Default Account
 	}
-	a.CardSchemeData.Request.POSEntryMode = posEntryMode(a.Source, a.Recurring.Subsequent)
+	if a.HasCashback() {
+		a.CardSchemeData.Request.ProcessingCode.TransactionTypeCode = "09" // Purchase with Cash Back
+	}
 	a.MastercardSchemeData.Request.AdditionalData = additionalData(*a)
+
+	if a.Source == entity.CardPresent {
+		// The POS entry mode is the one the terminal read the card with, unless the chip of a swiped card was not read
+		if a.ChipFallback() {
+			a.CardSchemeData.Request.POSEntryMode.PanEntryMode = entity.PANEntryChipFallback
+		}
+		a.MastercardSchemeData.Request.PointOfServiceData = cardPresentPointOfServiceData(a.Terminal, a.CardAcceptor.Address)
+		return
+	}
+
+	a.CardSchemeData.Request.POSEntryMode = posEntryMode(a.Source, a.Recurring.Subsequent)
 	a.MastercardSchemeData.Request.PointOfServiceData = pointOfServiceData(a.Source, a.Recurring.Subsequent, a.CardAcceptor.Address)
 }
 
@@ -56,10 +70,14 @@ func posEntryMode(s entity.Source, subseqRecurring bool) entity.POSEntryMode {
 
 func additionalData(a entity.Authorization) entity.AdditionalRequestData {
 	ad := entity.AdditionalRequestData{
-		TransactionCategoryCode:    transactionCategoryCode(a.Source),
-		OriginalEcommerceIndicator: originalEcommerceIndicator(a.ThreeDSecure, a.Recurring, a.Exemption),
-		AuthenticationData:         authenticationData(a.ThreeDSecure),
-		PinServiceCode:             "",
+		TransactionCategoryCode: transactionCategoryCode(a.Source),
+		AuthenticationData:      authenticationData(a.ThreeDSecure),
+		PinServiceCode:          "",
+	}
+
+	// Card present authorizations have no electronic commerce indicators
+	if a.Source != entity.CardPresent {
+		ad.OriginalEcommerceIndicator = originalEcommerceIndicator(a.ThreeDSecure, a.Recurring, a.Exemption)
 	}
 
 	if a.Exemption != "" {
@@ -104,6 +122,55 @@ func pointOfServiceData(source entity.Source, subseqRecurring bool, caa entity.C
 	}
 }
 
+func cardPresentPointOfServiceData(t entity.Terminal, caa entity.CardAcceptorAddress) entity.PointOfServiceData {
+	return entity.PointOfServiceData{
+		TerminalAttendance:                       mapTerminalAttendance(t.TerminalLevel),
+		TerminalLocation:                         0, // On premises of card acceptor facility
+		CardHolderPresence:                       0, // Cardholder present
+		CardPresence:                             0, // Card present
+		CardCaptureCapabilities:                  0, // Terminal/operator does not have card capture capability
+		TransactionStatus:                        0, // Normal request (original presentment)
+		TransactionSecurity:                      0, // No security concern
+		CardHolderActivatedTerminalLevel:         mapCardHolderActivatedTerminalLevel(t.TerminalLevel),
+		CardDataTerminalInputCapabilityIndicator: mapTerminalInputCapability(t.TerminalCapability),
+		AuthorizationLifeCycle:                   "00",
+		CountryCode:                              caa.CountryCode,
+		PostalCode:                               caa.PostalCode,
+	}
+}
+
+func mapTerminalAttendance(level entity.TerminalLevel) int {
+	if level == entity.AutomatedDispensingMachine {
+		return 1 // Unattended terminal
+	}
+
+	return 0 // Attended terminal
+}
+
+func mapCardHolderActivatedTerminalLevel(level entity.TerminalLevel) int {
+	if level == entity.AutomatedDispensingMachine {
+		return 1 // Authorized Level 1 CAT: Automated dispensing machine with PIN
+	}
+
+	return 0 // Not a CAT device
+}
+
+// DE61 SF11 POS Card Data Terminal Input Capability Indicator
+func mapTerminalInputCapability(capability entity.TerminalCapability) int {
+	switch capability {
+	case entity.MagneticStripeRead:
+		return 2 // Terminal supports magnetic stripe input only
+	case entity.TerminalContactlessEMVinput:
+		return 3 // Contactless EMV/Chip (Proximity Chip)
+	case entity.TerminalEMVContactChipAndMagneticStripeAndKeyEntry:
+		return 8 // Terminal supports EMV contact chip input, magnetic stripe input and key entry input
+	case entity.EMVProximityReadCapableOnly:
+		return 9 // EMV proximity read capable only
+	default:
+		return 0 // Input capability unknown or unspecified
+	}
+}
+
 func mapCardHolderPresence(s entity.Source, subseqRecurring bool) int {
 	switch {
 	case s == entity.Moto:
@@ -207,7 +274,7 @@ func authenticationData(t entity.ThreeDSecure) entity.AuthenticationData {
 	}
 }
 
-func authorizationResultFromMessage(msg Message) (entity.CardSchemeResponse, entity.MastercardSchemeResponse) {
+func authorizationResultFromMessage(msg Message, amount int) (entity.CardSchemeResponse, entity.MastercardSchemeResponse) {
 	csr := entity.CardSchemeResponse{
 		Status: entity.AuthorizationStatusFromCardSchemeResponseCode(msg.DataElements.DE39_ResponseCode),
 		ResponseCode: entity.ResponseCode{
@@ -216,6 +283,15 @@ func authorizationResultFromMessage(msg Message) (entity.CardSchemeResponse, ent
 		},
 		AuthorizationIDResponse: msg.DataElements.DE38_AuthorizationIdResponse,
 		TraceId:                 entity.TraceIDFromString(fmt.Sprintf("%s%s", msg.DataElements.DE63_NetworkData, msg.DataElements.DE15_SettlementDate)).String(),
+		IccData:                 processing.IssuerIccData(msg.DataElements.DE55_IntegratedCircuitCardData),
+	}
+	if msg.DataElements.DE39_ResponseCode == entity.PartialApprovalResponseCode {
+		// DE4 of the response holds the amount the issuer approved, DE6 the same amount in the currency of the cardholder
+		csr.ApprovedAmount = int(msg.DataElements.DE4_TransactionAmount)
+		if !entity.ValidPartialApproval(csr.ApprovedAmount, amount) {
+			csr.Status = entity.AuthorizeFailed
+			csr.ApprovedAmount = 0
+		}
 	}
 
 	msr := entity.MastercardSchemeResponse{
@@ -381,7 +457,44 @@ func mapInitiator(file entity.CitMitIndicator) string {
 	return initiatedBy + subCategory
 }
 
-func messageFromAuthorization(a entity.Authorization) *Message {
+// additionalAmounts returns the amounts that are part of the transaction amount, debited from the account of the
+// processing code
+func additionalAmounts(a entity.Authorization) []cis.DE54_AmountsAdditional {
+	if len(a.AdditionalAmounts) == 0 {
+		return nil
+	}
+
+	amounts := make([]cis.DE54_AmountsAdditional, 0, len(a.AdditionalAmounts))
+	for _, aa := range a.AdditionalAmounts {
+		amounts = append(amounts, cis.DE54_AmountsAdditional{
+			SF1_AccountType:  a.CardSchemeData.Request.ProcessingCode.FromAccountTypeCode,
+			SF2_AmountType:   mapAdditionalAmountType(aa.Type),
+			SF3_CurrencyCode: aa.Currency.Numeric(),
+			SF4_AmountSign:   "D", // Debit
+			SF5_Amount:       int64(aa.Amount),
+		})
+	}
+
+	return amounts
+}
+
+func mapAdditionalAmountType(t entity.AdditionalAmountType) string {
+	switch t {
+	case entity.CashbackAmount:
+		return "40" // Amount Cash Back
+	case entity.SurchargeAmount:
+		return "42" // Amount Surcharge
+	default:
+		return "44" // Amount Gratuity
+	}
+}
+
+func messageFromAuthorization(a entity.Authorization, pin entity.ZonePinBlock) (*Message, error) {
+	iccData, err := iso8583.ParseTLVs(a.Card.IccData, iso8583.BerTLV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid icc data: %w", err)
+	}
+
 	return &Message{
 		Mti: iso8583.NewMti(AuthorizationRequestMTI),
 		DataElements: cis.DataElements{
@@ -396,7 +509,10 @@ func messageFromAuthorization(a entity.Authorization) *Message {
 			DE18_MerchantType:                    a.CardAcceptor.CategoryCode,
 			DE20_PrimaryAccountNumberCountryCode: a.Card.Info.IssuerCountryCode,
 			DE22_PointOfServiceEntryMode:         fmt.Sprintf("%s%s", pos.PanEntryCode(a.CardSchemeData.Request.POSEntryMode.PanEntryMode), pos.PinEntryCode(a.CardSchemeData.Request.POSEntryMode.PinEntryMode)),
+			DE23_CardSequenceNumber:              a.Card.SequenceNumber,
 			DE32_AcquringInstitutionCode:         entity.MastercardInstitutionID,
+			DE35_TrackTwoData:                    a.Card.Track2Data,
+			DE41_CardAcceptorTerminalId:          a.Terminal.TerminalId,
 			DE42_CardAcceptorCodeId:              a.CardAcceptor.ID,
 			DE43_CardAcceptorNameAndLocation: &cis.DE43_CardAcceptorNameAndLocation{
 				SF1_Name:               a.CardAcceptor.Name,
@@ -408,11 +524,16 @@ func messageFromAuthorization(a entity.Authorization) *Message {
 				SE22_MultiPurposeMerchantIndicator:          cis.NewDE48_SE22MultiPurposeMerchantIndicator(a.MastercardSchemeData.Request.AdditionalData.LowRiskIndicator, mapInitiator(a.CitMitIndicator)),
 				SE42_ElectronicCommerceIndicators:           cis.NewDE48_SE42_ElectronicCommerceIndicators(a.MastercardSchemeData.Request.AdditionalData.OriginalEcommerceIndicator),
 				SE43_UniversalCardholderAuthenticationField: a.ThreeDSecure.AuthenticationVerificationValue,
-				SE63_TraceId:                    cis.NewDE48_SE63_TraceId(entity.TraceIDFromString(a.Recurring.TraceID)),
-				SE66_AuthenticationData:         cis.NewDE48_SE66_AuthenticationData(a.MastercardSchemeData.Request.AdditionalData.AuthenticationData),
-				SE92_CardholderVerificationCode: a.Card.Cvv,
+				SE61_ExtendedConditionCodes:                 cis.NewDE48_SE61_ExtendedConditionCodes(a.PartialApprovalSupported),
+				SE63_TraceId:                                cis.NewDE48_SE63_TraceId(entity.TraceIDFromString(a.Recurring.TraceID)),
+				SE66_AuthenticationData:                     cis.NewDE48_SE66_AuthenticationData(a.MastercardSchemeData.Request.AdditionalData.AuthenticationData),
+				SE92_CardholderVerificationCode:             a.Card.Cvv,
 			},
-			DE49_TransactionCurrencyCode: a.Currency.Numeric(),
+			DE49_TransactionCurrencyCode:           a.Currency.Numeric(),
+			DE52_PinData:                           string(pin.PinBlock),
+			DE53_SecurityRelatedControlInformation: securityRelatedControlInformation(pin),
+			DE54_AdditionalAmounts:                 additionalAmounts(a),
+			DE55_IntegratedCircuitCardData:         iccData,
 			DE61_PointOfServiceData: &cis.DE61_PointOfServiceData{
 				SF1_TerminalAttendance:                        fmt.Sprintf("%d", a.MastercardSchemeData.Request.PointOfServiceData.TerminalAttendance),
 				SF3_TerminalLocation:                          fmt.Sprintf("%d", a.MastercardSchemeData.Request.PointOfServiceData.TerminalLocation),
@@ -428,5 +549,5 @@ func messageFromAuthorization(a entity.Authorization) *Message {
 				SF14_PostalCode:                               a.MastercardSchemeData.Request.PointOfServiceData.PostalCode,
 			},
 		},
-	}
+	}, nil
 }
@@ -0,0 +1,254 @@
+package mastercard
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+func TestCardPresentPointOfServiceData(t *testing.T) {
+	address := entity.CardAcceptorAddress{City: "Breda", CountryCode: "NLD", PostalCode: "4811AA"}
+
+	tests := []struct {
+		name     string
+		terminal entity.Terminal
+		expected entity.PointOfServiceData
+	}{
+		{
+			name:     "attended_chip_terminal",
+			terminal: entity.Terminal{TerminalCapability: entity.TerminalEMVContactChipAndMagneticStripeAndKeyEntry},
+			expected: entity.PointOfServiceData{
+				TerminalAttendance:                       0,
+				CardHolderActivatedTerminalLevel:         0,
+				CardDataTerminalInputCapabilityIndicator: 8,
+				AuthorizationLifeCycle:                   "00",
+				CountryCode:                              "NLD",
+				PostalCode:                               "4811AA",
+			},
+		},
+		{
+			name:     "contactless_terminal",
+			terminal: entity.Terminal{TerminalCapability: entity.TerminalContactlessEMVinput},
+			expected: entity.PointOfServiceData{
+				CardDataTerminalInputCapabilityIndicator: 3,
+				AuthorizationLifeCycle:                   "00",
+				CountryCode:                              "NLD",
+				PostalCode:                               "4811AA",
+			},
+		},
+		{
+			name:     "unattended_magnetic_stripe_terminal",
+			terminal: entity.Terminal{TerminalCapability: entity.MagneticStripeRead, TerminalLevel: entity.AutomatedDispensingMachine},
+			expected: entity.PointOfServiceData{
+				TerminalAttendance:                       1,
+				CardHolderActivatedTerminalLevel:         1,
+				CardDataTerminalInputCapabilityIndicator: 2,
+				AuthorizationLifeCycle:                   "00",
+				CountryCode:                              "NLD",
+				PostalCode:                               "4811AA",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardPresentPointOfServiceData(tt.terminal, address)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got: %+v, wanted: %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMessageFromAuthorization_CardPresent(t *testing.T) {
+	// The data objects of an ARQC
+	iccData, _ := hex.DecodeString("9F26080102030405060708" + "9F270180" + "9F0206000000001000" + "9F34031F0302")
+
+	tests := []struct {
+		name           string
+		sequenceNumber string
+		iccData        []byte
+		wantDE23       string
+		wantDE55       iso8583.TLVs
+		wantErr        bool
+	}{
+		{
+			name:           "chip",
+			sequenceNumber: "001",
+			iccData:        iccData,
+			wantDE23:       "001",
+			wantDE55: iso8583.TLVs{
+				{Tag: "9F26", Value: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}},
+				{Tag: "9F27", Value: []byte{0x80}},
+				{Tag: "9F02", Value: []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00}},
+				{Tag: "9F34", Value: []byte{0x1F, 0x03, 0x02}},
+			},
+		},
+		{
+			name:     "magnetic_stripe",
+			wantDE23: "",
+		},
+		{
+			name:    "truncated_icc_data",
+			iccData: iccData[:6],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := entity.Authorization{
+				Source:   entity.CardPresent,
+				Currency: currencycode.Must("EUR"),
+				Card:     entity.Card{Number: "5204740000001002", SequenceNumber: tt.sequenceNumber, IccData: tt.iccData},
+			}
+			a.MastercardSchemeData.Request.PointOfServiceData.CountryCode = "NLD"
+
+			msg, err := messageFromAuthorization(a, entity.ZonePinBlock{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("messageFromAuthorization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			de := msg.DataElements
+			if de.DE23_CardSequenceNumber != tt.wantDE23 {
+				t.Errorf("DE23_CardSequenceNumber got: %s, wanted: %s", de.DE23_CardSequenceNumber, tt.wantDE23)
+			}
+			if len(de.DE55_IntegratedCircuitCardData) != len(tt.wantDE55) {
+				t.Fatalf("DE55_IntegratedCircuitCardData got: %v, wanted: %v", de.DE55_IntegratedCircuitCardData, tt.wantDE55)
+			}
+			for i, tlv := range tt.wantDE55 {
+				got := de.DE55_IntegratedCircuitCardData[i]
+				if got.Tag != tlv.Tag || !reflect.DeepEqual(got.Value, tlv.Value) {
+					t.Errorf("DE55_IntegratedCircuitCardData[%d] got: %s %X, wanted: %s %X", i, got.Tag, got.Value, tlv.Tag, tlv.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizationResultFromMessage_IssuerIccData(t *testing.T) {
+	issuerAuthenticationData := iso8583.TLVs{{Tag: "91", Value: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x00, 0x12}}}
+
+	msg := Message{DataElements: cis.DataElements{DE39_ResponseCode: "00", DE55_IntegratedCircuitCardData: issuerAuthenticationData}}
+
+	csr, _ := authorizationResultFromMessage(msg, 1000)
+	if want, _ := issuerAuthenticationData.Marshal(iso8583.BerTLV); !reflect.DeepEqual(csr.IccData, want) {
+		t.Errorf("IccData got: %X, wanted: %X", csr.IccData, want)
+	}
+}
+
+func TestApprovedAmount(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseCode   string
+		approvedAmount int64
+		expected       int
+		expectedStatus entity.AuthorizationStatus
+		wantReversal   bool
+	}{
+		{
+			name:           "approved",
+			responseCode:   "00",
+			approvedAmount: 1000,
+			expected:       0,
+			expectedStatus: entity.AuthorizeApproved,
+		},
+		{
+			name:           "partially_approved",
+			responseCode:   "10",
+			approvedAmount: 600,
+			expected:       600,
+			expectedStatus: entity.AuthorizeApproved,
+		},
+		{
+			name:           "partially_approved_without_amount",
+			responseCode:   "10",
+			approvedAmount: 0,
+			expected:       0,
+			expectedStatus: entity.AuthorizeFailed,
+			wantReversal:   true,
+		},
+		{
+			name:           "partially_approved_more_than_requested",
+			responseCode:   "10",
+			approvedAmount: 1200,
+			expected:       0,
+			expectedStatus: entity.AuthorizeFailed,
+			wantReversal:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := Message{DataElements: cis.DataElements{DE4_TransactionAmount: tt.approvedAmount, DE39_ResponseCode: tt.responseCode}}
+			csr, _ := authorizationResultFromMessage(msg, 1000)
+			if csr.ApprovedAmount != tt.expected {
+				t.Errorf("got: %d, wanted: %d", csr.ApprovedAmount, tt.expected)
+			}
+			if csr.Status != tt.expectedStatus {
+				t.Errorf("status got: %s, wanted: %s", csr.Status, tt.expectedStatus)
+			}
+			// The issuer may hold the amount of a failed partial approval, so it is reversed
+			a := entity.Authorization{Amount: 1000, CardSchemeData: entity.CardSchemeData{Response: csr}}
+			if got := a.InvalidPartialApproval(); got != tt.wantReversal {
+				t.Errorf("InvalidPartialApproval() got: %t, wanted: %t", got, tt.wantReversal)
+			}
+		})
+	}
+}
+
+func TestAdditionalAmounts(t *testing.T) {
+	tests := []struct {
+		name               string
+		additionalAmounts  []entity.AdditionalAmount
+		wantProcessingCode string
+		want               []cis.DE54_AmountsAdditional
+	}{
+		{
+			name:               "none",
+			wantProcessingCode: "000000",
+		},
+		{
+			name:               "cashback",
+			additionalAmounts:  []entity.AdditionalAmount{{Type: entity.CashbackAmount, Amount: 2000, Currency: currencycode.Must("EUR")}},
+			wantProcessingCode: "090000",
+			want: []cis.DE54_AmountsAdditional{
+				{SF1_AccountType: "00", SF2_AmountType: "40", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 2000},
+			},
+		},
+		{
+			name:               "surcharge",
+			additionalAmounts:  []entity.AdditionalAmount{{Type: entity.SurchargeAmount, Amount: 50, Currency: currencycode.Must("EUR")}},
+			wantProcessingCode: "000000",
+			want: []cis.DE54_AmountsAdditional{
+				{SF1_AccountType: "00", SF2_AmountType: "42", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 50},
+			},
+		},
+		{
+			name:               "gratuity",
+			additionalAmounts:  []entity.AdditionalAmount{{Type: entity.GratuityAmount, Amount: 150, Currency: currencycode.Must("EUR")}},
+			wantProcessingCode: "000000",
+			want: []cis.DE54_AmountsAdditional{
+				{SF1_AccountType: "00", SF2_AmountType: "44", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 150},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := entity.Authorization{Source: entity.CardPresent, AdditionalAmounts: tt.additionalAmounts}
+			authorizationSchemeData(&a)
+			if got := a.CardSchemeData.Request.ProcessingCode.String(); got != tt.wantProcessingCode {
+				t.Errorf("processing code got: %s, wanted: %s", got, tt.wantProcessingCode)
+			}
+			if got := additionalAmounts(a); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: %v, wanted: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package mastercard
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+// The elements with cardholder data DataElements does not define, they are dropped from its undefined elements
+const (
+	trackOneData = 45
+	newPinData   = 125
+)
+
+// Journal stores the messages exchanged for a transaction, it handles its own errors so a failing
+// journal does not fail the transaction
+type Journal interface {
+	Record(ctx context.Context, messages ...entity.JournalMessage)
+}
+
+// journal records the request and, when one arrived, the response of the exchange
+func (m Mip) journal(ctx context.Context, transactionID uuid.UUID, transactionType entity.TransactionType, req *Message, res Response) {
+	if m.messageJournal == nil {
+		return
+	}
+
+	messages := []entity.JournalMessage{journalMessage(transactionID, transactionType, entity.Outbound, *req)}
+	if res.Error() == nil {
+		messages = append(messages, journalMessage(transactionID, transactionType, entity.Inbound, res.Message()))
+	}
+
+	m.messageJournal.Record(ctx, messages...)
+}
+
+func journalMessage(transactionID uuid.UUID, transactionType entity.TransactionType, direction entity.MessageDirection, msg Message) entity.JournalMessage {
+	elements, err := entity.JournalElements(Masked(msg).DataElements)
+	if err != nil {
+		elements = entity.JournalError(err)
+	}
+
+	return entity.JournalMessage{
+		TransactionID:   transactionID,
+		TransactionType: transactionType,
+		Scheme:          entity.Mastercard,
+		Direction:       direction,
+		Mti:             msg.Mti.String(),
+		Stan:            msg.DataElements.DE11_SystemTraceAuditNumber,
+		Elements:        elements,
+	}
+}
+
+// Masked returns a copy of the message without cardholder data: the PAN is masked, the track data,
+// PIN blocks, CVC 2, UCAF, the MoneySend account numbers and the PAN, track and name data objects of the chip are
+// dropped
+func Masked(msg Message) Message {
+	de := msg.DataElements
+
+	de.DE2_PrimaryAccountNumber = entity.MaskPan(de.DE2_PrimaryAccountNumber)
+	de.DE35_TrackTwoData = ""
+	de.DE52_PinData = ""
+	de.DE55_IntegratedCircuitCardData = de.DE55_IntegratedCircuitCardData.Without(entity.IccCardholderDataTags...)
+	de.UndefinedElements = de.UndefinedElements.Without(trackOneData, newPinData)
+
+	if de.DE48_AdditionalData != nil {
+		additionalData := *de.DE48_AdditionalData
+		additionalData.SE43_UniversalCardholderAuthenticationField = ""
+		additionalData.SE92_CardholderVerificationCode = ""
+		de.DE48_AdditionalData = &additionalData
+	}
+
+	if de.DE108_MoneySendReferenceData != nil {
+		moneySend := *de.DE108_MoneySendReferenceData
+		moneySend.SE01_ReceiverData = withoutAccountNumber(moneySend.SE01_ReceiverData)
+		moneySend.SE02_SenderData = withoutAccountNumber(moneySend.SE02_SenderData)
+		de.DE108_MoneySendReferenceData = &moneySend
+	}
+
+	msg.DataElements = de
+
+	return msg
+}
+
+// withoutAccountNumber returns a copy of the MoneySend customer data without the account number, which may be a PAN
+func withoutAccountNumber(data *cis.DE108_CustomerData) *cis.DE108_CustomerData {
+	if data == nil {
+		return nil
+	}
+
+	customer := *data
+	customer.SF11_AccountNumber = ""
+
+	return &customer
+}
@@ -0,0 +1,92 @@
+package mastercard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+func TestMasked(t *testing.T) {
+	msg := Message{
+		Mti: iso8583.NewMti("0100"),
+		DataElements: cis.DataElements{
+			DE2_PrimaryAccountNumber:    "5204740000001002",
+			DE11_SystemTraceAuditNumber: "123456",
+			DE35_TrackTwoData:           "5204740000001002=2512201987654321",
+			DE48_AdditionalData: &cis.DE48_AdditionalData{
+				SE43_UniversalCardholderAuthenticationField: "kE7cEMtbRBStCgAQADkXj9AAAAA=",
+				SE92_CardholderVerificationCode:             "936",
+			},
+			DE52_PinData: "PINBLOCK",
+			DE55_IntegratedCircuitCardData: iso8583.TLVs{
+				{Tag: "5A", Value: []byte{0x52, 0x04, 0x74, 0x00, 0x00, 0x00, 0x10, 0x02}},
+				{Tag: "9F02", Value: []byte{0x00, 0x00, 0x00, 0x00, 0x50, 0x00}},
+			},
+			UndefinedElements: iso8583.RawElements{
+				trackOneData: []byte("B5204740000001002^DOE/JOHN^2512201"),
+				newPinData:   []byte("NEWPINBLOCK"),
+			},
+		},
+	}
+
+	got := Masked(msg).DataElements
+
+	if got.DE2_PrimaryAccountNumber != "52047400####1002" {
+		t.Errorf("Masked() PAN = %s, want 52047400####1002", got.DE2_PrimaryAccountNumber)
+	}
+	if got.DE35_TrackTwoData != "" {
+		t.Errorf("Masked() track 2 = %s, want none", got.DE35_TrackTwoData)
+	}
+	if got.DE52_PinData != "" {
+		t.Errorf("Masked() PIN = %s, want none", got.DE52_PinData)
+	}
+	if got.DE48_AdditionalData.SE92_CardholderVerificationCode != "" {
+		t.Errorf("Masked() CVC 2 = %s, want none", got.DE48_AdditionalData.SE92_CardholderVerificationCode)
+	}
+	if got.DE48_AdditionalData.SE43_UniversalCardholderAuthenticationField != "" {
+		t.Errorf("Masked() UCAF = %s, want none", got.DE48_AdditionalData.SE43_UniversalCardholderAuthenticationField)
+	}
+	if len(got.DE55_IntegratedCircuitCardData) != 1 || got.DE55_IntegratedCircuitCardData[0].Tag != "9F02" {
+		t.Errorf("Masked() ICC data = %v, want only 9F02", got.DE55_IntegratedCircuitCardData)
+	}
+	if len(got.UndefinedElements) != 0 {
+		t.Errorf("Masked() undefined elements = %v, want none", got.UndefinedElements.Numbers())
+	}
+	if got.DE11_SystemTraceAuditNumber != "123456" {
+		t.Errorf("Masked() STAN = %s, want 123456", got.DE11_SystemTraceAuditNumber)
+	}
+
+	de := msg.DataElements
+	if de.DE2_PrimaryAccountNumber != "5204740000001002" || de.DE48_AdditionalData.SE92_CardholderVerificationCode != "936" ||
+		len(de.DE55_IntegratedCircuitCardData) != 2 || len(de.UndefinedElements) != 2 {
+		t.Errorf("Masked() changed the message that was sent")
+	}
+}
+
+func TestJournalMessage(t *testing.T) {
+	msg := Message{
+		Mti: iso8583.NewMti("0100"),
+		DataElements: cis.DataElements{
+			DE2_PrimaryAccountNumber:    "5204740000001002",
+			DE11_SystemTraceAuditNumber: "123456",
+			DE35_TrackTwoData:           "5204740000001002=2512201987654321",
+			DE52_PinData:                "PINBLOCK",
+		},
+	}
+
+	got := journalMessage(uuid.New(), entity.AuthorizationTransaction, entity.Outbound, msg)
+
+	if got.Mti != "0100" || got.Stan != "123456" {
+		t.Errorf("journalMessage() = %s %s, want 0100 123456", got.Mti, got.Stan)
+	}
+	for _, sensitive := range []string{"5204740000001002", "987654321", "PINBLOCK"} {
+		if strings.Contains(string(got.Elements), sensitive) {
+			t.Errorf("journalMessage() elements contain %s: %s", sensitive, got.Elements)
+		}
+	}
+}
@@ -26,7 +26,7 @@ type Message struct {
 
 // Encode writes the message MTI and Data Elements, including the Private Data Subelements, to an encoder
 func (m *Message) Encode(encoder Iso8583Encoder) error {
-	return encoder.EncodeIso8583(m.Mti, m.DataElements)
+	return encoder.EncodeIso8583(m.Mti, &m.DataElements)
 }
 
 // Decode reads a message from a decoder
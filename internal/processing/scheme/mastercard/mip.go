@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
@@ -14,10 +15,13 @@ import (
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 )
 
-func NewMip(pool pool, sp StanProvider) Mip {
+func NewMip(pool pool, sp StanProvider, messageJournal Journal, pinTranslator PinTranslator) Mip {
 	return Mip{
-		pool:         pool,
-		stanProvider: sp,
+		pool:           pool,
+		stanProvider:   sp,
+		messageJournal: messageJournal,
+		pinTranslator:  pinTranslator,
+		lastEcho:       &atomic.Int64{},
 	}
 }
 
@@ -31,8 +35,12 @@ type StanProvider interface {
 }
 
 type Mip struct {
-	pool         pool
-	stanProvider StanProvider
+	pool           pool
+	stanProvider   StanProvider
+	messageJournal Journal
+	pinTranslator  PinTranslator
+	// lastEcho is the unix time in nanoseconds of the last answered echo, it is shared by the copies
+	lastEcho *atomic.Int64
 }
 
 var (
@@ -46,14 +54,25 @@ func (m Mip) Authorize(ctx context.Context, a *entity.Authorization) error {
 	a.ProcessingDate = time.Now()
 	authorizationSchemeData(a)
 
-	req := NewRequest(messageFromAuthorization(*a))
+	pin, err := m.zonePinBlock(ctx, *a)
+	if err != nil {
+		return err
+	}
+
+	msg, err := messageFromAuthorization(*a, pin)
+	if err != nil {
+		return err
+	}
+
+	req := NewRequest(msg)
 
 	res := m.pool.Send(ctx, req).(Response)
+	m.journal(ctx, a.ID, entity.AuthorizationTransaction, req.message, res)
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send authorization request to MIP: %w", res.Error())
 	}
 
-	a.CardSchemeData.Response, a.MastercardSchemeData.Response = authorizationResultFromMessage(res.Message())
+	a.CardSchemeData.Response, a.MastercardSchemeData.Response = authorizationResultFromMessage(res.Message(), a.Amount)
 
 	return nil
 }
@@ -65,7 +84,9 @@ func (m Mip) Reverse(ctx context.Context, r *entity.Reversal) error {
 
 	req := NewRequest(messageFromReversal(*r))
 
-	res := m.pool.Send(ctx, req).(Response)
+	// Reversals release the funds the issuer holds, so they are still sent while the pool drains
+	res := m.pool.Send(connection.WithDrainBypass(ctx), req).(Response)
+	m.journal(ctx, r.ID, entity.ReversalTransaction, req.message, res)
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send reversal request to MIP: %w", res.Error())
 	}
@@ -83,6 +104,7 @@ func (m Mip) Refund(ctx context.Context, r *entity.Refund) error {
 	req := NewRequest(messageFromRefund(*r))
 
 	res := m.pool.Send(ctx, req).(Response)
+	m.journal(ctx, r.ID, entity.RefundTransaction, req.message, res)
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send refund request to MIP: %w", res.Error())
 	}
@@ -92,6 +114,24 @@ func (m Mip) Refund(ctx context.Context, r *entity.Refund) error {
 	return nil
 }
 
+func (m Mip) Payout(ctx context.Context, p *entity.Payout) error {
+	p.Stan = m.nextStan()
+	p.ProcessingDate = time.Now()
+	payoutSchemeData(p)
+
+	req := NewRequest(messageFromPayout(*p))
+
+	res := m.pool.Send(ctx, req).(Response)
+	m.journal(ctx, p.ID, entity.PayoutTransaction, req.message, res)
+	if res.Error() != nil {
+		return fmt.Errorf("failed to send payout request to MIP: %w", res.Error())
+	}
+
+	p.CardSchemeData.Response, p.MastercardSchemeData.Response = refundResultFromMessage(res.Message())
+
+	return nil
+}
+
 func (m Mip) Echo(ctx context.Context) error {
 	echo := Echo()
 
@@ -99,10 +139,19 @@ func (m Mip) Echo(ctx context.Context) error {
 	if res.Error() != nil {
 		return fmt.Errorf("%w", res.Error())
 	}
+	m.lastEcho.Store(time.Now().UnixNano())
 
 	return nil
 }
 
+// LastEcho returns when the last echo was answered, the zero time when none was
+func (m Mip) LastEcho() time.Time {
+	if nanos := m.lastEcho.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
 func (m Mip) nextStan() int {
 	return m.stanProvider.Next()
 }
@@ -0,0 +1,91 @@
+package mastercard
+
+import (
+	"fmt"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+	"gitlab.cmpayments.local/creditcard/platform/countrycode"
+)
+
+// moneySendPersonToPerson is the DE48 SE77 transaction type identifier of a person-to-person MoneySend payment
+const moneySendPersonToPerson = "C07"
+
+func payoutSchemeData(p *entity.Payout) {
+	p.CardSchemeData.Request.ProcessingCode = entity.ProcessingCode{
+		TransactionTypeCode: "28", // Payment Transaction
+		FromAccountTypeCode: "00", // Default Account
+		ToAccountTypeCode:   "00", // Default Account
+	}
+	p.CardSchemeData.Request.POSEntryMode = posEntryMode(entity.Ecommerce, false)
+	p.MastercardSchemeData.Request.AdditionalData = entity.AdditionalRequestData{
+		TransactionCategoryCode: transactionCategoryCode(entity.Ecommerce),
+	}
+	p.MastercardSchemeData.Request.PointOfServiceData = pointOfServiceData(entity.Ecommerce, false, p.CardAcceptor.Address)
+}
+
+// moneySendReferenceData holds the receiver and sender of a payout in DE108
+func moneySendReferenceData(p entity.Payout) *cis.DE108_MoneySendReferenceData {
+	return &cis.DE108_MoneySendReferenceData{
+		SE01_ReceiverData: customerData(p.Recipient),
+		SE02_SenderData:   customerData(p.Sender),
+	}
+}
+
+func customerData(party entity.PayoutParty) *cis.DE108_CustomerData {
+	return &cis.DE108_CustomerData{
+		SF01_FirstName:     party.FirstName,
+		SF03_LastName:      party.LastName,
+		SF04_StreetAddress: party.Address,
+		SF05_City:          party.City,
+		SF07_CountryCode:   party.CountryCode,
+		SF08_PostalCode:    party.PostalCode,
+		SF11_AccountNumber: party.AccountNumber,
+	}
+}
+
+func messageFromPayout(p entity.Payout) *Message {
+	return &Message{
+		Mti: iso8583.NewMti(AuthorizationRequestMTI),
+		DataElements: cis.DataElements{
+			DE2_PrimaryAccountNumber:             p.Card.Number,
+			DE3_ProcessingCode:                   p.CardSchemeData.Request.ProcessingCode.String(),
+			DE4_TransactionAmount:                int64(p.Amount),
+			DE7_TransmissionDateTime:             cis.DE7FromTime(p.ProcessingDate),
+			DE11_SystemTraceAuditNumber:          fmt.Sprintf("%06d", p.Stan),
+			DE12_LocalTransactionTime:            p.LocalTransactionDateTime.Format("150405"),
+			DE13_LocalTransactionDate:            p.LocalTransactionDateTime.Format("0102"),
+			DE18_MerchantType:                    p.CardAcceptor.CategoryCode,
+			DE20_PrimaryAccountNumberCountryCode: p.Card.Info.IssuerCountryCode,
+			DE22_PointOfServiceEntryMode:         fmt.Sprintf("%s%s", p.CardSchemeData.Request.POSEntryMode.PanEntryMode, p.CardSchemeData.Request.POSEntryMode.PinEntryMode),
+			DE32_AcquringInstitutionCode:         entity.MastercardInstitutionID,
+			DE42_CardAcceptorCodeId:              p.CardAcceptor.ID,
+			DE43_CardAcceptorNameAndLocation: &cis.DE43_CardAcceptorNameAndLocation{
+				SF1_Name:               p.CardAcceptor.Name,
+				SF3_City:               p.CardAcceptor.Address.City,
+				SF5_StateOrCountryCode: p.CardAcceptor.Address.CountryCode,
+			},
+			DE48_AdditionalData: &cis.DE48_AdditionalData{
+				TransactionCategoryCode:        p.MastercardSchemeData.Request.AdditionalData.TransactionCategoryCode,
+				SE77_TransactionTypeIdentifier: moneySendPersonToPerson,
+			},
+			DE49_TransactionCurrencyCode: p.Currency.Numeric(),
+			DE61_PointOfServiceData: &cis.DE61_PointOfServiceData{
+				SF1_TerminalAttendance:                        fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.TerminalAttendance),
+				SF3_TerminalLocation:                          fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.TerminalLocation),
+				SF4_CardholderPresence:                        fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.CardHolderPresence),
+				SF5_CardPresence:                              fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.CardPresence),
+				SF6_CardCaptureCapabilities:                   fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.CardCaptureCapabilities),
+				SF7_TransactionStatus:                         fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.TransactionStatus),
+				SF8_TransactionSecurity:                       fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.TransactionSecurity),
+				SF10_CardholderActivatedTerminalLevel:         fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.CardHolderActivatedTerminalLevel),
+				SF11_CardDataTerminalInputCapabilityIndicator: fmt.Sprintf("%d", p.MastercardSchemeData.Request.PointOfServiceData.CardDataTerminalInputCapabilityIndicator),
+				SF12_AuthorizationLifeCycle:                   p.MastercardSchemeData.Request.PointOfServiceData.AuthorizationLifeCycle,
+				SF13_CountryCode:                              countrycode.Must(p.MastercardSchemeData.Request.PointOfServiceData.CountryCode).Mastercard.Numeric(),
+				SF14_PostalCode:                               p.MastercardSchemeData.Request.PointOfServiceData.PostalCode,
+			},
+			DE108_MoneySendReferenceData: moneySendReferenceData(p),
+		},
+	}
+}
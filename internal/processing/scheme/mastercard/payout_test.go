@@ -0,0 +1,84 @@
+package mastercard
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+func TestMessageFromPayout(t *testing.T) {
+	p := entity.Payout{
+		Card: entity.Card{
+			Number: "5204740000001002",
+			Expiry: entity.Expiry{Year: time.Now().Add(time.Hour * 24 * 730).Format("06"), Month: "05"},
+		},
+		CardAcceptor: entity.CardAcceptor{
+			Name:         "MaxCorp Inc.",
+			Address:      entity.CardAcceptorAddress{City: "Breda", CountryCode: "NLD", PostalCode: "4811AA"},
+			ID:           "12345",
+			CategoryCode: "6012",
+		},
+		Currency:                 currencycode.Must("EUR"),
+		Amount:                   5000,
+		Stan:                     7,
+		LocalTransactionDateTime: data.LocalTransactionDateTime(time.Now()),
+		Sender: entity.PayoutParty{
+			FirstName:     "John",
+			LastName:      "Smith",
+			Address:       "Main Street 1",
+			City:          "Breda",
+			CountryCode:   "NLD",
+			AccountNumber: "NL91ABNA0417164300",
+		},
+		Recipient: entity.PayoutParty{FirstName: "Jane", LastName: "Doe", AccountNumber: "5204740000001002"},
+	}
+	payoutSchemeData(&p)
+
+	got := messageFromPayout(p)
+
+	if got.DataElements.DE3_ProcessingCode != "280000" {
+		t.Errorf("DE3_ProcessingCode = %s, want 280000", got.DataElements.DE3_ProcessingCode)
+	}
+	if got.DataElements.DE48_AdditionalData.SE77_TransactionTypeIdentifier != "C07" {
+		t.Errorf("DE48 SE77_TransactionTypeIdentifier = %s, want C07", got.DataElements.DE48_AdditionalData.SE77_TransactionTypeIdentifier)
+	}
+
+	want := &cis.DE108_MoneySendReferenceData{
+		SE01_ReceiverData: &cis.DE108_CustomerData{
+			SF01_FirstName:     "Jane",
+			SF03_LastName:      "Doe",
+			SF11_AccountNumber: "5204740000001002",
+		},
+		SE02_SenderData: &cis.DE108_CustomerData{
+			SF01_FirstName:     "John",
+			SF03_LastName:      "Smith",
+			SF04_StreetAddress: "Main Street 1",
+			SF05_City:          "Breda",
+			SF07_CountryCode:   "NLD",
+			SF11_AccountNumber: "NL91ABNA0417164300",
+		},
+	}
+	if !reflect.DeepEqual(got.DataElements.DE108_MoneySendReferenceData, want) {
+		t.Errorf("\ngot =  %+v\nwant = %+v", got.DataElements.DE108_MoneySendReferenceData, want)
+	}
+
+	// The account numbers of the receiver and the sender are not journaled
+	masked := Masked(*got)
+	if account := masked.DataElements.DE108_MoneySendReferenceData.SE01_ReceiverData.SF11_AccountNumber; account != "" {
+		t.Errorf("Masked() kept the receiver account number %s", account)
+	}
+	if account := masked.DataElements.DE108_MoneySendReferenceData.SE02_SenderData.SF11_AccountNumber; account != "" {
+		t.Errorf("Masked() kept the sender account number %s", account)
+	}
+
+	// Masking does not change the message that is sent
+	if got.DataElements.DE108_MoneySendReferenceData.SE02_SenderData.SF11_AccountNumber != "NL91ABNA0417164300" {
+		t.Errorf("Masked() changed the sender account number of the message")
+	}
+}
@@ -0,0 +1,45 @@
+package mastercard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+var ErrNoPinTranslator = errors.New("no PIN translator to send the online PIN")
+
+// PinTranslator translates the PIN block of a terminal to the zone PIN key shared with Mastercard
+type PinTranslator interface {
+	TranslatePin(ctx context.Context, pin entity.OnlinePin, pan string) (entity.ZonePinBlock, error)
+}
+
+// zonePinBlock translates the online PIN of an authorization, an authorization without one has no PIN block
+func (m Mip) zonePinBlock(ctx context.Context, a entity.Authorization) (entity.ZonePinBlock, error) {
+	if a.Card.OnlinePin.Empty() {
+		return entity.ZonePinBlock{}, nil
+	}
+
+	if m.pinTranslator == nil {
+		return entity.ZonePinBlock{}, ErrNoPinTranslator
+	}
+
+	pin, err := m.pinTranslator.TranslatePin(ctx, a.Card.OnlinePin, a.Card.Number)
+	if err != nil {
+		return entity.ZonePinBlock{}, fmt.Errorf("failed to translate PIN: %w", err)
+	}
+
+	return pin, nil
+}
+
+// DE53 Security-Related Control Information of a PIN block
+// SF1 PIN Security Type Code 99, SF2 PIN Encryption Type Code 01 ANSI DES, SF3 PIN Block Format Code 01 ISO format 0,
+// SF4 PIN Key Index Number and zeros for SF5 and SF6
+func securityRelatedControlInformation(pin entity.ZonePinBlock) string {
+	if len(pin.PinBlock) == 0 {
+		return ""
+	}
+
+	return "99" + "01" + "01" + pin.KeyIndex + "00000000"
+}
@@ -19,3 +19,8 @@ func (mcr Request) Packet() ([]byte, error) {
 
 	return packet, err
 }
+
+// Stan is shown by the connection pool for the request a connection is attending
+func (mcr Request) Stan() string {
+	return mcr.message.DataElements.DE11_SystemTraceAuditNumber
+}
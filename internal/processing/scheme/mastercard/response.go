@@ -22,7 +22,7 @@ type Response struct {
 func NewResponse(packet []byte, error error) connection.Received {
 	var msg Message
 	if error == nil && packet != nil {
-		msg, error = decode(packet[2:])
+		msg, error = Decode(packet[2:])
 	}
 	return Response{
 		packet:  packet,
@@ -56,7 +56,9 @@ func (mcr Response) Error() error {
 	return mcr.error
 }
 
-func decode(payload []byte) (Message, error) {
+// Decode reads a message from a packet without its length prefix, opts are applied after the options of the
+// MIP format, like iso8583.Offsets to learn where the elements are
+func Decode(payload []byte, opts ...iso8583.Opt) (Message, error) {
 	const (
 		asciiZero  = byte(48)
 		ebcdicZero = byte(240)
@@ -67,9 +69,9 @@ func decode(payload []byte) (Message, error) {
 	var decoder Iso8583Decoder
 	switch mtiZero {
 	case asciiZero:
-		decoder = iso8583.NewDecoder(bytes.NewReader(payload), iso8583.FormatAscii, iso8583.NoRdwLayout)
+		decoder = iso8583.NewDecoder(bytes.NewReader(payload), append([]iso8583.Opt{iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.UndefinedElements(cis.UndefinedFormats)}, opts...)...)
 	case ebcdicZero:
-		decoder = iso8583.NewDecoder(bytes.NewReader(payload), iso8583.FormatEbcdic, iso8583.NoRdwLayout)
+		decoder = iso8583.NewDecoder(bytes.NewReader(payload), append([]iso8583.Opt{iso8583.FormatEbcdic, iso8583.NoRdwLayout, iso8583.UndefinedElements(cis.UndefinedFormats)}, opts...)...)
 	default:
 		return Message{}, ErrUnknownEncoding
 	}
@@ -90,6 +92,8 @@ func getNetworkManagementResponse(reqMsg Message) ([]byte, error) {
 			DE39_ResponseCode:                     "00",
 			DE63_NetworkData:                      reqMsg.DataElements.DE63_NetworkData,
 			DE70_NetworkManagementInformationCode: reqMsg.DataElements.DE70_NetworkManagementInformationCode,
+			// The elements we do not define are echoed as they were received
+			UndefinedElements: reqMsg.DataElements.UndefinedElements,
 		},
 	}
 
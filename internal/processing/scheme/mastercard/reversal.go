@@ -1,10 +1,12 @@
 package mastercard
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
 	"gitlab.cmpayments.local/creditcard/platform/countrycode"
@@ -13,6 +15,9 @@ import (
 const (
 	reversalRequestMTI  = `0400`
 	reversalResponseMTI = `0410`
+
+	// responseReceivedTooLate is the DE39 of the reversal of an authorization whose response never arrived
+	responseReceivedTooLate = "68"
 )
 
 func reversalSchemeData(r *entity.Reversal) {
@@ -32,7 +37,7 @@ func reversalResultFromMessage(msg Message) entity.CardSchemeResponse {
 }
 
 func messageFromReversal(r entity.Reversal) *Message {
-	return &Message{
+	msg := &Message{
 		Mti: iso8583.NewMti(reversalRequestMTI),
 		DataElements: cis.DataElements{
 			DE2_PrimaryAccountNumber:             r.Authorization.Card.Number,
@@ -84,4 +89,14 @@ func messageFromReversal(r entity.Reversal) *Message {
 			},
 		},
 	}
+
+	// An authorization that was abandoned at the shutdown deadline has no response, so there is no
+	// approval code or trace ID to send back
+	if errors.Is(r.Reason, authorization.ErrUnanswered) {
+		msg.DataElements.DE38_AuthorizationIdResponse = ""
+		msg.DataElements.DE39_ResponseCode = responseReceivedTooLate
+		msg.DataElements.DE48_AdditionalData.SE63_TraceId = nil
+	}
+
+	return msg
 }
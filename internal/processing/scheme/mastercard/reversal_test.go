@@ -0,0 +1,90 @@
+package mastercard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
+)
+
+func TestMessageFromReversal(t *testing.T) {
+	a := entity.Authorization{
+		Card: entity.Card{
+			Number: "5204740000001002",
+			Expiry: entity.Expiry{Year: time.Now().Add(time.Hour * 24 * 730).Format("06"), Month: "05"},
+		},
+		CardAcceptor: entity.CardAcceptor{
+			Name:         "MaxCorp Inc.",
+			Address:      entity.CardAcceptorAddress{City: "Breda", CountryCode: "NLD"},
+			ID:           "12345",
+			CategoryCode: "5999",
+		},
+		Currency:                 currencycode.Must("EUR"),
+		Amount:                   5000,
+		Stan:                     7,
+		LocalTransactionDateTime: data.LocalTransactionDateTime(time.Now()),
+	}
+	a.CardSchemeData.Response.AuthorizationIDResponse = "A1B2C3"
+	a.MastercardSchemeData.Request.PointOfServiceData.CountryCode = "NLD"
+	a.MastercardSchemeData.Response.TraceID = entity.MTraceID{
+		FinancialNetworkCode:   "MCC",
+		BanknetReferenceNumber: "ABC123",
+		NetworkReportingDate:   "0701",
+	}
+
+	tests := []struct {
+		name          string
+		responseCode  string
+		reason        error
+		wantDE38      string
+		wantDE39      string
+		wantSE63Empty bool
+	}{
+		{
+			name:         "voided_authorization",
+			responseCode: "00",
+			wantDE38:     "A1B2C3",
+			wantDE39:     "00",
+		},
+		{
+			name:         "invalid_partial_approval",
+			responseCode: entity.PartialApprovalResponseCode,
+			reason:       entity.ErrInvalidPartialApproval,
+			wantDE38:     "A1B2C3",
+			wantDE39:     entity.PartialApprovalResponseCode,
+		},
+		{
+			name:          "unanswered_authorization",
+			reason:        fmt.Errorf("%w: %w", authorization.ErrUnanswered, connection.ErrShutdownDeadline),
+			wantDE38:      "",
+			wantDE39:      responseReceivedTooLate,
+			wantSE63Empty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := a
+			a.CardSchemeData.Response.ResponseCode.Value = tt.responseCode
+			got := messageFromReversal(entity.Reversal{Authorization: a, Amount: a.Amount, Reason: tt.reason})
+
+			if got.DataElements.DE4_TransactionAmount != int64(a.Amount) {
+				t.Errorf("DE4_TransactionAmount = %d, want %d", got.DataElements.DE4_TransactionAmount, a.Amount)
+			}
+			if got.DataElements.DE38_AuthorizationIdResponse != tt.wantDE38 {
+				t.Errorf("DE38_AuthorizationIdResponse = %q, want %q", got.DataElements.DE38_AuthorizationIdResponse, tt.wantDE38)
+			}
+			if got.DataElements.DE39_ResponseCode != tt.wantDE39 {
+				t.Errorf("DE39_ResponseCode = %q, want %q", got.DataElements.DE39_ResponseCode, tt.wantDE39)
+			}
+			if empty := got.DataElements.DE48_AdditionalData.SE63_TraceId == nil; empty != tt.wantSE63Empty {
+				t.Errorf("DE48 SE63_TraceId = %+v, want empty %v", got.DataElements.DE48_AdditionalData.SE63_TraceId, tt.wantSE63Empty)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/pos"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
 	"gitlab.cmpayments.local/creditcard/platform/countrycode"
@@ -26,10 +27,24 @@ func AuthorizationSchemeData(a *entity.Authorization) {
 		FromAccountTypeCode: "00", // Default Account
 		ToAccountTypeCode:   "00", // Default Account
 	}
-	a.CardSchemeData.Request.POSEntryMode = posEntryMode(a.Recurring.Subsequent, a.CitMitIndicator)
-	a.VisaSchemeData.Request.PosConditionCode = posConditionCode(a.Source, a.Recurring.Subsequent)
-	a.VisaSchemeData.Request.AdditionalPOSInformation = additionalPOSInformation(a.Source, a.ThreeDSecure)
+	if a.HasCashback() {
+		a.CardSchemeData.Request.ProcessingCode.TransactionTypeCode = "09" // Purchase with cash back
+	}
 	a.VisaSchemeData.Request.PrivateUseFields = privateUseFields(a.Recurring)
+
+	if a.Source == entity.CardPresent {
+		// The POS entry mode is the one the terminal read the card with
+		a.VisaSchemeData.Request.PosConditionCode = cardPresentPosConditionCode(a.Terminal.TerminalLevel)
+		a.VisaSchemeData.Request.AdditionalPOSInformation = cardPresentAdditionalPOSInformation(a.Terminal, a.ChipFallback())
+	} else {
+		a.CardSchemeData.Request.POSEntryMode = posEntryMode(a.Recurring.Subsequent, a.CitMitIndicator)
+		a.VisaSchemeData.Request.PosConditionCode = posConditionCode(a.Source, a.Recurring.Subsequent)
+		a.VisaSchemeData.Request.AdditionalPOSInformation = additionalPOSInformation(a.Source, a.ThreeDSecure)
+	}
+
+	if a.PartialApprovalSupported {
+		a.VisaSchemeData.Request.AdditionalPOSInformation.AdditionalAuthorizationIndicators = "2" // Terminal accepts partial authorization responses
+	}
 }
 
 func posEntryMode(subseqRecurring bool, indicator entity.CitMitIndicator) entity.POSEntryMode {
@@ -84,6 +99,59 @@ func additionalPOSInformation(s entity.Source, tds entity.ThreeDSecure) entity.A
 	}
 }
 
+// F025 Point-of-Service Condition Code
+func cardPresentPosConditionCode(level entity.TerminalLevel) string {
+	if level == entity.AutomatedDispensingMachine {
+		return "02" // Unattended acceptor terminal
+	}
+
+	return "00" // Normal transaction
+}
+
+func cardPresentAdditionalPOSInformation(t entity.Terminal, chipFallback bool) entity.AdditionalPOSInformation {
+	return entity.AdditionalPOSInformation{
+		TerminalType:                               mapTerminalType(t.TerminalLevel),
+		TerminalEntryCapability:                    mapTerminalEntryCapability(t.TerminalCapability),
+		ChipConditionCode:                          mapChipConditionCode(chipFallback),
+		SpecialConditionIndicator:                  "0",  // Default value
+		ChipTransactionIndicator:                   "0",  // Not applicable
+		ChipCardAuthenticationReliabilityIndicator: "0",  // Fill for field 60.7 present, or subsequent subfields that are present.
+		TypeOrLevelIndicator:                       "00", // Not applicable, no electronic commerce transaction
+		CardholderIDMethodIndicator:                "0",  // Not specified
+		AdditionalAuthorizationIndicators:          "0",  // Not applicable
+	}
+}
+
+// F060.1 Terminal Type
+func mapTerminalType(level entity.TerminalLevel) string {
+	if level == entity.AutomatedDispensingMachine {
+		return "3" // Unattended cardholder-activated terminal
+	}
+
+	return "0" // Unspecified
+}
+
+// F060.2 Terminal Entry Capability
+func mapTerminalEntryCapability(capability entity.TerminalCapability) string {
+	switch capability {
+	case entity.MagneticStripeRead:
+		return "2" // Magnetic stripe read capability
+	case entity.TerminalContactlessEMVinput, entity.EMVProximityReadCapableOnly, entity.TerminalEMVContactChipAndMagneticStripeAndKeyEntry:
+		return "5" // Chip-capable terminal
+	default:
+		return "0" // Unknown
+	}
+}
+
+// F060.3 Chip Condition Code
+func mapChipConditionCode(chipFallback bool) string {
+	if chipFallback {
+		return "2" // Service code 2xx or 6xx, the last read at the chip terminal was an unsuccessful chip read
+	}
+
+	return "0" // Not applicable
+}
+
 func mapElectricCommerceIndicator(s entity.Source, tds entity.ThreeDSecure) string {
 	switch {
 	case s == entity.Moto:
@@ -130,8 +198,8 @@ func mapInitiator(initiator entity.CitMitIndicator) string {
 	return initiatedBy + subCategory
 }
 
-func authorizationResultFromMessage(msg Message) (entity.CardSchemeResponse, entity.VisaSchemeResponse) {
-	return entity.CardSchemeResponse{
+func authorizationResultFromMessage(msg Message, amount int) (entity.CardSchemeResponse, entity.VisaSchemeResponse) {
+	csr := entity.CardSchemeResponse{
 		Status: entity.AuthorizationStatusFromCardSchemeResponseCode(msg.Fields.F039_ResponseCode),
 		ResponseCode: entity.ResponseCode{
 			Value:       msg.Fields.F039_ResponseCode,
@@ -139,7 +207,19 @@ func authorizationResultFromMessage(msg Message) (entity.CardSchemeResponse, ent
 		},
 		AuthorizationIDResponse: msg.Fields.F038_AuthorizationIdenticationResponse,
 		TraceId:                 fromTraceId(msg.Fields.F062_CustomPaymentServiceFields.SF2_TransactionIdentifier),
-	}, entity.VisaSchemeResponse{TransactionId: msg.Fields.F062_CustomPaymentServiceFields.SF2_TransactionIdentifier}
+		IccData:                 processing.IssuerIccData(msg.Fields.F055_IntegratedCircuitCardData.HEX01_ChipCardData),
+	}
+
+	if msg.Fields.F039_ResponseCode == entity.PartialApprovalResponseCode {
+		// F004 of the response holds the amount the issuer approved, F054 the original amount with amount type 57
+		csr.ApprovedAmount = int(msg.Fields.F004_TransactionAmount)
+		if !entity.ValidPartialApproval(csr.ApprovedAmount, amount) {
+			csr.Status = entity.AuthorizeFailed
+			csr.ApprovedAmount = 0
+		}
+	}
+
+	return csr, entity.VisaSchemeResponse{TransactionId: msg.Fields.F062_CustomPaymentServiceFields.SF2_TransactionIdentifier}
 }
 
 func fromTraceId(traceId int) string {
@@ -149,11 +229,15 @@ func fromTraceId(traceId int) string {
 	return strconv.Itoa(traceId)
 }
 
-func MessageFromAuthorization(a entity.Authorization) (*Message, error) {
+func MessageFromAuthorization(a entity.Authorization, pin entity.ZonePinBlock) (*Message, error) {
 	cavv, err := cavvRequestData(a.ThreeDSecure)
 	if err != nil {
 		return nil, err
 	}
+	iccData, err := iso8583.ParseTLVs(a.Card.IccData, iso8583.BerTLV)
+	if err != nil {
+		return nil, fmt.Errorf("invalid icc data: %w", err)
+	}
 	return &Message{
 		Mti: iso8583.NewMti(authorizationRequestMTI),
 		Fields: base1.Fields{
@@ -168,17 +252,26 @@ func MessageFromAuthorization(a entity.Authorization) (*Message, error) {
 			F018_MerchantType:                           a.CardAcceptor.CategoryCode,
 			F019_AcquiringInstituteCountryCode:          countrycode.Must("NLD").Numeric(),
 			F022_PosEntryMode:                           fmt.Sprintf("%s%s0", pos.PanEntryCode(a.CardSchemeData.Request.POSEntryMode.PanEntryMode), pos.PinEntryCode(a.CardSchemeData.Request.POSEntryMode.PinEntryMode)),
+			F023_CardSequenceNumber:                     a.Card.SequenceNumber,
 			F025_PosCondition:                           a.VisaSchemeData.Request.PosConditionCode,
 			F032_AcquiringInstitutionIdentificationCode: entity.VisaInstitutionID,
 			F034_ElectronicCommerceData:                 mapElectronicCommerceData(a.Exemption),
+			F035_Track2Data:                             a.Card.Track2Data,
 			F037_RetrievalReferenceNumber:               retrievalReferenceNumber(a.Stan),
+			F041_CardAcceptorTerminalIdentification:     a.Terminal.TerminalId,
 			F042_CardAcceptorIdentificationCode:         fmt.Sprintf("%*s", -15, fmt.Sprintf("%s%s", a.Psp.Prefix, a.CardAcceptor.ID)),
 			F043_CardAcceptorNameLocation: base1.F043_CardAcceptorNameLocation{
 				SF1_CarAcceptorName:  a.CardAcceptor.Name,
 				SF2_CardAcceptorCity: a.CardAcceptor.Address.City,
 				SF3_CountryCode:      countrycode.Must(a.CardAcceptor.Address.CountryCode).Alpha2(),
 			},
-			F049_TransactionCurrencyCode: a.Currency.Numeric(),
+			F049_TransactionCurrencyCode:           a.Currency.Numeric(),
+			F052_PinData:                           string(pin.PinBlock),
+			F053_SecurityRelatedControlInformation: securityRelatedControlInformation(pin),
+			F054_AdditionalAmounts:                 additionalAmounts(a),
+			F055_IntegratedCircuitCardData: base1.F055_IntegratedCircuitCardData{
+				HEX01_ChipCardData: iccData,
+			},
 			F060_AdditionalPointOfServiceInformation: base1.F060_AdditionalPOSInformation{
 				B1: fmt.Sprintf("%s%s", a.VisaSchemeData.Request.AdditionalPOSInformation.TerminalType, a.VisaSchemeData.Request.AdditionalPOSInformation.TerminalEntryCapability),
 				B2: fmt.Sprintf("%s%s", a.VisaSchemeData.Request.AdditionalPOSInformation.ChipConditionCode, a.VisaSchemeData.Request.AdditionalPOSInformation.SpecialConditionIndicator),
@@ -204,6 +297,38 @@ func MessageFromAuthorization(a entity.Authorization) (*Message, error) {
 	}, nil
 }
 
+// additionalAmounts returns the amounts that are part of the transaction amount, debited from the account of the
+// processing code
+func additionalAmounts(a entity.Authorization) []base1.F054_AdditionalAmount {
+	if len(a.AdditionalAmounts) == 0 {
+		return nil
+	}
+
+	amounts := make([]base1.F054_AdditionalAmount, 0, len(a.AdditionalAmounts))
+	for _, aa := range a.AdditionalAmounts {
+		amounts = append(amounts, base1.F054_AdditionalAmount{
+			SF1_AccountType:  a.CardSchemeData.Request.ProcessingCode.FromAccountTypeCode,
+			SF2_AmountType:   mapAdditionalAmountType(aa.Type),
+			SF3_CurrencyCode: aa.Currency.Numeric(),
+			SF4_AmountSign:   "D", // Debit
+			SF5_Amount:       int64(aa.Amount),
+		})
+	}
+
+	return amounts
+}
+
+func mapAdditionalAmountType(t entity.AdditionalAmountType) string {
+	switch t {
+	case entity.CashbackAmount:
+		return "40" // Cash back amount
+	case entity.SurchargeAmount:
+		return "42" // Surcharge amount
+	default:
+		return "44" // Gratuity amount
+	}
+}
+
 func toTraceId(r entity.Recurring) int {
 	tId, err := strconv.Atoi(r.TraceID)
 	if err != nil {
@@ -4,7 +4,10 @@ import (
 	"reflect"
 	"testing"
 
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
 )
 
 func Test3dSecure(t *testing.T) {
@@ -76,3 +79,175 @@ func TestCvvRequestData(t *testing.T) {
 		})
 	}
 }
+
+func TestCardPresentAdditionalPOSInformation(t *testing.T) {
+	tests := []struct {
+		name         string
+		terminal     entity.Terminal
+		chipFallback bool
+		expected     entity.AdditionalPOSInformation
+	}{
+		{
+			name:     "attended_chip_terminal",
+			terminal: entity.Terminal{TerminalCapability: entity.TerminalEMVContactChipAndMagneticStripeAndKeyEntry},
+			expected: entity.AdditionalPOSInformation{TerminalType: "0", TerminalEntryCapability: "5", ChipConditionCode: "0"},
+		},
+		{
+			name:         "chip_fallback",
+			terminal:     entity.Terminal{TerminalCapability: entity.TerminalEMVContactChipAndMagneticStripeAndKeyEntry},
+			chipFallback: true,
+			expected:     entity.AdditionalPOSInformation{TerminalType: "0", TerminalEntryCapability: "5", ChipConditionCode: "2"},
+		},
+		{
+			name:     "unattended_magnetic_stripe_terminal",
+			terminal: entity.Terminal{TerminalCapability: entity.MagneticStripeRead, TerminalLevel: entity.AutomatedDispensingMachine},
+			expected: entity.AdditionalPOSInformation{TerminalType: "3", TerminalEntryCapability: "2", ChipConditionCode: "0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardPresentAdditionalPOSInformation(tt.terminal, tt.chipFallback)
+			if got.TerminalType != tt.expected.TerminalType ||
+				got.TerminalEntryCapability != tt.expected.TerminalEntryCapability ||
+				got.ChipConditionCode != tt.expected.ChipConditionCode {
+				t.Errorf("got: %+v, wanted: %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPartialApproval(t *testing.T) {
+	tests := []struct {
+		name                     string
+		source                   entity.Source
+		partialApprovalSupported bool
+		expected                 string
+	}{
+		{
+			name:     "ecommerce",
+			source:   entity.Ecommerce,
+			expected: "0",
+		},
+		{
+			name:                     "ecommerce_partial_approval_supported",
+			source:                   entity.Ecommerce,
+			partialApprovalSupported: true,
+			expected:                 "2",
+		},
+		{
+			name:                     "card_present_partial_approval_supported",
+			source:                   entity.CardPresent,
+			partialApprovalSupported: true,
+			expected:                 "2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := entity.Authorization{Source: tt.source, PartialApprovalSupported: tt.partialApprovalSupported}
+			AuthorizationSchemeData(&a)
+			if got := a.VisaSchemeData.Request.AdditionalPOSInformation.AdditionalAuthorizationIndicators; got != tt.expected {
+				t.Errorf("got: %s, wanted: %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApprovedAmount(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseCode   string
+		approvedAmount int64
+		expected       int
+		expectedStatus entity.AuthorizationStatus
+		wantReversal   bool
+	}{
+		{
+			name:           "approved",
+			responseCode:   "00",
+			approvedAmount: 1000,
+			expected:       0,
+			expectedStatus: entity.AuthorizeApproved,
+		},
+		{
+			name:           "partially_approved",
+			responseCode:   "10",
+			approvedAmount: 600,
+			expected:       600,
+			expectedStatus: entity.AuthorizeApproved,
+		},
+		{
+			name:           "partially_approved_without_amount",
+			responseCode:   "10",
+			approvedAmount: 0,
+			expected:       0,
+			expectedStatus: entity.AuthorizeFailed,
+			wantReversal:   true,
+		},
+		{
+			name:           "partially_approved_more_than_requested",
+			responseCode:   "10",
+			approvedAmount: 1200,
+			expected:       0,
+			expectedStatus: entity.AuthorizeFailed,
+			wantReversal:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csr, _ := authorizationResultFromMessage(Message{Fields: base1.Fields{F004_TransactionAmount: tt.approvedAmount, F039_ResponseCode: tt.responseCode}}, 1000)
+			if csr.ApprovedAmount != tt.expected {
+				t.Errorf("got: %d, wanted: %d", csr.ApprovedAmount, tt.expected)
+			}
+			if csr.Status != tt.expectedStatus {
+				t.Errorf("status got: %s, wanted: %s", csr.Status, tt.expectedStatus)
+			}
+			// The issuer may hold the amount of a failed partial approval, so it is reversed
+			a := entity.Authorization{Amount: 1000, CardSchemeData: entity.CardSchemeData{Response: csr}}
+			if got := a.InvalidPartialApproval(); got != tt.wantReversal {
+				t.Errorf("InvalidPartialApproval() got: %t, wanted: %t", got, tt.wantReversal)
+			}
+		})
+	}
+}
+
+func TestAdditionalAmounts(t *testing.T) {
+	tests := []struct {
+		name               string
+		additionalAmounts  []entity.AdditionalAmount
+		wantProcessingCode string
+		want               []base1.F054_AdditionalAmount
+	}{
+		{
+			name:               "none",
+			wantProcessingCode: "000000",
+		},
+		{
+			name:               "cashback",
+			additionalAmounts:  []entity.AdditionalAmount{{Type: entity.CashbackAmount, Amount: 2000, Currency: currencycode.Must("EUR")}},
+			wantProcessingCode: "090000",
+			want: []base1.F054_AdditionalAmount{
+				{SF1_AccountType: "00", SF2_AmountType: "40", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 2000},
+			},
+		},
+		{
+			name:               "gratuity",
+			additionalAmounts:  []entity.AdditionalAmount{{Type: entity.GratuityAmount, Amount: 150, Currency: currencycode.Must("EUR")}},
+			wantProcessingCode: "000000",
+			want: []base1.F054_AdditionalAmount{
+				{SF1_AccountType: "00", SF2_AmountType: "44", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 150},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := entity.Authorization{Source: entity.CardPresent, AdditionalAmounts: tt.additionalAmounts}
+			AuthorizationSchemeData(&a)
+			if got := a.CardSchemeData.Request.ProcessingCode.String(); got != tt.wantProcessingCode {
+				t.Errorf("processing code got: %s, wanted: %s", got, tt.wantProcessingCode)
+			}
+			if got := additionalAmounts(a); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: %v, wanted: %v", got, tt.want)
+			}
+		})
+	}
+}
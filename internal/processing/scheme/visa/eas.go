@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/yerden/go-util/bcd"
@@ -17,11 +18,14 @@ import (
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 )
 
-func NewEas(pool pool, sp StanProvider, ssid string) Eas {
+func NewEas(pool pool, sp StanProvider, ssid string, messageJournal Journal, pinTranslator PinTranslator) Eas {
 	return Eas{
 		pool:            pool,
 		stanProvider:    sp,
 		sourceStationID: ssid,
+		messageJournal:  messageJournal,
+		pinTranslator:   pinTranslator,
+		lastEcho:        &atomic.Int64{},
 	}
 }
 
@@ -38,6 +42,10 @@ type Eas struct {
 	pool            pool
 	stanProvider    StanProvider
 	sourceStationID string
+	messageJournal  Journal
+	pinTranslator   PinTranslator
+	// lastEcho is the unix time in nanoseconds of the last answered echo, it is shared by the copies
+	lastEcho *atomic.Int64
 }
 
 var (
@@ -49,7 +57,11 @@ func (m Eas) Authorize(ctx context.Context, a *entity.Authorization) error {
 	a.Stan = m.nextStan()
 	a.ProcessingDate = time.Now()
 	AuthorizationSchemeData(a)
-	message, err := MessageFromAuthorization(*a)
+	pin, err := m.zonePinBlock(ctx, *a)
+	if err != nil {
+		return err
+	}
+	message, err := MessageFromAuthorization(*a, pin)
 	if err != nil {
 		return err
 	}
@@ -59,12 +71,14 @@ func (m Eas) Authorize(ctx context.Context, a *entity.Authorization) error {
 	fmt.Printf("send in authorization with RRN: %s\n", req.message.Fields.F037_RetrievalReferenceNumber)
 
 	res := m.pool.Send(ctx, req).(Response)
+	m.journal(ctx, a.ID, entity.AuthorizationTransaction, req.message, res)
+	// The RRN is needed to reverse an authorization that got no response as well
+	a.CardSchemeData.Request.RetrievalReferenceNumber = req.message.Fields.F037_RetrievalReferenceNumber
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send authorization request to EAS: %w", res.Error())
 	}
 
-	a.CardSchemeData.Request.RetrievalReferenceNumber = req.message.Fields.F037_RetrievalReferenceNumber
-	a.CardSchemeData.Response, a.VisaSchemeData.Response = authorizationResultFromMessage(res.Message())
+	a.CardSchemeData.Response, a.VisaSchemeData.Response = authorizationResultFromMessage(res.Message(), a.Amount)
 
 	// This field is set here because we aren't sending it in right now for Visa (and thus it isn't part of the message yet).
 	// Unlike Mastercard, Visa doesn't downgrade the ecommerce indicator, so therefor we use the ecommerce we send to the cardscheme here.
@@ -82,7 +96,9 @@ func (m Eas) Reverse(ctx context.Context, r *entity.Reversal) error {
 
 	fmt.Printf("send in reversal with RRN: %s\n", req.message.Fields.F037_RetrievalReferenceNumber)
 
-	res := m.pool.Send(ctx, req).(Response)
+	// Reversals release the funds the issuer holds, so they are still sent while the pool drains
+	res := m.pool.Send(connection.WithDrainBypass(ctx), req).(Response)
+	m.journal(ctx, r.ID, entity.ReversalTransaction, req.message, res)
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send reversal request to EAS: %w", res.Error())
 	}
@@ -104,12 +120,33 @@ func (m Eas) Refund(ctx context.Context, r *entity.Refund) error {
 	fmt.Printf("send in refund with RRN: %s\n", req.message.Fields.F037_RetrievalReferenceNumber)
 
 	res := m.pool.Send(ctx, req).(Response)
+	m.journal(ctx, r.ID, entity.RefundTransaction, req.message, res)
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send refund request to EAS: %w", res.Error())
 	}
 
 	r.CardSchemeData.Request.RetrievalReferenceNumber = req.message.Fields.F037_RetrievalReferenceNumber
-	r.CardSchemeData.Response, r.VisaSchemeData.Response = authorizationResultFromMessage(res.Message())
+	r.CardSchemeData.Response, r.VisaSchemeData.Response = authorizationResultFromMessage(res.Message(), r.Amount)
+
+	return nil
+}
+
+func (m Eas) Payout(ctx context.Context, p *entity.Payout) error {
+	p.Stan = m.nextStan()
+	p.ProcessingDate = time.Now()
+	PayoutSchemeData(p)
+
+	req := NewRequest(messageFromPayout(*p))
+	req.message.SourceStationID = m.sourceStationID
+
+	res := m.pool.Send(ctx, req).(Response)
+	m.journal(ctx, p.ID, entity.PayoutTransaction, req.message, res)
+	if res.Error() != nil {
+		return fmt.Errorf("failed to send payout request to EAS: %w", res.Error())
+	}
+
+	p.CardSchemeData.Request.RetrievalReferenceNumber = req.message.Fields.F037_RetrievalReferenceNumber
+	p.CardSchemeData.Response, p.VisaSchemeData.Response = authorizationResultFromMessage(res.Message(), p.Amount)
 
 	return nil
 }
@@ -121,10 +158,19 @@ func (m Eas) Echo(ctx context.Context) error {
 	if res.Error() != nil {
 		return fmt.Errorf("failed to send echo request to EAS: %w", res.Error())
 	}
+	m.lastEcho.Store(time.Now().UnixNano())
 
 	return nil
 }
 
+// LastEcho returns when the last echo was answered, the zero time when none was
+func (m Eas) LastEcho() time.Time {
+	if nanos := m.lastEcho.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
 func NewHeader(msgLength int, ssid string) []byte {
 	buf := make([]byte, 22)
 
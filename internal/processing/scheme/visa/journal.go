@@ -0,0 +1,68 @@
+package visa
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+// The fields with cardholder data Fields does not define, they are dropped from its undefined elements
+const (
+	trackOneData = 45
+)
+
+// Journal stores the messages exchanged for a transaction, it handles its own errors so a failing
+// journal does not fail the transaction
+type Journal interface {
+	Record(ctx context.Context, messages ...entity.JournalMessage)
+}
+
+// journal records the request and, when one arrived, the response of the exchange
+func (m Eas) journal(ctx context.Context, transactionID uuid.UUID, transactionType entity.TransactionType, req *Message, res Response) {
+	if m.messageJournal == nil {
+		return
+	}
+
+	messages := []entity.JournalMessage{journalMessage(transactionID, transactionType, entity.Outbound, *req)}
+	if res.Error() == nil {
+		messages = append(messages, journalMessage(transactionID, transactionType, entity.Inbound, res.Message()))
+	}
+
+	m.messageJournal.Record(ctx, messages...)
+}
+
+func journalMessage(transactionID uuid.UUID, transactionType entity.TransactionType, direction entity.MessageDirection, msg Message) entity.JournalMessage {
+	elements, err := entity.JournalElements(Masked(msg).Fields)
+	if err != nil {
+		elements = entity.JournalError(err)
+	}
+
+	return entity.JournalMessage{
+		TransactionID:   transactionID,
+		TransactionType: transactionType,
+		Scheme:          entity.Visa,
+		Direction:       direction,
+		Mti:             msg.Mti.String(),
+		Stan:            msg.Fields.F011_SystemTraceAuditNumber,
+		Elements:        elements,
+	}
+}
+
+// Masked returns a copy of the message without cardholder data: the PAN is masked, the track data,
+// PIN block, CAVV, CVV2, Mastercard UCAF, the account number of the sender of an original credit and the PAN, track
+// and name data objects of the chip are dropped
+func Masked(msg Message) Message {
+	msg.Fields.F002_PrimaryAccountNumber = entity.MaskPan(msg.Fields.F002_PrimaryAccountNumber)
+	msg.Fields.F035_Track2Data = ""
+	msg.Fields.F052_PinData = ""
+	msg.Fields.UndefinedElements = msg.Fields.UndefinedElements.Without(trackOneData)
+	msg.Fields.F055_IntegratedCircuitCardData.HEX01_ChipCardData = msg.Fields.F055_IntegratedCircuitCardData.HEX01_ChipCardData.Without(entity.IccCardholderDataTags...)
+	msg.Fields.F126_PrivateUseFields.SF9_CAVVData = ""
+	msg.Fields.F126_PrivateUseFields.SF10_CVV2AuthorizationRequestData = ""
+	msg.Fields.F126_PrivateUseFields.SF16_MastercardUCAFField = ""
+	msg.Fields.F104_TransactionSpecificData.HEX5F_SenderData.T02_SenderAccountNumber = ""
+
+	return msg
+}
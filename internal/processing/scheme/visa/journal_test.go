@@ -0,0 +1,52 @@
+package visa
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+func TestJournalMessage(t *testing.T) {
+	msg := Message{
+		Mti: iso8583.NewMti("0100"),
+		Fields: base1.Fields{
+			F002_PrimaryAccountNumber:   "4111111111111111",
+			F011_SystemTraceAuditNumber: "123456",
+			F035_Track2Data:             "4111111111111111=2512201987654321",
+			F052_PinData:                "PINBLOCK",
+			F126_PrivateUseFields: base1.F126_PrivateUseFields{
+				SF9_CAVVData:                      "0700010000000000000000000000000000000000",
+				SF10_CVV2AuthorizationRequestData: "11 936",
+			},
+		},
+	}
+
+	got := journalMessage(uuid.New(), entity.AuthorizationTransaction, entity.Outbound, msg)
+
+	if got.Mti != "0100" || got.Stan != "123456" {
+		t.Errorf("journalMessage() = %s %s, want 0100 123456", got.Mti, got.Stan)
+	}
+	for _, sensitive := range []string{"4111111111111111", "0700010000000000000000000000000000000000", "936", "987654321", "PINBLOCK"} {
+		if strings.Contains(string(got.Elements), sensitive) {
+			t.Errorf("journalMessage() elements contain %s: %s", sensitive, got.Elements)
+		}
+	}
+
+	var elements map[string]interface{}
+	if err := json.Unmarshal(got.Elements, &elements); err != nil {
+		t.Fatalf("journalMessage() elements are not JSON: %v", err)
+	}
+	if pan := elements["F002_PrimaryAccountNumber"]; pan != "41111111####1111" {
+		t.Errorf("journalMessage() PAN = %v, want 41111111####1111", pan)
+	}
+
+	if msg.Fields.F002_PrimaryAccountNumber != "4111111111111111" {
+		t.Errorf("journalMessage() changed the message that was sent")
+	}
+}
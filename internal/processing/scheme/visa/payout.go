@@ -0,0 +1,89 @@
+package visa
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/pos"
+	"gitlab.cmpayments.local/creditcard/clearing/pkg/mastercard/countrycode"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+const (
+	payoutRequestMTI = `0100`
+
+	// personToPerson is the business application identifier of an original credit between persons
+	personToPerson = `PP`
+)
+
+func PayoutSchemeData(p *entity.Payout) {
+	p.CardSchemeData.Request.ProcessingCode = entity.ProcessingCode{
+		TransactionTypeCode: "26", // Original Credit
+		FromAccountTypeCode: "00", // Default Account
+		ToAccountTypeCode:   "00", // Default Account
+	}
+	p.CardSchemeData.Request.POSEntryMode = posEntryMode(false, entity.CitMitIndicator{})
+	p.VisaSchemeData.Request.PosConditionCode = posConditionCode(entity.Ecommerce, false)
+	p.VisaSchemeData.Request.AdditionalPOSInformation = additionalPOSInformation(entity.Ecommerce, entity.ThreeDSecure{EcommerceIndicator: 7})
+}
+
+// transactionSpecificData holds the business application identifier and the sender of a payout in field 104
+func transactionSpecificData(p entity.Payout) base1.F104_TransactionSpecificData {
+	return base1.F104_TransactionSpecificData{
+		HEX57_BusinessApplicationIdentifier: base1.HEX57_BusinessApplicationIdentifier{
+			T01_BusinessApplicationIdentifier: personToPerson,
+		},
+		HEX5F_SenderData: base1.HEX5F_SenderData{
+			T02_SenderAccountNumber: p.Sender.AccountNumber,
+			T03_SenderName:          p.Sender.Name(),
+			T04_SenderAddress:       p.Sender.Address,
+			T05_SenderCity:          p.Sender.City,
+			T07_SenderCountry:       countrycode.Must(countrycode.FromAlpha3(p.Sender.CountryCode)).Numeric(),
+			T0A_RecipientName:       p.Recipient.Name(),
+		},
+	}
+}
+
+func messageFromPayout(p entity.Payout) *Message {
+	return &Message{
+		Mti: iso8583.NewMti(payoutRequestMTI),
+		Fields: base1.Fields{
+			F002_PrimaryAccountNumber:                   p.Card.Number,
+			F003_ProcessingCode:                         p.CardSchemeData.Request.ProcessingCode.String(),
+			F004_TransactionAmount:                      int64(p.Amount),
+			F007_TransmissionDateTime:                   base1.F007FromTime(time.Now()),
+			F011_SystemTraceAuditNumber:                 fmt.Sprintf("%06d", p.Stan),
+			F012_LocalTransactionTime:                   p.LocalTransactionDateTime.Format(`150405`),
+			F013_LocalTransactionDate:                   p.LocalTransactionDateTime.Format(`0102`),
+			F014_ExpirationDate:                         p.Card.Expiry.String(),
+			F018_MerchantType:                           p.CardAcceptor.CategoryCode,
+			F019_AcquiringInstituteCountryCode:          countrycode.NLD.Numeric(),
+			F022_PosEntryMode:                           fmt.Sprintf("%s%s0", pos.PanEntryCode(p.CardSchemeData.Request.POSEntryMode.PanEntryMode), pos.PinEntryCode(p.CardSchemeData.Request.POSEntryMode.PinEntryMode)),
+			F025_PosCondition:                           p.VisaSchemeData.Request.PosConditionCode,
+			F032_AcquiringInstitutionIdentificationCode: entity.VisaInstitutionID,
+			F037_RetrievalReferenceNumber:               retrievalReferenceNumber(p.Stan),
+			F042_CardAcceptorIdentificationCode:         fmt.Sprintf("%s%s", p.Psp.Prefix, p.CardAcceptor.ID),
+			F043_CardAcceptorNameLocation: base1.F043_CardAcceptorNameLocation{
+				SF1_CarAcceptorName:  p.CardAcceptor.Name,
+				SF2_CardAcceptorCity: p.CardAcceptor.Address.City,
+				SF3_CountryCode:      countrycode.Must(countrycode.FromAlpha3(p.CardAcceptor.Address.CountryCode)).Alpha2(),
+			},
+			F049_TransactionCurrencyCode: p.Currency.Numeric(),
+			F060_AdditionalPointOfServiceInformation: base1.F060_AdditionalPOSInformation{
+				B1: fmt.Sprintf("%s%s", p.VisaSchemeData.Request.AdditionalPOSInformation.TerminalType, p.VisaSchemeData.Request.AdditionalPOSInformation.TerminalEntryCapability),
+				B2: fmt.Sprintf("%s%s", p.VisaSchemeData.Request.AdditionalPOSInformation.ChipConditionCode, p.VisaSchemeData.Request.AdditionalPOSInformation.SpecialConditionIndicator),
+				B3: "00",
+				B4: fmt.Sprintf("%s%s", p.VisaSchemeData.Request.AdditionalPOSInformation.ChipTransactionIndicator, p.VisaSchemeData.Request.AdditionalPOSInformation.ChipCardAuthenticationReliabilityIndicator),
+				B5: p.VisaSchemeData.Request.AdditionalPOSInformation.TypeOrLevelIndicator,
+				B6: fmt.Sprintf("%s%s", p.VisaSchemeData.Request.AdditionalPOSInformation.CardholderIDMethodIndicator, p.VisaSchemeData.Request.AdditionalPOSInformation.AdditionalAuthorizationIndicators),
+			},
+			F063_NetworkData: base1.F063_NetworkData{
+				SF1_NetworkID: mapNetworkId(),
+			},
+			F104_TransactionSpecificData: transactionSpecificData(p),
+		},
+	}
+}
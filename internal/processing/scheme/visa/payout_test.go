@@ -0,0 +1,68 @@
+package visa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/data"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+func TestMessageFromPayout(t *testing.T) {
+	p := entity.Payout{
+		Card: entity.Card{
+			Number: "4619031141704650",
+			Expiry: entity.Expiry{Year: time.Now().Add(time.Hour * 24 * 730).Format("06"), Month: "05"},
+		},
+		CardAcceptor: entity.CardAcceptor{
+			Name:         "MaxCorp Inc.",
+			Address:      entity.CardAcceptorAddress{City: "Breda", CountryCode: "NLD"},
+			ID:           "12345",
+			CategoryCode: "6012",
+		},
+		Currency:                 currencycode.Must("EUR"),
+		Amount:                   5000,
+		Stan:                     7,
+		LocalTransactionDateTime: data.LocalTransactionDateTime(time.Now()),
+		Sender: entity.PayoutParty{
+			FirstName:     "John",
+			LastName:      "Smith",
+			Address:       "Main Street 1",
+			City:          "Breda",
+			CountryCode:   "NLD",
+			AccountNumber: "NL91ABNA0417164300",
+		},
+		Recipient: entity.PayoutParty{FirstName: "Jane", LastName: "Doe"},
+	}
+	PayoutSchemeData(&p)
+
+	got := messageFromPayout(p)
+
+	if got.Fields.F003_ProcessingCode != "260000" {
+		t.Errorf("F003_ProcessingCode = %s, want 260000", got.Fields.F003_ProcessingCode)
+	}
+
+	want := base1.F104_TransactionSpecificData{
+		HEX57_BusinessApplicationIdentifier: base1.HEX57_BusinessApplicationIdentifier{T01_BusinessApplicationIdentifier: "PP"},
+		HEX5F_SenderData: base1.HEX5F_SenderData{
+			T02_SenderAccountNumber: "NL91ABNA0417164300",
+			T03_SenderName:          "John Smith",
+			T04_SenderAddress:       "Main Street 1",
+			T05_SenderCity:          "Breda",
+			T07_SenderCountry:       "528",
+			T0A_RecipientName:       "Jane Doe",
+		},
+	}
+	if !reflect.DeepEqual(got.Fields.F104_TransactionSpecificData, want) {
+		t.Errorf("\ngot =  %+v\nwant = %+v", got.Fields.F104_TransactionSpecificData, want)
+	}
+
+	// The account number of the sender is not journaled
+	if masked := Masked(*got); masked.Fields.F104_TransactionSpecificData.HEX5F_SenderData.T02_SenderAccountNumber != "" {
+		t.Errorf("Masked() kept the sender account number %s", masked.Fields.F104_TransactionSpecificData.HEX5F_SenderData.T02_SenderAccountNumber)
+	}
+}
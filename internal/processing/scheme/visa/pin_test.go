@@ -0,0 +1,70 @@
+package visa
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+type pinTranslatorFunc func(ctx context.Context, pin entity.OnlinePin, pan string) (entity.ZonePinBlock, error)
+
+func (f pinTranslatorFunc) TranslatePin(ctx context.Context, pin entity.OnlinePin, pan string) (entity.ZonePinBlock, error) {
+	return f(ctx, pin, pan)
+}
+
+func TestZonePinBlock(t *testing.T) {
+	errTranslation := errors.New("translation failed")
+	translator := pinTranslatorFunc(func(_ context.Context, pin entity.OnlinePin, pan string) (entity.ZonePinBlock, error) {
+		if pan != "4761340000000035" {
+			return entity.ZonePinBlock{}, errTranslation
+		}
+		return entity.ZonePinBlock{PinBlock: []byte{0x1b, 0x9c, 0x18, 0x45, 0xeb, 0x99, 0x3a, 0x7a}, KeyIndex: "02"}, nil
+	})
+	onlinePin := entity.OnlinePin{PinBlock: []byte{1, 2, 3, 4, 5, 6, 7, 8}, KeySerialNumber: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	tests := []struct {
+		name            string
+		translator      PinTranslator
+		card            entity.Card
+		wantErr         error
+		wantControlInfo string
+	}{
+		{
+			name:       "no_online_pin",
+			translator: translator,
+			card:       entity.Card{Number: "4761340000000035"},
+		},
+		{
+			name:            "online_pin",
+			translator:      translator,
+			card:            entity.Card{Number: "4761340000000035", OnlinePin: onlinePin},
+			wantControlInfo: "2001010200000000",
+		},
+		{
+			name:       "translation_failed",
+			translator: translator,
+			card:       entity.Card{Number: "4111111111111111", OnlinePin: onlinePin},
+			wantErr:    errTranslation,
+		},
+		{
+			name:    "no_pin_translator",
+			card:    entity.Card{Number: "4761340000000035", OnlinePin: onlinePin},
+			wantErr: ErrNoPinTranslator,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eas := NewEas(nil, nil, "", nil, tt.translator)
+			pin, err := eas.zonePinBlock(context.Background(), entity.Authorization{Card: tt.card})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("zonePinBlock() error = %v, wanted %v", err, tt.wantErr)
+			}
+
+			if got := securityRelatedControlInformation(pin); got != tt.wantControlInfo {
+				t.Errorf("securityRelatedControlInformation() = %q, wanted %q", got, tt.wantControlInfo)
+			}
+		})
+	}
+}
@@ -32,7 +32,7 @@ type Response struct {
 func NewResponse(packet []byte, error error) connection.Received {
 	var msg Message
 	if error == nil && packet != nil {
-		msg, error = decode(packet[2:])
+		msg, error = Decode(packet[2:])
 	}
 	return Response{
 		packet:  packet,
@@ -56,7 +56,7 @@ func (mcr Response) PacketToSend() ([]byte, error) {
 	}
 
 	log.Printf("Unexpected MTI: %s", mcr.message.Mti.String())
-	log.Printf("Message: %#v", mcr.message.Fields)
+	log.Printf("Message: %#v", Masked(mcr.message).Fields)
 
 	return nil, ErrNotExpectedMessage
 }
@@ -69,7 +69,9 @@ func (mcr Response) Error() error {
 	return mcr.error
 }
 
-func decode(payload []byte) (Message, error) {
+// Decode reads a message from a packet without its length prefix, opts are applied after the options of the
+// BASE I format, like iso8583.Offsets to learn where the fields are
+func Decode(payload []byte, opts ...iso8583.Opt) (Message, error) {
 	var msg Message
 
 	header, message := splitHeaderAndMessage(payload)
@@ -83,7 +85,8 @@ func decode(payload []byte) (Message, error) {
 		return msg, fmt.Errorf("decode(): rejection code: %s", rejectionCode)
 	}
 
-	decoder := iso8583.NewDecoder(bytes.NewReader(message), iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen)
+	opts = append([]iso8583.Opt{iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen, iso8583.UndefinedElements(base1.UndefinedFormats)}, opts...)
+	decoder := iso8583.NewDecoder(bytes.NewReader(message), opts...)
 
 	err := msg.Decode(decoder)
 	return msg, err
@@ -96,6 +99,8 @@ func getNetworkManagementResponse(reqMsg *Message) ([]byte, error) {
 			F007_TransmissionDateTime:             reqMsg.Fields.F007_TransmissionDateTime,
 			F011_SystemTraceAuditNumber:           reqMsg.Fields.F011_SystemTraceAuditNumber,
 			F070_NetworkManagementInformationCode: reqMsg.Fields.F070_NetworkManagementInformationCode,
+			// The fields we do not define are echoed as they were received
+			UndefinedElements: reqMsg.Fields.UndefinedElements,
 		},
 	}
 
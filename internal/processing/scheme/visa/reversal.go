@@ -80,6 +80,11 @@ func mapReasonCode(reason error) string {
 		return "2502"
 	case errors.Is(reason, connection.ErrNoFreeConnections):
 		return "2502"
+	case errors.Is(reason, connection.ErrShutdownDeadline):
+		return "2502"
+	// The issuer partially approved without a valid amount, the transaction is not completed
+	case errors.Is(reason, entity.ErrInvalidPartialApproval):
+		return "2502"
 	// No error received. Transaction voided by customer, return 2501
 	case reason == nil:
 		return "2501"
@@ -1,9 +1,14 @@
 package visa
 
 import (
+	"errors"
 	"testing"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/platform/currencycode"
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/connection"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
 )
@@ -41,3 +46,56 @@ func TestMessageFromReversal(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageFromReversal_invalidPartialApproval(t *testing.T) {
+	a := entity.Authorization{
+		Card: entity.Card{
+			Number: "4761739001010010",
+			Expiry: entity.Expiry{Year: time.Now().Add(time.Hour * 24 * 730).Format("06"), Month: "05"},
+		},
+		CardAcceptor: entity.CardAcceptor{
+			Name:         "MaxCorp Inc.",
+			Address:      entity.CardAcceptorAddress{City: "Breda", CountryCode: "NLD"},
+			ID:           "12345",
+			CategoryCode: "5999",
+		},
+		Currency: currencycode.Must("EUR"),
+		Amount:   5000,
+		Stan:     7,
+	}
+	a.CardSchemeData.Response.ResponseCode.Value = entity.PartialApprovalResponseCode
+	a.CardSchemeData.Response.AuthorizationIDResponse = "A1B2C3"
+
+	got := messageFromReversal(entity.Reversal{Authorization: a, Amount: a.Amount, Reason: entity.ErrInvalidPartialApproval})
+
+	if got.Fields.F004_TransactionAmount != int64(a.Amount) {
+		t.Errorf("F004_TransactionAmount = %d, want %d", got.Fields.F004_TransactionAmount, a.Amount)
+	}
+	if got.Fields.F038_AuthorizationIdenticationResponse != "A1B2C3" {
+		t.Errorf("F038_AuthorizationIdenticationResponse = %q, want A1B2C3", got.Fields.F038_AuthorizationIdenticationResponse)
+	}
+	if got.Fields.F063_NetworkData.SF3_MessageReasonCode != "2502" {
+		t.Errorf("F063 SF3_MessageReasonCode = %q, want 2502", got.Fields.F063_NetworkData.SF3_MessageReasonCode)
+	}
+}
+
+func TestMapReasonCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		reason error
+		want   string
+	}{
+		{name: "voided", want: "2501"},
+		{name: "timeout", reason: connection.ErrRequestTimeout, want: "2502"},
+		{name: "shutdown_deadline", reason: connection.ErrShutdownDeadline, want: "2502"},
+		{name: "invalid_partial_approval", reason: entity.ErrInvalidPartialApproval, want: "2502"},
+		{name: "other", reason: errors.New("canceled by the merchant"), want: "2501"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapReasonCode(tt.reason); got != tt.want {
+				t.Errorf("mapReasonCode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
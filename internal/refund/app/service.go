@@ -26,10 +26,15 @@ type Repository interface {
 	GetAllRefunds(ctx context.Context, pspID uuid.UUID, filters entity.Filters, params map[string]interface{}) (entity.Metadata, []entity.Refund, error)
 }
 
+type PolicyEvaluator interface {
+	EvaluateRefund(ctx context.Context, r entity.Refund) error
+}
+
 type RefundService struct {
 	log          platform.Logger
 	repo         Repository
 	tokenizer    Tokenizer
+	policy       PolicyEvaluator
 	riskAssessor *risk.Assessor
 	mapper       *authorization.Mapper
 }
@@ -38,19 +43,24 @@ func NewRefundService(
 	logger platform.Logger,
 	repo Repository,
 	tokenizer Tokenizer,
+	policy PolicyEvaluator,
 	mapper *authorization.Mapper,
 ) RefundService {
 	return RefundService{
 		log:          logger,
 		repo:         repo,
 		tokenizer:    tokenizer,
+		policy:       policy,
 		riskAssessor: risk.NewAssessor(risk.Rules()),
 		mapper:       mapper,
 	}
 }
 
 func (rs RefundService) Authorize(ctx context.Context, r *entity.Refund) error {
-	var err error
+	err := rs.policy.EvaluateRefund(ctx, *r)
+	if err != nil {
+		return fmt.Errorf("refund not allowed by policy: %w", err)
+	}
 
 	r.Card.PanTokenID, err = rs.tokenizer.Tokenize(ctx, r.Psp.ID.String(), r.Card)
 	if err != nil {
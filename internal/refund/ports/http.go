@@ -21,24 +21,26 @@ import (
 
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/cardinfo"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/refund/app"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/web"
+	httpErrors "gitlab.cmpayments.local/creditcard/authorization/pkg/web/errors"
 )
 
 type refundHandler struct {
-	logger                     platform.Logger
-	allowProductionCardNumbers bool
-	refundService              app.RefundService
-	cardRanges                 *cardinfo.Collection
+	logger          platform.Logger
+	cardNumberGuard *cardinfo.CardNumberGuard
+	refundService   app.RefundService
+	cardRanges      *cardinfo.Collection
 }
 
-func NewRefundHandler(allowProductionCardNumbers bool, logger platform.Logger, refundService app.RefundService, cardRanges *cardinfo.Collection) *refundHandler {
+func NewRefundHandler(cardNumberGuard *cardinfo.CardNumberGuard, logger platform.Logger, refundService app.RefundService, cardRanges *cardinfo.Collection) *refundHandler {
 	return &refundHandler{
-		allowProductionCardNumbers: allowProductionCardNumbers,
-		logger:                     logger,
-		refundService:              refundService,
-		cardRanges:                 cardRanges,
+		cardNumberGuard: cardNumberGuard,
+		logger:          logger,
+		refundService:   refundService,
+		cardRanges:      cardRanges,
 	}
 }
 
@@ -61,6 +63,11 @@ func (h *refundHandler) CreateRefund(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.cardNumberGuard.Check(ctx, input.Card.Number); err != nil {
+		platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"card.number": {err.Error()}})
+		return
+	}
+
 	cardInfo, ok := h.cardRanges.Find(input.Card.Number)
 	if !ok {
 		platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{"card.number": {"unknown card range"}})
@@ -74,7 +81,16 @@ func (h *refundHandler) CreateRefund(w http.ResponseWriter, r *http.Request) {
 
 	err := h.refundService.Authorize(ctx, &refund)
 	if err != nil {
+		var violation entity.PolicyViolation
+		var unavailable *authorization.UnavailableError
 		switch {
+		case errors.As(err, &violation):
+			h.logger.Info(ctx, violation.Error())
+			platformErr.FailedValidationResponse(ctx, w, h.logger, map[string][]string{violation.Field: {violation.Reason}})
+			return
+		case errors.As(err, &unavailable):
+			httpErrors.ServiceUnavailableResponse(ctx, w, h.logger, err, unavailable.RetryAfter)
+			return
 		case errors.Is(err, tokenization.ErrFailedTokenize):
 			h.logger.Error(liblogging.ContextWithError(ctx, err), "internal server error")
 			platformErr.ServerErrorResponse(ctx, w, h.logger, tokenization.ErrFailedTokenize)
@@ -62,7 +62,7 @@ func NewReversalService(
 
 func (rs ReversalService) Reverse(ctx context.Context, pspID uuid.UUID, r *entity.Reversal) error {
 	var err error
-	if r.Authorization == (entity.Authorization{}) {
+	if r.Authorization.ID == uuid.Nil {
 		r.Authorization, err = rs.authRepo.GetAuthorizationWithSchemeData(ctx, pspID, r.AuthorizationID)
 		if err != nil {
 			switch {
@@ -103,7 +103,11 @@ func (rs ReversalService) Reverse(ctx context.Context, pspID uuid.UUID, r *entit
 	if err != nil {
 		return fmt.Errorf("failed to GetCaptureSummary: %w", err)
 	}
-	r.Amount = r.Authorization.Amount - summary.TotalCapturedAmount
+	r.Amount = r.Authorization.ApprovedAmount() - summary.TotalCapturedAmount
+	if errors.Is(r.Reason, entity.ErrInvalidPartialApproval) {
+		// Nothing was approved for the merchant, but the issuer may hold the whole amount that was requested
+		r.Amount = r.Authorization.Amount
+	}
 
 	err = rs.reversalRepo.CreateReversal(ctx, *r)
 	if err != nil {
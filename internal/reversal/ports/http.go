@@ -15,23 +15,25 @@ import (
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tokenization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/cardinfo"
 	reversalApp "gitlab.cmpayments.local/creditcard/authorization/internal/reversal/app"
+	httpErrors "gitlab.cmpayments.local/creditcard/authorization/pkg/web/errors"
 )
 
+// reversalHandler does not check the card number, a reversal uses the card of the authorization
+// which was checked when the authorization was created.
 type reversalHandler struct {
-	logger                     platform.Logger
-	allowProductionCardNumbers bool
-	cardRanges                 *cardinfo.Collection
-	reversalService            reversalApp.ReversalService
+	logger          platform.Logger
+	cardRanges      *cardinfo.Collection
+	reversalService reversalApp.ReversalService
 }
 
-func NewReversalHandler(allowProductionCardNumbers bool, cardRanges *cardinfo.Collection, logger platform.Logger, reversalService reversalApp.ReversalService) reversalHandler {
+func NewReversalHandler(cardRanges *cardinfo.Collection, logger platform.Logger, reversalService reversalApp.ReversalService) reversalHandler {
 	return reversalHandler{
-		allowProductionCardNumbers: allowProductionCardNumbers,
-		cardRanges:                 cardRanges,
-		logger:                     logger,
-		reversalService:            reversalService,
+		cardRanges:      cardRanges,
+		logger:          logger,
+		reversalService: reversalService,
 	}
 }
 
@@ -51,7 +53,11 @@ func (h reversalHandler) ReverseAuthorization(w http.ResponseWriter, r *http.Req
 
 	err = h.reversalService.Reverse(ctx, psp.ID, &reversal)
 	if err != nil {
+		var unavailable *authorization.UnavailableError
 		switch {
+		case errors.As(err, &unavailable):
+			httpErrors.ServiceUnavailableResponse(ctx, w, h.logger, err, unavailable.RetryAfter)
+			return
 		case errors.Is(err, entity.ErrRecordNotFound):
 			platformErr.NotFoundResponse(ctx, w, h.logger)
 			return
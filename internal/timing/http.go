@@ -26,3 +26,11 @@ func MetricsHandler(t *Tracker) http.HandlerFunc {
 		jsonresult.Ok(writer, res)
 	}
 }
+
+// PrometheusHandler exposes the timers and gauges of the tracker in the Prometheus text format
+func PrometheusHandler(t *Tracker) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		t.prometheus.write(writer)
+	}
+}
@@ -0,0 +1,223 @@
+package timing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+)
+
+const metricPrefix = "authorization_"
+
+// AuthorizationResponseCodeTag holds the response code of the authorization at the scheme. The response_code
+// tag can't be used for it, a reversal later in the same request overwrites that one.
+const AuthorizationResponseCodeTag = "authorization_response_code"
+
+// buckets are the upper bounds in seconds of the latency histograms, the Prometheus client defaults
+var buckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(buckets))}
+}
+
+func (h *histogram) observe(d time.Duration) {
+	s := d.Seconds()
+	for i, b := range buckets {
+		if s <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += s
+}
+
+type authorizationKey struct {
+	scheme       string
+	status       string
+	responseCode string
+}
+
+type gaugeSeries struct {
+	labels map[string]string
+	value  func() float64
+}
+
+type gaugeFamily struct {
+	help   string
+	series []gaugeSeries
+}
+
+// prometheus keeps the metrics that are exposed in the Prometheus text format. It is fed with the
+// same timers as the rings, so the wrappers don't need to know about it.
+type prometheus struct {
+	mu             sync.Mutex
+	routes         map[string]*histogram
+	segments       map[string]*histogram
+	authorizations map[authorizationKey]uint64
+	gauges         map[string]*gaugeFamily
+}
+
+func newPrometheus() *prometheus {
+	return &prometheus{
+		routes:         map[string]*histogram{},
+		segments:       map[string]*histogram{},
+		authorizations: map[authorizationKey]uint64{},
+		gauges:         map[string]*gaugeFamily{},
+	}
+}
+
+func (p *prometheus) capture(t timer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if t.route != "" {
+		h, ok := p.routes[t.route]
+		if !ok {
+			h = newHistogram()
+			p.routes[t.route] = h
+		}
+		h.observe(t.rootDuration)
+	}
+
+	for label, d := range t.finishedTimers {
+		h, ok := p.segments[label]
+		if !ok {
+			h = newHistogram()
+			p.segments[label] = h
+		}
+		h.observe(d)
+
+		// The scheme connection wrapper times authorizations as scheme.<scheme>.authorize
+		if strings.HasPrefix(label, "scheme.") && strings.HasSuffix(label, ".authorize") {
+			p.authorizations[authorizationKey{
+				scheme:       t.tags["scheme"],
+				status:       authorizationStatus(t.tags[AuthorizationResponseCodeTag]),
+				responseCode: t.tags[AuthorizationResponseCodeTag],
+			}]++
+		}
+	}
+}
+
+// authorizationStatus maps the response code to the status of the authorization, an authorization
+// without response code never got an answer from the scheme
+func authorizationStatus(responseCode string) string {
+	if responseCode == "" {
+		return entity.AuthorizeFailed.String()
+	}
+	return entity.AuthorizationStatusFromCardSchemeResponseCode(responseCode).String()
+}
+
+func (p *prometheus) gauge(name, help string, labels map[string]string, value func() float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, ok := p.gauges[name]
+	if !ok {
+		f = &gaugeFamily{help: help}
+		p.gauges[name] = f
+	}
+	f.series = append(f.series, gaugeSeries{labels: labels, value: value})
+}
+
+func (p *prometheus) write(w io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	writeHistograms(w, metricPrefix+"http_request_duration_seconds", "Duration of the HTTP requests per route.", "route", p.routes)
+	writeHistograms(w, metricPrefix+"segment_duration_seconds", "Duration of the timed segments, like repository and scheme calls.", "segment", p.segments)
+
+	name := metricPrefix + "authorizations_total"
+	fmt.Fprintf(w, "# HELP %s Authorizations sent to the schemes per scheme, status and response code.\n", name)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+
+	keys := make([]authorizationKey, 0, len(p.authorizations))
+	for k := range p.authorizations {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].scheme+keys[i].status+keys[i].responseCode < keys[j].scheme+keys[j].status+keys[j].responseCode
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{scheme=%q,status=%q,response_code=%q} %d\n",
+			name, escape(k.scheme), escape(k.status), escape(k.responseCode), p.authorizations[k])
+	}
+
+	names := make([]string, 0, len(p.gauges))
+	for n := range p.gauges {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		f := p.gauges[n]
+		fmt.Fprintf(w, "# HELP %s %s\n", n, f.help)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", n)
+		for _, s := range f.series {
+			fmt.Fprintf(w, "%s%s %s\n", n, formatLabels(s.labels), formatFloat(s.value()))
+		}
+	}
+}
+
+func writeHistograms(w io.Writer, name, help, label string, histograms map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+
+	values := make([]string, 0, len(histograms))
+	for v := range histograms {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	for _, v := range values {
+		h := histograms[v]
+		for i, b := range buckets {
+			fmt.Fprintf(w, "%s_bucket{%s=%q,le=%q} %d\n", name, label, escape(v), formatFloat(b), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", name, label, escape(v), h.count)
+		fmt.Fprintf(w, "%s_sum{%s=%q} %s\n", name, label, escape(v), formatFloat(h.sum))
+		fmt.Fprintf(w, "%s_count{%s=%q} %d\n", name, label, escape(v), h.count)
+	}
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, escape(labels[k])))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// escape removes the characters that %q would escape differently than the Prometheus text format
+func escape(v string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, v)
+}
@@ -0,0 +1,43 @@
+package timing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheus_write(t *testing.T) {
+	p := newPrometheus()
+
+	timed := newTimer()
+	timed.route = "create_authorization"
+	timed.rootDuration = 30 * time.Millisecond
+	timed.finishedTimers["AuthorizationRepository.CreateAuthorization"] = 4 * time.Millisecond
+	timed.finishedTimers["scheme.mastercard.authorize"] = 20 * time.Millisecond
+	timed.tags["scheme"] = "mastercard"
+	timed.tags[AuthorizationResponseCodeTag] = "05"
+	// The reversal of the authorization overwrites the response code of the request
+	timed.tags["response_code"] = "00"
+	p.capture(*timed)
+
+	p.gauge("authorization_pool_connections", "Connections.", map[string]string{"pool": "visa", "state": "connected"},
+		func() float64 { return 2 })
+
+	var b bytes.Buffer
+	p.write(&b)
+
+	for _, want := range []string{
+		`authorization_http_request_duration_seconds_bucket{route="create_authorization",le="0.025"} 0`,
+		`authorization_http_request_duration_seconds_bucket{route="create_authorization",le="0.05"} 1`,
+		`authorization_http_request_duration_seconds_count{route="create_authorization"} 1`,
+		`authorization_segment_duration_seconds_bucket{segment="AuthorizationRepository.CreateAuthorization",le="0.005"} 1`,
+		`authorization_authorizations_total{scheme="mastercard",status="declined",response_code="05"} 1`,
+		"# TYPE authorization_pool_connections gauge",
+		`authorization_pool_connections{pool="visa",state="connected"} 2`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("write() missing %s in\n%s", want, b.String())
+		}
+	}
+}
@@ -12,6 +12,7 @@ func newTimer() *timer {
 
 // timer is one specific timer collection of metrics for one specific
 type timer struct {
+	route          string
 	tags           map[string]string
 	startedTimers  map[string]time.Time
 	finishedTimers map[string]time.Duration
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Slice struct {
@@ -18,8 +20,9 @@ func (s Slice) Label() string {
 
 func NewTracker(slices ...Slice) *Tracker {
 	t := Tracker{
-		rings:  make([]*ring, 0),
-		timers: make(chan timer),
+		rings:      make([]*ring, 0),
+		timers:     make(chan timer),
+		prometheus: newPrometheus(),
 	}
 
 	for _, slice := range slices {
@@ -31,8 +34,9 @@ func NewTracker(slices ...Slice) *Tracker {
 
 // Tracker manages the timing of things, collecting all data and exposing the results
 type Tracker struct {
-	rings  []*ring
-	timers chan timer
+	rings      []*ring
+	timers     chan timer
+	prometheus *prometheus
 }
 
 func (t *Tracker) Process(ctx context.Context) {
@@ -45,6 +49,7 @@ func (t *Tracker) Process(ctx context.Context) {
 				for _, r := range t.rings {
 					r.capture(timed)
 				}
+				t.prometheus.capture(timed)
 			}
 		}
 	}()
@@ -57,10 +62,18 @@ func (t *Tracker) Process(ctx context.Context) {
 func (t *Tracker) Http(label string, handler http.HandlerFunc) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		request = request.WithContext(contextWithTimer(request.Context()))
+		// Name the server span after the route instead of the method
+		trace.SpanFromContext(request.Context()).SetName(label)
 		StartRoot(request.Context())
 		handler(writer, request)
 		StopRoot(request.Context())
 		timed := timerFromContext(request.Context())
+		timed.route = label
 		t.timers <- *timed
 	}
 }
+
+// Gauge registers a gauge that is exposed by the PrometheusHandler, value is called on every scrape
+func (t *Tracker) Gauge(name, help string, labels map[string]string, value func() float64) {
+	t.prometheus.gauge(name, help, labels, value)
+}
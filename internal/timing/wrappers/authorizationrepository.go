@@ -1,5 +1,5 @@
 // Code generated by timing/wrappers/generate, DO NOT EDIT.
-// Generated on Mon May 15 15:07 2023
+// Generated on Mon Oct 19 16:05 2026
 package timingwrappers
 
 import (
@@ -7,6 +7,7 @@ import (
 	uuid "github.com/google/uuid"
 	app "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app"
 	entity "gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	tracing "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	timing "gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 )
 
@@ -17,45 +18,63 @@ type AuthorizationRepository struct {
 func (w AuthorizationRepository) AuthorizationAlreadyReversed(ctx context.Context, id uuid.UUID) (bool, error) {
 	timing.Start(ctx, "AuthorizationRepository.AuthorizationAlreadyReversed")
 	defer timing.Stop(ctx, "AuthorizationRepository.AuthorizationAlreadyReversed")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.AuthorizationAlreadyReversed")
+	defer span.End()
 	return w.Base.AuthorizationAlreadyReversed(ctx, id)
 }
 func (w AuthorizationRepository) CreateAuthorization(ctx context.Context, a entity.Authorization) error {
 	timing.Start(ctx, "AuthorizationRepository.CreateAuthorization")
 	defer timing.Stop(ctx, "AuthorizationRepository.CreateAuthorization")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.CreateAuthorization")
+	defer span.End()
 	return w.Base.CreateAuthorization(ctx, a)
 }
 func (w AuthorizationRepository) CreateMastercardAuthorization(ctx context.Context, a entity.Authorization) error {
 	timing.Start(ctx, "AuthorizationRepository.CreateMastercardAuthorization")
 	defer timing.Stop(ctx, "AuthorizationRepository.CreateMastercardAuthorization")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.CreateMastercardAuthorization")
+	defer span.End()
 	return w.Base.CreateMastercardAuthorization(ctx, a)
 }
 func (w AuthorizationRepository) CreateVisaAuthorization(ctx context.Context, a entity.Authorization) error {
 	timing.Start(ctx, "AuthorizationRepository.CreateVisaAuthorization")
 	defer timing.Stop(ctx, "AuthorizationRepository.CreateVisaAuthorization")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.CreateVisaAuthorization")
+	defer span.End()
 	return w.Base.CreateVisaAuthorization(ctx, a)
 }
 func (w AuthorizationRepository) GetAllAuthorizations(ctx context.Context, pspID uuid.UUID, filters entity.Filters, params map[string]interface{}) (entity.Metadata, []entity.Authorization, error) {
 	timing.Start(ctx, "AuthorizationRepository.GetAllAuthorizations")
 	defer timing.Stop(ctx, "AuthorizationRepository.GetAllAuthorizations")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.GetAllAuthorizations")
+	defer span.End()
 	return w.Base.GetAllAuthorizations(ctx, pspID, filters, params)
 }
 func (w AuthorizationRepository) GetAuthorization(ctx context.Context, pspID uuid.UUID, authorizationID uuid.UUID) (entity.Authorization, error) {
 	timing.Start(ctx, "AuthorizationRepository.GetAuthorization")
 	defer timing.Stop(ctx, "AuthorizationRepository.GetAuthorization")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.GetAuthorization")
+	defer span.End()
 	return w.Base.GetAuthorization(ctx, pspID, authorizationID)
 }
 func (w AuthorizationRepository) GetAuthorizationWithSchemeData(ctx context.Context, pspID uuid.UUID, authorizationID uuid.UUID) (entity.Authorization, error) {
 	timing.Start(ctx, "AuthorizationRepository.GetAuthorizationWithSchemeData")
 	defer timing.Stop(ctx, "AuthorizationRepository.GetAuthorizationWithSchemeData")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.GetAuthorizationWithSchemeData")
+	defer span.End()
 	return w.Base.GetAuthorizationWithSchemeData(ctx, pspID, authorizationID)
 }
 func (w AuthorizationRepository) UpdateAuthorizationResponse(ctx context.Context, a entity.Authorization) error {
 	timing.Start(ctx, "AuthorizationRepository.UpdateAuthorizationResponse")
 	defer timing.Stop(ctx, "AuthorizationRepository.UpdateAuthorizationResponse")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.UpdateAuthorizationResponse")
+	defer span.End()
 	return w.Base.UpdateAuthorizationResponse(ctx, a)
 }
 func (w AuthorizationRepository) UpdateAuthorizationStatus(ctx context.Context, authorizationID uuid.UUID, status entity.Status) error {
 	timing.Start(ctx, "AuthorizationRepository.UpdateAuthorizationStatus")
 	defer timing.Stop(ctx, "AuthorizationRepository.UpdateAuthorizationStatus")
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.UpdateAuthorizationStatus")
+	defer span.End()
 	return w.Base.UpdateAuthorizationStatus(ctx, authorizationID, status)
 }
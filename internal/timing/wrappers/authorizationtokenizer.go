@@ -1,11 +1,12 @@
 // Code generated by timing/wrappers/generate, DO NOT EDIT.
-// Generated on Mon May 15 15:07 2023
+// Generated on Mon Oct 19 16:05 2026
 package timingwrappers
 
 import (
 	"context"
 	app "gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app"
 	entity "gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	tracing "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	timing "gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 )
 
@@ -16,10 +17,14 @@ type AuthorizationTokenizer struct {
 func (w AuthorizationTokenizer) Detokenize(ctx context.Context, merchantID string, card entity.Card) (entity.Card, error) {
 	timing.Start(ctx, "AuthorizationTokenizer.Detokenize")
 	defer timing.Stop(ctx, "AuthorizationTokenizer.Detokenize")
+	ctx, span := tracing.Start(ctx, "AuthorizationTokenizer.Detokenize")
+	defer span.End()
 	return w.Base.Detokenize(ctx, merchantID, card)
 }
 func (w AuthorizationTokenizer) Tokenize(ctx context.Context, merchantID string, card entity.Card) (string, error) {
 	timing.Start(ctx, "AuthorizationTokenizer.Tokenize")
 	defer timing.Stop(ctx, "AuthorizationTokenizer.Tokenize")
+	ctx, span := tracing.Start(ctx, "AuthorizationTokenizer.Tokenize")
+	defer span.End()
 	return w.Base.Tokenize(ctx, merchantID, card)
 }
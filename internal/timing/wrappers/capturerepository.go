@@ -1,5 +1,5 @@
 // Code generated by timing/wrappers/generate, DO NOT EDIT.
-// Generated on Mon May 15 15:07 2023
+// Generated on Mon Oct 19 16:05 2026
 package timingwrappers
 
 import (
@@ -7,6 +7,7 @@ import (
 	uuid "github.com/google/uuid"
 	app "gitlab.cmpayments.local/creditcard/authorization/internal/capture/app"
 	entity "gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	tracing "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	timing "gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 )
 
@@ -17,35 +18,49 @@ type CaptureRepository struct {
 func (w CaptureRepository) CreateCapture(ctx context.Context, capture entity.Capture) error {
 	timing.Start(ctx, "CaptureRepository.CreateCapture")
 	defer timing.Stop(ctx, "CaptureRepository.CreateCapture")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.CreateCapture")
+	defer span.End()
 	return w.Base.CreateCapture(ctx, capture)
 }
 func (w CaptureRepository) CreateRefundCapture(ctx context.Context, capture entity.RefundCapture) error {
 	timing.Start(ctx, "CaptureRepository.CreateRefundCapture")
 	defer timing.Stop(ctx, "CaptureRepository.CreateRefundCapture")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.CreateRefundCapture")
+	defer span.End()
 	return w.Base.CreateRefundCapture(ctx, capture)
 }
 func (w CaptureRepository) FinalCaptureExists(ctx context.Context, authorizationID uuid.UUID) (bool, error) {
 	timing.Start(ctx, "CaptureRepository.FinalCaptureExists")
 	defer timing.Stop(ctx, "CaptureRepository.FinalCaptureExists")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.FinalCaptureExists")
+	defer span.End()
 	return w.Base.FinalCaptureExists(ctx, authorizationID)
 }
 func (w CaptureRepository) GetCaptureRefundSummary(ctx context.Context, refund entity.Refund) (entity.CaptureRefundSummary, error) {
 	timing.Start(ctx, "CaptureRepository.GetCaptureRefundSummary")
 	defer timing.Stop(ctx, "CaptureRepository.GetCaptureRefundSummary")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.GetCaptureRefundSummary")
+	defer span.End()
 	return w.Base.GetCaptureRefundSummary(ctx, refund)
 }
 func (w CaptureRepository) GetCaptureSummary(ctx context.Context, authorization entity.Authorization) (entity.CaptureSummary, error) {
 	timing.Start(ctx, "CaptureRepository.GetCaptureSummary")
 	defer timing.Stop(ctx, "CaptureRepository.GetCaptureSummary")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.GetCaptureSummary")
+	defer span.End()
 	return w.Base.GetCaptureSummary(ctx, authorization)
 }
 func (w CaptureRepository) GetCapturesByAuthorizationIDs(ctx context.Context, ids []string) ([]entity.Capture, error) {
 	timing.Start(ctx, "CaptureRepository.GetCapturesByAuthorizationIDs")
 	defer timing.Stop(ctx, "CaptureRepository.GetCapturesByAuthorizationIDs")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.GetCapturesByAuthorizationIDs")
+	defer span.End()
 	return w.Base.GetCapturesByAuthorizationIDs(ctx, ids)
 }
 func (w CaptureRepository) UpdateCapture(ctx context.Context, capture entity.Capture) error {
 	timing.Start(ctx, "CaptureRepository.UpdateCapture")
 	defer timing.Stop(ctx, "CaptureRepository.UpdateCapture")
+	ctx, span := tracing.Start(ctx, "CaptureRepository.UpdateCapture")
+	defer span.End()
 	return w.Base.UpdateCapture(ctx, capture)
 }
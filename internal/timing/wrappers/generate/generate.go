@@ -97,6 +97,10 @@ func main() {
 				jen.Qual("gitlab.cmpayments.local/creditcard/authorization/internal/timing", "Stop").Call(
 					jen.Id("ctx"), jen.Lit(*outType+"."+meth.Name()),
 				),
+				jen.List(jen.Id("ctx"), jen.Id("span")).Op(":=").Qual("gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing", "Start").Call(
+					jen.Id("ctx"), jen.Lit(*outType+"."+meth.Name()),
+				),
+				jen.Defer().Id("span").Dot("End").Call(),
 				jen.Return(
 					jen.Id("w").Dot("Base").Dot(meth.Name()).Call(jen.List(paramPass...)),
 				),
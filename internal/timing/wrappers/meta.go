@@ -4,7 +4,11 @@ package timingwrappers
 //go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/authorization/app.Tokenizer -out AuthorizationTokenizer
 
 //go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/refund/app.Repository -out RefundRepository
+//go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/payout/app.Repository -out PayoutRepository
 //go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/capture/app.CaptureRepository -out CaptureRepository
 //go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/reversal/app.ReversalRepository -out ReversalRepository
+//go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/merchant/app.Repository -out MerchantRepository
+//go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/policy/app.Repository -out PolicyRepository
+//go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/authorization/internal/journal/app.Repository -out JournalRepository
 
 //go:generate go run ./generate/generate.go -in gitlab.cmpayments.local/creditcard/platform/events/pubsub.Publisher -out Publisher
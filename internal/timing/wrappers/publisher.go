@@ -1,9 +1,10 @@
 // Code generated by timing/wrappers/generate, DO NOT EDIT.
-// Generated on Mon May 15 15:07 2023
+// Generated on Mon Oct 19 16:05 2026
 package timingwrappers
 
 import (
 	"context"
+	tracing "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	timing "gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 	pubsub "gitlab.cmpayments.local/creditcard/platform/events/pubsub"
 )
@@ -15,5 +16,7 @@ type Publisher struct {
 func (w Publisher) Publish(ctx context.Context, topic string, p pubsub.Publishable) error {
 	timing.Start(ctx, "Publisher.Publish")
 	defer timing.Stop(ctx, "Publisher.Publish")
+	ctx, span := tracing.Start(ctx, "Publisher.Publish")
+	defer span.End()
 	return w.Base.Publish(ctx, topic, p)
 }
@@ -1,10 +1,11 @@
 // Code generated by timing/wrappers/generate, DO NOT EDIT.
-// Generated on Mon May 15 15:07 2023
+// Generated on Mon Oct 19 16:05 2026
 package timingwrappers
 
 import (
 	"context"
 	entity "gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	tracing "gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	app "gitlab.cmpayments.local/creditcard/authorization/internal/reversal/app"
 	timing "gitlab.cmpayments.local/creditcard/authorization/internal/timing"
 )
@@ -16,10 +17,14 @@ type ReversalRepository struct {
 func (w ReversalRepository) CreateReversal(ctx context.Context, reversal entity.Reversal) error {
 	timing.Start(ctx, "ReversalRepository.CreateReversal")
 	defer timing.Stop(ctx, "ReversalRepository.CreateReversal")
+	ctx, span := tracing.Start(ctx, "ReversalRepository.CreateReversal")
+	defer span.End()
 	return w.Base.CreateReversal(ctx, reversal)
 }
 func (w ReversalRepository) UpdateReversalResponse(ctx context.Context, reversal entity.Reversal) error {
 	timing.Start(ctx, "ReversalRepository.UpdateReversalResponse")
 	defer timing.Stop(ctx, "ReversalRepository.UpdateReversalResponse")
+	ctx, span := tracing.Start(ctx, "ReversalRepository.UpdateReversalResponse")
+	defer span.End()
 	return w.Base.UpdateReversalResponse(ctx, reversal)
 }
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/entity"
+	"gitlab.cmpayments.local/creditcard/authorization/internal/infrastructure/tracing"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/processing/authorization"
 	"gitlab.cmpayments.local/creditcard/authorization/internal/timing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SchemeConnection is a handwritten timing wrapper
@@ -20,7 +24,9 @@ func (sc SchemeConnection) Echo(ctx context.Context) error {
 	timingLabel := fmt.Sprintf("scheme.%s.echo", sc.Scheme)
 	timing.Start(ctx, timingLabel)
 	timing.Tag(ctx, "scheme", sc.Scheme)
+	ctx, span := tracing.Start(ctx, timingLabel, attribute.String("scheme", sc.Scheme))
 	res := sc.Connection.Echo(ctx)
+	endSpan(span, res)
 	timing.Stop(ctx, timingLabel)
 	return res
 }
@@ -29,8 +35,12 @@ func (sc SchemeConnection) Authorize(ctx context.Context, authorization *entity.
 	timingLabel := fmt.Sprintf("scheme.%s.authorize", sc.Scheme)
 	timing.Start(ctx, timingLabel)
 	timing.Tag(ctx, "scheme", authorization.Card.Info.Scheme)
+	ctx, span := tracing.Start(ctx, timingLabel, attribute.String("scheme", authorization.Card.Info.Scheme))
 	res := sc.Connection.Authorize(ctx, authorization)
 	timing.Tag(ctx, "response_code", authorization.CardSchemeData.Response.ResponseCode.Value)
+	timing.Tag(ctx, timing.AuthorizationResponseCodeTag, authorization.CardSchemeData.Response.ResponseCode.Value)
+	span.SetAttributes(schemeAttributes(authorization.Stan, authorization.CardSchemeData)...)
+	endSpan(span, res)
 	timing.Stop(ctx, timingLabel)
 	return res
 }
@@ -39,8 +49,11 @@ func (sc SchemeConnection) Reverse(ctx context.Context, reversal *entity.Reversa
 	timingLabel := fmt.Sprintf("scheme.%s.reverse", sc.Scheme)
 	timing.Start(ctx, timingLabel)
 	timing.Tag(ctx, "scheme", reversal.Authorization.Card.Info.Scheme)
+	ctx, span := tracing.Start(ctx, timingLabel, attribute.String("scheme", reversal.Authorization.Card.Info.Scheme))
 	res := sc.Connection.Reverse(ctx, reversal)
 	timing.Tag(ctx, "response_code", reversal.CardSchemeData.Response.ResponseCode.Value)
+	span.SetAttributes(schemeAttributes(reversal.Authorization.Stan, reversal.CardSchemeData)...)
+	endSpan(span, res)
 	timing.Stop(ctx, timingLabel)
 	return res
 }
@@ -49,8 +62,42 @@ func (sc SchemeConnection) Refund(ctx context.Context, refund *entity.Refund) er
 	timingLabel := fmt.Sprintf("scheme.%s.refund", sc.Scheme)
 	timing.Start(ctx, timingLabel)
 	timing.Tag(ctx, "scheme", refund.Card.Info.Scheme)
+	ctx, span := tracing.Start(ctx, timingLabel, attribute.String("scheme", refund.Card.Info.Scheme))
 	res := sc.Connection.Refund(ctx, refund)
 	timing.Tag(ctx, "response_code", refund.CardSchemeData.Response.ResponseCode.Value)
+	span.SetAttributes(schemeAttributes(refund.Stan, refund.CardSchemeData)...)
+	endSpan(span, res)
 	timing.Stop(ctx, timingLabel)
 	return res
 }
+
+func (sc SchemeConnection) Payout(ctx context.Context, payout *entity.Payout) error {
+	timingLabel := fmt.Sprintf("scheme.%s.payout", sc.Scheme)
+	timing.Start(ctx, timingLabel)
+	timing.Tag(ctx, "scheme", payout.Card.Info.Scheme)
+	ctx, span := tracing.Start(ctx, timingLabel, attribute.String("scheme", payout.Card.Info.Scheme))
+	res := sc.Connection.Payout(ctx, payout)
+	timing.Tag(ctx, "response_code", payout.CardSchemeData.Response.ResponseCode.Value)
+	span.SetAttributes(schemeAttributes(payout.Stan, payout.CardSchemeData)...)
+	endSpan(span, res)
+	timing.Stop(ctx, timingLabel)
+	return res
+}
+
+// schemeAttributes returns the span attributes that identify the message at the scheme. The card
+// number must never be added.
+func schemeAttributes(stan int, data entity.CardSchemeData) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("stan", stan),
+		attribute.String("rrn", data.Request.RetrievalReferenceNumber),
+		attribute.String("response_code", data.Response.ResponseCode.Value),
+	}
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -39,6 +39,13 @@ type ProcessingCode struct {
 	ToAccountTypeCode   string `json:"toAccountTypeCode"`
 }
 
+// AdditionalAmount CIS DE54 and BASE I F054, an amount that is part of the amount such as the cash back
+type AdditionalAmount struct {
+	Type     string `json:"type"`
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+}
+
 // POSEntryMode DE 22—Point-of-Service (POS) Entry Mode
 type POSEntryMode struct {
 	// SF 1 POS Terminal PAN Entry Mode
@@ -107,6 +114,7 @@ type AuthorizationCaptureMessageV1 struct {
 	Source                                 string                `json:"source"`
 	CardAcceptor                           CardAcceptor          `json:"cardAcceptor"`
 	ProcessingCode                         ProcessingCode        `json:"processingCode"`
+	AdditionalAmounts                      []AdditionalAmount    `json:"additionalAmounts,omitempty"`
 	MastercardSchemeData                   *MastercardSchemeData `json:"mastercardSchemeData,omitempty"`
 }
 
@@ -0,0 +1,67 @@
+package iso8583
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Accessor is implemented by the code iso8583/generate emits for a struct with iso8583-tags. It gives the
+// encoder and decoder the fields of the elements without reflection, structs without it are accessed
+// through reflection.
+type Accessor interface {
+	// Iso8583Value returns the value of the element and whether the value is empty
+	Iso8583Value(number int) (interface{}, bool)
+
+	// Iso8583Pointer returns a pointer to decode the element into, nil pointers are allocated first
+	Iso8583Pointer(number int) interface{}
+}
+
+// accessorsDisabled makes the generated accessors be ignored
+var accessorsDisabled atomic.Bool
+
+// DisableAccessors makes the encoder and decoder use reflection for all structs, also those with generated
+// accessors. It is meant for tests and benchmarks that compare both.
+func DisableAccessors(disabled bool) {
+	accessorsDisabled.Store(disabled)
+}
+
+// fields gives access to the values of the elements of a struct
+type fields struct {
+	accessor Accessor
+	values   reflect.Value
+}
+
+func structFields(v interface{}) fields {
+	if accessor, ok := v.(Accessor); ok {
+		// A Message has no struct fields to reflect on
+		if _, defined := v.(definer); defined || !accessorsDisabled.Load() {
+			return fields{accessor: accessor}
+		}
+	}
+
+	return fields{values: reflect.Indirect(reflect.ValueOf(v))}
+}
+
+// value returns the value of the element and whether it is empty. Unexported fields give their zero
+// value.
+func (f fields) value(element Definition) (interface{}, bool) {
+	if f.accessor != nil {
+		return f.accessor.Iso8583Value(element.Number)
+	}
+
+	field := f.values.Field(element.Field)
+	if !field.CanInterface() {
+		return reflect.New(field.Type()).Elem().Interface(), field.IsZero()
+	}
+
+	return field.Interface(), field.IsZero()
+}
+
+// pointer returns a pointer to decode the element into
+func (f fields) pointer(element Definition) interface{} {
+	if f.accessor != nil {
+		return f.accessor.Iso8583Pointer(element.Number)
+	}
+
+	return StructField(f.values, element.Field).Addr().Interface()
+}
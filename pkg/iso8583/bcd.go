@@ -6,6 +6,16 @@ import (
 	"github.com/yerden/go-util/bcd"
 )
 
+// bcd4 is 8-4-2-1 BCD with the field separator of track 2 data, which BASE I sends as the nibble D
+var bcd4 = &bcd.BCD{
+	Map: map[byte]byte{
+		'0': 0x0, '1': 0x1, '2': 0x2, '3': 0x3,
+		'4': 0x4, '5': 0x5, '6': 0x6, '7': 0x7,
+		'8': 0x8, '9': 0x9, '=': 0xd,
+	},
+	Filler: 0xf,
+}
+
 type bcdEncoder struct {
 	w io.Writer
 	r io.Reader
@@ -16,7 +26,7 @@ func (be bcdEncoder) Write(p []byte) (n int, err error) {
 		p = append([]byte("0"), p...)
 	}
 
-	enc := bcd.NewEncoder(bcd.Standard)
+	enc := bcd.NewEncoder(bcd4)
 	dst := make([]byte, bcd.EncodedLen(len(p)))
 	_, err = enc.Encode(dst, p)
 	if err != nil {
@@ -37,7 +47,7 @@ func (be bcdEncoder) Read(p []byte) (n int, err error) {
 		return bcdN, err
 	}
 
-	dec := bcd.NewDecoder(bcd.Standard)
+	dec := bcd.NewDecoder(bcd4)
 	// Length of the fields needs to be read binary
 	// Data of the fields needs to be read with bcd4 decoding.
 	// We need to split these two.
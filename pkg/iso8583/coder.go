@@ -6,6 +6,9 @@ type coder struct {
 
 	mtiFormat dataEncoding
 	lenFormat lengthEncoding
+
+	offsets   *[]ElementOffset
+	undefined map[int]string
 }
 
 func applyOpts(c coder, opts ...Opt) coder {
@@ -46,3 +49,11 @@ func HexLen(c coder) coder {
 	c.lenFormat = LengthEncodingHex
 	return c
 }
+
+// Offsets makes the decoder record where it found the elements of a message in offsets
+func Offsets(offsets *[]ElementOffset) Opt {
+	return func(c coder) coder {
+		c.offsets = offsets
+		return c
+	}
+}
@@ -70,13 +70,16 @@ func (d *decoder) DecodeIso8583(mti *MTI, v interface{}) error {
 		r = d.r
 	}
 
-	var transformer io.Reader
-	if d.format == EBCDIC {
-		transformer = &reader{r, charmap.CodePage1047.NewDecoder()}
-	} else {
-		transformer = r
+	// Count the bytes read to know where each element starts
+	var counter *countingReader
+	if d.offsets != nil {
+		*d.offsets = (*d.offsets)[:0]
+		counter = &countingReader{r: r}
+		r = counter
 	}
 
+	transformer := d.transformer(r)
+
 	// The first 4 bytes are the MTI
 	if _, err := io.ReadFull(d.mtiFormat.decoder(r, transformer, ""), mti[:]); err != nil {
 		if errors.Is(err, io.EOF) && d.layout != WithRDW {
@@ -99,7 +102,9 @@ func (d *decoder) DecodeIso8583(mti *MTI, v interface{}) error {
 		return fmt.Errorf("could not read primary bit map; %w", err)
 	}
 
-	values := reflect.Indirect(reflect.ValueOf(v))
+	d.offset(counter, 0, 0)
+
+	values := structFields(v)
 
 	// Go over each bit and unmarshal the fields that are present
 	// New bit maps are added as we find them
@@ -115,6 +120,7 @@ func (d *decoder) DecodeIso8583(mti *MTI, v interface{}) error {
 
 			// element number
 			number := set*64 + bit
+			start := counter.position()
 
 			if bit == 1 {
 				// Field 1 of any set is the bit map for the next set
@@ -127,6 +133,7 @@ func (d *decoder) DecodeIso8583(mti *MTI, v interface{}) error {
 				// Append bit map to the list of bit maps to be processed
 				//nolint:makezero
 				bitmaps = append(bitmaps, bitmap)
+				d.offset(counter, number, start)
 
 				continue
 			}
@@ -141,12 +148,20 @@ func (d *decoder) DecodeIso8583(mti *MTI, v interface{}) error {
 			// Obtain definition for this field so we know how to read it
 			element, ok := definitions[number]
 			if !ok {
-				// We do not have the definition for this field
-				return fmt.Errorf("missing definition for element %d", number)
-			}
+				// We do not have the definition for this field, keep its bytes when the struct takes them
+				raw, err := d.readRaw(r, number)
+				if err != nil {
+					return err
+				}
 
-			// Get the variable we must decode into
-			field := StructField(values, element.Field)
+				if !values.keepRaw(number, raw) {
+					return fmt.Errorf("missing definition for element %d", number)
+				}
+
+				d.offset(counter, number, start)
+
+				continue
+			}
 
 			var r2 io.Reader
 			if element.Representation.Binary() {
@@ -157,15 +172,35 @@ func (d *decoder) DecodeIso8583(mti *MTI, v interface{}) error {
 			}
 
 			// Pass a pointer to the variable where the data must be decoded into
-			if err := Decode(field.Addr().Interface(), element, r2, d.lenFormat); err != nil {
+			if err := Decode(values.pointer(element), element, r2, d.lenFormat); err != nil {
 				return NewElementError(number, err)
 			}
+
+			d.offset(counter, number, start)
 		}
 	}
 
 	return nil
 }
 
+// transformer returns a reader of r that transcodes the text of the message
+func (d *decoder) transformer(r io.Reader) io.Reader {
+	if d.format == EBCDIC {
+		return &reader{r, charmap.CodePage1047.NewDecoder()}
+	}
+
+	return r
+}
+
+// offset records the element that was read from start up to the current position of counter
+func (d *decoder) offset(counter *countingReader, number, start int) {
+	if counter == nil {
+		return
+	}
+
+	*d.offsets = append(*d.offsets, ElementOffset{Number: number, Offset: start, Length: counter.n - start})
+}
+
 // StructField prepares a struct field to be decoded into.
 // If the field is not initialized it will be. If the field is a pointer it is dereferenced
 func StructField(values reflect.Value, idx int) reflect.Value {
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/text/encoding/charmap"
 )
@@ -73,6 +74,8 @@ const (
 	LengthEncodingHex
 	LengthEncodingBin
 	LengthEncodingHexBit4
+	LengthEncodingBer
+	LengthEncodingEbcdic
 )
 
 func (le lengthEncoding) defDecode(d []byte) (int, error) {
@@ -94,16 +97,39 @@ func (le lengthEncoding) decode(def decodeLen, d []byte) (int, error) {
 		return int(ascii[0]), nil
 	case LengthEncodingAscii:
 		return strconv.Atoi(string(d))
+	case LengthEncodingEbcdic:
+		ascii, err := charmap.CodePage1047.NewDecoder().Bytes(d)
+		if err != nil {
+			return 0, err
+		}
+
+		return strconv.Atoi(string(ascii))
 	case LengthEncodingBin:
 		i := int(d[0])
 		return i, nil
 	case LengthEncodingHexBit4:
-		return len(le.encodeHexBit4(len(d), len(d))), nil
+		// A binary number of 1 or 2 bytes
+		var length int
+		for _, b := range d {
+			length = length<<8 | int(b)
+		}
+
+		return length, nil
 	default:
 		return def(d)
 	}
 }
 
+// size returns the number of bytes of a length indicator of the given number of digits. BER-TLV lengths have a
+// size of their own, see readBerLength.
+func (le lengthEncoding) size(indicator int) int {
+	if le == LengthEncodingHexBit4 {
+		return len(le.encodeHexBit4(indicator, 0))
+	}
+
+	return indicator
+}
+
 func (le lengthEncoding) encode(d []byte, i int) []byte {
 	switch le {
 	default:
@@ -115,19 +141,22 @@ func (le lengthEncoding) encode(d []byte, i int) []byte {
 		return []byte{byte(len(d))}
 	case LengthEncodingHexBit4:
 		return le.encodeHexBit4(i, len(d))
+	case LengthEncodingBer:
+		return berLength(len(d))
+	case LengthEncodingEbcdic:
+		digits, _ := charmap.CodePage1047.NewEncoder().Bytes([]byte(fmt.Sprintf("%0*d", i, len(d))))
+		return digits
 	}
 }
 
 func (le lengthEncoding) encodeHexBit4(maxLen, dataLen int) []byte {
 
-	res := []byte{uint8(dataLen)}
-
 	// Handle LLLL encode - turn eg 5 to 0005.
 	if maxLen == 4 {
-		res = append([]uint8{0}, res...)
+		return []byte{uint8(dataLen >> 8), uint8(dataLen)}
 	}
 
-	return res
+	return []byte{uint8(dataLen)}
 }
 
 type dataEncoding uint8
@@ -200,8 +229,17 @@ func (de dataEncoding) decoder(r, d io.Reader, tlvTag string) io.Reader {
 	}
 }
 
+// definer is implemented by types that hold their definitions instead of having iso8583-tags, like Message
+type definer interface {
+	Iso8583Definitions() map[int]Definition
+}
+
+// definitionsCache holds the definitions per struct type, as reflect.Type to map[int]Definition
+var definitionsCache sync.Map
+
 // StructDefinitions uses reflection to read the element definitions from the tags of struct fields
 // The definitions are returned indexed by element number
+// The tags of a type are parsed once, the definitions are cached and shared so they must not be modified
 //
 // The specification of a message is implemented using a struct with tagged fields.
 // The name of the field is irrelevant and can be freely chosen. The field type and the iso8583-tag determine
@@ -266,11 +304,14 @@ func (de dataEncoding) decoder(r, d io.Reader, tlvTag string) io.Reader {
 //		    When a field has it's default value, instead of omitting it fill it with zeros or spaces (depending on
 //		    the field type) up to minlength. If there is no minlength then up to the field length.
 //
-//	 lenenc=[ascii/hex/bin]
-//	     Override how the length property of a specific field is encoded.
+//	 lenenc=[ascii/ebcdic/hex/bin/hexBit4/ber]
+//	     Override how the length property of a specific field is encoded. hexBit4 is a binary length of 2 bytes
+//	     for 4 dots and 1 byte otherwise, ber a BER-TLV length of its own size. Binary fields are not transcoded,
+//	     ebcdic gives them the digits of a length in an EBCDIC message.
 //
 //	 dataenc=[ascii/ebcdic/bcd4]
-//	     Override the data encoding of the whole message for a specific field.
+//	     Override the data encoding of the whole message for a specific field. bcd4 packs two digits in a byte, the
+//	     = of track 2 data as the nibble D, and leaves a bin length a byte.
 //
 //	 bcd4len=[1-9+]
 //	     The amount of data that was bcd4 encoded.
@@ -278,8 +319,24 @@ func (de dataEncoding) decoder(r, d io.Reader, tlvTag string) io.Reader {
 //	 subbitmap=[1-8]
 //	     The length of the subfield bitmap; no value means no bitmap at all
 //
+//	 dataenc=tlv, tlvTag=[hex]
+//	     The subfield is a data object with the tag, written before its length. A struct whose subfields have
+//	     tags is read as data objects in any order: tags the struct does not have are kept in its RawElements
+//	     by the number of the tag, 0x9F02 as 40706.
+//
+// A Message has no tags, it returns the definitions of its Spec.
+//
 //nolint:funlen,gocognit,cyclop
 func StructDefinitions(v interface{}) (map[int]Definition, error) {
+	if d, ok := v.(definer); ok {
+		definitions := d.Iso8583Definitions()
+		if len(definitions) == 0 {
+			return nil, fmt.Errorf("no iso8583 elements found in %T, is its spec compiled?", v)
+		}
+
+		return definitions, nil
+	}
+
 	t := reflect.TypeOf(v)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -289,7 +346,9 @@ func StructDefinitions(v interface{}) (map[int]Definition, error) {
 		return nil, fmt.Errorf("value must be (a pointer to) a struct, got %T", v)
 	}
 
-	// TODO cache the definitions by indexing on t
+	if cached, ok := definitionsCache.Load(t); ok {
+		return cached.(map[int]Definition), nil
+	}
 
 	definitions := make(map[int]Definition, t.NumField())
 
@@ -302,154 +361,173 @@ func StructDefinitions(v interface{}) (map[int]Definition, error) {
 			continue
 		}
 
-		// Element number, format and optional attributes
-		number, format, attributes := parseTag(tag)
-		if number == 0 {
-			return nil, fmt.Errorf("struct field %q has no number in iso8583-tag: %s", field.Name, tag)
+		element, err := parseDefinition(fmt.Sprintf("struct field %q", field.Name), idx, tag)
+		if err != nil {
+			return nil, err
 		}
 
-		if format == "" {
-			return nil, fmt.Errorf("struct field %q has no format in iso8583-tag: %s", field.Name, tag)
-		}
+		definitions[element.Number] = element
+	}
 
-		element := Definition{
-			Field:  idx,
-			Number: number,
-		}
+	if len(definitions) == 0 {
+		return nil, fmt.Errorf("no iso8583 elements found in %T", v)
+	}
 
-		// Parse the format into the data representation, variable length indication field, and field length
-		if i := strings.Index(format, "-"); i != -1 {
-			// Representation and length separated by a dash is a fixed length field
-			element.Representation = notation[format[:i]]
-			format = format[i+1:]
-		} else if i := strings.Index(format, "."); i != -1 {
-			// Representation and length separated by one or more periods is a variable length field
-			element.Representation = notation[format[:i]]
-			for format[i] == '.' {
-				element.LengthIndicator++
-				i++
-			}
-			format = format[i:]
-		}
+	definitionsCache.Store(t, definitions)
 
-		if element.Representation == 0 {
-			return nil, fmt.Errorf("struct field %q has invalid representation in iso8583-tag %q", field.Name, tag)
-		}
+	return definitions, nil
+}
 
-		// The remaining format must be the field length
-		var err error
-		if element.Length, err = strconv.Atoi(format); err != nil {
-			return nil, fmt.Errorf(
-				"struct field %q has invalid length in iso8583-tag %q: %w",
-				field.Name, tag, err,
-			)
-		}
+// parseDefinition parses an iso8583-tag into the definition of an element, name tells what the tag belongs to in
+// errors
+//
+//nolint:funlen,gocognit,cyclop
+func parseDefinition(name string, idx int, tag string) (Definition, error) {
+	// Element number, format and optional attributes
+	number, format, attributes := parseTag(tag)
+	if number == 0 {
+		return Definition{}, fmt.Errorf("%s has no number in iso8583-tag: %s", name, tag)
+	}
 
-		// Determine the minimum length of the value
-		attr, ok := attributes["minlength"]
-		_, tlvOk := attributes["tlvTag"]
-		switch {
-		case tlvOk:
-			// for fields set as tlv we don't need it to modify LengthMin as 1
-		case ok:
-			if element.LengthMin, err = strconv.Atoi(attr); err != nil {
-				return nil, fmt.Errorf(
-					"struct field %q has invalid minlength-attribute %q in iso8583-tag: %w",
-					field.Name, attr, err,
-				)
-			}
-		case element.LengthIndicator == 0:
-			// The field has a fixed length
-			element.LengthMin = element.Length
-		default:
-			// The field has a variable length
-			element.LengthMin = 1
-		}
+	if format == "" {
+		return Definition{}, fmt.Errorf("%s has no format in iso8583-tag: %s", name, tag)
+	}
 
-		if attr, ok := attributes["justify"]; ok {
-			switch attr {
-			case "left":
-				element.Justification = JustifyLeft
-			case "right":
-				element.Justification = JustifyRight
-			default:
-				return nil, fmt.Errorf("struct field %q has invalid justify-attribute %q in iso8583-tag", field.Name, attr)
-			}
-		}
+	element := Definition{
+		Field:  idx,
+		Number: number,
+	}
 
-		if _, ok := attributes["omitempty"]; ok {
-			element.OmitEmpty = true
+	// Parse the format into the data representation, variable length indication field, and field length
+	if i := strings.Index(format, "-"); i != -1 {
+		// Representation and length separated by a dash is a fixed length field
+		element.Representation = notation[format[:i]]
+		format = format[i+1:]
+	} else if i := strings.Index(format, "."); i != -1 {
+		// Representation and length separated by one or more periods is a variable length field
+		element.Representation = notation[format[:i]]
+		for format[i] == '.' {
+			element.LengthIndicator++
+			i++
 		}
+		format = format[i:]
+	}
 
-		if _, ok := attributes["autofill"]; ok {
-			element.AutoFill = true
-		}
+	if element.Representation == 0 {
+		return Definition{}, fmt.Errorf("%s has invalid representation in iso8583-tag %q", name, tag)
+	}
 
-		if _, ok := attributes["subbitmap"]; ok {
-			element.SubBitmap, err = strconv.Atoi(attributes["subbitmap"])
-			if err != nil {
-				return nil, fmt.Errorf("struct field %q has invalid subbitmap-attribute %q in iso8583-tag", field.Name, attr)
-			}
-		}
+	// The remaining format must be the field length
+	var err error
+	if element.Length, err = strconv.Atoi(format); err != nil {
+		return Definition{}, fmt.Errorf(
+			"%s has invalid length in iso8583-tag %q: %w",
+			name, tag, err,
+		)
+	}
 
-		if attr, ok := attributes["tlvTag"]; ok {
-			if _, err = strconv.ParseInt(attr, 16, 64); err != nil {
-				return nil, fmt.Errorf("struct field %q has missing tag-attribute %q for a tlv encoding: %w", field.Name, attr, err)
-			}
-			element.TlvTag = attr
+	// Determine the minimum length of the value
+	attr, ok := attributes["minlength"]
+	_, tlvOk := attributes["tlvTag"]
+	switch {
+	case tlvOk:
+		// for fields set as tlv we don't need it to modify LengthMin as 1
+	case ok:
+		if element.LengthMin, err = strconv.Atoi(attr); err != nil {
+			return Definition{}, fmt.Errorf(
+				"%s has invalid minlength-attribute %q in iso8583-tag: %w",
+				name, attr, err,
+			)
 		}
+	case element.LengthIndicator == 0:
+		// The field has a fixed length
+		element.LengthMin = element.Length
+	default:
+		// The field has a variable length
+		element.LengthMin = 1
+	}
 
-		attr, ok = attributes["dataenc"]
-		switch {
-		case !ok:
-			element.DataEncoding = DataEncodingDefault
-		case attr == "ascii":
-			element.DataEncoding = DataEncodingAscii
-		case attr == "ebcdic":
-			element.DataEncoding = DataEncodingEbcdic
-		case attr == "bcd4":
-			element.DataEncoding = DataEncodingBcd4
-		case attr == "tlv":
-			// tlv encoder requires the tlvTag to write out the data
-			if element.TlvTag == "" {
-				return nil, fmt.Errorf("struct field %q has missing tag-attribute %q for a tlv encoding: %w", field.Name, attr, err)
-			}
-			element.DataEncoding = DataEncodingTlv
+	if attr, ok := attributes["justify"]; ok {
+		switch attr {
+		case "left":
+			element.Justification = JustifyLeft
+		case "right":
+			element.Justification = JustifyRight
 		default:
-			return nil, fmt.Errorf("struct field %q has invalid dataenc-attribute %q in iso8583-tag", field.Name, attr)
+			return Definition{}, fmt.Errorf("%s has invalid justify-attribute %q in iso8583-tag", name, attr)
 		}
+	}
 
-		attr, ok = attributes["lenenc"]
-		switch {
-		case !ok:
-			element.LengthEncoding = LengthEncodingDefault
-		case attr == "ascii":
-			element.LengthEncoding = LengthEncodingAscii
-		case attr == "hex":
-			element.LengthEncoding = LengthEncodingHex
-		case attr == "bin":
-			element.LengthEncoding = LengthEncodingBin
-		case attr == "hexBit4":
-			element.LengthEncoding = LengthEncodingHexBit4
-		default:
-			return nil, fmt.Errorf("struct field %q has invalid lenenc-attribute %q in iso8583-tag", field.Name, attr)
+	if _, ok := attributes["omitempty"]; ok {
+		element.OmitEmpty = true
+	}
+
+	if _, ok := attributes["autofill"]; ok {
+		element.AutoFill = true
+	}
+
+	if _, ok := attributes["subbitmap"]; ok {
+		element.SubBitmap, err = strconv.Atoi(attributes["subbitmap"])
+		if err != nil {
+			return Definition{}, fmt.Errorf("%s has invalid subbitmap-attribute %q in iso8583-tag", name, attr)
 		}
+	}
 
-		if attr, ok = attributes["bcd4len"]; ok {
-			element.Bcd4Len, err = strconv.Atoi(attr)
-			if err != nil {
-				return nil, fmt.Errorf("struct field %q has invalid bcd4len-attribute %q in iso8583-tag", field.Name, attr)
-			}
+	if attr, ok := attributes["tlvTag"]; ok {
+		if _, err = strconv.ParseInt(attr, 16, 64); err != nil {
+			return Definition{}, fmt.Errorf("%s has missing tag-attribute %q for a tlv encoding: %w", name, attr, err)
 		}
+		element.TlvTag = attr
+	}
 
-		definitions[number] = element
+	attr, ok = attributes["dataenc"]
+	switch {
+	case !ok:
+		element.DataEncoding = DataEncodingDefault
+	case attr == "ascii":
+		element.DataEncoding = DataEncodingAscii
+	case attr == "ebcdic":
+		element.DataEncoding = DataEncodingEbcdic
+	case attr == "bcd4":
+		element.DataEncoding = DataEncodingBcd4
+	case attr == "tlv":
+		// tlv encoder requires the tlvTag to write out the data
+		if element.TlvTag == "" {
+			return Definition{}, fmt.Errorf("%s has missing tag-attribute %q for a tlv encoding: %w", name, attr, err)
+		}
+		element.DataEncoding = DataEncodingTlv
+	default:
+		return Definition{}, fmt.Errorf("%s has invalid dataenc-attribute %q in iso8583-tag", name, attr)
 	}
 
-	if len(definitions) == 0 {
-		return nil, fmt.Errorf("no iso8583 elements found in %T", v)
+	attr, ok = attributes["lenenc"]
+	switch {
+	case !ok:
+		element.LengthEncoding = LengthEncodingDefault
+	case attr == "ascii":
+		element.LengthEncoding = LengthEncodingAscii
+	case attr == "hex":
+		element.LengthEncoding = LengthEncodingHex
+	case attr == "bin":
+		element.LengthEncoding = LengthEncodingBin
+	case attr == "hexBit4":
+		element.LengthEncoding = LengthEncodingHexBit4
+	case attr == "ber":
+		element.LengthEncoding = LengthEncodingBer
+	case attr == "ebcdic":
+		element.LengthEncoding = LengthEncodingEbcdic
+	default:
+		return Definition{}, fmt.Errorf("%s has invalid lenenc-attribute %q in iso8583-tag", name, attr)
 	}
 
-	return definitions, nil
+	if attr, ok = attributes["bcd4len"]; ok {
+		element.Bcd4Len, err = strconv.Atoi(attr)
+		if err != nil {
+			return Definition{}, fmt.Errorf("%s has invalid bcd4len-attribute %q in iso8583-tag", name, attr)
+		}
+	}
+
+	return element, nil
 }
 
 // parseTag parses the attributes from a struct field's iso8583-tag.
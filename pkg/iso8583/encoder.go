@@ -6,7 +6,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
-	"reflect"
 
 	"golang.org/x/text/encoding/charmap"
 )
@@ -39,6 +38,26 @@ type encoder struct {
 	w io.Writer
 }
 
+// setPresent flags the element in the bit maps, adding the bit maps it needs
+func setPresent(bitmaps []bitMap, number int) []bitMap {
+	// Determine the bit for this field number
+	set := number / 64
+	bit := number % 64
+
+	// Create all the bit maps we need to flag this field as being present
+	for len(bitmaps) <= set {
+		// Set the first bit in the now currently last bit map, indicating there will be another bit map following
+		bitmaps[len(bitmaps)-1].SetField(1)
+		// Add the next bit map
+		bitmaps = append(bitmaps, bitMap{}) //nolint:makezero
+	}
+
+	// Set the bit in the appropriate bit map indicating presence of this field
+	bitmaps[set].SetField(bit)
+
+	return bitmaps
+}
+
 // EncodeIso8583 writes an iso8583-encoded message to its writer
 //
 //nolint:funlen,cyclop,gocognit,wrapcheck
@@ -50,15 +69,13 @@ func (e *encoder) EncodeIso8583(mti MTI, v interface{}) error {
 		return err
 	}
 
-	values := reflect.Indirect(reflect.ValueOf(v))
+	values := structFields(v)
 
 	// There will always be at least 1 bit map, the primary bit map
 	bitmaps := make([]bitMap, 1)
 
 	// Populate bit maps based on the presence of values
 	for number, element := range definitions {
-		// Determine the bit for this field number
-		set := number / 64
 		bit := number % 64
 
 		if bit == 1 {
@@ -70,20 +87,22 @@ func (e *encoder) EncodeIso8583(mti MTI, v interface{}) error {
 		}
 
 		// Test if a value is provided for this field
-		if values.Field(element.Field).IsZero() && !element.AutoFill {
+		if _, zero := values.value(element); zero && !element.AutoFill {
 			continue
 		}
 
-		// Create all the bit maps we need to flag this field as being present
-		for len(bitmaps) <= set {
-			// Set the first bit in the now currently last bit map, indicating there will be another bit map following
-			bitmaps[len(bitmaps)-1].SetField(1)
-			// Add the next bit map
-			bitmaps = append(bitmaps, bitMap{}) //nolint:makezero
+		bitmaps = setPresent(bitmaps, number)
+	}
+
+	// Elements that were kept raw are written again, unless the struct defines them or they are a bit map or
+	// checksum
+	raw := values.rawElements()
+	for number := range raw {
+		if _, ok := definitions[number]; ok || number%64 <= 1 {
+			continue
 		}
 
-		// Set the bit in the appropriate bit map indicating presence of this field
-		bitmaps[set].SetField(bit)
+		bitmaps = setPresent(bitmaps, number)
 	}
 
 	w := &bytes.Buffer{}
@@ -136,8 +155,17 @@ func (e *encoder) EncodeIso8583(mti MTI, v interface{}) error {
 				continue
 			}
 
-			element := definitions[number]
-			value := values.Field(element.Field).Interface()
+			element, ok := definitions[number]
+			if !ok {
+				// The raw element holds its length indicator and data as they were read, it is not transcoded
+				if _, err := w.Write(raw[number]); err != nil {
+					return fmt.Errorf("could not write value for element %d; %w", number, err)
+				}
+
+				continue
+			}
+
+			value, _ := values.value(element)
 
 			var w2 io.Writer
 			if element.Representation.Binary() {
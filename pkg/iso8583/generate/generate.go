@@ -0,0 +1,238 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/dave/jennifer/jen"
+)
+
+const iso8583Pkg = "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
+// element is a struct field with an iso8583-tag
+type element struct {
+	number int
+	field  *types.Var
+}
+
+func main() {
+	inPkg := flag.String("pkg", ".", "Package with the structs to generate iso8583 accessors for")
+	out := flag.String("out", "iso8583_accessors.go", "File to write the accessors to")
+	flag.Parse()
+
+	pkg := loadPackage(*inPkg)
+
+	log.Printf("Generate iso8583 accessors for %s", pkg.PkgPath)
+
+	f := jen.NewFilePathName(pkg.PkgPath, pkg.Name)
+	f.PackageComment("Code generated by iso8583/generate, DO NOT EDIT.")
+	f.PackageComment("Generated on " + time.Now().Format("Mon Jan 2 15:04 2006"))
+
+	// The structs with accessors, the subfields of their elements are accessed through a pointer
+	generated := make(map[*types.TypeName][]element)
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+
+		st, ok := typeName.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		if elements := taggedFields(st); len(elements) > 0 {
+			generated[typeName] = elements
+		}
+	}
+
+	var typeNames []*types.TypeName
+	for typeName := range generated {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Slice(typeNames, func(i, j int) bool { return typeNames[i].Name() < typeNames[j].Name() })
+
+	var assertions []jen.Code
+	for _, typeName := range typeNames {
+		assertions = append(assertions, jen.Id("_").Qual(iso8583Pkg, "Accessor").Op("=").Parens(jen.Op("*").Id(typeName.Name())).Parens(jen.Nil()))
+	}
+	f.Var().Defs(assertions...)
+
+	for _, typeName := range typeNames {
+		valueCases, pointerCases := []jen.Code{}, []jen.Code{}
+		for _, e := range generated[typeName] {
+			valueCases = append(valueCases, jen.Case(jen.Lit(e.number)).Block(valueReturn(pkg.Types, e.field, generated)))
+			pointerCases = append(pointerCases, jen.Case(jen.Lit(e.number)).Block(pointerReturn(e.field)...))
+		}
+
+		f.Comment("Iso8583Value implements iso8583.Accessor")
+		f.Func().Params(jen.Id("v").Op("*").Id(typeName.Name())).Id("Iso8583Value").
+			Params(jen.Id("number").Int()).
+			Params(jen.Interface(), jen.Bool()).
+			Block(
+				jen.Switch(jen.Id("number")).Block(valueCases...),
+				jen.Return(jen.Nil(), jen.True()),
+			)
+
+		f.Comment("Iso8583Pointer implements iso8583.Accessor")
+		f.Func().Params(jen.Id("v").Op("*").Id(typeName.Name())).Id("Iso8583Pointer").
+			Params(jen.Id("number").Int()).
+			Interface().
+			Block(
+				jen.Switch(jen.Id("number")).Block(pointerCases...),
+				jen.Return(jen.Nil()),
+			)
+	}
+
+	if err := f.Save(*out); err != nil {
+		failErr(err)
+	}
+}
+
+// taggedFields returns the fields with an iso8583-tag ordered by element number
+func taggedFields(st *types.Struct) []element {
+	var elements []element
+	for i := 0; i < st.NumFields(); i++ {
+		tag, ok := reflect.StructTag(st.Tag(i)).Lookup("iso8583")
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimSpace(strings.SplitN(tag, "=", 2)[0]))
+		if err != nil || number == 0 {
+			failErr(fmt.Errorf("struct field %q has no number in iso8583-tag: %s", st.Field(i).Name(), tag))
+		}
+
+		elements = append(elements, element{number: number, field: st.Field(i)})
+	}
+
+	sort.Slice(elements, func(i, j int) bool { return elements[i].number < elements[j].number })
+
+	return elements
+}
+
+// valueReturn returns the value of the field and whether it is empty, like reflection would. Unexported
+// fields give their zero value. A struct that has an accessor itself is returned by pointer to use it, unless
+// its pointer marshals itself and the value does not.
+func valueReturn(pkg *types.Package, field *types.Var, generated map[*types.TypeName][]element) jen.Code {
+	if !field.Exported() {
+		var isZero jen.Code = jen.True()
+		if field.Name() != "_" {
+			isZero = zero(jen.Id("v").Dot(field.Name()), field.Type())
+		}
+		return jen.Return(jen.Op("*").Id("new").Call(typeCode(field.Type())), isZero)
+	}
+
+	value := jen.Id("v").Dot(field.Name())
+	isZero := zero(jen.Id("v").Dot(field.Name()), field.Type())
+
+	if named, ok := field.Type().(*types.Named); ok {
+		_, hasAccessor := generated[named.Obj()]
+		marshaler, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), true, pkg, "MarshalIso8583")
+		if hasAccessor && marshaler == nil {
+			value = jen.Op("&").Id("v").Dot(field.Name())
+		}
+	}
+
+	return jen.Return(value, isZero)
+}
+
+// pointerReturn returns a pointer to the field to decode into, a nil pointer field is allocated first.
+// Unexported fields are decoded into a new variable that is thrown away.
+func pointerReturn(field *types.Var) []jen.Code {
+	pointer, isPointer := field.Type().(*types.Pointer)
+
+	if !field.Exported() {
+		if isPointer {
+			return []jen.Code{jen.Return(jen.New(typeCode(pointer.Elem())))}
+		}
+		return []jen.Code{jen.Return(jen.New(typeCode(field.Type())))}
+	}
+
+	if isPointer {
+		return []jen.Code{
+			jen.If(jen.Id("v").Dot(field.Name()).Op("==").Nil()).Block(
+				jen.Id("v").Dot(field.Name()).Op("=").New(typeCode(pointer.Elem())),
+			),
+			jen.Return(jen.Id("v").Dot(field.Name())),
+		}
+	}
+
+	return []jen.Code{jen.Return(jen.Op("&").Id("v").Dot(field.Name()))}
+}
+
+// zero returns the expression that tells whether the value is its zero value
+func zero(value *jen.Statement, t types.Type) jen.Code {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return value.Op("==").Lit("")
+		case u.Info()&types.IsBoolean != 0:
+			return jen.Op("!").Add(value)
+		default:
+			return value.Op("==").Lit(0)
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface, *types.Chan, *types.Signature:
+		return value.Op("==").Nil()
+	default:
+		if types.Comparable(t) {
+			return value.Op("==").Parens(typeCode(t).Values())
+		}
+		return jen.Qual("reflect", "ValueOf").Call(value).Dot("IsZero").Call()
+	}
+}
+
+func typeCode(t types.Type) *jen.Statement {
+	switch tt := t.(type) {
+	case *types.Named:
+		if tt.Obj().Pkg() == nil {
+			return jen.Id(tt.Obj().Name())
+		}
+		return jen.Qual(tt.Obj().Pkg().Path(), tt.Obj().Name())
+	case *types.Basic:
+		return jen.Id(tt.Name())
+	case *types.Pointer:
+		return jen.Op("*").Add(typeCode(tt.Elem()))
+	case *types.Slice:
+		return jen.Index().Add(typeCode(tt.Elem()))
+	case *types.Array:
+		return jen.Index(jen.Lit(int(tt.Len()))).Add(typeCode(tt.Elem()))
+	case *types.Map:
+		return jen.Map(typeCode(tt.Key())).Add(typeCode(tt.Elem()))
+	default:
+		failErr(fmt.Errorf("unsupported field type %s", t))
+		return nil
+	}
+}
+
+func loadPackage(path string) *packages.Package {
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports}
+	pkgs, err := packages.Load(cfg, path)
+	if err != nil {
+		failErr(fmt.Errorf("loading packages for inspection: %v", err))
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		os.Exit(1)
+	}
+
+	return pkgs[0]
+}
+
+func failErr(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
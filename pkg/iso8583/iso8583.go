@@ -73,27 +73,38 @@ func Encode(v interface{}, element Definition, w io.Writer) error {
 		}
 	}
 
-	// Assert data complies with element.Representation
+	// Assert data complies with element.Representation, the errors leave the value out as it may be cardholder data
 	if err := element.Representation.Assert(data); err != nil {
-		return fmt.Errorf("invalid value %q; %w", data, err)
+		return fmt.Errorf("invalid value; %w", err)
 	}
 
 	// Assert length of value
 	length := len(data)
 	if length > element.Length {
-		return fmt.Errorf("value length %d (%s) exceeds element length %d", length, string(data), element.Length)
+		return fmt.Errorf("value length %d exceeds element length %d", length, element.Length)
 	}
 
 	if length < element.LengthMin {
-		return fmt.Errorf("value length %d (%s) subceeds element minlength %d", length, string(data), element.LengthMin)
+		return fmt.Errorf("value length %d subceeds element minlength %d", length, element.LengthMin)
 	}
 
 	if element.LengthIndicator > 0 {
 		// Data has a variable length indication field prepended
 		li := element.LengthEncoding.encode(data, element.LengthIndicator)
 
+		// A binary length indicator is a byte, only the value is BCD or EBCDIC
+		lw := w
+		if element.LengthEncoding == LengthEncodingBin {
+			switch ew := w.(type) {
+			case bcdEncoder:
+				lw = ew.w
+			case *writer:
+				lw = ew.w
+			}
+		}
+
 		// Append length indication
-		if _, err := w.Write(li); err != nil {
+		if _, err := lw.Write(li); err != nil {
 			return fmt.Errorf("could not write value length; %w", err)
 		}
 	}
@@ -116,10 +127,28 @@ func Decode(v interface{}, element Definition, r io.Reader, lenEnc lengthEncodin
 	// Determine how much data must be read
 	length := element.Length
 
-	if element.LengthIndicator > 0 {
+	switch {
+	case element.LengthIndicator > 0 && element.LengthEncoding == LengthEncodingBer:
+		// A BER-TLV length tells its own size
+		var err error
+		if length, err = readBerLengthFrom(r); err != nil {
+			return err
+		}
+	case element.LengthIndicator > 0:
 		// Value has a variable length indication field prepended
-		li := make([]byte, element.LengthIndicator)
-		if _, err := io.ReadFull(r, li); err != nil {
+		li := make([]byte, element.LengthEncoding.size(element.LengthIndicator))
+
+		lr := r
+		if element.LengthEncoding == LengthEncodingBin {
+			switch er := r.(type) {
+			case bcdEncoder:
+				lr = er.r
+			case *reader:
+				lr = er.r
+			}
+		}
+
+		if _, err := io.ReadFull(lr, li); err != nil {
 			return err
 		}
 
@@ -176,6 +205,26 @@ func Marshal(v interface{}, element Definition) ([]byte, error) {
 		// this variable marshals itself
 		return marshaler.MarshalIso8583() //nolint:wrapcheck
 	}
+
+	// The common types of elements are marshaled without reflection
+	switch value := v.(type) {
+	case string:
+		if value == "" {
+			return nil, nil
+		}
+		return []byte(value), nil
+	case int64:
+		if value == 0 {
+			return nil, nil
+		}
+		return []byte(strconv.FormatInt(value, 10)), nil
+	case int:
+		if value == 0 {
+			return nil, nil
+		}
+		return []byte(strconv.Itoa(value)), nil
+	}
+
 	vv := reflect.Indirect(reflect.ValueOf(v))
 
 	if !vv.IsValid() || vv.IsZero() {
@@ -230,6 +279,21 @@ func Unmarshal(v interface{}, data []byte, element Definition, lenEnc lengthEnco
 		return nil
 	}
 
+	// The common types of elements are unmarshaled without reflection
+	switch value := v.(type) {
+	case *string:
+		*value = string(data)
+		return nil
+	case *int64:
+		i, err := strconv.ParseInt(string(data), 10, 0)
+		if err != nil {
+			return err
+		}
+
+		*value = i
+		return nil
+	}
+
 	// Get the value pointed to
 	vv := reflect.Indirect(reflect.ValueOf(v).Elem())
 
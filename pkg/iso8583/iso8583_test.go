@@ -3,6 +3,8 @@ package iso8583_test
 import (
 	"bytes"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
@@ -155,3 +157,82 @@ func TestIso8583(t *testing.T) {
 		t.Fatalf("Decoded:  %#v", out)
 	}
 }
+
+func TestStructDefinitionsConcurrent(t *testing.T) {
+	type elements struct {
+		Number string `iso8583:"2=n..19"`
+		Amount int64  `iso8583:"4=n-12, justify=right"`
+	}
+
+	var wg sync.WaitGroup
+	results := make([]map[int]iso8583.Definition, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			definitions, err := iso8583.StructDefinitions(&elements{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results[i] = definitions
+		}(i)
+	}
+	wg.Wait()
+
+	for _, definitions := range results {
+		if !reflect.DeepEqual(definitions, results[0]) || len(definitions) != 2 {
+			t.Fatalf("Expected the same 2 definitions for every caller, got %v", definitions)
+		}
+	}
+}
+
+func TestEncodeErrorsLeaveOutTheValue(t *testing.T) {
+	type elements struct {
+		Number    string `iso8583:"2=n..19, minlength=5"`
+		TrackTwo  string `iso8583:"35=ans..37"`
+		ExpiresOn string `iso8583:"14=n-4"`
+	}
+
+	tests := []struct {
+		name  string
+		in    elements
+		value string
+		want  string
+	}{
+		{
+			name:  "too_long",
+			in:    elements{Number: "52047400000010020000", TrackTwo: "5204740000001002=2512201987654321", ExpiresOn: "2512"},
+			value: "52047400000010020000",
+			want:  "could not encode; DE 2: value length 20 exceeds element length 19",
+		},
+		{
+			name:  "too_short",
+			in:    elements{Number: "5204", ExpiresOn: "2512"},
+			value: "5204",
+			want:  "could not encode; DE 2: value length 4 subceeds element minlength 5",
+		},
+		{
+			name:  "invalid_character",
+			in:    elements{Number: "5204740000001002", TrackTwo: "5204740000001002=2512201987654321\n", ExpiresOn: "2512"},
+			value: "5204740000001002=2512201987654321",
+			want:  `could not encode; DE 35: invalid value; invalid character 0xa at position 33`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := iso8583.NewEncoder(&buf, iso8583.FormatAscii, iso8583.NoRdwLayout).EncodeIso8583(iso8583.NewMti("0100"), &tt.in)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Expected error %q, got %q", tt.want, err)
+			}
+			if strings.Contains(err.Error(), tt.value) {
+				t.Errorf("Expected the error to leave out the value, got %q", err)
+			}
+		})
+	}
+}
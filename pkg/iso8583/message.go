@@ -0,0 +1,170 @@
+package iso8583
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Message holds the elements of a message laid out by a Spec, so messages of a network can be encoded and decoded
+// without a struct for them. It is passed to the encoder and decoder like a struct:
+//
+//	msg := iso8583.NewMessage(spec)
+//	err := iso8583.NewDecoder(r, iso8583.FormatEbcdic).DecodeIso8583(&mti, msg)
+//	pan := msg.String(2)
+//
+// Elements are kept as the text they are encoded from, elements with subfields as a Message of their own.
+type Message struct {
+	// Undefined holds the elements the spec does not describe, see RawElements
+	Undefined RawElements
+
+	spec *Spec
+
+	// values holds a *string or, for elements with subfields, a *Message by number
+	values map[int]interface{}
+}
+
+var _ Accessor = (*Message)(nil)
+
+// NewMessage returns an empty message for the compiled spec
+func NewMessage(spec *Spec) *Message {
+	return &Message{
+		spec:   spec,
+		values: make(map[int]interface{}),
+	}
+}
+
+// Spec returns the spec of the message
+func (m *Message) Spec() *Spec {
+	return m.spec
+}
+
+// Iso8583Definitions gives the encoder and decoder the definitions of the spec instead of those of iso8583-tags
+func (m *Message) Iso8583Definitions() map[int]Definition {
+	return m.spec.definitions
+}
+
+// Iso8583Value returns the value of the element and whether the value is empty
+func (m *Message) Iso8583Value(number int) (interface{}, bool) {
+	switch value := m.values[number].(type) {
+	case *string:
+		return *value, *value == ""
+	case *Message:
+		return value, value.empty()
+	}
+
+	if m.spec.subspecs[number] != nil {
+		return (*Message)(nil), true
+	}
+
+	return "", true
+}
+
+// Iso8583Pointer returns a pointer to decode the element into, the element is added when it is not present
+func (m *Message) Iso8583Pointer(number int) interface{} {
+	if value, ok := m.values[number]; ok {
+		return value
+	}
+
+	var value interface{}
+	if subspec := m.spec.subspecs[number]; subspec != nil {
+		value = NewMessage(subspec)
+	} else {
+		value = new(string)
+	}
+	m.values[number] = value
+
+	return value
+}
+
+func (m *Message) empty() bool {
+	for number := range m.values {
+		if _, empty := m.Iso8583Value(number); !empty {
+			return false
+		}
+	}
+
+	return len(m.Undefined) == 0
+}
+
+// Has tells whether the element has a value
+func (m *Message) Has(number int) bool {
+	_, empty := m.Iso8583Value(number)
+	return !empty
+}
+
+// Numbers returns the numbers of the elements that have a value, in order
+func (m *Message) Numbers() []int {
+	numbers := make([]int, 0, len(m.values))
+	for number := range m.values {
+		if m.Has(number) {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	return numbers
+}
+
+// String returns the value of an element without subfields, empty when it has no value
+func (m *Message) String(number int) string {
+	if value, ok := m.values[number].(*string); ok {
+		return *value
+	}
+
+	return ""
+}
+
+// Int returns the value of a numeric element, 0 when it has no value
+func (m *Message) Int(number int) (int64, error) {
+	value := m.String(number)
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("element %d is not numeric; %w", number, err)
+	}
+
+	return i, nil
+}
+
+// Set sets the value of an element without subfields, an empty value removes the element
+func (m *Message) Set(number int, value string) error {
+	if _, ok := m.spec.definitions[number]; !ok {
+		return fmt.Errorf("element %d is not in spec %q", number, m.spec.Name)
+	}
+
+	if m.spec.subspecs[number] != nil {
+		return fmt.Errorf("element %d of spec %q has subfields", number, m.spec.Name)
+	}
+
+	if value == "" {
+		delete(m.values, number)
+		return nil
+	}
+
+	m.values[number] = &value
+
+	return nil
+}
+
+// SetInt sets the value of a numeric element, the element is justified and padded by its definition
+func (m *Message) SetInt(number int, value int64) error {
+	return m.Set(number, strconv.FormatInt(value, 10))
+}
+
+// Subfields returns the subfields of an element, to read or set them. The element is added when it is not present.
+func (m *Message) Subfields(number int) (*Message, error) {
+	if m.spec.subspecs[number] == nil {
+		return nil, fmt.Errorf("element %d of spec %q has no subfields", number, m.spec.Name)
+	}
+
+	return m.Iso8583Pointer(number).(*Message), nil //nolint:forcetypeassert
+}
+
+// Delete removes the element
+func (m *Message) Delete(number int) {
+	delete(m.values, number)
+}
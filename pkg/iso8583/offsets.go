@@ -0,0 +1,34 @@
+package iso8583
+
+import "io"
+
+// ElementOffset is the position of an element in a decoded message, in bytes from the start of the
+// message (after the RDW). Element 0 is the MTI together with the primary bit map, the other bit maps
+// are reported as elements 1 and 65.
+type ElementOffset struct {
+	Number int
+	Offset int
+	Length int
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+
+	return n, err //nolint:wrapcheck
+}
+
+// position returns the number of bytes read so far, or 0 when nothing is counted
+func (c *countingReader) position() int {
+	if c == nil {
+		return 0
+	}
+
+	return c.n
+}
@@ -0,0 +1,149 @@
+package iso8583
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// RawElements holds the elements or subfields a struct does not define by their number, as the bytes they were
+// read from: the length indicator and the data, not transcoded.
+//
+// A struct that has a field of this type, without iso8583-tag, keeps the elements the decoder finds but can not
+// decode into a field, instead of failing. The encoder writes the elements in the field again after the ones the
+// struct defines, so they survive a decode and encode round trip. Copy the field to a new message to echo them.
+type RawElements map[int][]byte
+
+// Numbers returns the element numbers in order
+func (r RawElements) Numbers() []int {
+	numbers := make([]int, 0, len(r))
+	for number := range r {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	return numbers
+}
+
+// Without returns a copy without the elements with the numbers, like those that hold cardholder data
+func (r RawElements) Without(numbers ...int) RawElements {
+	if r == nil {
+		return nil
+	}
+
+	without := make(RawElements, len(r))
+	for number, raw := range r {
+		without[number] = raw
+	}
+	for _, number := range numbers {
+		delete(without, number)
+	}
+
+	return without
+}
+
+var rawElementsType = reflect.TypeOf(RawElements{})
+
+// rawFieldsCache holds the index of the RawElements field per struct type, -1 when it has none
+var rawFieldsCache sync.Map
+
+func rawField(t reflect.Type) int {
+	if cached, ok := rawFieldsCache.Load(t); ok {
+		return cached.(int)
+	}
+
+	idx := -1
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Type == rawElementsType && field.IsExported() {
+			idx = i
+			break
+		}
+	}
+
+	rawFieldsCache.Store(t, idx)
+
+	return idx
+}
+
+// raw returns the RawElements field of the struct, an invalid value when it has none
+func (f fields) raw() reflect.Value {
+	values := f.values
+	if f.accessor != nil {
+		values = reflect.Indirect(reflect.ValueOf(f.accessor))
+	}
+
+	idx := rawField(values.Type())
+	if idx == -1 {
+		return reflect.Value{}
+	}
+
+	return values.Field(idx)
+}
+
+// rawElements returns the elements in the RawElements field of the struct
+func (f fields) rawElements() RawElements {
+	field := f.raw()
+	if !field.IsValid() {
+		return nil
+	}
+
+	return field.Interface().(RawElements) //nolint:forcetypeassert
+}
+
+// keepRaw stores an element in the RawElements field of the struct, it tells whether the struct has one
+func (f fields) keepRaw(number int, raw []byte) bool {
+	field := f.raw()
+	if !field.IsValid() || !field.CanSet() {
+		return false
+	}
+
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(rawElementsType))
+	}
+	field.Interface().(RawElements)[number] = raw //nolint:forcetypeassert
+
+	return true
+}
+
+// UndefinedElements gives the decoder the formats, as the first attribute of an iso8583-tag with the other
+// attributes after it, of elements the struct does not define. Such elements are read into the RawElements of
+// the struct. Without a format the length of an element is unknown and the decoder fails on it.
+func UndefinedElements(formats map[int]string) Opt {
+	return func(c coder) coder {
+		c.undefined = formats
+		return c
+	}
+}
+
+// readRaw reads the element from r and returns the bytes it was read from
+func (d *decoder) readRaw(r io.Reader, number int) ([]byte, error) {
+	format, ok := d.undefined[number]
+	if !ok {
+		return nil, fmt.Errorf("missing definition for element %d", number)
+	}
+
+	element, err := parseDefinition(fmt.Sprintf("undefined element %d", number), -1, fmt.Sprintf("%d=%s", number, format))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw bytes.Buffer
+	tee := io.TeeReader(r, &raw)
+
+	var r2 io.Reader = tee
+	if !element.Representation.Binary() {
+		transformer := d.transformer(tee)
+		r2 = element.DataEncoding.decoder(transformer, transformer, element.TlvTag)
+	}
+
+	// The data is only read to learn where the element ends
+	var data string
+	if err := Decode(&data, element, r2, d.lenFormat); err != nil {
+		return nil, NewElementError(number, err)
+	}
+
+	return raw.Bytes(), nil
+}
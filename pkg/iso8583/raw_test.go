@@ -0,0 +1,115 @@
+package iso8583_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+func TestRawElements(t *testing.T) {
+	type subfields struct {
+		Subfield1 string `iso8583:"1=an-2, omitempty"`
+		Subfield2 string `iso8583:"2=an-3, omitempty"`
+	}
+
+	type allSubfields struct {
+		Subfield1 string `iso8583:"1=an-2, omitempty"`
+		Subfield2 string `iso8583:"2=an-3, omitempty"`
+		Subfield3 string `iso8583:"3=an-4, omitempty"`
+		Subfield4 string `iso8583:"4=an-1, omitempty"`
+	}
+
+	type knownSubfields struct {
+		Subfield1 string `iso8583:"1=an-2, omitempty"`
+		Subfield2 string `iso8583:"2=an-3, omitempty"`
+		Undefined iso8583.RawElements
+	}
+
+	// The sender knows all elements
+	type sent struct {
+		Number    string       `iso8583:"2=n..19"`
+		Amount    int64        `iso8583:"4=n-12, justify=right"`
+		Rate      string       `iso8583:"9=n-8"`
+		Private   allSubfields `iso8583:"62=b.255, lenenc=bin, subbitmap=1"`
+		Reference string       `iso8583:"70=ans...999"`
+	}
+
+	// We do not know elements 9 and 70 and subfields 3 and 4
+	type received struct {
+		Number    string         `iso8583:"2=n..19"`
+		Amount    int64          `iso8583:"4=n-12, justify=right"`
+		Private   knownSubfields `iso8583:"62=b.255, lenenc=bin, subbitmap=1"`
+		Undefined iso8583.RawElements
+	}
+
+	type withoutRaw struct {
+		Number  string    `iso8583:"2=n..19"`
+		Amount  int64     `iso8583:"4=n-12, justify=right"`
+		Private subfields `iso8583:"62=b.255, lenenc=bin, subbitmap=1"`
+	}
+
+	mti := iso8583.NewMti("0800")
+	in := sent{
+		Number:    "5200000000000007",
+		Amount:    1234,
+		Rate:      "61000000",
+		Private:   allSubfields{Subfield1: "AB", Subfield2: "CDE", Subfield3: "WXYZ", Subfield4: "Q"},
+		Reference: "unknown to us",
+	}
+	formats := map[int]string{9: "n-8", 70: "ans...999"}
+
+	for name, format := range map[string]iso8583.Opt{"ascii": iso8583.FormatAscii, "ebcdic": iso8583.FormatEbcdic} {
+		t.Run(name, func(t *testing.T) {
+			var sentBuf bytes.Buffer
+			if err := iso8583.NewEncoder(&sentBuf, format, iso8583.NoRdwLayout).EncodeIso8583(mti, &in); err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				decodedMti iso8583.MTI
+				out        received
+			)
+			decoder := iso8583.NewDecoder(bytes.NewReader(sentBuf.Bytes()), format, iso8583.NoRdwLayout, iso8583.UndefinedElements(formats))
+			if err := decoder.DecodeIso8583(&decodedMti, &out); err != nil {
+				t.Fatal(err)
+			}
+
+			if out.Number != in.Number || out.Amount != in.Amount || out.Private.Subfield1 != in.Private.Subfield1 {
+				t.Fatalf("Expected the defined elements to be decoded, got %#v", out)
+			}
+
+			if numbers := out.Undefined.Numbers(); !reflect.DeepEqual(numbers, []int{9, 70}) {
+				t.Fatalf("Expected elements 9 and 70 to be kept raw, got %v", numbers)
+			}
+
+			if numbers := out.Private.Undefined.Numbers(); !reflect.DeepEqual(numbers, []int{3, 4}) {
+				t.Fatalf("Expected subfields 3 and 4 to be kept raw, got %v", numbers)
+			}
+
+			// Encoding what we received gives the message that was sent
+			var receivedBuf bytes.Buffer
+			if err := iso8583.NewEncoder(&receivedBuf, format, iso8583.NoRdwLayout).EncodeIso8583(mti, &out); err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(sentBuf.Bytes(), receivedBuf.Bytes()) {
+				t.Errorf("Sent:        %x", sentBuf.Bytes())
+				t.Fatalf("Sent again:  %x", receivedBuf.Bytes())
+			}
+
+			// Without a format the length of the element is unknown
+			decoder = iso8583.NewDecoder(bytes.NewReader(sentBuf.Bytes()), format, iso8583.NoRdwLayout, iso8583.UndefinedElements(map[int]string{9: "n-8"}))
+			if err := decoder.DecodeIso8583(&decodedMti, &received{}); err == nil {
+				t.Fatal("Expected an error for element 70 without a format")
+			}
+
+			// A struct without RawElements fails on elements it does not define, as before
+			decoder = iso8583.NewDecoder(bytes.NewReader(sentBuf.Bytes()), format, iso8583.NoRdwLayout, iso8583.UndefinedElements(formats))
+			if err := decoder.DecodeIso8583(&decodedMti, &withoutRaw{}); err == nil {
+				t.Fatal("Expected an error for a struct without RawElements")
+			}
+		})
+	}
+}
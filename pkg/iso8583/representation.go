@@ -88,6 +88,7 @@ var notation = map[string]representation{
 	`an`:  alphabeticRepr | numericRepr,                           // alphabetic (A–Z and a–z) and numeric characters
 	`ans`: alphabeticRepr | numericRepr | spaceRepr | specialRepr, // alphabetic (A–Z and a–z), numeric, and special characters (including space)
 	`b`:   binaryRepr,                                             // binary representation of data in eight-bit bytes
+	`z`:   numericRepr | specialRepr,                              // track 2 and 3 code set, numeric digits 0–9 and the field separator =
 }
 
 // Classification per character
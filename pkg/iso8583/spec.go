@@ -0,0 +1,397 @@
+package iso8583
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes the elements of a message like the iso8583-tags of a struct do, so the layout of a message can
+// be loaded from a YAML or JSON file instead of being compiled in. A Message is encoded and decoded against it.
+//
+//	name: example
+//	elements:
+//	  - number: 2
+//	    name: PrimaryAccountNumber
+//	    format: n..19
+//	    lenenc: hex
+//	  - number: 62
+//	    format: b.255
+//	    lenenc: bin
+//	    subbitmap: 8
+//	    subfields:
+//	      - number: 1
+//	        format: an-1
+//	        omitempty: true
+//
+// The attributes of an element are those of an iso8583-tag and mean the same, see StructDefinitions. Elements
+// with subfields are encoded like a struct field, those without like a string.
+type Spec struct {
+	Name     string        `yaml:"name" json:"name"`
+	Elements []ElementSpec `yaml:"elements" json:"elements"`
+
+	// definitions, subspecs and numbers are set by Compile. The Field of a definition is the index of the
+	// element in Elements.
+	definitions map[int]Definition
+	subspecs    map[int]*Spec
+	numbers     map[string]int
+}
+
+// ElementSpec describes an element, or a subfield, of a Spec
+type ElementSpec struct {
+	Number int    `yaml:"number" json:"number"`
+	Name   string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Format is the representation and length, like the first attribute of an iso8583-tag: n-6 or ans..99
+	Format string `yaml:"format" json:"format"`
+
+	MinLength      *int   `yaml:"minlength,omitempty" json:"minlength,omitempty"`
+	Justify        string `yaml:"justify,omitempty" json:"justify,omitempty"`
+	OmitEmpty      bool   `yaml:"omitempty,omitempty" json:"omitempty,omitempty"`
+	AutoFill       bool   `yaml:"autofill,omitempty" json:"autofill,omitempty"`
+	DataEncoding   string `yaml:"dataenc,omitempty" json:"dataenc,omitempty"`
+	LengthEncoding string `yaml:"lenenc,omitempty" json:"lenenc,omitempty"`
+	Bcd4Len        int    `yaml:"bcd4len,omitempty" json:"bcd4len,omitempty"`
+	SubBitmap      int    `yaml:"subbitmap,omitempty" json:"subbitmap,omitempty"`
+	TlvTag         string `yaml:"tlvTag,omitempty" json:"tlvTag,omitempty"`
+
+	Subfields []ElementSpec `yaml:"subfields,omitempty" json:"subfields,omitempty"`
+}
+
+// Tag returns the iso8583-tag of the element
+func (e ElementSpec) Tag() string {
+	attributes := []string{fmt.Sprintf("%d=%s", e.Number, e.Format)}
+
+	if e.MinLength != nil {
+		attributes = append(attributes, "minlength="+strconv.Itoa(*e.MinLength))
+	}
+	if e.Justify != "" {
+		attributes = append(attributes, "justify="+e.Justify)
+	}
+	if e.OmitEmpty {
+		attributes = append(attributes, "omitempty")
+	}
+	if e.AutoFill {
+		attributes = append(attributes, "autofill")
+	}
+	if e.DataEncoding != "" {
+		attributes = append(attributes, "dataenc="+e.DataEncoding)
+	}
+	if e.LengthEncoding != "" {
+		attributes = append(attributes, "lenenc="+e.LengthEncoding)
+	}
+	if e.Bcd4Len != 0 {
+		attributes = append(attributes, "bcd4len="+strconv.Itoa(e.Bcd4Len))
+	}
+	if e.SubBitmap != 0 {
+		attributes = append(attributes, "subbitmap="+strconv.Itoa(e.SubBitmap))
+	}
+	if e.TlvTag != "" {
+		attributes = append(attributes, "tlvTag="+e.TlvTag)
+	}
+
+	return strings.Join(attributes, ", ")
+}
+
+// ReadSpec reads a spec in YAML or JSON from r and compiles it
+func ReadSpec(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read spec; %w", err)
+	}
+
+	var spec Spec
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&spec)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse spec; %w", err)
+	}
+
+	if err := spec.Compile(); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// LoadSpec reads a spec from a YAML or JSON file and compiles it
+func LoadSpec(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open spec; %w", err)
+	}
+	defer f.Close()
+
+	spec, err := ReadSpec(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return spec, nil
+}
+
+// Compile parses the elements of the spec into their definitions. ReadSpec and LoadSpec compile the spec,
+// a spec that is built in code must be compiled before it is used.
+func (s *Spec) Compile() error {
+	if len(s.Elements) == 0 {
+		return fmt.Errorf("spec %q has no elements", s.Name)
+	}
+
+	definitions := make(map[int]Definition, len(s.Elements))
+	subspecs := make(map[int]*Spec)
+	numbers := make(map[string]int)
+
+	for idx, element := range s.Elements {
+		name := fmt.Sprintf("element %d of spec %q", element.Number, s.Name)
+
+		if strings.ContainsAny(element.Format, ",=") {
+			return fmt.Errorf("%s has invalid format %q", name, element.Format)
+		}
+
+		definition, err := parseDefinition(name, idx, element.Tag())
+		if err != nil {
+			return err
+		}
+
+		if _, ok := definitions[definition.Number]; ok {
+			return fmt.Errorf("spec %q has element %d more than once", s.Name, definition.Number)
+		}
+		definitions[definition.Number] = definition
+
+		if element.Name != "" {
+			if _, ok := numbers[element.Name]; ok {
+				return fmt.Errorf("spec %q has element name %q more than once", s.Name, element.Name)
+			}
+			numbers[element.Name] = definition.Number
+		}
+
+		if len(element.Subfields) > 0 {
+			subspec := &Spec{Name: fmt.Sprintf("%s/%d", s.Name, definition.Number), Elements: element.Subfields}
+			if err := subspec.Compile(); err != nil {
+				return err
+			}
+			subspecs[definition.Number] = subspec
+		}
+	}
+
+	s.definitions, s.subspecs, s.numbers = definitions, subspecs, numbers
+
+	return nil
+}
+
+// Definitions returns the definitions of the elements by number, like StructDefinitions. They are shared so they
+// must not be modified.
+func (s *Spec) Definitions() map[int]Definition {
+	return s.definitions
+}
+
+// Number returns the number of the element with the name
+func (s *Spec) Number(name string) (int, bool) {
+	number, ok := s.numbers[name]
+	return number, ok
+}
+
+// Element returns the spec of the element with the number
+func (s *Spec) Element(number int) (ElementSpec, bool) {
+	definition, ok := s.definitions[number]
+	if !ok {
+		return ElementSpec{}, false
+	}
+
+	return s.Elements[definition.Field], true
+}
+
+// Subfields returns the spec of the subfields of the element, nil when it has none
+func (s *Spec) Subfields(number int) *Spec {
+	return s.subspecs[number]
+}
+
+// StructSpec returns the spec of a struct with iso8583-tags, to write the layout of an existing message to a
+// file. Struct fields of a struct type without MarshalIso8583 are described as subfields.
+func StructSpec(name string, v interface{}) (*Spec, error) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("value must be (a pointer to) a struct, got %T", v)
+	}
+
+	spec, err := structSpec(name, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := spec.Compile(); err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+func structSpec(name string, t reflect.Type) (*Spec, error) {
+	spec := &Spec{Name: name}
+
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+
+		tag, ok := field.Tag.Lookup("iso8583")
+		if !ok {
+			// Ignore struct fields without iso8583-tag
+			continue
+		}
+
+		number, format, attributes := parseTag(tag)
+		element := ElementSpec{
+			Number:         number,
+			Format:         format,
+			Justify:        attributes["justify"],
+			DataEncoding:   attributes["dataenc"],
+			LengthEncoding: attributes["lenenc"],
+			TlvTag:         attributes["tlvTag"],
+		}
+
+		if field.IsExported() {
+			element.Name = field.Name
+		}
+
+		_, element.OmitEmpty = attributes["omitempty"]
+		_, element.AutoFill = attributes["autofill"]
+
+		var err error
+		if attr, ok := attributes["minlength"]; ok {
+			var minLength int
+			if minLength, err = strconv.Atoi(attr); err != nil {
+				return nil, fmt.Errorf("struct field %q has invalid minlength-attribute %q in iso8583-tag: %w", field.Name, attr, err)
+			}
+			element.MinLength = &minLength
+		}
+		if attr, ok := attributes["bcd4len"]; ok {
+			if element.Bcd4Len, err = strconv.Atoi(attr); err != nil {
+				return nil, fmt.Errorf("struct field %q has invalid bcd4len-attribute %q in iso8583-tag: %w", field.Name, attr, err)
+			}
+		}
+		if attr, ok := attributes["subbitmap"]; ok {
+			if element.SubBitmap, err = strconv.Atoi(attr); err != nil {
+				return nil, fmt.Errorf("struct field %q has invalid subbitmap-attribute %q in iso8583-tag: %w", field.Name, attr, err)
+			}
+		}
+
+		if subfields := subfieldsType(field.Type); subfields != nil {
+			subspec, err := structSpec(fmt.Sprintf("%s/%d", name, number), subfields)
+			if err != nil {
+				return nil, err
+			}
+			element.Subfields = subspec.Elements
+		}
+
+		spec.Elements = append(spec.Elements, element)
+	}
+
+	return spec, nil
+}
+
+var marshalerType = reflect.TypeOf((*marshaler)(nil)).Elem()
+
+// subfieldsType returns the struct type of a field that is encoded as subfields, nil when it is encoded as a value
+func subfieldsType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+
+	if t.Kind() != reflect.Struct || t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+		return nil
+	}
+
+	return t
+}
+
+// Validate compares a struct with iso8583-tags with the spec. It returns an error listing the elements and
+// subfields that are defined differently, or that only one of them has.
+func (s *Spec) Validate(v interface{}) error {
+	other, err := StructSpec(s.Name, v)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(s.compare(other)...)
+}
+
+func (s *Spec) compare(other *Spec) []error {
+	numbers := make([]int, 0, len(s.definitions)+len(other.definitions))
+	for number := range s.definitions {
+		numbers = append(numbers, number)
+	}
+	for number := range other.definitions {
+		if _, ok := s.definitions[number]; !ok {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	var errs []error
+
+	for _, number := range numbers {
+		definition, ok := s.definitions[number]
+		otherDefinition, otherOk := other.definitions[number]
+
+		switch {
+		case !otherOk:
+			errs = append(errs, fmt.Errorf("%s: element %d is not in the struct", s.Name, number))
+			continue
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s: element %d is not in the spec", s.Name, number))
+			continue
+		}
+
+		// The field index differs between a spec and a struct
+		definition.Field, otherDefinition.Field = 0, 0
+		if !reflect.DeepEqual(definition, otherDefinition) {
+			element, _ := s.Element(number)
+			otherElement, _ := other.Element(number)
+			errs = append(errs, fmt.Errorf(
+				"%s: element %d is %q in the spec but %q in the struct",
+				s.Name, number, element.Tag(), otherElement.Tag(),
+			))
+
+			continue
+		}
+
+		subspec, otherSubspec := s.subspecs[number], other.subspecs[number]
+		switch {
+		case subspec == nil && otherSubspec == nil:
+		case otherSubspec == nil:
+			errs = append(errs, fmt.Errorf("%s: element %d has subfields in the spec but not in the struct", s.Name, number))
+		case subspec == nil:
+			errs = append(errs, fmt.Errorf("%s: element %d has subfields in the struct but not in the spec", s.Name, number))
+		default:
+			errs = append(errs, subspec.compare(otherSubspec)...)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,203 @@
+package iso8583_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+const exampleSpec = `
+name: example
+elements:
+  - number: 2
+    name: PrimaryAccountNumber
+    format: n..19
+    lenenc: hex
+  - number: 4
+    name: Amount
+    format: n-12
+    justify: right
+  - number: 62
+    format: b.255
+    lenenc: bin
+    subbitmap: 1
+    subfields:
+      - number: 1
+        format: an-1
+        omitempty: true
+      - number: 2
+        format: n-4
+        justify: right
+        omitempty: true
+  - number: 70
+    format: n-3
+`
+
+// exampleElements are the elements of exampleSpec as a struct
+type exampleElements struct {
+	PrimaryAccountNumber string            `iso8583:"2=n..19, lenenc=hex"`
+	Amount               int64             `iso8583:"4=n-12, justify=right"`
+	Private              *exampleSubfields `iso8583:"62=b.255, lenenc=bin, subbitmap=1"`
+	Network              string            `iso8583:"70=n-3"`
+}
+
+type exampleSubfields struct {
+	Indicator string `iso8583:"1=an-1, omitempty"`
+	Count     string `iso8583:"2=n-4, justify=right, omitempty"`
+}
+
+func TestSpecMessage(t *testing.T) {
+	mti := iso8583.NewMti("0100")
+	elements := exampleElements{
+		PrimaryAccountNumber: "5200000000000007",
+		Amount:               1234,
+		Private:              &exampleSubfields{Indicator: "Y", Count: "12"},
+		Network:              "001",
+	}
+
+	jsonSpec := `{"name": "example", "elements": [
+		{"number": 2, "name": "PrimaryAccountNumber", "format": "n..19", "lenenc": "hex"},
+		{"number": 4, "name": "Amount", "format": "n-12", "justify": "right"},
+		{"number": 62, "format": "b.255", "lenenc": "bin", "subbitmap": 1, "subfields": [
+			{"number": 1, "format": "an-1", "omitempty": true},
+			{"number": 2, "format": "n-4", "justify": "right", "omitempty": true}
+		]},
+		{"number": 70, "format": "n-3"}
+	]}`
+
+	for name, source := range map[string]string{"yaml": exampleSpec, "json": jsonSpec} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := iso8583.ReadSpec(strings.NewReader(source))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := spec.Validate(&elements); err != nil {
+				t.Fatalf("Expected the struct to match the spec, got %s", err)
+			}
+
+			var structBuf bytes.Buffer
+			if err := iso8583.NewEncoder(&structBuf, iso8583.FormatEbcdic, iso8583.NoRdwLayout).EncodeIso8583(mti, &elements); err != nil {
+				t.Fatal(err)
+			}
+
+			// Set the message through the accessors, it must encode like the struct
+			msg := iso8583.NewMessage(spec)
+			number, _ := spec.Number("PrimaryAccountNumber")
+			if err := msg.Set(number, "5200000000000007"); err != nil {
+				t.Fatal(err)
+			}
+			if err := msg.SetInt(4, 1234); err != nil {
+				t.Fatal(err)
+			}
+			private, err := msg.Subfields(62)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := private.Set(1, "Y"); err != nil {
+				t.Fatal(err)
+			}
+			if err := private.Set(2, "12"); err != nil {
+				t.Fatal(err)
+			}
+			if err := msg.Set(70, "001"); err != nil {
+				t.Fatal(err)
+			}
+
+			var msgBuf bytes.Buffer
+			if err := iso8583.NewEncoder(&msgBuf, iso8583.FormatEbcdic, iso8583.NoRdwLayout).EncodeIso8583(mti, msg); err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(structBuf.Bytes(), msgBuf.Bytes()) {
+				t.Errorf("Struct:  %x", structBuf.Bytes())
+				t.Fatalf("Message: %x", msgBuf.Bytes())
+			}
+
+			// Decode the message of the struct
+			var decodedMti iso8583.MTI
+			decoded := iso8583.NewMessage(spec)
+			decoder := iso8583.NewDecoder(bytes.NewReader(structBuf.Bytes()), iso8583.FormatEbcdic, iso8583.NoRdwLayout)
+			if err := decoder.DecodeIso8583(&decodedMti, decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			if numbers := decoded.Numbers(); len(numbers) != 4 {
+				t.Fatalf("Expected 4 elements, got %v", numbers)
+			}
+
+			if pan := decoded.String(2); pan != elements.PrimaryAccountNumber {
+				t.Errorf("Expected PAN %s, got %s", elements.PrimaryAccountNumber, pan)
+			}
+
+			if amount, err := decoded.Int(4); err != nil || amount != elements.Amount {
+				t.Errorf("Expected amount %d, got %d (%v)", elements.Amount, amount, err)
+			}
+
+			private, err = decoded.Subfields(62)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count, _ := private.Int(2); private.String(1) != "Y" || count != 12 {
+				t.Errorf("Expected subfields Y and 12, got %s and %s", private.String(1), private.String(2))
+			}
+		})
+	}
+}
+
+func TestSpecErrors(t *testing.T) {
+	spec, err := iso8583.ReadSpec(strings.NewReader(exampleSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := iso8583.NewMessage(spec)
+	if err := msg.Set(3, "000000"); err == nil {
+		t.Error("Expected an error for an element that is not in the spec")
+	}
+	if err := msg.Set(62, "Y"); err == nil {
+		t.Error("Expected an error for setting an element with subfields")
+	}
+	if _, err := msg.Subfields(2); err == nil {
+		t.Error("Expected an error for the subfields of an element without them")
+	}
+
+	for _, invalid := range []string{
+		"name: x\nelements: []\n",
+		"name: x\nelements:\n  - number: 2\n    format: x..19\n",
+		"name: x\nelements:\n  - number: 2\n    format: n..19\n    lenenc: octal\n",
+		"name: x\nelements:\n  - number: 2\n    format: n..19\n  - number: 2\n    format: n-3\n",
+		"name: x\nelements:\n  - number: 2\n    format: n..19\n    unknown: true\n",
+	} {
+		if _, err := iso8583.ReadSpec(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected an error for spec %q", invalid)
+		}
+	}
+
+	// The struct differs in element 4, subfield 2 of element 62 and has no element 70
+	type other struct {
+		PrimaryAccountNumber string `iso8583:"2=n..19, lenenc=hex"`
+		Amount               int64  `iso8583:"4=n-12"`
+		Private              struct {
+			Indicator string `iso8583:"1=an-1, omitempty"`
+			Count     string `iso8583:"2=n-5, justify=right, omitempty"`
+		} `iso8583:"62=b.255, lenenc=bin, subbitmap=1"`
+	}
+
+	err = spec.Validate(other{})
+	if err == nil {
+		t.Fatal("Expected the struct not to match the spec")
+	}
+
+	for _, expected := range []string{
+		`example: element 4 is "4=n-12, justify=right" in the spec but "4=n-12" in the struct`,
+		`example/62: element 2 is "2=n-4, justify=right, omitempty" in the spec but "2=n-5, justify=right, omitempty" in the struct`,
+		`example: element 70 is not in the struct`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %q", expected, err.Error())
+		}
+	}
+}
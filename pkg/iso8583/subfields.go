@@ -1,10 +1,13 @@
 package iso8583
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // MarshalSubfields marshals all values for iso8583-fields into w
@@ -15,7 +18,11 @@ func MarshalSubfields(v interface{}, w io.Writer, parentElement Definition) erro
 		return err
 	}
 
-	values := reflect.Indirect(reflect.ValueOf(v))
+	values := structFields(v)
+
+	if tlvSubfields(definitions) {
+		return marshalTLVSubfields(values, definitions, w)
+	}
 
 	// If an optional subfield is presented without data, but a succeeding subfield has data, the empty
 	// optional subfields has to be provided anyhow. We therefor need to know the highest provided field
@@ -25,9 +32,11 @@ func MarshalSubfields(v interface{}, w io.Writer, parentElement Definition) erro
 	bitmap := bitMap{}
 
 	for number, element := range definitions {
-		if element.OmitEmpty && values.Field(element.Field).IsZero() {
-			// ignore empty fields that may be omitted
-			continue
+		if element.OmitEmpty {
+			if _, zero := values.value(element); zero {
+				// ignore empty fields that may be omitted
+				continue
+			}
 		}
 
 		if number > numberMax {
@@ -37,6 +46,20 @@ func MarshalSubfields(v interface{}, w io.Writer, parentElement Definition) erro
 		bitmap.SetField(number)
 	}
 
+	// Subfields that were kept raw are written again after the defined ones, which are all written then
+	raw := undefinedSubfields(values.rawElements(), definitions, parentElement)
+	if len(raw) > 0 {
+		for number := range definitions {
+			if number > numberMax {
+				numberMax = number
+			}
+		}
+
+		for number := range raw {
+			bitmap.SetField(number)
+		}
+	}
+
 	if parentElement.SubBitmap > 0 {
 		if _, err := w.Write(bitmap.Bytes()[0:parentElement.SubBitmap]); err != nil {
 			return fmt.Errorf("could not subfield bitmap element %d; %w", parentElement.Number, err)
@@ -55,13 +78,16 @@ func MarshalSubfields(v interface{}, w io.Writer, parentElement Definition) erro
 			continue
 		}
 
-		field := values.Field(element.Field)
-		if !field.CanInterface() {
-			// If we can not obtain the actual value, use the fields zero-value
-			field = reflect.New(field.Type()).Elem()
+		// Unexported fields give their zero-value
+		value, _ := values.value(element)
+
+		if err = Encode(value, element, w); err != nil {
+			return NewElementError(number, err)
 		}
+	}
 
-		if err = Encode(field.Interface(), element, w); err != nil {
+	for _, number := range raw.Numbers() {
+		if _, err := w.Write(raw[number]); err != nil {
 			return NewElementError(number, err)
 		}
 	}
@@ -69,6 +95,19 @@ func MarshalSubfields(v interface{}, w io.Writer, parentElement Definition) erro
 	return nil
 }
 
+// undefinedSubfields returns the raw subfields that can be written again: those in the subfield bit map that the
+// struct does not define
+func undefinedSubfields(raw RawElements, definitions map[int]Definition, parentElement Definition) RawElements {
+	undefined := make(RawElements, len(raw))
+	for number, data := range raw {
+		if _, ok := definitions[number]; !ok && number > 0 && number <= parentElement.SubBitmap*8 {
+			undefined[number] = data
+		}
+	}
+
+	return undefined
+}
+
 // UnmarshalSubfields unmarshals all values from r into iso8583-fields in v
 func UnmarshalSubfields(v interface{}, r io.Reader, parentElement Definition, lenEnc lengthEncoding) error {
 	// Read field definitions from struct
@@ -77,6 +116,10 @@ func UnmarshalSubfields(v interface{}, r io.Reader, parentElement Definition, le
 		return err
 	}
 
+	if tlvSubfields(definitions) {
+		return unmarshalTLVSubfields(structFields(v), definitions, r, lenEnc)
+	}
+
 	// If a bitmap is present, use it. Otherwise, assume all fields will be present.
 	bitmap := bitMap{}
 	if parentElement.SubBitmap > 0 {
@@ -96,7 +139,7 @@ func UnmarshalSubfields(v interface{}, r io.Reader, parentElement Definition, le
 		}
 	}
 
-	values := reflect.Indirect(reflect.ValueOf(v))
+	values := structFields(v)
 	// If subfield 3 is presented, so must 1 and 2. But 4 and higher don't have to be
 	for number := 1; number <= numberMax; number++ {
 		element, ok := definitions[number]
@@ -108,11 +151,8 @@ func UnmarshalSubfields(v interface{}, r io.Reader, parentElement Definition, le
 			continue
 		}
 
-		// Get the variable we must decode into
-		field := StructField(values, element.Field)
-
 		// Pass a pointer to the variable where the data must be decoded into
-		if err := Decode(field.Addr().Interface(), element, r, lenEnc); err != nil {
+		if err := Decode(values.pointer(element), element, r, lenEnc); err != nil {
 			if errors.Is(err, io.EOF) {
 				// Not all subfields have to be present (ex PDS 158)
 				break
@@ -122,5 +162,154 @@ func UnmarshalSubfields(v interface{}, r io.Reader, parentElement Definition, le
 		}
 	}
 
+	return keepUndefinedSubfields(values, bitmap, r, parentElement, numberMax)
+}
+
+// keepUndefinedSubfields keeps the subfields in the bit map after the ones the struct defines in its RawElements.
+// Their length is unknown, so the rest of the element is kept with the first of them and the others are kept
+// empty, which writes the same bytes again.
+func keepUndefinedSubfields(values fields, bitmap bitMap, r io.Reader, parentElement Definition, numberMax int) error {
+	var undefined []int
+	for number := numberMax + 1; number <= parentElement.SubBitmap*8; number++ {
+		if bitmap.Field(number) {
+			undefined = append(undefined, number)
+		}
+	}
+
+	if len(undefined) == 0 || !values.raw().IsValid() {
+		return nil
+	}
+
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return NewElementError(undefined[0], err)
+	}
+
+	for i, number := range undefined {
+		if i > 0 {
+			rest = []byte{}
+		}
+		values.keepRaw(number, rest)
+	}
+
+	return nil
+}
+
+// tlvSubfields tells whether the subfields are data objects, which all have a tag
+func tlvSubfields(definitions map[int]Definition) bool {
+	for _, element := range definitions {
+		if element.TlvTag == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tlvFormat returns the format of the data object of a subfield with a tag. The tags of data objects with a binary
+// length, like the Visa dataset IDs and their tags, are a byte: 5F is not the start of a 2 byte BER-TLV tag there.
+func (d Definition) tlvFormat() TLVFormat {
+	switch d.LengthEncoding {
+	case LengthEncodingHexBit4:
+		return TLVFormat{TagSize: 1, LengthSize: d.LengthEncoding.size(d.LengthIndicator)}
+	case LengthEncodingBin:
+		return TLVFormat{TagSize: 1, LengthSize: 1}
+	default:
+		return BerTLV
+	}
+}
+
+// marshalTLVSubfields writes the subfields that have a value as data objects in the order of their numbers,
+// followed by the data objects that were kept raw
+func marshalTLVSubfields(values fields, definitions map[int]Definition, w io.Writer) error {
+	numbers := make([]int, 0, len(definitions))
+	for number := range definitions {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		element := definitions[number]
+
+		value, zero := values.value(element)
+		if zero && element.OmitEmpty {
+			continue
+		}
+
+		if err := Encode(value, element, w); err != nil {
+			return NewElementError(number, err)
+		}
+	}
+
+	raw := values.rawElements()
+	for _, number := range raw.Numbers() {
+		if _, err := w.Write(raw[number]); err != nil {
+			return NewElementError(number, err)
+		}
+	}
+
+	return nil
+}
+
+// unmarshalTLVSubfields reads the data objects in r into the subfields with their tag, in any order. Data
+// objects with other tags are kept in the RawElements of the struct by the number of their tag.
+func unmarshalTLVSubfields(values fields, definitions map[int]Definition, r io.Reader, lenEnc lengthEncoding) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	// The tag and the length of an unknown tag are read like those of the first subfield
+	tags := make(map[string]Definition, len(definitions))
+	var first Definition
+	for number, element := range definitions {
+		tags[strings.ToUpper(element.TlvTag)] = element
+		if first.Number == 0 || number < first.Number {
+			first = element
+		}
+	}
+
+	for offset := 0; offset < len(data); {
+		tag, tagSize, err := first.tlvFormat().decodeTag(data[offset:])
+		if err != nil {
+			return fmt.Errorf("data object at %d: %w", offset, err)
+		}
+
+		element, known := tags[tag]
+		if !known {
+			element = first
+		}
+
+		length, lengthSize, err := element.tlvFormat().decodeLength(data[offset+tagSize:])
+		if err != nil {
+			return fmt.Errorf("tag %s: %w", tag, err)
+		}
+
+		start := offset + tagSize + lengthSize
+		end := start + length
+		if end > len(data) {
+			return fmt.Errorf("tag %s has length %d, %d bytes left", tag, length, len(data)-start)
+		}
+
+		if !known {
+			number, _ := strconv.ParseInt(tag, 16, 64)
+			if !values.keepRaw(int(number), data[offset:end]) {
+				return fmt.Errorf("missing definition for tag %s", tag)
+			}
+
+			offset = end
+
+			continue
+		}
+
+		// The tag and length are read, what remains is a value of a fixed length
+		element.TlvTag, element.LengthIndicator, element.Length = "", 0, length
+		if err := Decode(values.pointer(element), element, bytes.NewReader(data[start:end]), lenEnc); err != nil {
+			return NewElementError(element.Number, err)
+		}
+
+		offset = end
+	}
+
 	return nil
 }
@@ -1,12 +1,455 @@
 package iso8583
 
 import (
-	"encoding/binary"
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
 )
 
+// TLVFormat describes how the tags and lengths of TLV encoded data objects are written. The zero value is
+// BER-TLV as EMV uses it for the chip data in DE55 and F055.
+//
+// Other layouts set the size of the tags and lengths, like the Visa F034 datasets with a 1 byte ID and a 2 byte
+// length that hold BER-TLV objects with EBCDIC values:
+//
+//	TLVFormat{TagSize: 1, LengthSize: 2, Nested: &TLVFormat{Ebcdic: true}}
+//
+// or the Mastercard DE48 and DE108 subelements with a 2 digit tag and length:
+//
+//	TLVFormat{TagSize: 2, LengthSize: 2, Text: true}
+type TLVFormat struct {
+	// TagSize is the size of a tag in bytes, or digits for Text, 0 reads BER-TLV tags whose first byte tells
+	// their size
+	TagSize int
+
+	// LengthSize is the size of a length in bytes, or digits for Text, 0 reads BER-TLV lengths
+	LengthSize int
+
+	// Text writes the tags and lengths as decimal digits instead of binary
+	Text bool
+
+	// Ebcdic transcodes the values, and the tags and lengths of Text, from EBCDIC so Value holds ASCII
+	Ebcdic bool
+
+	// Nested is the format of the data objects in every value, like those in the Visa F034 datasets. Without
+	// it only the values of constructed BER-TLV tags are read as data objects, in the same format.
+	Nested *TLVFormat
+}
+
+// BerTLV is the format of the EMV chip data
+var BerTLV = TLVFormat{}
+
+// TLV is a data object: a tag and a value
+type TLV struct {
+	// Tag is the tag in upper case hex, or the digits of Text tags
+	Tag string
+
+	// Value is the value, not transcoded when it holds data objects
+	Value []byte
+
+	// TLVs are the data objects in the value of a constructed data object. They are encoded instead of Value
+	// when they are set.
+	TLVs TLVs
+}
+
+// TLVs are data objects in the order they were read. Data objects with tags that are not known to the
+// reader are kept like the others, so they are written again.
+type TLVs []TLV
+
+// Constructed tells whether the first byte of a BER-TLV tag flags a value of data objects
+func (t TLV) Constructed() bool {
+	tag, err := hex.DecodeString(t.Tag)
+	return err == nil && len(tag) > 0 && tag[0]&berConstructed != 0
+}
+
+const (
+	berConstructed = 0x20
+	berMoreBytes   = 0x1F
+	berLongForm    = 0x80
+	berPadding     = 0x00
+)
+
+// ParseTLVs reads all data objects from data
+func ParseTLVs(data []byte, format TLVFormat) (TLVs, error) {
+	var tlvs TLVs
+
+	for offset := 0; offset < len(data); {
+		if format.berTags() && data[offset] == berPadding {
+			// EMV allows zero bytes before, between and after data objects
+			offset++
+			continue
+		}
+
+		tlv, n, err := format.decodeTLV(data[offset:])
+		if err != nil {
+			return tlvs, fmt.Errorf("data object at %d: %w", offset, err)
+		}
+
+		tlvs = append(tlvs, tlv)
+		offset += n
+	}
+
+	return tlvs, nil
+}
+
+// Marshal writes the data objects in the format
+func (t TLVs) Marshal(format TLVFormat) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, tlv := range t {
+		data, err := format.encodeTLV(tlv)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Find returns the first data object with the tag
+func (t TLVs) Find(tag string) (TLV, bool) {
+	for _, tlv := range t {
+		if strings.EqualFold(tlv.Tag, tag) {
+			return tlv, true
+		}
+	}
+
+	return TLV{}, false
+}
+
+// Set replaces the value of the first data object with the tag, or adds the data object after the others
+func (t *TLVs) Set(tag string, value []byte) {
+	for i := range *t {
+		if strings.EqualFold((*t)[i].Tag, tag) {
+			(*t)[i].Value, (*t)[i].TLVs = value, nil
+			return
+		}
+	}
+
+	*t = append(*t, TLV{Tag: strings.ToUpper(tag), Value: value})
+}
+
+// Without returns a copy without the data objects with the tags, also in constructed data objects
+func (t TLVs) Without(tags ...string) TLVs {
+	if t == nil {
+		return nil
+	}
+
+	without := make(TLVs, 0, len(t))
+
+next:
+	for _, tlv := range t {
+		for _, tag := range tags {
+			if strings.EqualFold(tlv.Tag, tag) {
+				continue next
+			}
+		}
+
+		if tlv.TLVs != nil {
+			tlv.TLVs = tlv.TLVs.Without(tags...)
+		}
+		without = append(without, tlv)
+	}
+
+	return without
+}
+
+// MarshalIso8583 writes the data objects as BER-TLV, so they can be the value of an element like DE55
+func (t TLVs) MarshalIso8583() ([]byte, error) {
+	return t.Marshal(BerTLV)
+}
+
+// UnmarshalIso8583 reads the BER-TLV data objects of an element
+func (t *TLVs) UnmarshalIso8583(data []byte) error {
+	tlvs, err := ParseTLVs(data, BerTLV)
+	if err != nil {
+		return err
+	}
+
+	*t = tlvs
+
+	return nil
+}
+
+func (f TLVFormat) berTags() bool {
+	return f.TagSize == 0 && !f.Text
+}
+
+func (f TLVFormat) transcoded() bool {
+	return f.Ebcdic && f.Nested == nil
+}
+
+// decodeTLV reads the data object at the start of data, it returns the data object and its size
+func (f TLVFormat) decodeTLV(data []byte) (TLV, int, error) {
+	var tlv TLV
+
+	tag, offset, err := f.decodeTag(data)
+	if err != nil {
+		return tlv, 0, err
+	}
+	tlv.Tag = tag
+
+	length, n, err := f.decodeLength(data[offset:])
+	if err != nil {
+		return tlv, 0, fmt.Errorf("tag %s: %w", tag, err)
+	}
+	offset += n
+
+	if offset+length > len(data) {
+		return tlv, 0, fmt.Errorf("tag %s has length %d, %d bytes left", tag, length, len(data)-offset)
+	}
+
+	value := data[offset : offset+length]
+
+	switch {
+	case f.Nested != nil:
+		if tlv.TLVs, err = ParseTLVs(value, *f.Nested); err != nil {
+			return tlv, 0, fmt.Errorf("tag %s: %w", tag, err)
+		}
+	case f.berTags() && tlv.Constructed():
+		if tlv.TLVs, err = ParseTLVs(value, f); err != nil {
+			return tlv, 0, fmt.Errorf("tag %s: %w", tag, err)
+		}
+	case f.transcoded():
+		if value, err = charmap.CodePage1047.NewDecoder().Bytes(value); err != nil {
+			return tlv, 0, fmt.Errorf("tag %s: %w", tag, err)
+		}
+	}
+
+	tlv.Value = append([]byte(nil), value...)
+
+	return tlv, offset + length, nil
+}
+
+// encodeTLV writes the data object
+func (f TLVFormat) encodeTLV(tlv TLV) ([]byte, error) {
+	value := tlv.Value
+
+	var err error
+
+	switch {
+	case tlv.TLVs != nil && f.Nested != nil:
+		value, err = tlv.TLVs.Marshal(*f.Nested)
+	case tlv.TLVs != nil:
+		value, err = tlv.TLVs.Marshal(f)
+	case f.transcoded():
+		value, err = charmap.CodePage1047.NewEncoder().Bytes(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tag %s: %w", tlv.Tag, err)
+	}
+
+	tag, err := f.encodeTag(tlv.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := f.encodeLength(len(value))
+	if err != nil {
+		return nil, fmt.Errorf("tag %s: %w", tlv.Tag, err)
+	}
+
+	return append(append(tag, length...), value...), nil
+}
+
+func (f TLVFormat) decodeTag(data []byte) (string, int, error) {
+	size := f.TagSize
+
+	if f.berTags() {
+		// The first byte tells whether more bytes follow, which flag whether another follows
+		size = 1
+		if len(data) > 0 && data[0]&berMoreBytes == berMoreBytes {
+			for size < len(data) && data[size]&0x80 != 0 {
+				size++
+			}
+			size++
+		}
+	}
+
+	if size > len(data) {
+		return "", 0, fmt.Errorf("tag is truncated, %d bytes left", len(data))
+	}
+
+	if !f.Text {
+		return strings.ToUpper(hex.EncodeToString(data[:size])), size, nil
+	}
+
+	tag, err := f.text(data[:size])
+	if err != nil {
+		return "", 0, err
+	}
+
+	return string(tag), size, nil
+}
+
+func (f TLVFormat) encodeTag(tag string) ([]byte, error) {
+	if f.Text {
+		if len(tag) != f.TagSize {
+			return nil, fmt.Errorf("tag %s is not %d digits", tag, f.TagSize)
+		}
+
+		return f.binary([]byte(tag))
+	}
+
+	data, err := hex.DecodeString(tag)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("tag %q is not hex", tag)
+	}
+
+	if f.TagSize > 0 && len(data) != f.TagSize {
+		return nil, fmt.Errorf("tag %s is not %d bytes", tag, f.TagSize)
+	}
+
+	return data, nil
+}
+
+func (f TLVFormat) decodeLength(data []byte) (int, int, error) {
+	size := f.LengthSize
+	if size == 0 && !f.Text {
+		return readBerLength(data)
+	}
+
+	if size == 0 || size > len(data) {
+		return 0, 0, fmt.Errorf("length is truncated, %d bytes left", len(data))
+	}
+
+	if !f.Text {
+		var length int
+		for _, b := range data[:size] {
+			length = length<<8 | int(b)
+		}
+
+		return length, size, nil
+	}
+
+	digits, err := f.text(data[:size])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	length, err := strconv.Atoi(string(digits))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid length %q", digits)
+	}
+
+	return length, size, nil
+}
+
+func (f TLVFormat) encodeLength(length int) ([]byte, error) {
+	switch {
+	case f.Text:
+		digits := fmt.Sprintf("%0*d", f.LengthSize, length)
+		if len(digits) > f.LengthSize {
+			return nil, fmt.Errorf("length %d does not fit %d digits", length, f.LengthSize)
+		}
+
+		return f.binary([]byte(digits))
+	case f.LengthSize == 0:
+		return berLength(length), nil
+	case f.LengthSize < 8 && length >= 1<<(8*f.LengthSize):
+		return nil, fmt.Errorf("length %d does not fit %d bytes", length, f.LengthSize)
+	}
+
+	data := make([]byte, f.LengthSize)
+	for i := f.LengthSize - 1; i >= 0; i-- {
+		data[i] = byte(length)
+		length >>= 8
+	}
+
+	return data, nil
+}
+
+// text returns the text of the tags and lengths of Text formats
+func (f TLVFormat) text(data []byte) ([]byte, error) {
+	if !f.Ebcdic {
+		return data, nil
+	}
+
+	return charmap.CodePage1047.NewDecoder().Bytes(data) //nolint:wrapcheck
+}
+
+// binary returns the bytes of the text of tags and lengths of Text formats
+func (f TLVFormat) binary(text []byte) ([]byte, error) {
+	if !f.Ebcdic {
+		return text, nil
+	}
+
+	return charmap.CodePage1047.NewEncoder().Bytes(text) //nolint:wrapcheck
+}
+
+// readBerLength reads a BER-TLV length: one byte up to 127, otherwise a byte with the number of bytes that
+// follow
+func readBerLength(data []byte) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, errors.New("length is missing")
+	}
+
+	if data[0]&berLongForm == 0 {
+		return int(data[0]), 1, nil
+	}
+
+	size := int(data[0] &^ berLongForm)
+	if size == 0 || size > 4 {
+		return 0, 0, fmt.Errorf("unsupported length of %d bytes", size)
+	}
+
+	if 1+size > len(data) {
+		return 0, 0, fmt.Errorf("length is truncated, %d bytes left", len(data))
+	}
+
+	var length int
+	for _, b := range data[1 : 1+size] {
+		length = length<<8 | int(b)
+	}
+
+	return length, 1 + size, nil
+}
+
+// berLength returns a BER-TLV length
+func berLength(length int) []byte {
+	if length < berLongForm {
+		return []byte{byte(length)}
+	}
+
+	var data []byte
+	for ; length > 0; length >>= 8 {
+		data = append([]byte{byte(length)}, data...)
+	}
+
+	return append([]byte{berLongForm | byte(len(data))}, data...)
+}
+
+// readBerLengthFrom reads a BER-TLV length from r
+func readBerLengthFrom(r io.Reader) (int, error) {
+	first := make([]byte, 1)
+	if _, err := io.ReadFull(r, first); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	if first[0]&berLongForm == 0 {
+		return int(first[0]), nil
+	}
+
+	data := make([]byte, 1+int(first[0]&^berLongForm))
+	data[0] = first[0]
+	if _, err := io.ReadFull(r, data[1:]); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	length, _, err := readBerLength(data)
+
+	return length, err
+}
+
+// tlvEncoder passes the value of a data object through, the tag and length are written by Encode and read by
+// the subfields of the struct
 type tlvEncoder struct {
 	w   io.Writer
 	r   io.Reader
@@ -17,6 +460,11 @@ func (e tlvEncoder) Write(bytes []byte) (n int, err error) {
 	return e.w.Write(bytes)
 }
 
+func (e tlvEncoder) Read(value []byte) (n int, err error) {
+	return e.r.Read(value)
+}
+
+// WriteTlvTag returns the bytes of a tag in hex
 func WriteTlvTag(tag string) (packed []byte, err error) {
 	tagBytes := make([]byte, hex.DecodedLen(len(tag)))
 
@@ -28,20 +476,3 @@ func WriteTlvTag(tag string) (packed []byte, err error) {
 
 	return packed, nil
 }
-
-func (e tlvEncoder) Read(value []byte) (n int, err error) {
-	err = binary.Read(e.r, binary.BigEndian, e.tag)
-	if err != nil {
-		return 0, fmt.Errorf("error reading TLV tag %s: %w", e.tag, err)
-	}
-
-	err = binary.Read(e.r, binary.BigEndian, value)
-	if err != nil {
-		return 0, fmt.Errorf("error reading TLV value %s: %w", value, err)
-	}
-
-	out := make([]byte, 0)
-	length, _ := io.ReadFull(e.r, out)
-
-	return length, nil
-}
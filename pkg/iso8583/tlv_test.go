@@ -0,0 +1,277 @@
+package iso8583_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	data, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestParseTLVs(t *testing.T) {
+	long := strings.Repeat("AB", 200)
+
+	tests := []struct {
+		name     string
+		format   iso8583.TLVFormat
+		data     string
+		expected iso8583.TLVs
+	}{
+		{
+			name:   "ber",
+			format: iso8583.BerTLV,
+			// Amount, a 2 byte tag, a constructed tag, an unknown 3 byte tag and a long length
+			data: "9F02 06 000000001234" +
+				"70 07 5A 05 5200000000" +
+				"DF8101 01 01" +
+				"C1 81C8 " + long,
+			expected: iso8583.TLVs{
+				{Tag: "9F02", Value: unhex(t, "000000001234")},
+				{Tag: "70", Value: unhex(t, "5A055200000000"), TLVs: iso8583.TLVs{{Tag: "5A", Value: unhex(t, "5200000000")}}},
+				{Tag: "DF8101", Value: unhex(t, "01")},
+				{Tag: "C1", Value: unhex(t, long)},
+			},
+		},
+		{
+			name: "visa datasets",
+			format: iso8583.TLVFormat{
+				TagSize: 1, LengthSize: 2, Nested: &iso8583.TLVFormat{Ebcdic: true},
+			},
+			// Dataset 01 with tag 86 "2.2.0" and dataset 4A with tag 87 "1"
+			data: "01 0007 86 05 F24BF24BF0" + "4A 0003 87 01 F1",
+			expected: iso8583.TLVs{
+				{Tag: "01", Value: unhex(t, "8605F24BF24BF0"), TLVs: iso8583.TLVs{{Tag: "86", Value: []byte("2.2.0")}}},
+				{Tag: "4A", Value: unhex(t, "8701F1"), TLVs: iso8583.TLVs{{Tag: "87", Value: []byte("1")}}},
+			},
+		},
+		{
+			name:   "ebcdic subelements",
+			format: iso8583.TLVFormat{TagSize: 2, LengthSize: 2, Text: true, Ebcdic: true},
+			// Subelement 87 "M" and 99 "XYZ"
+			data: "F8F7 F0F1 D4" + "F9F9 F0F3 E7E8E9",
+			expected: iso8583.TLVs{
+				{Tag: "87", Value: []byte("M")},
+				{Tag: "99", Value: []byte("XYZ")},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := unhex(t, test.data)
+
+			tlvs, err := iso8583.ParseTLVs(data, test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tlvs, test.expected) {
+				t.Fatalf("Expected %+v, got %+v", test.expected, tlvs)
+			}
+
+			// All data objects are written again as they were
+			encoded, err := tlvs.Marshal(test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(data, encoded) {
+				t.Fatalf("Expected %X, got %X", data, encoded)
+			}
+		})
+	}
+}
+
+func TestTLVsEdit(t *testing.T) {
+	tlvs, err := iso8583.ParseTLVs(unhex(t, "00 9F02 06 000000001234 00 70 07 5A 05 5200000000 00"), iso8583.BerTLV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tlvs) != 2 {
+		t.Fatalf("Expected the padding to be skipped, got %+v", tlvs)
+	}
+
+	tlvs.Set("9f02", unhex(t, "000000000500"))
+	tlvs.Set("9F03", unhex(t, "000000000000"))
+
+	if amount, ok := tlvs.Find("9F02"); !ok || !bytes.Equal(amount.Value, unhex(t, "000000000500")) {
+		t.Errorf("Expected 9F02 to be replaced, got %+v", amount)
+	}
+
+	// The PAN in the constructed data object is left out
+	data, err := tlvs.Without("5A").Marshal(iso8583.BerTLV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := unhex(t, "9F02 06 000000000500 70 00 9F03 06 000000000000"); !bytes.Equal(data, expected) {
+		t.Errorf("Expected %X, got %X", expected, data)
+	}
+
+	for _, invalid := range []string{"9F", "9F02", "9F02 06 0000", "5A 84 0000000001"} {
+		if _, err := iso8583.ParseTLVs(unhex(t, invalid), iso8583.BerTLV); err == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestTLVSubfields(t *testing.T) {
+	type chipData struct {
+		Amount     string              `iso8583:"1=b..6, lenenc=ber, dataenc=tlv, tlvTag=9F02, omitempty"`
+		Cryptogram string              `iso8583:"2=b..8, lenenc=ber, dataenc=tlv, tlvTag=9F26, omitempty"`
+		Undefined  iso8583.RawElements // The tags that are not defined above
+	}
+
+	type elements struct {
+		PrimaryAccountNumber string   `iso8583:"2=n..19"`
+		ChipData             chipData `iso8583:"55=b...255"`
+	}
+
+	// The cryptogram comes before the amount, 9F10 is not defined
+	chip := unhex(t, "9F26 08 0102030405060708 9F10 03 010203 9F02 06 000000001234")
+
+	mti := iso8583.NewMti("0100")
+	var sent bytes.Buffer
+	if err := iso8583.NewEncoder(&sent, iso8583.FormatAscii, iso8583.NoRdwLayout).EncodeIso8583(mti, &struct {
+		PrimaryAccountNumber string `iso8583:"2=n..19"`
+		ChipData             string `iso8583:"55=b...255"`
+	}{"5200000000000007", string(chip)}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		decodedMti iso8583.MTI
+		decoded    elements
+	)
+	if err := iso8583.NewDecoder(bytes.NewReader(sent.Bytes()), iso8583.FormatAscii, iso8583.NoRdwLayout).DecodeIso8583(&decodedMti, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.ChipData.Amount != string(unhex(t, "000000001234")) || decoded.ChipData.Cryptogram != string(unhex(t, "0102030405060708")) {
+		t.Fatalf("Expected the amount and cryptogram, got %+v", decoded.ChipData)
+	}
+
+	if raw := decoded.ChipData.Undefined[0x9F10]; !bytes.Equal(raw, unhex(t, "9F10 03 010203")) {
+		t.Fatalf("Expected tag 9F10 to be kept, got %X", raw)
+	}
+
+	// The defined tags are written in the order of their numbers, the others after them
+	var buf bytes.Buffer
+	if err := iso8583.NewEncoder(&buf, iso8583.FormatAscii, iso8583.NoRdwLayout).EncodeIso8583(mti, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	tlvs, err := iso8583.ParseTLVs(buf.Bytes()[len(buf.Bytes())-len(chip):], iso8583.BerTLV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []string
+	for _, tlv := range tlvs {
+		tags = append(tags, tlv.Tag)
+	}
+
+	if expected := []string{"9F02", "9F26", "9F10"}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("Expected tags %v, got %v", expected, tags)
+	}
+}
+
+func TestBinaryLengthTLVSubfields(t *testing.T) {
+	type senderData struct {
+		Name      string              `iso8583:"3=b..30, dataenc=ebcdic, lenenc=hexBit4, tlvTag=03, omitempty"`
+		Undefined iso8583.RawElements // The tags that are not defined above
+	}
+
+	type datasets struct {
+		Sender    senderData          `iso8583:"2=b....252, dataenc=tlv, lenenc=hexBit4, tlvTag=5F, omitempty"`
+		Undefined iso8583.RawElements // The datasets that are not defined above
+	}
+
+	type elements struct {
+		PrimaryAccountNumber string   `iso8583:"2=n..19"`
+		TransactionData      datasets `iso8583:"104=b.255, lenenc=bin"`
+	}
+
+	// Like the Visa datasets, the dataset IDs and tags are a byte: 5F is not the start of a 2 byte tag. Tag 0A and
+	// dataset 57 are not defined.
+	data := unhex(t, "5F 000A 03 04 D1968895 0A 02 C1C2 57 0004 01 02 D7D7")
+
+	mti := iso8583.NewMti("0100")
+	var sent bytes.Buffer
+	if err := iso8583.NewEncoder(&sent, iso8583.FormatAscii, iso8583.NoRdwLayout).EncodeIso8583(mti, &struct {
+		PrimaryAccountNumber string `iso8583:"2=n..19"`
+		TransactionData      string `iso8583:"104=b.255, lenenc=bin"`
+	}{"4111111111111111", string(data)}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		decodedMti iso8583.MTI
+		decoded    elements
+	)
+	if err := iso8583.NewDecoder(bytes.NewReader(sent.Bytes()), iso8583.FormatAscii, iso8583.NoRdwLayout).DecodeIso8583(&decodedMti, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.TransactionData.Sender.Name != "John" {
+		t.Fatalf("Expected the sender name John, got %+v", decoded.TransactionData.Sender)
+	}
+
+	if raw := decoded.TransactionData.Sender.Undefined[0x0A]; !bytes.Equal(raw, unhex(t, "0A 02 C1C2")) {
+		t.Fatalf("Expected tag 0A to be kept, got %X", raw)
+	}
+
+	if raw := decoded.TransactionData.Undefined[0x57]; !bytes.Equal(raw, unhex(t, "57 0004 01 02 D7D7")) {
+		t.Fatalf("Expected dataset 57 to be kept, got %X", raw)
+	}
+}
+
+func TestTLVsElement(t *testing.T) {
+	type elements struct {
+		PrimaryAccountNumber string       `iso8583:"2=n..19"`
+		ChipData             iso8583.TLVs `iso8583:"55=b...255, lenenc=ebcdic"`
+	}
+
+	chip := unhex(t, "9F26 08 0102030405060708 9F02 06 000000001234")
+	tlvs, err := iso8583.ParseTLVs(chip, iso8583.BerTLV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mti := iso8583.NewMti("0100")
+	var buf bytes.Buffer
+	if err := iso8583.NewEncoder(&buf, iso8583.FormatEbcdic, iso8583.NoRdwLayout).EncodeIso8583(mti, &elements{"5200000000000007", tlvs}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The binary data objects follow the length digits in EBCDIC
+	if expected := append([]byte{0xF0, 0xF2, 0xF0}, chip...); !bytes.HasSuffix(buf.Bytes(), expected) {
+		t.Fatalf("Expected element 55 to be %X, got %X", expected, buf.Bytes())
+	}
+
+	var (
+		decodedMti iso8583.MTI
+		decoded    elements
+	)
+	if err := iso8583.NewDecoder(bytes.NewReader(buf.Bytes()), iso8583.FormatEbcdic, iso8583.NoRdwLayout).DecodeIso8583(&decodedMti, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded.ChipData, tlvs) {
+		t.Fatalf("Expected %+v, got %+v", tlvs, decoded.ChipData)
+	}
+}
@@ -1,5 +1,9 @@
 package cis
 
+import "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
+//go:generate go run ../../iso8583/generate/generate.go -out iso8583_accessors.go
+
 // All the data elements for CIS that are needed
 // Fields that have a 'subfields' comment are complex DE's that currently do not have a specific struct to implement their SF's
 //
@@ -20,6 +24,7 @@ type DataElements struct {
 	DE18_MerchantType                      string                            `iso8583:"18=n-4"`
 	DE20_PrimaryAccountNumberCountryCode   string                            `iso8583:"20=n-3, justify=right"`
 	DE22_PointOfServiceEntryMode           string                            `iso8583:"22=n-3"`
+	DE23_CardSequenceNumber                string                            `iso8583:"23=n-3, justify=right"`
 	DE28_TransactionFeeAmount              *DE28_TransactionFeeAmount        `iso8583:"28=an-9"`
 	DE32_AcquringInstitutionCode           string                            `iso8583:"32=n..6"`
 	DE33_ForwardingInstitutionIDCode       string                            `iso8583:"33=n..6"`
@@ -27,7 +32,7 @@ type DataElements struct {
 	DE37_RetrievalReferenceNumber          string                            `iso8583:"37=an-12"` // Subfields
 	DE38_AuthorizationIdResponse           string                            `iso8583:"38=ans-6, justify=left"`
 	DE39_ResponseCode                      string                            `iso8583:"39=an-2"`
-	DE41_CardAcceptorTerminalId            string                            `iso8583:"41=ans-8"`
+	DE41_CardAcceptorTerminalId            string                            `iso8583:"41=ans-8, justify=left"`
 	DE42_CardAcceptorCodeId                string                            `iso8583:"42=ans-15, justify=left"`
 	DE43_CardAcceptorNameAndLocation       *DE43_CardAcceptorNameAndLocation `iso8583:"43=ans-40"`
 	DE44_AdditionalResponseData            string                            `iso8583:"44=ans..25"`
@@ -37,6 +42,7 @@ type DataElements struct {
 	DE52_PinData                           string                            `iso8583:"52=b-8"`
 	DE53_SecurityRelatedControlInformation string                            `iso8583:"53=n-16"` // Subfields
 	DE54_AdditionalAmounts                 []DE54_AmountsAdditional          `iso8583:"54=ans...120"`
+	DE55_IntegratedCircuitCardData         iso8583.TLVs                      `iso8583:"55=b...255, lenenc=ebcdic"` // EMV chip data objects
 	DE56_PaymentAccountData                string                            `iso8583:"56=an...37"`
 	DE60_AdviceReasonCode                  string                            `iso8583:"60=ans...60"` // Subfields
 	DE61_PointOfServiceData                *DE61_PointOfServiceData          `iso8583:"61=ans...26"`
@@ -46,7 +52,7 @@ type DataElements struct {
 	DE94_ServiceIndicator                  string                            `iso8583:"94=ans-7"`
 	DE95_ReplacementAmounts                *DE95_ReplacementAmounts          `iso8583:"95=n-42"`
 	DE96_MessageSecurityCode               string                            `iso8583:"96=n-8"`
-	DE108_MoneySendReferenceData           string                            `iso8583:"108=ans...999"`
+	DE108_MoneySendReferenceData           *DE108_MoneySendReferenceData     `iso8583:"108=ans...999"`
 	DE112_AdditionalDataNationalUse        string                            `iso8583:"112=ans...591"`
 	DE120_RecordData                       string                            `iso8583:"120=ans...999"` // Subfields
 	DE121_AuthorizingAgentIDCode           string                            `iso8583:"121=n...6"`     // Subfields
@@ -54,4 +60,7 @@ type DataElements struct {
 	DE123_ReceiptFreeText                  string                            `iso8583:"123=ans...512"`
 	DE126_PrivateData                      string                            `iso8583:"126=ans...100"`
 	DE127_PrivateData                      string                            `iso8583:"127=ans...100"`
+
+	// UndefinedElements holds the elements that are not defined above, read with the UndefinedFormats
+	UndefinedElements iso8583.RawElements
 }
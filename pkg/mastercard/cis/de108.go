@@ -1,12 +1,198 @@
 package cis
 
-type DE108_AdditionalTransactionReferenceData struct {
-	_ string `iso8583:"1=ans...322"` // no idea why this is used //nolint:gofmt
-	_ string `iso8583:"2=ans...322"` // no idea why this is used //nolint:gofmt
-	// actual data
-	SE03_TransactionReferenceData *DE108_SE03_TransactionReferenceData `iso8583:"3=ans...138"`
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+)
+
+// DE108_MoneySendReferenceData holds the receiver, sender and transaction data of a MoneySend transaction. Each
+// subelement is its 2 digit ID, a 3 digit length and its subfields, each of which is its 2 digit ID, a 2 digit length
+// and its data.
+type DE108_MoneySendReferenceData struct {
+	SE01_ReceiverData             *DE108_CustomerData                  `iso8583:"1=ans...322, omitempty"`
+	SE02_SenderData               *DE108_CustomerData                  `iso8583:"2=ans...322, omitempty"`
+	SE03_TransactionReferenceData *DE108_SE03_TransactionReferenceData `iso8583:"3=ans...138, omitempty"`
+
+	// UndefinedSubelements holds the subelements that are not defined above, their length and data by number
+	UndefinedSubelements iso8583.RawElements
+}
+
+// MarshalIso8583 marshals the DE108_MoneySendReferenceData subelements
+func (de *DE108_MoneySendReferenceData) MarshalIso8583() ([]byte, error) {
+	return marshalTagged(de, 3, de.UndefinedSubelements)
+}
+
+// UnmarshalIso8583 unmarshals the DE108_MoneySendReferenceData subelements
+func (de *DE108_MoneySendReferenceData) UnmarshalIso8583(d []byte) error {
+	return unmarshalTagged(de, d, 3, &de.UndefinedSubelements)
+}
+
+// DE108_CustomerData is the data of the receiver in subelement 01 and of the sender in subelement 02
+type DE108_CustomerData struct {
+	SF01_FirstName     string `iso8583:"1=ans..35, omitempty"`
+	SF02_MiddleName    string `iso8583:"2=ans..1, omitempty"`
+	SF03_LastName      string `iso8583:"3=ans..35, omitempty"`
+	SF04_StreetAddress string `iso8583:"4=ans..50, omitempty"`
+	SF05_City          string `iso8583:"5=ans..25, omitempty"`
+	SF06_StateCode     string `iso8583:"6=ans..3, omitempty"`
+	SF07_CountryCode   string `iso8583:"7=ans..3, omitempty"` // ISO alpha-3 country code
+	SF08_PostalCode    string `iso8583:"8=ans..10, omitempty"`
+	SF09_PhoneNumber   string `iso8583:"9=ans..20, omitempty"`
+	SF10_DateOfBirth   string `iso8583:"10=n..8, omitempty"` // MMDDYYYY
+	SF11_AccountNumber string `iso8583:"11=ans..50, omitempty"`
+
+	// UndefinedSubfields holds the subfields that are not defined above, their length and data by number
+	UndefinedSubfields iso8583.RawElements
+}
+
+// MarshalIso8583 marshals the DE108_CustomerData subfields
+func (se *DE108_CustomerData) MarshalIso8583() ([]byte, error) {
+	return marshalTagged(se, 2, se.UndefinedSubfields)
+}
+
+// UnmarshalIso8583 unmarshals the DE108_CustomerData subfields
+func (se *DE108_CustomerData) UnmarshalIso8583(d []byte) error {
+	return unmarshalTagged(se, d, 2, &se.UndefinedSubfields)
 }
 
 type DE108_SE03_TransactionReferenceData struct {
-	SF01_UniqueTransactionReference string `iso8583:"1=ans..19"`
+	SF01_UniqueTransactionReference string `iso8583:"1=ans..19, omitempty"`
+	SF02_AdditionalMessage          string `iso8583:"2=ans..65, omitempty"`
+
+	// UndefinedSubfields holds the subfields that are not defined above, their length and data by number
+	UndefinedSubfields iso8583.RawElements
+}
+
+// MarshalIso8583 marshals the DE108_SE03_TransactionReferenceData subfields
+func (se *DE108_SE03_TransactionReferenceData) MarshalIso8583() ([]byte, error) {
+	return marshalTagged(se, 2, se.UndefinedSubfields)
+}
+
+// UnmarshalIso8583 unmarshals the DE108_SE03_TransactionReferenceData subfields
+func (se *DE108_SE03_TransactionReferenceData) UnmarshalIso8583(d []byte) error {
+	return unmarshalTagged(se, d, 2, &se.UndefinedSubfields)
+}
+
+// marshalTagged writes the non-empty subelements or subfields of v in order, each after its 2 digit ID and a length
+// of the given number of digits. The undefined ones are written as they were read after them.
+//
+//nolint:wrapcheck
+func marshalTagged(v interface{}, lengthIndicator int, undefined iso8583.RawElements) ([]byte, error) {
+	definitions, err := iso8583.StructDefinitions(v)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int, 0, len(definitions))
+	for number := range definitions {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	values := reflect.Indirect(reflect.ValueOf(v))
+
+	var buf bytes.Buffer
+	for _, number := range numbers {
+		element := definitions[number]
+		field := values.Field(element.Field)
+		if field.IsZero() {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(&buf, "%02d", number); err != nil {
+			return nil, iso8583.NewElementError(number, err)
+		}
+
+		element.LengthIndicator = lengthIndicator
+		if err := iso8583.Encode(field.Interface(), element, &buf); err != nil {
+			return nil, iso8583.NewElementError(number, err)
+		}
+	}
+
+	for _, number := range undefined.Numbers() {
+		if _, ok := definitions[number]; ok {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(&buf, "%02d%s", number, undefined[number]); err != nil {
+			return nil, iso8583.NewElementError(number, err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unmarshalTagged reads the subelements or subfields of v in any order, each after its 2 digit ID and a length of the
+// given number of digits. The ones v does not define are kept in undefined with their length.
+//
+//nolint:wrapcheck
+func unmarshalTagged(v interface{}, d []byte, lengthIndicator int, undefined *iso8583.RawElements) error {
+	definitions, err := iso8583.StructDefinitions(v)
+	if err != nil {
+		return err
+	}
+
+	values := reflect.Indirect(reflect.ValueOf(v))
+	r := bytes.NewBuffer(d)
+
+	for r.Len() > 0 {
+		id := make([]byte, 2)
+		if _, err := io.ReadFull(r, id); err != nil {
+			return fmt.Errorf("could not read ID; %w", err)
+		}
+
+		number, err := strconv.Atoi(string(id))
+		if err != nil {
+			return fmt.Errorf("invalid ID; %w", err)
+		}
+
+		element, ok := definitions[number]
+		if !ok {
+			raw, err := readTagged(r, lengthIndicator)
+			if err != nil {
+				return iso8583.NewElementError(number, err)
+			}
+
+			if *undefined == nil {
+				*undefined = iso8583.RawElements{}
+			}
+			(*undefined)[number] = raw
+
+			continue
+		}
+
+		element.LengthIndicator = lengthIndicator
+		field := iso8583.StructField(values, element.Field)
+		if err := iso8583.Decode(field.Addr().Interface(), element, r, iso8583.LengthEncodingAscii); err != nil {
+			return iso8583.NewElementError(number, err)
+		}
+	}
+
+	return nil
+}
+
+// readTagged reads the length and data of a subelement or subfield that is not defined
+func readTagged(r io.Reader, lengthIndicator int) ([]byte, error) {
+	length := make([]byte, lengthIndicator)
+	if _, err := io.ReadFull(r, length); err != nil {
+		return nil, fmt.Errorf("could not read length; %w", err)
+	}
+
+	n, err := strconv.Atoi(string(length))
+	if err != nil {
+		return nil, fmt.Errorf("invalid length; %w", err)
+	}
+
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("could not read data; %w", err)
+	}
+
+	return append(length, data...), nil
 }
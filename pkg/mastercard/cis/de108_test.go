@@ -0,0 +1,46 @@
+package cis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+func TestDE108MoneySendReferenceData(t *testing.T) {
+	de := cis.DE108_MoneySendReferenceData{
+		SE01_ReceiverData: &cis.DE108_CustomerData{SF01_FirstName: "Jane", SF03_LastName: "Doe"},
+		SE02_SenderData: &cis.DE108_CustomerData{
+			SF01_FirstName:     "John",
+			SF03_LastName:      "Smith",
+			SF07_CountryCode:   "NLD",
+			SF11_AccountNumber: "NL91ABNA0417164300",
+		},
+	}
+
+	data, err := de.MarshalIso8583()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The subelements have a 3 digit length, their subfields a 2 digit length
+	expected := "01015" + "0104Jane" + "0303Doe" +
+		"02046" + "0104John" + "0305Smith" + "0703NLD" + "1118NL91ABNA0417164300"
+	if string(data) != expected {
+		t.Fatalf("Expected %s, got %s", expected, data)
+	}
+
+	var out cis.DE108_MoneySendReferenceData
+	if err := out.UnmarshalIso8583(append(data, "99003XYZ"...)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(out.SE01_ReceiverData, de.SE01_ReceiverData) || !reflect.DeepEqual(out.SE02_SenderData, de.SE02_SenderData) {
+		t.Fatalf("Expected %+v and %+v, got %+v and %+v", de.SE01_ReceiverData, de.SE02_SenderData, out.SE01_ReceiverData, out.SE02_SenderData)
+	}
+
+	// Subelement 99 is not defined, it is kept with its length
+	if raw := string(out.UndefinedSubelements[99]); raw != "003XYZ" {
+		t.Fatalf("Expected subelement 99 to be kept with its length, got %q", raw)
+	}
+}
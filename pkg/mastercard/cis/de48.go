@@ -48,6 +48,9 @@ type DE48_AdditionalData struct {
 	SE90_LodgingAndAutoRentalIndicator              string                                  `iso8583:"90=a-1"`
 	SE92_CardholderVerificationCode                 string                                  `iso8583:"92=n-3"`
 	SE95_PromotionCode                              string                                  `iso8583:"95=an-6"`
+
+	// UndefinedSubelements holds the subelements that are not defined above, their length and data by number
+	UndefinedSubelements iso8583.RawElements
 }
 
 // MarshalIso8583 marshals the DE48_AdditionalData subelements
@@ -101,9 +104,46 @@ func (de *DE48_AdditionalData) MarshalIso8583() ([]byte, error) {
 		}
 	}
 
+	// The subelements we do not know are written as they were read, after their tag
+	for _, number := range de.UndefinedSubelements.Numbers() {
+		if _, ok := definitions[number]; ok {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(&buf, "%02d%s", number, de.UndefinedSubelements[number]); err != nil {
+			return nil, fmt.Errorf(`cannot write de48/se%s: %w`, strconv.Itoa(number), err)
+		}
+	}
+
 	return buf.Bytes(), nil
 }
 
+// keepUndefined reads a subelement that is not defined, its 2 digit length and data, into the undefined
+// subelements
+func (de *DE48_AdditionalData) keepUndefined(number int, r *bytes.Buffer) error {
+	length := make([]byte, 2)
+	if _, err := io.ReadFull(r, length); err != nil {
+		return fmt.Errorf("could not read length of de48/se%s; %w", strconv.Itoa(number), err)
+	}
+
+	n, err := strconv.Atoi(string(length))
+	if err != nil {
+		return fmt.Errorf("invalid length of de48/se%s; %w", strconv.Itoa(number), err)
+	}
+
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return fmt.Errorf("could not read de48/se%s; %w", strconv.Itoa(number), err)
+	}
+
+	if de.UndefinedSubelements == nil {
+		de.UndefinedSubelements = iso8583.RawElements{}
+	}
+	de.UndefinedSubelements[number] = append(length, data...)
+
+	return nil
+}
+
 //nolint:wrapcheck
 func (de *DE48_AdditionalData) UnmarshalIso8583(d []byte) error {
 	r := bytes.NewBuffer(d)
@@ -143,7 +183,12 @@ func (de *DE48_AdditionalData) UnmarshalIso8583(d []byte) error {
 
 		element, ok := definitions[number]
 		if !ok {
-			return fmt.Errorf("missing definition for SE %d", number)
+			// Subelements carry their length, so the ones we do not know are kept as they are
+			if err := de.keepUndefined(number, r); err != nil {
+				return err
+			}
+
+			continue
 		}
 
 		// The next two bytes of each SE must contain the subelement length, a numeric value in the
@@ -222,6 +267,10 @@ type DE48_SE42_ElectronicCommerceIndicators struct {
 }
 
 func NewDE48_SE42_ElectronicCommerceIndicators(i entity.SLI) *DE48_SE42_ElectronicCommerceIndicators {
+	if (i == entity.SLI{}) {
+		return nil
+	}
+
 	return &DE48_SE42_ElectronicCommerceIndicators{
 		SF1_SecurityLevelIndicatorAndUCAFCollectionIndicator: fmt.Sprintf("%d%d%d", i.SecurityProtocol, i.CardholderAuthentication, i.UCAFCollectionIndicator),
 	}
@@ -310,6 +359,16 @@ type DE48_SE61_ExtendedConditionCodes struct {
 	SF5_FinalAuthorizationIndicator                int `iso8583:"5=n-1, autofill"`
 }
 
+func NewDE48_SE61_ExtendedConditionCodes(partialApprovalSupported bool) *DE48_SE61_ExtendedConditionCodes {
+	if !partialApprovalSupported {
+		return nil
+	}
+
+	return &DE48_SE61_ExtendedConditionCodes{
+		SF1_PartialApprovalTerminalSupportIndicator: 1,
+	}
+}
+
 type DE48_SE63_TraceId struct {
 	SF1_NetworkData    string `iso8583:"1=ans-9,omitempty"`
 	SF2_DateSettlement string `iso8583:"2=ans-6,omitempty,justify=right"`
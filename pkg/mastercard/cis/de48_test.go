@@ -0,0 +1,53 @@
+package cis_test
+
+import (
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+func TestDE48UndefinedSubelements(t *testing.T) {
+	// Subelement 99 is not defined, 87 is
+	var de cis.DE48_AdditionalData
+	if err := de.UnmarshalIso8583([]byte("T9903XYZ8701M")); err != nil {
+		t.Fatal(err)
+	}
+
+	if de.TransactionCategoryCode != "T" || de.SE87_Cvv2Response != "M" {
+		t.Fatalf("Expected the defined subelements to be decoded, got %#v", de)
+	}
+
+	if raw := string(de.UndefinedSubelements[99]); raw != "03XYZ" {
+		t.Fatalf("Expected subelement 99 to be kept with its length, got %q", raw)
+	}
+
+	// The undefined subelements are written after the defined ones
+	data, err := de.MarshalIso8583()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "T8701M9903XYZ" {
+		t.Fatalf("Expected T8701M9903XYZ, got %s", data)
+	}
+}
+
+func TestDE48ExtendedConditionCodes(t *testing.T) {
+	de := cis.DE48_AdditionalData{
+		TransactionCategoryCode:     "R",
+		SE61_ExtendedConditionCodes: cis.NewDE48_SE61_ExtendedConditionCodes(true),
+	}
+
+	data, err := de.MarshalIso8583()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "R610510000" {
+		t.Fatalf("Expected R610510000, got %s", data)
+	}
+
+	if cis.NewDE48_SE61_ExtendedConditionCodes(false) != nil {
+		t.Fatal("Expected no extended condition codes without partial approval support")
+	}
+}
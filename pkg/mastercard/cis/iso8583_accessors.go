@@ -0,0 +1,1010 @@
+// Code generated by iso8583/generate, DO NOT EDIT.
+// Generated on Mon Oct 19 16:18 2026
+package cis
+
+import iso8583 "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
+var (
+	_ iso8583.Accessor = (*DE108_CustomerData)(nil)
+	_ iso8583.Accessor = (*DE108_MoneySendReferenceData)(nil)
+	_ iso8583.Accessor = (*DE108_SE03_TransactionReferenceData)(nil)
+	_ iso8583.Accessor = (*DE28_TransactionFeeAmount)(nil)
+	_ iso8583.Accessor = (*DE43_CardAcceptorNameAndLocation)(nil)
+	_ iso8583.Accessor = (*DE48_AdditionalData)(nil)
+	_ iso8583.Accessor = (*DE48_SE22MultiPurposeMerchantIndicator)(nil)
+	_ iso8583.Accessor = (*DE48_SE33_PANMappingFileInformation)(nil)
+	_ iso8583.Accessor = (*DE48_SE37_AdditionalMerchantData)(nil)
+	_ iso8583.Accessor = (*DE48_SE42_ElectronicCommerceIndicators)(nil)
+	_ iso8583.Accessor = (*DE48_SE61_ExtendedConditionCodes)(nil)
+	_ iso8583.Accessor = (*DE48_SE63_TraceId)(nil)
+	_ iso8583.Accessor = (*DE48_SE66_AuthenticationData)(nil)
+	_ iso8583.Accessor = (*DE54_AmountsAdditional)(nil)
+	_ iso8583.Accessor = (*DE61_PointOfServiceData)(nil)
+	_ iso8583.Accessor = (*DE7_TransmissionDateAndTime)(nil)
+	_ iso8583.Accessor = (*DE90_OriginalDataElements)(nil)
+	_ iso8583.Accessor = (*DE95_ReplacementAmounts)(nil)
+	_ iso8583.Accessor = (*DataElements)(nil)
+)
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE108_CustomerData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF01_FirstName, v.SF01_FirstName == ""
+	case 2:
+		return v.SF02_MiddleName, v.SF02_MiddleName == ""
+	case 3:
+		return v.SF03_LastName, v.SF03_LastName == ""
+	case 4:
+		return v.SF04_StreetAddress, v.SF04_StreetAddress == ""
+	case 5:
+		return v.SF05_City, v.SF05_City == ""
+	case 6:
+		return v.SF06_StateCode, v.SF06_StateCode == ""
+	case 7:
+		return v.SF07_CountryCode, v.SF07_CountryCode == ""
+	case 8:
+		return v.SF08_PostalCode, v.SF08_PostalCode == ""
+	case 9:
+		return v.SF09_PhoneNumber, v.SF09_PhoneNumber == ""
+	case 10:
+		return v.SF10_DateOfBirth, v.SF10_DateOfBirth == ""
+	case 11:
+		return v.SF11_AccountNumber, v.SF11_AccountNumber == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE108_CustomerData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF01_FirstName
+	case 2:
+		return &v.SF02_MiddleName
+	case 3:
+		return &v.SF03_LastName
+	case 4:
+		return &v.SF04_StreetAddress
+	case 5:
+		return &v.SF05_City
+	case 6:
+		return &v.SF06_StateCode
+	case 7:
+		return &v.SF07_CountryCode
+	case 8:
+		return &v.SF08_PostalCode
+	case 9:
+		return &v.SF09_PhoneNumber
+	case 10:
+		return &v.SF10_DateOfBirth
+	case 11:
+		return &v.SF11_AccountNumber
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE108_MoneySendReferenceData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SE01_ReceiverData, v.SE01_ReceiverData == nil
+	case 2:
+		return v.SE02_SenderData, v.SE02_SenderData == nil
+	case 3:
+		return v.SE03_TransactionReferenceData, v.SE03_TransactionReferenceData == nil
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE108_MoneySendReferenceData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		if v.SE01_ReceiverData == nil {
+			v.SE01_ReceiverData = new(DE108_CustomerData)
+		}
+		return v.SE01_ReceiverData
+	case 2:
+		if v.SE02_SenderData == nil {
+			v.SE02_SenderData = new(DE108_CustomerData)
+		}
+		return v.SE02_SenderData
+	case 3:
+		if v.SE03_TransactionReferenceData == nil {
+			v.SE03_TransactionReferenceData = new(DE108_SE03_TransactionReferenceData)
+		}
+		return v.SE03_TransactionReferenceData
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE108_SE03_TransactionReferenceData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF01_UniqueTransactionReference, v.SF01_UniqueTransactionReference == ""
+	case 2:
+		return v.SF02_AdditionalMessage, v.SF02_AdditionalMessage == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE108_SE03_TransactionReferenceData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF01_UniqueTransactionReference
+	case 2:
+		return &v.SF02_AdditionalMessage
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE28_TransactionFeeAmount) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_DebitCreditIndicator, v.SF1_DebitCreditIndicator == ""
+	case 2:
+		return v.SF2_Amount, v.SF2_Amount == 0
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE28_TransactionFeeAmount) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_DebitCreditIndicator
+	case 2:
+		return &v.SF2_Amount
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE43_CardAcceptorNameAndLocation) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_Name, v.SF1_Name == ""
+	case 2:
+		return *new(string), true
+	case 3:
+		return v.SF3_City, v.SF3_City == ""
+	case 4:
+		return *new(string), true
+	case 5:
+		return v.SF5_StateOrCountryCode, v.SF5_StateOrCountryCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE43_CardAcceptorNameAndLocation) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_Name
+	case 2:
+		return new(string)
+	case 3:
+		return &v.SF3_City
+	case 4:
+		return new(string)
+	case 5:
+		return &v.SF5_StateOrCountryCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_AdditionalData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 15:
+		return v.SE15_AuthorizationSystemAdviceDateTime, v.SE15_AuthorizationSystemAdviceDateTime == ""
+	case 20:
+		return v.SE20_CardholderVerificationMethod, v.SE20_CardholderVerificationMethod == ""
+	case 22:
+		return v.SE22_MultiPurposeMerchantIndicator, v.SE22_MultiPurposeMerchantIndicator == nil
+	case 23:
+		return v.SE23_PaymentInitiationChannel, v.SE23_PaymentInitiationChannel == ""
+	case 26:
+		return v.SE26_WalletProgramData, v.SE26_WalletProgramData == ""
+	case 32:
+		return v.SE32_MastercardAssignedId, v.SE32_MastercardAssignedId == ""
+	case 33:
+		return v.SE33_PanMappingFileInformation, v.SE33_PanMappingFileInformation == nil
+	case 36:
+		return v.SE36_VisaMvv, v.SE36_VisaMvv == ""
+	case 37:
+		return v.SE37_AdditionalMerchantData, v.SE37_AdditionalMerchantData == nil
+	case 42:
+		return v.SE42_ElectronicCommerceIndicators, v.SE42_ElectronicCommerceIndicators == nil
+	case 43:
+		return v.SE43_UniversalCardholderAuthenticationField, v.SE43_UniversalCardholderAuthenticationField == ""
+	case 44:
+		return v.SE44_TransactionIdentifier, v.SE44_TransactionIdentifier == ""
+	case 51:
+		return v.SE51_MerchantOnBehalfServices, v.SE51_MerchantOnBehalfServices == ""
+	case 52:
+		return v.SE52_TransactionIntegretyClass, v.SE52_TransactionIntegretyClass == ""
+	case 55:
+		return v.SE55_MerchantFraudScoringData, v.SE55_MerchantFraudScoringData == ""
+	case 57:
+		return v.SE57_SecurityServicesAdditionalDataForAcquirers, v.SE57_SecurityServicesAdditionalDataForAcquirers == ""
+	case 61:
+		return v.SE61_ExtendedConditionCodes, v.SE61_ExtendedConditionCodes == nil
+	case 63:
+		return v.SE63_TraceId, v.SE63_TraceId == nil
+	case 64:
+		return v.SE64_TransitProgram, v.SE64_TransitProgram == ""
+	case 66:
+		return v.SE66_AuthenticationData, v.SE66_AuthenticationData == nil
+	case 67:
+		return v.SE67_MoneySendInformation, v.SE67_MoneySendInformation == ""
+	case 76:
+		return v.SE76_ElectronicAcceptanceIndicator, v.SE76_ElectronicAcceptanceIndicator == ""
+	case 77:
+		return v.SE77_TransactionTypeIdentifier, v.SE77_TransactionTypeIdentifier == ""
+	case 80:
+		return v.SE80_PinServiceCode, v.SE80_PinServiceCode == ""
+	case 82:
+		return v.SE82_AddressVerificationServiceRequest, v.SE82_AddressVerificationServiceRequest == ""
+	case 83:
+		return v.SE83_AddressVerificationServiceResponse, v.SE83_AddressVerificationServiceResponse == ""
+	case 84:
+		return v.SE84_MerchantAdviceCode, v.SE84_MerchantAdviceCode == ""
+	case 86:
+		return v.SE86_RelationshipParticipantIndicator, v.SE86_RelationshipParticipantIndicator == ""
+	case 87:
+		return v.SE87_Cvv2Response, v.SE87_Cvv2Response == ""
+	case 90:
+		return v.SE90_LodgingAndAutoRentalIndicator, v.SE90_LodgingAndAutoRentalIndicator == ""
+	case 92:
+		return v.SE92_CardholderVerificationCode, v.SE92_CardholderVerificationCode == ""
+	case 95:
+		return v.SE95_PromotionCode, v.SE95_PromotionCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_AdditionalData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 15:
+		return &v.SE15_AuthorizationSystemAdviceDateTime
+	case 20:
+		return &v.SE20_CardholderVerificationMethod
+	case 22:
+		if v.SE22_MultiPurposeMerchantIndicator == nil {
+			v.SE22_MultiPurposeMerchantIndicator = new(DE48_SE22MultiPurposeMerchantIndicator)
+		}
+		return v.SE22_MultiPurposeMerchantIndicator
+	case 23:
+		return &v.SE23_PaymentInitiationChannel
+	case 26:
+		return &v.SE26_WalletProgramData
+	case 32:
+		return &v.SE32_MastercardAssignedId
+	case 33:
+		if v.SE33_PanMappingFileInformation == nil {
+			v.SE33_PanMappingFileInformation = new(DE48_SE33_PANMappingFileInformation)
+		}
+		return v.SE33_PanMappingFileInformation
+	case 36:
+		return &v.SE36_VisaMvv
+	case 37:
+		if v.SE37_AdditionalMerchantData == nil {
+			v.SE37_AdditionalMerchantData = new(DE48_SE37_AdditionalMerchantData)
+		}
+		return v.SE37_AdditionalMerchantData
+	case 42:
+		if v.SE42_ElectronicCommerceIndicators == nil {
+			v.SE42_ElectronicCommerceIndicators = new(DE48_SE42_ElectronicCommerceIndicators)
+		}
+		return v.SE42_ElectronicCommerceIndicators
+	case 43:
+		return &v.SE43_UniversalCardholderAuthenticationField
+	case 44:
+		return &v.SE44_TransactionIdentifier
+	case 51:
+		return &v.SE51_MerchantOnBehalfServices
+	case 52:
+		return &v.SE52_TransactionIntegretyClass
+	case 55:
+		return &v.SE55_MerchantFraudScoringData
+	case 57:
+		return &v.SE57_SecurityServicesAdditionalDataForAcquirers
+	case 61:
+		if v.SE61_ExtendedConditionCodes == nil {
+			v.SE61_ExtendedConditionCodes = new(DE48_SE61_ExtendedConditionCodes)
+		}
+		return v.SE61_ExtendedConditionCodes
+	case 63:
+		if v.SE63_TraceId == nil {
+			v.SE63_TraceId = new(DE48_SE63_TraceId)
+		}
+		return v.SE63_TraceId
+	case 64:
+		return &v.SE64_TransitProgram
+	case 66:
+		if v.SE66_AuthenticationData == nil {
+			v.SE66_AuthenticationData = new(DE48_SE66_AuthenticationData)
+		}
+		return v.SE66_AuthenticationData
+	case 67:
+		return &v.SE67_MoneySendInformation
+	case 76:
+		return &v.SE76_ElectronicAcceptanceIndicator
+	case 77:
+		return &v.SE77_TransactionTypeIdentifier
+	case 80:
+		return &v.SE80_PinServiceCode
+	case 82:
+		return &v.SE82_AddressVerificationServiceRequest
+	case 83:
+		return &v.SE83_AddressVerificationServiceResponse
+	case 84:
+		return &v.SE84_MerchantAdviceCode
+	case 86:
+		return &v.SE86_RelationshipParticipantIndicator
+	case 87:
+		return &v.SE87_Cvv2Response
+	case 90:
+		return &v.SE90_LodgingAndAutoRentalIndicator
+	case 92:
+		return &v.SE92_CardholderVerificationCode
+	case 95:
+		return &v.SE95_PromotionCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE22MultiPurposeMerchantIndicator) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_LowRisk, v.SF1_LowRisk == ""
+	case 2:
+		return *new(string), true
+	case 3:
+		return *new(string), true
+	case 4:
+		return *new(string), true
+	case 5:
+		return v.SF5_InitiatedTransactionIndicator, v.SF5_InitiatedTransactionIndicator == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE22MultiPurposeMerchantIndicator) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_LowRisk
+	case 2:
+		return new(string)
+	case 3:
+		return new(string)
+	case 4:
+		return new(string)
+	case 5:
+		return &v.SF5_InitiatedTransactionIndicator
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE33_PANMappingFileInformation) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return *new(string), true
+	case 2:
+		return *new(string), true
+	case 3:
+		return *new(string), true
+	case 4:
+		return *new(string), true
+	case 5:
+		return *new(string), true
+	case 6:
+		return v.SF6_TokenRequestorID, v.SF6_TokenRequestorID == ""
+	case 7:
+		return *new(string), true
+	case 8:
+		return *new(string), true
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE33_PANMappingFileInformation) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return new(string)
+	case 2:
+		return new(string)
+	case 3:
+		return new(string)
+	case 4:
+		return new(string)
+	case 5:
+		return new(string)
+	case 6:
+		return &v.SF6_TokenRequestorID
+	case 7:
+		return new(string)
+	case 8:
+		return new(string)
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE37_AdditionalMerchantData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_PaymentFacilitatorId, v.SF1_PaymentFacilitatorId == ""
+	case 2:
+		return v.SF2_IndependantSalesOrganisationId, v.SF2_IndependantSalesOrganisationId == ""
+	case 3:
+		return v.SF3_SubMerchantId, v.SF3_SubMerchantId == ""
+	case 4:
+		return v.SF4_MerchantCountryOfOrigin, v.SF4_MerchantCountryOfOrigin == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE37_AdditionalMerchantData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_PaymentFacilitatorId
+	case 2:
+		return &v.SF2_IndependantSalesOrganisationId
+	case 3:
+		return &v.SF3_SubMerchantId
+	case 4:
+		return &v.SF4_MerchantCountryOfOrigin
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE42_ElectronicCommerceIndicators) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_SecurityLevelIndicatorAndUCAFCollectionIndicator, v.SF1_SecurityLevelIndicatorAndUCAFCollectionIndicator == ""
+	case 2:
+		return v.SF2_OriginalSecurityLevelIndicatorAndUCAFCollectionIndicator, v.SF2_OriginalSecurityLevelIndicatorAndUCAFCollectionIndicator == ""
+	case 3:
+		return v.SF3_ReasonForUCAFDowngrade, v.SF3_ReasonForUCAFDowngrade == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE42_ElectronicCommerceIndicators) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_SecurityLevelIndicatorAndUCAFCollectionIndicator
+	case 2:
+		return &v.SF2_OriginalSecurityLevelIndicatorAndUCAFCollectionIndicator
+	case 3:
+		return &v.SF3_ReasonForUCAFDowngrade
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE61_ExtendedConditionCodes) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_PartialApprovalTerminalSupportIndicator, v.SF1_PartialApprovalTerminalSupportIndicator == 0
+	case 2:
+		return v.SF2_PurchaseAmountOnlyTerminalSupportIndicator, v.SF2_PurchaseAmountOnlyTerminalSupportIndicator == 0
+	case 3:
+		return v.SF3_RealTimeSubstantiationIndicattor, v.SF3_RealTimeSubstantiationIndicattor == 0
+	case 4:
+		return v.SF4_MerchantTransactionFroudScoringIndicator, v.SF4_MerchantTransactionFroudScoringIndicator == 0
+	case 5:
+		return v.SF5_FinalAuthorizationIndicator, v.SF5_FinalAuthorizationIndicator == 0
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE61_ExtendedConditionCodes) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_PartialApprovalTerminalSupportIndicator
+	case 2:
+		return &v.SF2_PurchaseAmountOnlyTerminalSupportIndicator
+	case 3:
+		return &v.SF3_RealTimeSubstantiationIndicattor
+	case 4:
+		return &v.SF4_MerchantTransactionFroudScoringIndicator
+	case 5:
+		return &v.SF5_FinalAuthorizationIndicator
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE63_TraceId) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_NetworkData, v.SF1_NetworkData == ""
+	case 2:
+		return v.SF2_DateSettlement, v.SF2_DateSettlement == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE63_TraceId) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_NetworkData
+	case 2:
+		return &v.SF2_DateSettlement
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE48_SE66_AuthenticationData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_ProgramProtocol, v.SF1_ProgramProtocol == ""
+	case 2:
+		return v.SF2_DirectoryServerTransactionId, v.SF2_DirectoryServerTransactionId == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE48_SE66_AuthenticationData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_ProgramProtocol
+	case 2:
+		return &v.SF2_DirectoryServerTransactionId
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE54_AmountsAdditional) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_AccountType, v.SF1_AccountType == ""
+	case 2:
+		return v.SF2_AmountType, v.SF2_AmountType == ""
+	case 3:
+		return v.SF3_CurrencyCode, v.SF3_CurrencyCode == ""
+	case 4:
+		return v.SF4_AmountSign, v.SF4_AmountSign == ""
+	case 5:
+		return v.SF5_Amount, v.SF5_Amount == 0
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE54_AmountsAdditional) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_AccountType
+	case 2:
+		return &v.SF2_AmountType
+	case 3:
+		return &v.SF3_CurrencyCode
+	case 4:
+		return &v.SF4_AmountSign
+	case 5:
+		return &v.SF5_Amount
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE61_PointOfServiceData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_TerminalAttendance, v.SF1_TerminalAttendance == ""
+	case 2:
+		return *new(string), true
+	case 3:
+		return v.SF3_TerminalLocation, v.SF3_TerminalLocation == ""
+	case 4:
+		return v.SF4_CardholderPresence, v.SF4_CardholderPresence == ""
+	case 5:
+		return v.SF5_CardPresence, v.SF5_CardPresence == ""
+	case 6:
+		return v.SF6_CardCaptureCapabilities, v.SF6_CardCaptureCapabilities == ""
+	case 7:
+		return v.SF7_TransactionStatus, v.SF7_TransactionStatus == ""
+	case 8:
+		return v.SF8_TransactionSecurity, v.SF8_TransactionSecurity == ""
+	case 9:
+		return *new(string), true
+	case 10:
+		return v.SF10_CardholderActivatedTerminalLevel, v.SF10_CardholderActivatedTerminalLevel == ""
+	case 11:
+		return v.SF11_CardDataTerminalInputCapabilityIndicator, v.SF11_CardDataTerminalInputCapabilityIndicator == ""
+	case 12:
+		return v.SF12_AuthorizationLifeCycle, v.SF12_AuthorizationLifeCycle == ""
+	case 13:
+		return v.SF13_CountryCode, v.SF13_CountryCode == ""
+	case 14:
+		return v.SF14_PostalCode, v.SF14_PostalCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE61_PointOfServiceData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_TerminalAttendance
+	case 2:
+		return new(string)
+	case 3:
+		return &v.SF3_TerminalLocation
+	case 4:
+		return &v.SF4_CardholderPresence
+	case 5:
+		return &v.SF5_CardPresence
+	case 6:
+		return &v.SF6_CardCaptureCapabilities
+	case 7:
+		return &v.SF7_TransactionStatus
+	case 8:
+		return &v.SF8_TransactionSecurity
+	case 9:
+		return new(string)
+	case 10:
+		return &v.SF10_CardholderActivatedTerminalLevel
+	case 11:
+		return &v.SF11_CardDataTerminalInputCapabilityIndicator
+	case 12:
+		return &v.SF12_AuthorizationLifeCycle
+	case 13:
+		return &v.SF13_CountryCode
+	case 14:
+		return &v.SF14_PostalCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE7_TransmissionDateAndTime) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_Date, v.SF1_Date == ""
+	case 2:
+		return v.SF2_Time, v.SF2_Time == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE7_TransmissionDateAndTime) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_Date
+	case 2:
+		return &v.SF2_Time
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE90_OriginalDataElements) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_OriginalMessageTypeIdentifier, v.SF1_OriginalMessageTypeIdentifier == ""
+	case 2:
+		return v.SF2_OriginalSystemTraceAuditNumber, v.SF2_OriginalSystemTraceAuditNumber == ""
+	case 3:
+		return v.SF3_OriginalTransmissionDateAndTime, v.SF3_OriginalTransmissionDateAndTime == nil
+	case 4:
+		return v.SF4_OriginalAcquiringInstituteIdCode, v.SF4_OriginalAcquiringInstituteIdCode == ""
+	case 5:
+		return v.SF5_OriginalForwardingInstituteIdCode, v.SF5_OriginalForwardingInstituteIdCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE90_OriginalDataElements) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_OriginalMessageTypeIdentifier
+	case 2:
+		return &v.SF2_OriginalSystemTraceAuditNumber
+	case 3:
+		if v.SF3_OriginalTransmissionDateAndTime == nil {
+			v.SF3_OriginalTransmissionDateAndTime = new(DE7_TransmissionDateAndTime)
+		}
+		return v.SF3_OriginalTransmissionDateAndTime
+	case 4:
+		return &v.SF4_OriginalAcquiringInstituteIdCode
+	case 5:
+		return &v.SF5_OriginalForwardingInstituteIdCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DE95_ReplacementAmounts) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_ActualAmountTransaction, v.SF1_ActualAmountTransaction == 0
+	case 2:
+		return v.SF2_ActualAmountSettlement, v.SF2_ActualAmountSettlement == 0
+	case 3:
+		return v.SF3_ActualAmountCardholderBilling, v.SF3_ActualAmountCardholderBilling == 0
+	case 4:
+		return *new(int64), true
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DE95_ReplacementAmounts) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_ActualAmountTransaction
+	case 2:
+		return &v.SF2_ActualAmountSettlement
+	case 3:
+		return &v.SF3_ActualAmountCardholderBilling
+	case 4:
+		return new(int64)
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *DataElements) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 2:
+		return v.DE2_PrimaryAccountNumber, v.DE2_PrimaryAccountNumber == ""
+	case 3:
+		return v.DE3_ProcessingCode, v.DE3_ProcessingCode == ""
+	case 4:
+		return v.DE4_TransactionAmount, v.DE4_TransactionAmount == 0
+	case 6:
+		return v.DE6_CardholderBillingAmount, v.DE6_CardholderBillingAmount == 0
+	case 7:
+		return v.DE7_TransmissionDateTime, v.DE7_TransmissionDateTime == nil
+	case 10:
+		return v.DE10_ConversionRateCardholderBilling, v.DE10_ConversionRateCardholderBilling == ""
+	case 11:
+		return v.DE11_SystemTraceAuditNumber, v.DE11_SystemTraceAuditNumber == ""
+	case 12:
+		return v.DE12_LocalTransactionTime, v.DE12_LocalTransactionTime == ""
+	case 13:
+		return v.DE13_LocalTransactionDate, v.DE13_LocalTransactionDate == ""
+	case 14:
+		return v.DE14_ExpirationDate, v.DE14_ExpirationDate == ""
+	case 15:
+		return v.DE15_SettlementDate, v.DE15_SettlementDate == ""
+	case 16:
+		return v.DE16_ConversionDate, v.DE16_ConversionDate == ""
+	case 18:
+		return v.DE18_MerchantType, v.DE18_MerchantType == ""
+	case 20:
+		return v.DE20_PrimaryAccountNumberCountryCode, v.DE20_PrimaryAccountNumberCountryCode == ""
+	case 22:
+		return v.DE22_PointOfServiceEntryMode, v.DE22_PointOfServiceEntryMode == ""
+	case 23:
+		return v.DE23_CardSequenceNumber, v.DE23_CardSequenceNumber == ""
+	case 28:
+		return v.DE28_TransactionFeeAmount, v.DE28_TransactionFeeAmount == nil
+	case 32:
+		return v.DE32_AcquringInstitutionCode, v.DE32_AcquringInstitutionCode == ""
+	case 33:
+		return v.DE33_ForwardingInstitutionIDCode, v.DE33_ForwardingInstitutionIDCode == ""
+	case 35:
+		return v.DE35_TrackTwoData, v.DE35_TrackTwoData == ""
+	case 37:
+		return v.DE37_RetrievalReferenceNumber, v.DE37_RetrievalReferenceNumber == ""
+	case 38:
+		return v.DE38_AuthorizationIdResponse, v.DE38_AuthorizationIdResponse == ""
+	case 39:
+		return v.DE39_ResponseCode, v.DE39_ResponseCode == ""
+	case 41:
+		return v.DE41_CardAcceptorTerminalId, v.DE41_CardAcceptorTerminalId == ""
+	case 42:
+		return v.DE42_CardAcceptorCodeId, v.DE42_CardAcceptorCodeId == ""
+	case 43:
+		return v.DE43_CardAcceptorNameAndLocation, v.DE43_CardAcceptorNameAndLocation == nil
+	case 44:
+		return v.DE44_AdditionalResponseData, v.DE44_AdditionalResponseData == ""
+	case 48:
+		return v.DE48_AdditionalData, v.DE48_AdditionalData == nil
+	case 49:
+		return v.DE49_TransactionCurrencyCode, v.DE49_TransactionCurrencyCode == ""
+	case 51:
+		return v.DE51_CardholderBillingCurrencyCode, v.DE51_CardholderBillingCurrencyCode == ""
+	case 52:
+		return v.DE52_PinData, v.DE52_PinData == ""
+	case 53:
+		return v.DE53_SecurityRelatedControlInformation, v.DE53_SecurityRelatedControlInformation == ""
+	case 54:
+		return v.DE54_AdditionalAmounts, v.DE54_AdditionalAmounts == nil
+	case 55:
+		return v.DE55_IntegratedCircuitCardData, v.DE55_IntegratedCircuitCardData == nil
+	case 56:
+		return v.DE56_PaymentAccountData, v.DE56_PaymentAccountData == ""
+	case 60:
+		return v.DE60_AdviceReasonCode, v.DE60_AdviceReasonCode == ""
+	case 61:
+		return v.DE61_PointOfServiceData, v.DE61_PointOfServiceData == nil
+	case 63:
+		return v.DE63_NetworkData, v.DE63_NetworkData == ""
+	case 70:
+		return v.DE70_NetworkManagementInformationCode, v.DE70_NetworkManagementInformationCode == ""
+	case 90:
+		return v.DE90_OriginalDataElements, v.DE90_OriginalDataElements == nil
+	case 94:
+		return v.DE94_ServiceIndicator, v.DE94_ServiceIndicator == ""
+	case 95:
+		return v.DE95_ReplacementAmounts, v.DE95_ReplacementAmounts == nil
+	case 96:
+		return v.DE96_MessageSecurityCode, v.DE96_MessageSecurityCode == ""
+	case 108:
+		return v.DE108_MoneySendReferenceData, v.DE108_MoneySendReferenceData == nil
+	case 112:
+		return v.DE112_AdditionalDataNationalUse, v.DE112_AdditionalDataNationalUse == ""
+	case 120:
+		return v.DE120_RecordData, v.DE120_RecordData == ""
+	case 121:
+		return v.DE121_AuthorizingAgentIDCode, v.DE121_AuthorizingAgentIDCode == ""
+	case 123:
+		return v.DE123_ReceiptFreeText, v.DE123_ReceiptFreeText == ""
+	case 124:
+		return v.DE124_MemberDefinedData, v.DE124_MemberDefinedData == ""
+	case 126:
+		return v.DE126_PrivateData, v.DE126_PrivateData == ""
+	case 127:
+		return v.DE127_PrivateData, v.DE127_PrivateData == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *DataElements) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 2:
+		return &v.DE2_PrimaryAccountNumber
+	case 3:
+		return &v.DE3_ProcessingCode
+	case 4:
+		return &v.DE4_TransactionAmount
+	case 6:
+		return &v.DE6_CardholderBillingAmount
+	case 7:
+		if v.DE7_TransmissionDateTime == nil {
+			v.DE7_TransmissionDateTime = new(DE7_TransmissionDateAndTime)
+		}
+		return v.DE7_TransmissionDateTime
+	case 10:
+		return &v.DE10_ConversionRateCardholderBilling
+	case 11:
+		return &v.DE11_SystemTraceAuditNumber
+	case 12:
+		return &v.DE12_LocalTransactionTime
+	case 13:
+		return &v.DE13_LocalTransactionDate
+	case 14:
+		return &v.DE14_ExpirationDate
+	case 15:
+		return &v.DE15_SettlementDate
+	case 16:
+		return &v.DE16_ConversionDate
+	case 18:
+		return &v.DE18_MerchantType
+	case 20:
+		return &v.DE20_PrimaryAccountNumberCountryCode
+	case 22:
+		return &v.DE22_PointOfServiceEntryMode
+	case 23:
+		return &v.DE23_CardSequenceNumber
+	case 28:
+		if v.DE28_TransactionFeeAmount == nil {
+			v.DE28_TransactionFeeAmount = new(DE28_TransactionFeeAmount)
+		}
+		return v.DE28_TransactionFeeAmount
+	case 32:
+		return &v.DE32_AcquringInstitutionCode
+	case 33:
+		return &v.DE33_ForwardingInstitutionIDCode
+	case 35:
+		return &v.DE35_TrackTwoData
+	case 37:
+		return &v.DE37_RetrievalReferenceNumber
+	case 38:
+		return &v.DE38_AuthorizationIdResponse
+	case 39:
+		return &v.DE39_ResponseCode
+	case 41:
+		return &v.DE41_CardAcceptorTerminalId
+	case 42:
+		return &v.DE42_CardAcceptorCodeId
+	case 43:
+		if v.DE43_CardAcceptorNameAndLocation == nil {
+			v.DE43_CardAcceptorNameAndLocation = new(DE43_CardAcceptorNameAndLocation)
+		}
+		return v.DE43_CardAcceptorNameAndLocation
+	case 44:
+		return &v.DE44_AdditionalResponseData
+	case 48:
+		if v.DE48_AdditionalData == nil {
+			v.DE48_AdditionalData = new(DE48_AdditionalData)
+		}
+		return v.DE48_AdditionalData
+	case 49:
+		return &v.DE49_TransactionCurrencyCode
+	case 51:
+		return &v.DE51_CardholderBillingCurrencyCode
+	case 52:
+		return &v.DE52_PinData
+	case 53:
+		return &v.DE53_SecurityRelatedControlInformation
+	case 54:
+		return &v.DE54_AdditionalAmounts
+	case 55:
+		return &v.DE55_IntegratedCircuitCardData
+	case 56:
+		return &v.DE56_PaymentAccountData
+	case 60:
+		return &v.DE60_AdviceReasonCode
+	case 61:
+		if v.DE61_PointOfServiceData == nil {
+			v.DE61_PointOfServiceData = new(DE61_PointOfServiceData)
+		}
+		return v.DE61_PointOfServiceData
+	case 63:
+		return &v.DE63_NetworkData
+	case 70:
+		return &v.DE70_NetworkManagementInformationCode
+	case 90:
+		if v.DE90_OriginalDataElements == nil {
+			v.DE90_OriginalDataElements = new(DE90_OriginalDataElements)
+		}
+		return v.DE90_OriginalDataElements
+	case 94:
+		return &v.DE94_ServiceIndicator
+	case 95:
+		if v.DE95_ReplacementAmounts == nil {
+			v.DE95_ReplacementAmounts = new(DE95_ReplacementAmounts)
+		}
+		return v.DE95_ReplacementAmounts
+	case 96:
+		return &v.DE96_MessageSecurityCode
+	case 108:
+		if v.DE108_MoneySendReferenceData == nil {
+			v.DE108_MoneySendReferenceData = new(DE108_MoneySendReferenceData)
+		}
+		return v.DE108_MoneySendReferenceData
+	case 112:
+		return &v.DE112_AdditionalDataNationalUse
+	case 120:
+		return &v.DE120_RecordData
+	case 121:
+		return &v.DE121_AuthorizingAgentIDCode
+	case 123:
+		return &v.DE123_ReceiptFreeText
+	case 124:
+		return &v.DE124_MemberDefinedData
+	case 126:
+		return &v.DE126_PrivateData
+	case 127:
+		return &v.DE127_PrivateData
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package cis_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+func authorizationRequest() cis.DataElements {
+	return cis.DataElements{
+		DE2_PrimaryAccountNumber:      "5200000000000007",
+		DE3_ProcessingCode:            "000000",
+		DE4_TransactionAmount:         1234,
+		DE7_TransmissionDateTime:      cis.DE7FromString("1019152030"),
+		DE11_SystemTraceAuditNumber:   "000123",
+		DE12_LocalTransactionTime:     "152030",
+		DE13_LocalTransactionDate:     "1019",
+		DE14_ExpirationDate:           "2512",
+		DE18_MerchantType:             "5999",
+		DE22_PointOfServiceEntryMode:  "810",
+		DE32_AcquringInstitutionCode:  "123456",
+		DE37_RetrievalReferenceNumber: "000000000123",
+		DE41_CardAcceptorTerminalId:   "TERM0001",
+		DE42_CardAcceptorCodeId:       "MERCHANT",
+		DE43_CardAcceptorNameAndLocation: &cis.DE43_CardAcceptorNameAndLocation{
+			SF1_Name:               "Shop",
+			SF3_City:               "Breda",
+			SF5_StateOrCountryCode: "NLD",
+		},
+		// DE48 writes its subelements in random order, a single one keeps the encoding comparable
+		DE48_AdditionalData: &cis.DE48_AdditionalData{
+			TransactionCategoryCode:         "T",
+			SE92_CardholderVerificationCode: "123",
+		},
+		DE49_TransactionCurrencyCode: "978",
+		DE54_AdditionalAmounts: []cis.DE54_AmountsAdditional{
+			{SF1_AccountType: "00", SF2_AmountType: "57", SF3_CurrencyCode: "978", SF4_AmountSign: "C", SF5_Amount: 1234},
+		},
+		DE61_PointOfServiceData: &cis.DE61_PointOfServiceData{
+			SF1_TerminalAttendance:                        "1",
+			SF3_TerminalLocation:                          "2",
+			SF4_CardholderPresence:                        "5",
+			SF5_CardPresence:                              "1",
+			SF6_CardCaptureCapabilities:                   "0",
+			SF7_TransactionStatus:                         "0",
+			SF8_TransactionSecurity:                       "0",
+			SF10_CardholderActivatedTerminalLevel:         "6",
+			SF11_CardDataTerminalInputCapabilityIndicator: "6",
+			SF12_AuthorizationLifeCycle:                   "00",
+			SF13_CountryCode:                              "528",
+			SF14_PostalCode:                               "4811",
+		},
+		DE90_OriginalDataElements: &cis.DE90_OriginalDataElements{
+			SF1_OriginalMessageTypeIdentifier:   "0100",
+			SF2_OriginalSystemTraceAuditNumber:  "000122",
+			SF3_OriginalTransmissionDateAndTime: cis.DE7FromString("1019152000"),
+		},
+	}
+}
+
+func encode(tb testing.TB, elements *cis.DataElements) []byte {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	if err := iso8583.NewEncoder(&buf, iso8583.FormatEbcdic, iso8583.NoRdwLayout).EncodeIso8583(iso8583.NewMti("0100"), elements); err != nil {
+		tb.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func decode(tb testing.TB, data []byte) cis.DataElements {
+	tb.Helper()
+
+	var (
+		mti      iso8583.MTI
+		elements cis.DataElements
+	)
+	if err := iso8583.NewDecoder(bytes.NewReader(data), iso8583.FormatEbcdic, iso8583.NoRdwLayout).DecodeIso8583(&mti, &elements); err != nil {
+		tb.Fatal(err)
+	}
+
+	return elements
+}
+
+// The generated accessors must encode and decode exactly like reflection does
+func TestAccessorsMatchReflection(t *testing.T) {
+	in := authorizationRequest()
+
+	generated := encode(t, &in)
+	generatedOut := decode(t, generated)
+
+	iso8583.DisableAccessors(true)
+	defer iso8583.DisableAccessors(false)
+
+	reflected := encode(t, &in)
+	reflectedOut := decode(t, reflected)
+
+	if !bytes.Equal(generated, reflected) {
+		t.Errorf("Encoded with accessors: %x", generated)
+		t.Fatalf("Encoded with reflection: %x", reflected)
+	}
+
+	if !reflect.DeepEqual(generatedOut, reflectedOut) {
+		t.Errorf("Decoded with accessors:  %#v", generatedOut)
+		t.Fatalf("Decoded with reflection: %#v", reflectedOut)
+	}
+
+	if !reflect.DeepEqual(in, generatedOut) {
+		t.Errorf("Expected: %#v", in)
+		t.Fatalf("Decoded:  %#v", generatedOut)
+	}
+}
+
+func benchmarkEncode(b *testing.B, reflection bool) {
+	iso8583.DisableAccessors(reflection)
+	defer iso8583.DisableAccessors(false)
+
+	in := authorizationRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		encode(b, &in)
+	}
+}
+
+func benchmarkDecode(b *testing.B, reflection bool) {
+	in := authorizationRequest()
+	data := encode(b, &in)
+
+	iso8583.DisableAccessors(reflection)
+	defer iso8583.DisableAccessors(false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		decode(b, data)
+	}
+}
+
+func BenchmarkEncodeAccessors(b *testing.B)  { benchmarkEncode(b, false) }
+func BenchmarkEncodeReflection(b *testing.B) { benchmarkEncode(b, true) }
+func BenchmarkDecodeAccessors(b *testing.B)  { benchmarkDecode(b, false) }
+func BenchmarkDecodeReflection(b *testing.B) { benchmarkDecode(b, true) }
@@ -0,0 +1,45 @@
+package cis_test
+
+import (
+	"bytes"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/mastercard/cis"
+)
+
+// TestSpec makes sure spec.yaml describes the data elements, run isodump -spec mastercard when they change
+func TestSpec(t *testing.T) {
+	spec, err := iso8583.LoadSpec("spec.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := spec.Validate(cis.DataElements{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A message decoded with the spec encodes like the struct
+	elements := authorizationRequest()
+	data := encode(t, &elements)
+
+	var mti iso8583.MTI
+	msg := iso8583.NewMessage(spec)
+	if err := iso8583.NewDecoder(bytes.NewReader(data), iso8583.FormatEbcdic, iso8583.NoRdwLayout).DecodeIso8583(&mti, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if pan := msg.String(2); pan != elements.DE2_PrimaryAccountNumber {
+		t.Errorf("Expected PAN %s, got %s", elements.DE2_PrimaryAccountNumber, pan)
+	}
+
+	var buf bytes.Buffer
+	if err := iso8583.NewEncoder(&buf, iso8583.FormatEbcdic, iso8583.NoRdwLayout).EncodeIso8583(mti, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Errorf("Struct:  %x", data)
+		t.Fatalf("Message: %x", buf.Bytes())
+	}
+}
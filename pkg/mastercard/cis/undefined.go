@@ -0,0 +1,31 @@
+package cis
+
+// UndefinedFormats are the formats of the CIS data elements DataElements does not define. The decoder reads them
+// into the UndefinedElements with these, so they can be logged and sent again. Elements that are not listed fail
+// the decoding, as their length is not known.
+var UndefinedFormats = map[int]string{
+	5:   "n-12",      // Amount, Settlement
+	8:   "n-8",       // Amount, Cardholder Billing Fee
+	9:   "n-8",       // Conversion Rate, Settlement
+	19:  "n-3",       // Acquiring Institution Country Code
+	26:  "n-2",       // POS PIN Capture Code
+	40:  "an-3",      // Service Restriction Code
+	45:  "ans..76",   // Track 1 Data
+	50:  "n-3",       // Currency Code, Settlement
+	62:  "ans...100", // Intermediate Network Facility (INF) Data
+	100: "n..11",     // Receiving Institution ID Code
+	102: "ans..28",   // Account ID 1
+	103: "ans..28",   // Account ID 2
+	104: "ans...999", // Digital Payment Data
+	105: "ans...999", // Multi-Use Transaction Identification Data
+	110: "ans...999", // Additional Data-2
+	113: "ans...999", // Reserved for National Use
+	114: "ans...999", // Reserved for National Use
+	115: "ans...999", // Reserved for National Use
+	116: "ans...999", // Reserved for National Use
+	117: "ans...999", // Reserved for National Use
+	118: "ans...999", // Reserved for National Use
+	119: "ans...999", // Reserved for National Use
+	122: "ans...999", // Additional Record Data
+	125: "b-8",       // New PIN Data
+}
@@ -36,6 +36,11 @@ func (d *daily) Next() int {
 	return <-d.next
 }
 
+// Buffered returns the number of reserved values that can be handed out without going to the store
+func (d *daily) Buffered() int {
+	return len(d.next) + len(d.reserved)
+}
+
 func (d *daily) Fill(ctx context.Context) error {
 
 	err := d.grow(ctx)
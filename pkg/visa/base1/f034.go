@@ -1,19 +1,30 @@
 package base1
 
+import "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
 type F034_ElectronicCommerceData struct {
 	HEX01_AuthenticationData                  HEX01_AuthenticationData                  `iso8583:"1=b....9, dataenc=tlv, lenenc=hexBit4, tlvTag=01, omitempty"` // length = dataset ID + data
 	HEX02_AcceptanceEnvironmentAdditionalData HEX02_AcceptanceEnvironmentAdditionalData `iso8583:"2=b....3, dataenc=tlv, lenenc=hexBit4, tlvTag=02, omitempty"`
 	HEX4A_StrongConsumerAuthentication        HEX4A_StrongConsumerAuthentication        `iso8583:"3=b....12, dataenc=tlv, lenenc=hexBit4, tlvTag=4A, omitempty"`
+
+	// UndefinedDatasets holds the datasets with other IDs by their ID
+	UndefinedDatasets iso8583.RawElements
 }
 
 // HEX01_AuthenticationData Dataset ID Hex 01
 type HEX01_AuthenticationData struct {
 	T86_3DSecureProtocolVersionNumber string `iso8583:"1=b..8, dataenc=ebcdic, lenenc=hexBit4, minlength=5, tlvTag=86, omitempty"` // Tag 86
+
+	// UndefinedTags holds the other tags of the dataset by their tag
+	UndefinedTags iso8583.RawElements
 }
 
 // HEX02_AcceptanceEnvironmentAdditionalData Dataset ID Hex 02
 type HEX02_AcceptanceEnvironmentAdditionalData struct {
 	T80_InitiatingPartyIndicator string `iso8583:"1=b..1, dataenc=ebcdic, lenenc=hexBit4, tlvTag=80, omitempty"` // Tag 80
+
+	// UndefinedTags holds the other tags of the dataset by their tag
+	UndefinedTags iso8583.RawElements
 }
 
 // HEX4A_StrongConsumerAuthentication Dataset ID Hex 4A
@@ -22,4 +33,7 @@ type HEX4A_StrongConsumerAuthentication struct {
 	T88_SecureCorporatePaymentIndicator           string `iso8583:"2=b..1, dataenc=ebcdic, lenenc=hexBit4, tlvTag=88, omitempty"` // Tag 88
 	T89_TransactionRiskAnalysisExemptionIndicator string `iso8583:"3=b..1, dataenc=ebcdic, lenenc=hexBit4, tlvTag=89, omitempty"` // Tag 89
 	T8A_DelegatedAuthenticationIndicator          string `iso8583:"4=b..1, dataenc=ebcdic, lenenc=hexBit4, tlvTag=8A, omitempty"` // Tag 8A
+
+	// UndefinedTags holds the other tags of the dataset by their tag
+	UndefinedTags iso8583.RawElements
 }
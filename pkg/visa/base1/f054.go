@@ -0,0 +1,10 @@
+package base1
+
+// F054_AdditionalAmount is one of the up to 6 amounts of 20 characters in field 54
+type F054_AdditionalAmount struct {
+	SF1_AccountType  string `iso8583:"1=n-2"`
+	SF2_AmountType   string `iso8583:"2=n-2"`
+	SF3_CurrencyCode string `iso8583:"3=n-3"`
+	SF4_AmountSign   string `iso8583:"4=a-1"` // C for credit, D for debit
+	SF5_Amount       int64  `iso8583:"5=n-12, justify=right"`
+}
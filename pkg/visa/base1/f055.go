@@ -0,0 +1,11 @@
+package base1
+
+import "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
+// F055_IntegratedCircuitCardData holds the chip data in dataset 01, the BER-TLV data objects of the card and terminal
+type F055_IntegratedCircuitCardData struct {
+	HEX01_ChipCardData iso8583.TLVs `iso8583:"1=b....252, lenenc=hexBit4, tlvTag=01, omitempty"`
+
+	// UndefinedDatasets holds the datasets with other IDs by their ID
+	UndefinedDatasets iso8583.RawElements
+}
@@ -1,5 +1,7 @@
 package base1
 
+import "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
 type F062_CustomPaymentService struct {
 	SF1_AuthorizationCharacteristicsIndicator string `iso8583:"1=an-1, dataenc=ebcdic, omitempty, minlength=0"`
 	SF2_TransactionIdentifier                 int    `iso8583:"2=n-15, dataenc=bcd4, justify=right, omitempty"`
@@ -27,4 +29,7 @@ type F062_CustomPaymentService struct {
 	SF24_ProgramIdentifier                    string `iso8583:"24=an-6, dataenc=ebcdic, justify=right, omitempty"`
 	SF25_SpendQualifiedIndicator              string `iso8583:"25=an-1, dataenc=ebcdic, justify=right, omitempty"`
 	SF26_AccountStatus                        string `iso8583:"26=an-1, dataenc=ebcdic, justify=right, omitempty"`
+
+	// UndefinedSubfields holds the subfields after the ones above, as they were in the bit map
+	UndefinedSubfields iso8583.RawElements
 }
@@ -0,0 +1,36 @@
+package base1
+
+import "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
+// F104_TransactionSpecificData holds the usage 2 datasets of field 104, the data of original credits
+type F104_TransactionSpecificData struct {
+	HEX57_BusinessApplicationIdentifier HEX57_BusinessApplicationIdentifier `iso8583:"1=b....252, dataenc=tlv, lenenc=hexBit4, tlvTag=57, omitempty"`
+	HEX5F_SenderData                    HEX5F_SenderData                    `iso8583:"2=b....252, dataenc=tlv, lenenc=hexBit4, tlvTag=5F, omitempty"`
+
+	// UndefinedDatasets holds the datasets with other IDs by their ID
+	UndefinedDatasets iso8583.RawElements
+}
+
+// HEX57_BusinessApplicationIdentifier Dataset ID Hex 57
+type HEX57_BusinessApplicationIdentifier struct {
+	T01_BusinessApplicationIdentifier string `iso8583:"1=b..2, dataenc=ebcdic, lenenc=hexBit4, tlvTag=01, omitempty"` // Tag 01
+
+	// UndefinedTags holds the other tags of the dataset by their tag
+	UndefinedTags iso8583.RawElements
+}
+
+// HEX5F_SenderData Dataset ID Hex 5F
+type HEX5F_SenderData struct {
+	T01_SenderReferenceNumber string `iso8583:"1=b..16, dataenc=ebcdic, lenenc=hexBit4, tlvTag=01, omitempty"` // Tag 01
+	T02_SenderAccountNumber   string `iso8583:"2=b..34, dataenc=ebcdic, lenenc=hexBit4, tlvTag=02, omitempty"` // Tag 02
+	T03_SenderName            string `iso8583:"3=b..30, dataenc=ebcdic, lenenc=hexBit4, tlvTag=03, omitempty"` // Tag 03
+	T04_SenderAddress         string `iso8583:"4=b..35, dataenc=ebcdic, lenenc=hexBit4, tlvTag=04, omitempty"` // Tag 04
+	T05_SenderCity            string `iso8583:"5=b..25, dataenc=ebcdic, lenenc=hexBit4, tlvTag=05, omitempty"` // Tag 05
+	T06_SenderState           string `iso8583:"6=b..2, dataenc=ebcdic, lenenc=hexBit4, tlvTag=06, omitempty"`  // Tag 06
+	T07_SenderCountry         string `iso8583:"7=b..3, dataenc=ebcdic, lenenc=hexBit4, tlvTag=07, omitempty"`  // Tag 07, ISO numeric country code
+	T08_SourceOfFunds         string `iso8583:"8=b..2, dataenc=ebcdic, lenenc=hexBit4, tlvTag=08, omitempty"`  // Tag 08
+	T0A_RecipientName         string `iso8583:"9=b..30, dataenc=ebcdic, lenenc=hexBit4, tlvTag=0A, omitempty"` // Tag 0A
+
+	// UndefinedTags holds the other tags of the dataset by their tag
+	UndefinedTags iso8583.RawElements
+}
@@ -1,5 +1,7 @@
 package base1
 
+import "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+
 type F126_PrivateUseFields struct {
 	SF1_UnusedReserved                      string                        `iso8583:"1=ans-25, minlength=0, omitempty"` // fields 1 to 4 are the same, total length 155 (workaround for subfield definitions constrain)
 	SF2_UnusedReserved                      string                        `iso8583:"2=ans-57, minlength=0, omitempty"`
@@ -25,6 +27,9 @@ type F126_PrivateUseFields struct {
 	_                                       string                        `iso8583:"22=ans-1, minlength=0, omitempty"`
 	_                                       string                        `iso8583:"23=ans-1, minlength=0, omitempty"`
 	_                                       string                        `iso8583:"24=ans-1, minlength=0, omitempty"`
+
+	// UndefinedSubfields holds the subfields after the ones above, as they were in the bit map
+	UndefinedSubfields iso8583.RawElements
 }
 
 type SF18_AgentUniqueAccountResult struct {
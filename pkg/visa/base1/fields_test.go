@@ -0,0 +1,111 @@
+package base1_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+func TestTrack2Data(t *testing.T) {
+	in := authorizationRequest()
+	in.F035_Track2Data = "4111111111111111=2512101123"
+
+	// 27 digits in BCD after a leading zero, the field separator is the nibble D
+	expected, _ := hex.DecodeString("1B" + "04111111111111111D2512101123")
+	encoded := encode(t, &in)
+	if !bytes.Contains(encoded, expected) {
+		t.Fatalf("Expected field 35 %X in %X", expected, encoded)
+	}
+
+	if out := decode(t, encoded); out.F035_Track2Data != in.F035_Track2Data {
+		t.Fatalf("Expected track 2 data %s, got %s", in.F035_Track2Data, out.F035_Track2Data)
+	}
+}
+
+func TestPinData(t *testing.T) {
+	in := authorizationRequest()
+	in.F052_PinData = string([]byte{0x1b, 0x9c, 0x18, 0x45, 0xeb, 0x99, 0x3a, 0x7a})
+	in.F053_SecurityRelatedControlInformation = "2001010100000000"
+
+	// The PIN block is 8 bytes, the security related control information 16 digits in BCD
+	expected, _ := hex.DecodeString("1B9C1845EB993A7A" + "2001010100000000")
+	encoded := encode(t, &in)
+	if !bytes.Contains(encoded, expected) {
+		t.Fatalf("Expected fields 52 and 53 %X in %X", expected, encoded)
+	}
+
+	out := decode(t, encoded)
+	if out.F052_PinData != in.F052_PinData || out.F053_SecurityRelatedControlInformation != in.F053_SecurityRelatedControlInformation {
+		t.Fatalf("Expected PIN data %X and %s, got %X and %s", in.F052_PinData, in.F053_SecurityRelatedControlInformation, out.F052_PinData, out.F053_SecurityRelatedControlInformation)
+	}
+}
+
+func TestAdditionalResponseData(t *testing.T) {
+	// A response with the response source and the address verification result code up to F044.9, 10 bytes
+	var buf bytes.Buffer
+	encoder := iso8583.NewEncoder(&buf, iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen)
+	if err := encoder.EncodeIso8583(iso8583.NewMti("0110"), &struct {
+		F039 string `iso8583:"39=an-2, dataenc=ebcdic"`
+		F044 string `iso8583:"44=ans.25, dataenc=ebcdic, lenenc=bin"`
+		F049 string `iso8583:"49=n-3, dataenc=bcd4"`
+	}{"00", "5 00 00000", "978"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The length indicator is the binary 0A, not transcoded to EBCDIC (25) like the value
+	expected, _ := hex.DecodeString("0A" + "F540F0F040F0F0F0F0F0" + "0978")
+	if !bytes.HasSuffix(buf.Bytes(), expected) {
+		t.Fatalf("Expected fields 44 and 49 %X in %X", expected, buf.Bytes())
+	}
+
+	if out := decode(t, buf.Bytes()); out.F039_ResponseCode != "00" || out.F049_TransactionCurrencyCode != "978" {
+		t.Fatalf("Expected response code 00 and currency 978 after field 44, got %s and %s", out.F039_ResponseCode, out.F049_TransactionCurrencyCode)
+	}
+}
+
+func TestAdditionalAmounts(t *testing.T) {
+	in := authorizationRequest()
+	in.F054_AdditionalAmounts = []base1.F054_AdditionalAmount{
+		{SF1_AccountType: "00", SF2_AmountType: "40", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 2000},
+		{SF1_AccountType: "00", SF2_AmountType: "44", SF3_CurrencyCode: "978", SF4_AmountSign: "D", SF5_Amount: 150},
+	}
+
+	// The length of the 2 amounts of 20 characters and the amounts 0040978D000000002000 and 0044978D000000000150 in
+	// EBCDIC
+	expected, _ := hex.DecodeString("28" +
+		"F0F0F4F0F9F7F8C4F0F0F0F0F0F0F0F0F2F0F0F0" +
+		"F0F0F4F4F9F7F8C4F0F0F0F0F0F0F0F0F0F1F5F0")
+	encoded := encode(t, &in)
+	if !bytes.Contains(encoded, expected) {
+		t.Fatalf("Expected field 54 %X in %X", expected, encoded)
+	}
+
+	if out := decode(t, encoded); !reflect.DeepEqual(out.F054_AdditionalAmounts, in.F054_AdditionalAmounts) {
+		t.Fatalf("Expected additional amounts %+v, got %+v", in.F054_AdditionalAmounts, out.F054_AdditionalAmounts)
+	}
+}
+
+func TestTransactionSpecificData(t *testing.T) {
+	in := authorizationRequest()
+	in.F104_TransactionSpecificData = base1.F104_TransactionSpecificData{
+		HEX57_BusinessApplicationIdentifier: base1.HEX57_BusinessApplicationIdentifier{T01_BusinessApplicationIdentifier: "PP"},
+		HEX5F_SenderData:                    base1.HEX5F_SenderData{T03_SenderName: "JOHN SMITH", T07_SenderCountry: "528"},
+	}
+
+	// Dataset 57 of 4 bytes with tag 01 PP and dataset 5F of 17 bytes with tag 03 JOHN SMITH and tag 07 528 in EBCDIC
+	expected, _ := hex.DecodeString("1B" +
+		"570004" + "0102D7D7" +
+		"5F0011" + "030AD1D6C8D540E2D4C9E3C8" + "0703F5F2F8")
+	encoded := encode(t, &in)
+	if !bytes.Contains(encoded, expected) {
+		t.Fatalf("Expected field 104 %X in %X", expected, encoded)
+	}
+
+	if out := decode(t, encoded); !reflect.DeepEqual(out.F104_TransactionSpecificData, in.F104_TransactionSpecificData) {
+		t.Fatalf("Expected transaction specific data %+v, got %+v", in.F104_TransactionSpecificData, out.F104_TransactionSpecificData)
+	}
+}
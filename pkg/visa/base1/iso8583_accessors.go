@@ -0,0 +1,888 @@
+// Code generated by iso8583/generate, DO NOT EDIT.
+// Generated on Mon Oct 19 16:18 2026
+package base1
+
+import (
+	iso8583 "gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"reflect"
+)
+
+var (
+	_ iso8583.Accessor = (*F007_TransmissionDateAndTime)(nil)
+	_ iso8583.Accessor = (*F034_ElectronicCommerceData)(nil)
+	_ iso8583.Accessor = (*F043_CardAcceptorNameLocation)(nil)
+	_ iso8583.Accessor = (*F044_AdditionalResponseData)(nil)
+	_ iso8583.Accessor = (*F054_AdditionalAmount)(nil)
+	_ iso8583.Accessor = (*F055_IntegratedCircuitCardData)(nil)
+	_ iso8583.Accessor = (*F060_AdditionalPOSInformation)(nil)
+	_ iso8583.Accessor = (*F062_CustomPaymentService)(nil)
+	_ iso8583.Accessor = (*F063_NetworkData)(nil)
+	_ iso8583.Accessor = (*F090_OriginalDataElements)(nil)
+	_ iso8583.Accessor = (*F104_TransactionSpecificData)(nil)
+	_ iso8583.Accessor = (*F126_PrivateUseFields)(nil)
+	_ iso8583.Accessor = (*Fields)(nil)
+	_ iso8583.Accessor = (*HEX01_AuthenticationData)(nil)
+	_ iso8583.Accessor = (*HEX02_AcceptanceEnvironmentAdditionalData)(nil)
+	_ iso8583.Accessor = (*HEX4A_StrongConsumerAuthentication)(nil)
+	_ iso8583.Accessor = (*HEX57_BusinessApplicationIdentifier)(nil)
+	_ iso8583.Accessor = (*HEX5F_SenderData)(nil)
+	_ iso8583.Accessor = (*SF18_AgentUniqueAccountResult)(nil)
+)
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F007_TransmissionDateAndTime) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_Date, v.SF1_Date == ""
+	case 2:
+		return v.SF2_Time, v.SF2_Time == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F007_TransmissionDateAndTime) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_Date
+	case 2:
+		return &v.SF2_Time
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F034_ElectronicCommerceData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return &v.HEX01_AuthenticationData, reflect.ValueOf(v.HEX01_AuthenticationData).IsZero()
+	case 2:
+		return &v.HEX02_AcceptanceEnvironmentAdditionalData, reflect.ValueOf(v.HEX02_AcceptanceEnvironmentAdditionalData).IsZero()
+	case 3:
+		return &v.HEX4A_StrongConsumerAuthentication, reflect.ValueOf(v.HEX4A_StrongConsumerAuthentication).IsZero()
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F034_ElectronicCommerceData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.HEX01_AuthenticationData
+	case 2:
+		return &v.HEX02_AcceptanceEnvironmentAdditionalData
+	case 3:
+		return &v.HEX4A_StrongConsumerAuthentication
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F043_CardAcceptorNameLocation) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_CarAcceptorName, v.SF1_CarAcceptorName == ""
+	case 2:
+		return v.SF2_CardAcceptorCity, v.SF2_CardAcceptorCity == ""
+	case 3:
+		return v.SF3_CountryCode, v.SF3_CountryCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F043_CardAcceptorNameLocation) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_CarAcceptorName
+	case 2:
+		return &v.SF2_CardAcceptorCity
+	case 3:
+		return &v.SF3_CountryCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F044_AdditionalResponseData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return *new(string), true
+	case 2:
+		return *new(string), true
+	case 3:
+		return *new(string), true
+	case 4:
+		return *new(string), true
+	case 5:
+		return *new(string), true
+	case 6:
+		return *new(string), true
+	case 7:
+		return *new(string), true
+	case 8:
+		return *new(string), true
+	case 9:
+		return *new(string), true
+	case 10:
+		return v.SF10_CVV2ResultCode, v.SF10_CVV2ResultCode == ""
+	case 11:
+		return *new(string), true
+	case 12:
+		return *new(string), true
+	case 13:
+		return v.SF13_CavvResultsCode, v.SF13_CavvResultsCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F044_AdditionalResponseData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return new(string)
+	case 2:
+		return new(string)
+	case 3:
+		return new(string)
+	case 4:
+		return new(string)
+	case 5:
+		return new(string)
+	case 6:
+		return new(string)
+	case 7:
+		return new(string)
+	case 8:
+		return new(string)
+	case 9:
+		return new(string)
+	case 10:
+		return &v.SF10_CVV2ResultCode
+	case 11:
+		return new(string)
+	case 12:
+		return new(string)
+	case 13:
+		return &v.SF13_CavvResultsCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F054_AdditionalAmount) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_AccountType, v.SF1_AccountType == ""
+	case 2:
+		return v.SF2_AmountType, v.SF2_AmountType == ""
+	case 3:
+		return v.SF3_CurrencyCode, v.SF3_CurrencyCode == ""
+	case 4:
+		return v.SF4_AmountSign, v.SF4_AmountSign == ""
+	case 5:
+		return v.SF5_Amount, v.SF5_Amount == 0
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F054_AdditionalAmount) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_AccountType
+	case 2:
+		return &v.SF2_AmountType
+	case 3:
+		return &v.SF3_CurrencyCode
+	case 4:
+		return &v.SF4_AmountSign
+	case 5:
+		return &v.SF5_Amount
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F055_IntegratedCircuitCardData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.HEX01_ChipCardData, v.HEX01_ChipCardData == nil
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F055_IntegratedCircuitCardData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.HEX01_ChipCardData
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F060_AdditionalPOSInformation) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.B1, v.B1 == ""
+	case 2:
+		return v.B2, v.B2 == ""
+	case 3:
+		return v.B3, v.B3 == ""
+	case 4:
+		return v.B4, v.B4 == ""
+	case 5:
+		return v.B5, v.B5 == ""
+	case 6:
+		return v.B6, v.B6 == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F060_AdditionalPOSInformation) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.B1
+	case 2:
+		return &v.B2
+	case 3:
+		return &v.B3
+	case 4:
+		return &v.B4
+	case 5:
+		return &v.B5
+	case 6:
+		return &v.B6
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F062_CustomPaymentService) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_AuthorizationCharacteristicsIndicator, v.SF1_AuthorizationCharacteristicsIndicator == ""
+	case 2:
+		return v.SF2_TransactionIdentifier, v.SF2_TransactionIdentifier == 0
+	case 3:
+		return v.SF3_ValidationCode, v.SF3_ValidationCode == ""
+	case 4:
+		return v.SF4_MarketSpecificDataIdentifier, v.SF4_MarketSpecificDataIdentifier == ""
+	case 5:
+		return v.SF5_Duration, v.SF5_Duration == ""
+	case 6:
+		return v.SF6_Reserved, v.SF6_Reserved == ""
+	case 7:
+		return v.SF7_PurchaseIdentifier, v.SF7_PurchaseIdentifier == ""
+	case 8:
+		return *new(string), true
+	case 9:
+		return *new(string), true
+	case 10:
+		return *new(string), true
+	case 11:
+		return *new(string), true
+	case 12:
+		return *new(string), true
+	case 13:
+		return *new(string), true
+	case 14:
+		return *new(string), true
+	case 15:
+		return *new(string), true
+	case 16:
+		return v.SF16_Reserved, v.SF16_Reserved == ""
+	case 17:
+		return v.SF17_MastercardInterchangeCompliance, v.SF17_MastercardInterchangeCompliance == ""
+	case 18:
+		return *new(string), true
+	case 19:
+		return *new(string), true
+	case 20:
+		return v.SF20_MerchantVerificationValue, v.SF20_MerchantVerificationValue == ""
+	case 21:
+		return v.SF21_RiskAssesmentScoreAndReasonCodes, v.SF21_RiskAssesmentScoreAndReasonCodes == ""
+	case 22:
+		return v.SF22_RiskAssesmentConditionCodes, v.SF22_RiskAssesmentConditionCodes == ""
+	case 23:
+		return v.SF23_ProductID, v.SF23_ProductID == ""
+	case 24:
+		return v.SF24_ProgramIdentifier, v.SF24_ProgramIdentifier == ""
+	case 25:
+		return v.SF25_SpendQualifiedIndicator, v.SF25_SpendQualifiedIndicator == ""
+	case 26:
+		return v.SF26_AccountStatus, v.SF26_AccountStatus == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F062_CustomPaymentService) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_AuthorizationCharacteristicsIndicator
+	case 2:
+		return &v.SF2_TransactionIdentifier
+	case 3:
+		return &v.SF3_ValidationCode
+	case 4:
+		return &v.SF4_MarketSpecificDataIdentifier
+	case 5:
+		return &v.SF5_Duration
+	case 6:
+		return &v.SF6_Reserved
+	case 7:
+		return &v.SF7_PurchaseIdentifier
+	case 8:
+		return new(string)
+	case 9:
+		return new(string)
+	case 10:
+		return new(string)
+	case 11:
+		return new(string)
+	case 12:
+		return new(string)
+	case 13:
+		return new(string)
+	case 14:
+		return new(string)
+	case 15:
+		return new(string)
+	case 16:
+		return &v.SF16_Reserved
+	case 17:
+		return &v.SF17_MastercardInterchangeCompliance
+	case 18:
+		return new(string)
+	case 19:
+		return new(string)
+	case 20:
+		return &v.SF20_MerchantVerificationValue
+	case 21:
+		return &v.SF21_RiskAssesmentScoreAndReasonCodes
+	case 22:
+		return &v.SF22_RiskAssesmentConditionCodes
+	case 23:
+		return &v.SF23_ProductID
+	case 24:
+		return &v.SF24_ProgramIdentifier
+	case 25:
+		return &v.SF25_SpendQualifiedIndicator
+	case 26:
+		return &v.SF26_AccountStatus
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F063_NetworkData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_NetworkID, v.SF1_NetworkID == ""
+	case 2:
+		return *new(string), true
+	case 3:
+		return v.SF3_MessageReasonCode, v.SF3_MessageReasonCode == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F063_NetworkData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_NetworkID
+	case 2:
+		return new(string)
+	case 3:
+		return &v.SF3_MessageReasonCode
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F090_OriginalDataElements) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_OriginalMessageType, v.SF1_OriginalMessageType == ""
+	case 2:
+		return v.SF2_OriginalTraceNumber, v.SF2_OriginalTraceNumber == ""
+	case 3:
+		return v.SF3_OriginalTransmissionDateTime, v.SF3_OriginalTransmissionDateTime == ""
+	case 4:
+		return v.SF4_OriginalAcquirerID, v.SF4_OriginalAcquirerID == ""
+	case 5:
+		return v.SF5_OriginalForwardingInstitutionID, v.SF5_OriginalForwardingInstitutionID == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F090_OriginalDataElements) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_OriginalMessageType
+	case 2:
+		return &v.SF2_OriginalTraceNumber
+	case 3:
+		return &v.SF3_OriginalTransmissionDateTime
+	case 4:
+		return &v.SF4_OriginalAcquirerID
+	case 5:
+		return &v.SF5_OriginalForwardingInstitutionID
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F104_TransactionSpecificData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return &v.HEX57_BusinessApplicationIdentifier, reflect.ValueOf(v.HEX57_BusinessApplicationIdentifier).IsZero()
+	case 2:
+		return &v.HEX5F_SenderData, reflect.ValueOf(v.HEX5F_SenderData).IsZero()
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F104_TransactionSpecificData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.HEX57_BusinessApplicationIdentifier
+	case 2:
+		return &v.HEX5F_SenderData
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *F126_PrivateUseFields) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.SF1_UnusedReserved, v.SF1_UnusedReserved == ""
+	case 2:
+		return v.SF2_UnusedReserved, v.SF2_UnusedReserved == ""
+	case 3:
+		return v.SF3_UnusedReserved, v.SF3_UnusedReserved == ""
+	case 4:
+		return v.SF4_UnusedReserved, v.SF4_UnusedReserved == ""
+	case 5:
+		return v.SF5_MerchantIdentifier, v.SF5_MerchantIdentifier == ""
+	case 6:
+		return v.SF6_CardholderCertificateSerialNumber, v.SF6_CardholderCertificateSerialNumber == ""
+	case 7:
+		return v.SF7_MerchantCertificateSerialNumber, v.SF7_MerchantCertificateSerialNumber == ""
+	case 8:
+		return v.SF8_TransactionID, v.SF8_TransactionID == ""
+	case 9:
+		return v.SF9_CAVVData, v.SF9_CAVVData == ""
+	case 10:
+		return v.SF10_CVV2AuthorizationRequestData, v.SF10_CVV2AuthorizationRequestData == ""
+	case 11:
+		return *new(string), true
+	case 12:
+		return v.SF12_ServiceIndicators, v.SF12_ServiceIndicators == ""
+	case 13:
+		return v.SF13_POSEnvironment, v.SF13_POSEnvironment == ""
+	case 14:
+		return *new(string), true
+	case 15:
+		return v.SF15_MastercardUCAFCollectionIndicator, v.SF15_MastercardUCAFCollectionIndicator == ""
+	case 16:
+		return v.SF16_MastercardUCAFField, v.SF16_MastercardUCAFField == ""
+	case 17:
+		return *new(string), true
+	case 18:
+		return &v.SF18_AgentUniqueAccountResult, v.SF18_AgentUniqueAccountResult == (SF18_AgentUniqueAccountResult{})
+	case 19:
+		return v.SF19_DynamicCurrencyConversionIndicator, v.SF19_DynamicCurrencyConversionIndicator == ""
+	case 20:
+		return v.SF20_3DSecureIndicator, v.SF20_3DSecureIndicator == ""
+	case 21:
+		return *new(string), true
+	case 22:
+		return *new(string), true
+	case 23:
+		return *new(string), true
+	case 24:
+		return *new(string), true
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *F126_PrivateUseFields) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.SF1_UnusedReserved
+	case 2:
+		return &v.SF2_UnusedReserved
+	case 3:
+		return &v.SF3_UnusedReserved
+	case 4:
+		return &v.SF4_UnusedReserved
+	case 5:
+		return &v.SF5_MerchantIdentifier
+	case 6:
+		return &v.SF6_CardholderCertificateSerialNumber
+	case 7:
+		return &v.SF7_MerchantCertificateSerialNumber
+	case 8:
+		return &v.SF8_TransactionID
+	case 9:
+		return &v.SF9_CAVVData
+	case 10:
+		return &v.SF10_CVV2AuthorizationRequestData
+	case 11:
+		return new(string)
+	case 12:
+		return &v.SF12_ServiceIndicators
+	case 13:
+		return &v.SF13_POSEnvironment
+	case 14:
+		return new(string)
+	case 15:
+		return &v.SF15_MastercardUCAFCollectionIndicator
+	case 16:
+		return &v.SF16_MastercardUCAFField
+	case 17:
+		return new(string)
+	case 18:
+		return &v.SF18_AgentUniqueAccountResult
+	case 19:
+		return &v.SF19_DynamicCurrencyConversionIndicator
+	case 20:
+		return &v.SF20_3DSecureIndicator
+	case 21:
+		return new(string)
+	case 22:
+		return new(string)
+	case 23:
+		return new(string)
+	case 24:
+		return new(string)
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *Fields) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 2:
+		return v.F002_PrimaryAccountNumber, v.F002_PrimaryAccountNumber == ""
+	case 3:
+		return v.F003_ProcessingCode, v.F003_ProcessingCode == ""
+	case 4:
+		return v.F004_TransactionAmount, v.F004_TransactionAmount == 0
+	case 7:
+		return &v.F007_TransmissionDateTime, v.F007_TransmissionDateTime == (F007_TransmissionDateAndTime{})
+	case 11:
+		return v.F011_SystemTraceAuditNumber, v.F011_SystemTraceAuditNumber == ""
+	case 12:
+		return v.F012_LocalTransactionTime, v.F012_LocalTransactionTime == ""
+	case 13:
+		return v.F013_LocalTransactionDate, v.F013_LocalTransactionDate == ""
+	case 14:
+		return v.F014_ExpirationDate, v.F014_ExpirationDate == ""
+	case 18:
+		return v.F018_MerchantType, v.F018_MerchantType == ""
+	case 19:
+		return v.F019_AcquiringInstituteCountryCode, v.F019_AcquiringInstituteCountryCode == ""
+	case 22:
+		return v.F022_PosEntryMode, v.F022_PosEntryMode == ""
+	case 23:
+		return v.F023_CardSequenceNumber, v.F023_CardSequenceNumber == ""
+	case 25:
+		return v.F025_PosCondition, v.F025_PosCondition == ""
+	case 28:
+		return v.F028_TransactionFeeAmount, v.F028_TransactionFeeAmount == ""
+	case 32:
+		return v.F032_AcquiringInstitutionIdentificationCode, v.F032_AcquiringInstitutionIdentificationCode == ""
+	case 34:
+		return &v.F034_ElectronicCommerceData, reflect.ValueOf(v.F034_ElectronicCommerceData).IsZero()
+	case 35:
+		return v.F035_Track2Data, v.F035_Track2Data == ""
+	case 37:
+		return v.F037_RetrievalReferenceNumber, v.F037_RetrievalReferenceNumber == ""
+	case 38:
+		return v.F038_AuthorizationIdenticationResponse, v.F038_AuthorizationIdenticationResponse == ""
+	case 39:
+		return v.F039_ResponseCode, v.F039_ResponseCode == ""
+	case 41:
+		return v.F041_CardAcceptorTerminalIdentification, v.F041_CardAcceptorTerminalIdentification == ""
+	case 42:
+		return v.F042_CardAcceptorIdentificationCode, v.F042_CardAcceptorIdentificationCode == ""
+	case 43:
+		return &v.F043_CardAcceptorNameLocation, v.F043_CardAcceptorNameLocation == (F043_CardAcceptorNameLocation{})
+	case 44:
+		return &v.F044_AdditionalResponseData, v.F044_AdditionalResponseData == (F044_AdditionalResponseData{})
+	case 49:
+		return v.F049_TransactionCurrencyCode, v.F049_TransactionCurrencyCode == ""
+	case 52:
+		return v.F052_PinData, v.F052_PinData == ""
+	case 53:
+		return v.F053_SecurityRelatedControlInformation, v.F053_SecurityRelatedControlInformation == ""
+	case 54:
+		return v.F054_AdditionalAmounts, v.F054_AdditionalAmounts == nil
+	case 55:
+		return &v.F055_IntegratedCircuitCardData, reflect.ValueOf(v.F055_IntegratedCircuitCardData).IsZero()
+	case 60:
+		return &v.F060_AdditionalPointOfServiceInformation, v.F060_AdditionalPointOfServiceInformation == (F060_AdditionalPOSInformation{})
+	case 62:
+		return &v.F062_CustomPaymentServiceFields, reflect.ValueOf(v.F062_CustomPaymentServiceFields).IsZero()
+	case 63:
+		return &v.F063_NetworkData, v.F063_NetworkData == (F063_NetworkData{})
+	case 70:
+		return v.F070_NetworkManagementInformationCode, v.F070_NetworkManagementInformationCode == ""
+	case 90:
+		return &v.F090_OriginalDataElements, v.F090_OriginalDataElements == (F090_OriginalDataElements{})
+	case 104:
+		return &v.F104_TransactionSpecificData, reflect.ValueOf(v.F104_TransactionSpecificData).IsZero()
+	case 126:
+		return &v.F126_PrivateUseFields, reflect.ValueOf(v.F126_PrivateUseFields).IsZero()
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *Fields) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 2:
+		return &v.F002_PrimaryAccountNumber
+	case 3:
+		return &v.F003_ProcessingCode
+	case 4:
+		return &v.F004_TransactionAmount
+	case 7:
+		return &v.F007_TransmissionDateTime
+	case 11:
+		return &v.F011_SystemTraceAuditNumber
+	case 12:
+		return &v.F012_LocalTransactionTime
+	case 13:
+		return &v.F013_LocalTransactionDate
+	case 14:
+		return &v.F014_ExpirationDate
+	case 18:
+		return &v.F018_MerchantType
+	case 19:
+		return &v.F019_AcquiringInstituteCountryCode
+	case 22:
+		return &v.F022_PosEntryMode
+	case 23:
+		return &v.F023_CardSequenceNumber
+	case 25:
+		return &v.F025_PosCondition
+	case 28:
+		return &v.F028_TransactionFeeAmount
+	case 32:
+		return &v.F032_AcquiringInstitutionIdentificationCode
+	case 34:
+		return &v.F034_ElectronicCommerceData
+	case 35:
+		return &v.F035_Track2Data
+	case 37:
+		return &v.F037_RetrievalReferenceNumber
+	case 38:
+		return &v.F038_AuthorizationIdenticationResponse
+	case 39:
+		return &v.F039_ResponseCode
+	case 41:
+		return &v.F041_CardAcceptorTerminalIdentification
+	case 42:
+		return &v.F042_CardAcceptorIdentificationCode
+	case 43:
+		return &v.F043_CardAcceptorNameLocation
+	case 44:
+		return &v.F044_AdditionalResponseData
+	case 49:
+		return &v.F049_TransactionCurrencyCode
+	case 52:
+		return &v.F052_PinData
+	case 53:
+		return &v.F053_SecurityRelatedControlInformation
+	case 54:
+		return &v.F054_AdditionalAmounts
+	case 55:
+		return &v.F055_IntegratedCircuitCardData
+	case 60:
+		return &v.F060_AdditionalPointOfServiceInformation
+	case 62:
+		return &v.F062_CustomPaymentServiceFields
+	case 63:
+		return &v.F063_NetworkData
+	case 70:
+		return &v.F070_NetworkManagementInformationCode
+	case 90:
+		return &v.F090_OriginalDataElements
+	case 104:
+		return &v.F104_TransactionSpecificData
+	case 126:
+		return &v.F126_PrivateUseFields
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *HEX01_AuthenticationData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.T86_3DSecureProtocolVersionNumber, v.T86_3DSecureProtocolVersionNumber == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *HEX01_AuthenticationData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.T86_3DSecureProtocolVersionNumber
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *HEX02_AcceptanceEnvironmentAdditionalData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.T80_InitiatingPartyIndicator, v.T80_InitiatingPartyIndicator == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *HEX02_AcceptanceEnvironmentAdditionalData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.T80_InitiatingPartyIndicator
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *HEX4A_StrongConsumerAuthentication) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.T87_LowValueExemptionIndicator, v.T87_LowValueExemptionIndicator == ""
+	case 2:
+		return v.T88_SecureCorporatePaymentIndicator, v.T88_SecureCorporatePaymentIndicator == ""
+	case 3:
+		return v.T89_TransactionRiskAnalysisExemptionIndicator, v.T89_TransactionRiskAnalysisExemptionIndicator == ""
+	case 4:
+		return v.T8A_DelegatedAuthenticationIndicator, v.T8A_DelegatedAuthenticationIndicator == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *HEX4A_StrongConsumerAuthentication) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.T87_LowValueExemptionIndicator
+	case 2:
+		return &v.T88_SecureCorporatePaymentIndicator
+	case 3:
+		return &v.T89_TransactionRiskAnalysisExemptionIndicator
+	case 4:
+		return &v.T8A_DelegatedAuthenticationIndicator
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *HEX57_BusinessApplicationIdentifier) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.T01_BusinessApplicationIdentifier, v.T01_BusinessApplicationIdentifier == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *HEX57_BusinessApplicationIdentifier) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.T01_BusinessApplicationIdentifier
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *HEX5F_SenderData) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.T01_SenderReferenceNumber, v.T01_SenderReferenceNumber == ""
+	case 2:
+		return v.T02_SenderAccountNumber, v.T02_SenderAccountNumber == ""
+	case 3:
+		return v.T03_SenderName, v.T03_SenderName == ""
+	case 4:
+		return v.T04_SenderAddress, v.T04_SenderAddress == ""
+	case 5:
+		return v.T05_SenderCity, v.T05_SenderCity == ""
+	case 6:
+		return v.T06_SenderState, v.T06_SenderState == ""
+	case 7:
+		return v.T07_SenderCountry, v.T07_SenderCountry == ""
+	case 8:
+		return v.T08_SourceOfFunds, v.T08_SourceOfFunds == ""
+	case 9:
+		return v.T0A_RecipientName, v.T0A_RecipientName == ""
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *HEX5F_SenderData) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.T01_SenderReferenceNumber
+	case 2:
+		return &v.T02_SenderAccountNumber
+	case 3:
+		return &v.T03_SenderName
+	case 4:
+		return &v.T04_SenderAddress
+	case 5:
+		return &v.T05_SenderCity
+	case 6:
+		return &v.T06_SenderState
+	case 7:
+		return &v.T07_SenderCountry
+	case 8:
+		return &v.T08_SourceOfFunds
+	case 9:
+		return &v.T0A_RecipientName
+	}
+	return nil
+}
+
+// Iso8583Value implements iso8583.Accessor
+func (v *SF18_AgentUniqueAccountResult) Iso8583Value(number int) (interface{}, bool) {
+	switch number {
+	case 1:
+		return v.PS1, v.PS1 == ""
+	case 2:
+		return v.AgentUniqueId, v.AgentUniqueId == ""
+	case 3:
+		return *new(string), true
+	}
+	return nil, true
+}
+
+// Iso8583Pointer implements iso8583.Accessor
+func (v *SF18_AgentUniqueAccountResult) Iso8583Pointer(number int) interface{} {
+	switch number {
+	case 1:
+		return &v.PS1
+	case 2:
+		return &v.AgentUniqueId
+	case 3:
+		return new(string)
+	}
+	return nil
+}
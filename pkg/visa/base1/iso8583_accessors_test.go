@@ -0,0 +1,130 @@
+package base1_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+func authorizationRequest() base1.Fields {
+	return base1.Fields{
+		F002_PrimaryAccountNumber:                   "4111111111111111",
+		F003_ProcessingCode:                         "000000",
+		F004_TransactionAmount:                      1234,
+		F007_TransmissionDateTime:                   *base1.F007FromString("1019152030"),
+		F011_SystemTraceAuditNumber:                 "000321",
+		F012_LocalTransactionTime:                   "152030",
+		F013_LocalTransactionDate:                   "1019",
+		F014_ExpirationDate:                         "2512",
+		F018_MerchantType:                           "5999",
+		F019_AcquiringInstituteCountryCode:          "528",
+		F022_PosEntryMode:                           "0100",
+		F025_PosCondition:                           "59",
+		F032_AcquiringInstitutionIdentificationCode: "474537",
+		F034_ElectronicCommerceData: base1.F034_ElectronicCommerceData{
+			HEX01_AuthenticationData:           base1.HEX01_AuthenticationData{T86_3DSecureProtocolVersionNumber: "2.2.0"},
+			HEX4A_StrongConsumerAuthentication: base1.HEX4A_StrongConsumerAuthentication{T87_LowValueExemptionIndicator: "1"},
+		},
+		F035_Track2Data:                         "4111111111111111=25121011000012300000",
+		F037_RetrievalReferenceNumber:           "629215000321",
+		F041_CardAcceptorTerminalIdentification: "TERM0001",
+		F042_CardAcceptorIdentificationCode:     "MERCHANT",
+		F043_CardAcceptorNameLocation: base1.F043_CardAcceptorNameLocation{
+			SF1_CarAcceptorName:  "Shop",
+			SF2_CardAcceptorCity: "Breda",
+			SF3_CountryCode:      "NL",
+		},
+		F049_TransactionCurrencyCode:             "978",
+		F060_AdditionalPointOfServiceInformation: base1.F060_AdditionalPOSInformation{B1: "05", B2: "10"},
+		F062_CustomPaymentServiceFields:          base1.F062_CustomPaymentService{SF1_AuthorizationCharacteristicsIndicator: "Y"},
+		F063_NetworkData:                         base1.F063_NetworkData{SF1_NetworkID: "0002"},
+		F126_PrivateUseFields:                    base1.F126_PrivateUseFields{SF10_CVV2AuthorizationRequestData: "11 123"},
+	}
+}
+
+func encode(tb testing.TB, fields *base1.Fields) []byte {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	encoder := iso8583.NewEncoder(&buf, iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen)
+	if err := encoder.EncodeIso8583(iso8583.NewMti("0100"), fields); err != nil {
+		tb.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func decode(tb testing.TB, data []byte) base1.Fields {
+	tb.Helper()
+
+	var (
+		mti    iso8583.MTI
+		fields base1.Fields
+	)
+	decoder := iso8583.NewDecoder(bytes.NewReader(data), iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen)
+	if err := decoder.DecodeIso8583(&mti, &fields); err != nil {
+		tb.Fatal(err)
+	}
+
+	return fields
+}
+
+// The generated accessors must encode and decode exactly like reflection does
+func TestAccessorsMatchReflection(t *testing.T) {
+	in := authorizationRequest()
+
+	generated := encode(t, &in)
+	generatedOut := decode(t, generated)
+
+	iso8583.DisableAccessors(true)
+	defer iso8583.DisableAccessors(false)
+
+	reflected := encode(t, &in)
+	reflectedOut := decode(t, reflected)
+
+	if !bytes.Equal(generated, reflected) {
+		t.Errorf("Encoded with accessors: %x", generated)
+		t.Fatalf("Encoded with reflection: %x", reflected)
+	}
+
+	if !reflect.DeepEqual(generatedOut, reflectedOut) {
+		t.Errorf("Decoded with accessors:  %#v", generatedOut)
+		t.Fatalf("Decoded with reflection: %#v", reflectedOut)
+	}
+}
+
+func benchmarkEncode(b *testing.B, reflection bool) {
+	iso8583.DisableAccessors(reflection)
+	defer iso8583.DisableAccessors(false)
+
+	in := authorizationRequest()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		encode(b, &in)
+	}
+}
+
+func benchmarkDecode(b *testing.B, reflection bool) {
+	in := authorizationRequest()
+	data := encode(b, &in)
+
+	iso8583.DisableAccessors(reflection)
+	defer iso8583.DisableAccessors(false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		decode(b, data)
+	}
+}
+
+func BenchmarkEncodeAccessors(b *testing.B)  { benchmarkEncode(b, false) }
+func BenchmarkEncodeReflection(b *testing.B) { benchmarkEncode(b, true) }
+func BenchmarkDecodeAccessors(b *testing.B)  { benchmarkDecode(b, false) }
+func BenchmarkDecodeReflection(b *testing.B) { benchmarkDecode(b, true) }
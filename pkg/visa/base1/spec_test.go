@@ -0,0 +1,47 @@
+package base1_test
+
+import (
+	"bytes"
+	"testing"
+
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/iso8583"
+	"gitlab.cmpayments.local/creditcard/authorization/pkg/visa/base1"
+)
+
+// TestSpec makes sure spec.yaml describes the fields, run isodump -spec visa when they change
+func TestSpec(t *testing.T) {
+	spec, err := iso8583.LoadSpec("spec.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := spec.Validate(base1.Fields{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A message decoded with the spec encodes like the struct
+	fields := authorizationRequest()
+	data := encode(t, &fields)
+
+	var mti iso8583.MTI
+	msg := iso8583.NewMessage(spec)
+	decoder := iso8583.NewDecoder(bytes.NewReader(data), iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen)
+	if err := decoder.DecodeIso8583(&mti, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if pan := msg.String(2); pan != fields.F002_PrimaryAccountNumber {
+		t.Errorf("Expected PAN %s, got %s", fields.F002_PrimaryAccountNumber, pan)
+	}
+
+	var buf bytes.Buffer
+	encoder := iso8583.NewEncoder(&buf, iso8583.FormatAscii, iso8583.NoRdwLayout, iso8583.MtiBcd4, iso8583.HexLen)
+	if err := encoder.EncodeIso8583(mti, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Errorf("Struct:  %x", data)
+		t.Fatalf("Message: %x", buf.Bytes())
+	}
+}
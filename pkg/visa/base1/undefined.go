@@ -0,0 +1,28 @@
+package base1
+
+// UndefinedFormats are the formats of the BASE I fields Fields does not define. The decoder reads them into the
+// UndefinedElements with these, so they can be logged and sent again. Fields that are not listed fail the
+// decoding, as their length is not known.
+var UndefinedFormats = map[int]string{
+	6:   "n-12, dataenc=bcd4",                  // Amount, Cardholder Billing
+	10:  "n-8, dataenc=bcd4",                   // Conversion Rate, Cardholder Billing
+	15:  "n-4, dataenc=bcd4",                   // Settlement Date
+	20:  "n-3, dataenc=bcd4",                   // PAN Extended, Country Code
+	26:  "n-2, dataenc=bcd4",                   // Point-of-Service PIN Capture Code
+	33:  "n..11, dataenc=bcd4, lenenc=hex",     // Forwarding Institution Identification Code
+	45:  "ans.76, dataenc=ebcdic, lenenc=bin",  // Track 1 Data
+	48:  "ans.255, dataenc=ebcdic, lenenc=bin", // Additional Data—Private
+	51:  "n-3, dataenc=bcd4",                   // Currency Code, Cardholder Billing
+	59:  "ans.14, dataenc=ebcdic, lenenc=bin",  // National Point-of-Service Geographic Data
+	61:  "b.18, lenenc=bin",                    // Other Amounts
+	68:  "n-3, dataenc=bcd4",                   // Receiving Institution Country Code
+	100: "n..11, dataenc=bcd4, lenenc=hex",     // Receiving Institution Identification Code
+	102: "ans.28, dataenc=ebcdic, lenenc=bin",  // Account Identification 1
+	103: "ans.28, dataenc=ebcdic, lenenc=bin",  // Account Identification 2
+	115: "ans.24, dataenc=ebcdic, lenenc=bin",  // Additional Trace Data
+	116: "b.255, lenenc=bin",                   // Card Issuer Reference Data
+	117: "b.255, lenenc=bin",                   // National Use
+	118: "b.255, lenenc=bin",                   // Intra-Country Data
+	123: "b.255, lenenc=bin",                   // Verification Data
+	125: "b.255, lenenc=bin",                   // Supporting Information
+}
@@ -2,7 +2,10 @@ package errors
 
 import (
 	"context"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"gitlab.cmpayments.local/creditcard/platform"
 	platformhandler "gitlab.cmpayments.local/creditcard/platform/http/handler"
@@ -39,6 +42,18 @@ func NotFoundResponse(ctx context.Context, w http.ResponseWriter, logger platfor
 	errorResponse(ctx, w, logger, http.StatusNotFound, message, map[string][]string{})
 }
 
+// ServiceUnavailableResponse tells the client the request can be retried after retryAfter, rounded up
+// to whole seconds for the Retry-After header
+func ServiceUnavailableResponse(ctx context.Context, w http.ResponseWriter, logger platform.Logger, err error, retryAfter time.Duration) {
+	logger.Error(logging.ContextWithError(ctx, err), "service unavailable")
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+
+	message := "the card scheme is unavailable, retry after " + strconv.Itoa(seconds) + " seconds"
+	errorResponse(ctx, w, logger, http.StatusServiceUnavailable, message, nil)
+}
+
 func errorResponse(
 	ctx context.Context,
 	w http.ResponseWriter,